@@ -0,0 +1,83 @@
+//go:build !tinygo
+
+package benchmarks
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	config "github.com/gymshark/go-easy-config"
+)
+
+// allocationBudgets are the maximum allocations per operation allowed for each measured
+// path, about 25% above the counts measured with an otherwise empty environment. Lower a
+// budget when an optimisation lands; raise one only with a justification in the commit.
+var allocationBudgets = map[string]float64{
+	"LoadAndValidate/fields=50":  1360,
+	"LoadAndValidate/fields=200": 4700,
+	"LoadAndValidate/fields=500": 13800,
+	"Analyze/fields=50":          300,
+	"Analyze/fields=200":         640,
+	"Analyze/fields=500":         1850,
+}
+
+// isolateEnvironment clears the process environment for the rest of the test, since the
+// environment loader's allocations grow with the number of variables set.
+func isolateEnvironment(t *testing.T) {
+	saved := os.Environ()
+	os.Clearenv()
+	t.Cleanup(func() {
+		os.Clearenv()
+		for _, kv := range saved {
+			if name, value, ok := strings.Cut(kv, "="); ok && name != "" {
+				os.Setenv(name, value)
+			}
+		}
+	})
+}
+
+func measureLoad[C any](t *testing.T) float64 {
+	handler := newHandler[C](t, true)
+	return testing.AllocsPerRun(20, func() {
+		loadOnce(t, handler)
+	})
+}
+
+func measureAnalyze[C any](t *testing.T) float64 {
+	return testing.AllocsPerRun(20, func() {
+		var cfg C
+		if err := config.NewInterpolationEngine[C]().Analyze(&cfg); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestAllocationBudgets(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are inflated by the race detector")
+	}
+	if testing.Short() {
+		t.Skip("skipping allocation budgets in short mode")
+	}
+	isolateEnvironment(t)
+
+	measured := map[string]func(*testing.T) float64{
+		"LoadAndValidate/fields=50":  measureLoad[config50],
+		"LoadAndValidate/fields=200": measureLoad[config200],
+		"LoadAndValidate/fields=500": measureLoad[config500],
+		"Analyze/fields=50":          measureAnalyze[config50],
+		"Analyze/fields=200":         measureAnalyze[config200],
+		"Analyze/fields=500":         measureAnalyze[config500],
+	}
+	for name, measure := range measured {
+		t.Run(name, func(t *testing.T) {
+			allocs := measure(t)
+			if budget := allocationBudgets[name]; allocs > budget {
+				t.Errorf("%s allocated %.0f times per run, over its budget of %.0f", name, allocs, budget)
+			} else {
+				t.Logf("%.0f allocations per run (budget %.0f)", allocs, budget)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package benchmarks measures loading and validation of realistic configuration structs
+// (50 to 500 fields, mixed environment and JSON sources, interpolation and validate tags)
+// and enforces allocation budgets for those paths in its tests.
+//
+// Run the benchmarks and compare them with the published baseline using benchstat:
+//
+//	go test ./benchmarks -run '^$' -bench . -benchmem -count 10 > new.txt
+//	benchstat benchmarks/testdata/baseline.txt new.txt
+package benchmarks
+
+//go:generate go run ./internal/genfixtures -out fixtures_test.go
@@ -0,0 +1,767 @@
+// Code generated by genfixtures. DO NOT EDIT.
+
+package benchmarks
+
+import "time"
+
+// config50 has 50 fields.
+type config50 struct {
+	Env    string        `env:"B050_ENV" json:"env" config:"availableAs=ENV" validate:"required"`
+	Region string        `env:"B050_REGION" json:"region" config:"availableAs=REGION" validate:"required"`
+	F002   bool          `env:"B050_F002" json:"f002"`
+	F003   time.Duration `env:"B050_F003" json:"f003" validate:"min=0"`
+	F004   float64       `env:"B050_F004" json:"f004" validate:"gte=0"`
+	F005   []string      `env:"B050_F005" json:"f005" validate:"max=10"`
+	F006   string        `env:"B050_F006" json:"f006" validate:"required"`
+	F007   int           `env:"B050_F007" json:"f007" validate:"min=0"`
+	F008   bool          `env:"B050_F008" json:"f008"`
+	F009   time.Duration `env:"B050_F009" json:"f009" validate:"min=0"`
+	F010   float64       `env:"B050_F010_${ENV}" json:"f010"`
+	F011   []string      `env:"B050_F011" json:"f011" validate:"max=10"`
+	F012   string        `env:"B050_F012" json:"f012" validate:"required"`
+	F013   int           `env:"B050_F013" json:"f013" validate:"min=0"`
+	F014   bool          `env:"B050_F014" json:"f014"`
+	F015   time.Duration `env:"B050_F015" json:"f015" validate:"min=0"`
+	F016   float64       `env:"B050_F016" json:"f016" validate:"gte=0"`
+	F017   []string      `env:"B050_F017" json:"f017" validate:"max=10"`
+	F018   string        `env:"B050_F018" json:"f018" validate:"required"`
+	F019   int           `env:"B050_F019" json:"f019" validate:"min=0"`
+	F020   bool          `env:"B050_F020_${ENV}" json:"f020"`
+	F021   time.Duration `env:"B050_F021" json:"f021" validate:"min=0"`
+	F022   float64       `env:"B050_F022" json:"f022" validate:"gte=0"`
+	F023   []string      `env:"B050_F023" json:"f023" validate:"max=10"`
+	F024   string        `env:"B050_F024" json:"f024" validate:"required"`
+	F025   int           `env:"B050_F025" json:"f025" validate:"min=0"`
+	F026   bool          `env:"B050_F026" json:"f026"`
+	F027   time.Duration `env:"B050_F027" json:"f027" validate:"min=0"`
+	F028   float64       `env:"B050_F028" json:"f028" validate:"gte=0"`
+	F029   []string      `env:"B050_F029" json:"f029" validate:"max=10"`
+	F030   string        `env:"B050_F030_${ENV}" json:"f030"`
+	F031   int           `env:"B050_F031" json:"f031" validate:"min=0"`
+	F032   bool          `env:"B050_F032" json:"f032"`
+	F033   time.Duration `env:"B050_F033" json:"f033" validate:"min=0"`
+	F034   float64       `env:"B050_F034" json:"f034" validate:"gte=0"`
+	F035   []string      `env:"B050_F035" json:"f035" validate:"max=10"`
+	F036   string        `env:"B050_F036" json:"f036" validate:"required"`
+	F037   int           `env:"B050_F037" json:"f037" validate:"min=0"`
+	F038   bool          `env:"B050_F038" json:"f038"`
+	F039   time.Duration `env:"B050_F039" json:"f039" validate:"min=0"`
+	F040   float64       `env:"B050_F040_${ENV}" json:"f040"`
+	F041   []string      `env:"B050_F041" json:"f041" validate:"max=10"`
+	F042   string        `env:"B050_F042" json:"f042" validate:"required"`
+	F043   int           `env:"B050_F043" json:"f043" validate:"min=0"`
+	F044   bool          `env:"B050_F044" json:"f044"`
+	F045   time.Duration `env:"B050_F045" json:"f045" validate:"min=0"`
+	F046   float64       `env:"B050_F046" json:"f046" validate:"gte=0"`
+	F047   []string      `env:"B050_F047" json:"f047" validate:"max=10"`
+	F048   string        `env:"B050_F048" json:"f048" validate:"required"`
+	F049   int           `env:"B050_F049" json:"f049" validate:"min=0"`
+}
+
+// config200 has 200 fields.
+type config200 struct {
+	Env    string        `env:"B200_ENV" json:"env" config:"availableAs=ENV" validate:"required"`
+	Region string        `env:"B200_REGION" json:"region" config:"availableAs=REGION" validate:"required"`
+	F002   bool          `env:"B200_F002" json:"f002"`
+	F003   time.Duration `env:"B200_F003" json:"f003" validate:"min=0"`
+	F004   float64       `env:"B200_F004" json:"f004" validate:"gte=0"`
+	F005   []string      `env:"B200_F005" json:"f005" validate:"max=10"`
+	F006   string        `env:"B200_F006" json:"f006" validate:"required"`
+	F007   int           `env:"B200_F007" json:"f007" validate:"min=0"`
+	F008   bool          `env:"B200_F008" json:"f008"`
+	F009   time.Duration `env:"B200_F009" json:"f009" validate:"min=0"`
+	F010   float64       `env:"B200_F010_${ENV}" json:"f010"`
+	F011   []string      `env:"B200_F011" json:"f011" validate:"max=10"`
+	F012   string        `env:"B200_F012" json:"f012" validate:"required"`
+	F013   int           `env:"B200_F013" json:"f013" validate:"min=0"`
+	F014   bool          `env:"B200_F014" json:"f014"`
+	F015   time.Duration `env:"B200_F015" json:"f015" validate:"min=0"`
+	F016   float64       `env:"B200_F016" json:"f016" validate:"gte=0"`
+	F017   []string      `env:"B200_F017" json:"f017" validate:"max=10"`
+	F018   string        `env:"B200_F018" json:"f018" validate:"required"`
+	F019   int           `env:"B200_F019" json:"f019" validate:"min=0"`
+	F020   bool          `env:"B200_F020_${ENV}" json:"f020"`
+	F021   time.Duration `env:"B200_F021" json:"f021" validate:"min=0"`
+	F022   float64       `env:"B200_F022" json:"f022" validate:"gte=0"`
+	F023   []string      `env:"B200_F023" json:"f023" validate:"max=10"`
+	F024   string        `env:"B200_F024" json:"f024" validate:"required"`
+	F025   int           `env:"B200_F025" json:"f025" validate:"min=0"`
+	F026   bool          `env:"B200_F026" json:"f026"`
+	F027   time.Duration `env:"B200_F027" json:"f027" validate:"min=0"`
+	F028   float64       `env:"B200_F028" json:"f028" validate:"gte=0"`
+	F029   []string      `env:"B200_F029" json:"f029" validate:"max=10"`
+	F030   string        `env:"B200_F030_${ENV}" json:"f030"`
+	F031   int           `env:"B200_F031" json:"f031" validate:"min=0"`
+	F032   bool          `env:"B200_F032" json:"f032"`
+	F033   time.Duration `env:"B200_F033" json:"f033" validate:"min=0"`
+	F034   float64       `env:"B200_F034" json:"f034" validate:"gte=0"`
+	F035   []string      `env:"B200_F035" json:"f035" validate:"max=10"`
+	F036   string        `env:"B200_F036" json:"f036" validate:"required"`
+	F037   int           `env:"B200_F037" json:"f037" validate:"min=0"`
+	F038   bool          `env:"B200_F038" json:"f038"`
+	F039   time.Duration `env:"B200_F039" json:"f039" validate:"min=0"`
+	F040   float64       `env:"B200_F040_${ENV}" json:"f040"`
+	F041   []string      `env:"B200_F041" json:"f041" validate:"max=10"`
+	F042   string        `env:"B200_F042" json:"f042" validate:"required"`
+	F043   int           `env:"B200_F043" json:"f043" validate:"min=0"`
+	F044   bool          `env:"B200_F044" json:"f044"`
+	F045   time.Duration `env:"B200_F045" json:"f045" validate:"min=0"`
+	F046   float64       `env:"B200_F046" json:"f046" validate:"gte=0"`
+	F047   []string      `env:"B200_F047" json:"f047" validate:"max=10"`
+	F048   string        `env:"B200_F048" json:"f048" validate:"required"`
+	F049   int           `env:"B200_F049" json:"f049" validate:"min=0"`
+	F050   bool          `env:"B200_F050_${ENV}" json:"f050"`
+	F051   time.Duration `env:"B200_F051" json:"f051" validate:"min=0"`
+	F052   float64       `env:"B200_F052" json:"f052" validate:"gte=0"`
+	F053   []string      `env:"B200_F053" json:"f053" validate:"max=10"`
+	F054   string        `env:"B200_F054" json:"f054" validate:"required"`
+	F055   int           `env:"B200_F055" json:"f055" validate:"min=0"`
+	F056   bool          `env:"B200_F056" json:"f056"`
+	F057   time.Duration `env:"B200_F057" json:"f057" validate:"min=0"`
+	F058   float64       `env:"B200_F058" json:"f058" validate:"gte=0"`
+	F059   []string      `env:"B200_F059" json:"f059" validate:"max=10"`
+	F060   string        `env:"B200_F060_${ENV}" json:"f060"`
+	F061   int           `env:"B200_F061" json:"f061" validate:"min=0"`
+	F062   bool          `env:"B200_F062" json:"f062"`
+	F063   time.Duration `env:"B200_F063" json:"f063" validate:"min=0"`
+	F064   float64       `env:"B200_F064" json:"f064" validate:"gte=0"`
+	F065   []string      `env:"B200_F065" json:"f065" validate:"max=10"`
+	F066   string        `env:"B200_F066" json:"f066" validate:"required"`
+	F067   int           `env:"B200_F067" json:"f067" validate:"min=0"`
+	F068   bool          `env:"B200_F068" json:"f068"`
+	F069   time.Duration `env:"B200_F069" json:"f069" validate:"min=0"`
+	F070   float64       `env:"B200_F070_${ENV}" json:"f070"`
+	F071   []string      `env:"B200_F071" json:"f071" validate:"max=10"`
+	F072   string        `env:"B200_F072" json:"f072" validate:"required"`
+	F073   int           `env:"B200_F073" json:"f073" validate:"min=0"`
+	F074   bool          `env:"B200_F074" json:"f074"`
+	F075   time.Duration `env:"B200_F075" json:"f075" validate:"min=0"`
+	F076   float64       `env:"B200_F076" json:"f076" validate:"gte=0"`
+	F077   []string      `env:"B200_F077" json:"f077" validate:"max=10"`
+	F078   string        `env:"B200_F078" json:"f078" validate:"required"`
+	F079   int           `env:"B200_F079" json:"f079" validate:"min=0"`
+	F080   bool          `env:"B200_F080_${ENV}" json:"f080"`
+	F081   time.Duration `env:"B200_F081" json:"f081" validate:"min=0"`
+	F082   float64       `env:"B200_F082" json:"f082" validate:"gte=0"`
+	F083   []string      `env:"B200_F083" json:"f083" validate:"max=10"`
+	F084   string        `env:"B200_F084" json:"f084" validate:"required"`
+	F085   int           `env:"B200_F085" json:"f085" validate:"min=0"`
+	F086   bool          `env:"B200_F086" json:"f086"`
+	F087   time.Duration `env:"B200_F087" json:"f087" validate:"min=0"`
+	F088   float64       `env:"B200_F088" json:"f088" validate:"gte=0"`
+	F089   []string      `env:"B200_F089" json:"f089" validate:"max=10"`
+	F090   string        `env:"B200_F090_${ENV}" json:"f090"`
+	F091   int           `env:"B200_F091" json:"f091" validate:"min=0"`
+	F092   bool          `env:"B200_F092" json:"f092"`
+	F093   time.Duration `env:"B200_F093" json:"f093" validate:"min=0"`
+	F094   float64       `env:"B200_F094" json:"f094" validate:"gte=0"`
+	F095   []string      `env:"B200_F095" json:"f095" validate:"max=10"`
+	F096   string        `env:"B200_F096" json:"f096" validate:"required"`
+	F097   int           `env:"B200_F097" json:"f097" validate:"min=0"`
+	F098   bool          `env:"B200_F098" json:"f098"`
+	F099   time.Duration `env:"B200_F099" json:"f099" validate:"min=0"`
+	F100   float64       `env:"B200_F100_${ENV}" json:"f100"`
+	F101   []string      `env:"B200_F101" json:"f101" validate:"max=10"`
+	F102   string        `env:"B200_F102" json:"f102" validate:"required"`
+	F103   int           `env:"B200_F103" json:"f103" validate:"min=0"`
+	F104   bool          `env:"B200_F104" json:"f104"`
+	F105   time.Duration `env:"B200_F105" json:"f105" validate:"min=0"`
+	F106   float64       `env:"B200_F106" json:"f106" validate:"gte=0"`
+	F107   []string      `env:"B200_F107" json:"f107" validate:"max=10"`
+	F108   string        `env:"B200_F108" json:"f108" validate:"required"`
+	F109   int           `env:"B200_F109" json:"f109" validate:"min=0"`
+	F110   bool          `env:"B200_F110_${ENV}" json:"f110"`
+	F111   time.Duration `env:"B200_F111" json:"f111" validate:"min=0"`
+	F112   float64       `env:"B200_F112" json:"f112" validate:"gte=0"`
+	F113   []string      `env:"B200_F113" json:"f113" validate:"max=10"`
+	F114   string        `env:"B200_F114" json:"f114" validate:"required"`
+	F115   int           `env:"B200_F115" json:"f115" validate:"min=0"`
+	F116   bool          `env:"B200_F116" json:"f116"`
+	F117   time.Duration `env:"B200_F117" json:"f117" validate:"min=0"`
+	F118   float64       `env:"B200_F118" json:"f118" validate:"gte=0"`
+	F119   []string      `env:"B200_F119" json:"f119" validate:"max=10"`
+	F120   string        `env:"B200_F120_${ENV}" json:"f120"`
+	F121   int           `env:"B200_F121" json:"f121" validate:"min=0"`
+	F122   bool          `env:"B200_F122" json:"f122"`
+	F123   time.Duration `env:"B200_F123" json:"f123" validate:"min=0"`
+	F124   float64       `env:"B200_F124" json:"f124" validate:"gte=0"`
+	F125   []string      `env:"B200_F125" json:"f125" validate:"max=10"`
+	F126   string        `env:"B200_F126" json:"f126" validate:"required"`
+	F127   int           `env:"B200_F127" json:"f127" validate:"min=0"`
+	F128   bool          `env:"B200_F128" json:"f128"`
+	F129   time.Duration `env:"B200_F129" json:"f129" validate:"min=0"`
+	F130   float64       `env:"B200_F130_${ENV}" json:"f130"`
+	F131   []string      `env:"B200_F131" json:"f131" validate:"max=10"`
+	F132   string        `env:"B200_F132" json:"f132" validate:"required"`
+	F133   int           `env:"B200_F133" json:"f133" validate:"min=0"`
+	F134   bool          `env:"B200_F134" json:"f134"`
+	F135   time.Duration `env:"B200_F135" json:"f135" validate:"min=0"`
+	F136   float64       `env:"B200_F136" json:"f136" validate:"gte=0"`
+	F137   []string      `env:"B200_F137" json:"f137" validate:"max=10"`
+	F138   string        `env:"B200_F138" json:"f138" validate:"required"`
+	F139   int           `env:"B200_F139" json:"f139" validate:"min=0"`
+	F140   bool          `env:"B200_F140_${ENV}" json:"f140"`
+	F141   time.Duration `env:"B200_F141" json:"f141" validate:"min=0"`
+	F142   float64       `env:"B200_F142" json:"f142" validate:"gte=0"`
+	F143   []string      `env:"B200_F143" json:"f143" validate:"max=10"`
+	F144   string        `env:"B200_F144" json:"f144" validate:"required"`
+	F145   int           `env:"B200_F145" json:"f145" validate:"min=0"`
+	F146   bool          `env:"B200_F146" json:"f146"`
+	F147   time.Duration `env:"B200_F147" json:"f147" validate:"min=0"`
+	F148   float64       `env:"B200_F148" json:"f148" validate:"gte=0"`
+	F149   []string      `env:"B200_F149" json:"f149" validate:"max=10"`
+	F150   string        `env:"B200_F150_${ENV}" json:"f150"`
+	F151   int           `env:"B200_F151" json:"f151" validate:"min=0"`
+	F152   bool          `env:"B200_F152" json:"f152"`
+	F153   time.Duration `env:"B200_F153" json:"f153" validate:"min=0"`
+	F154   float64       `env:"B200_F154" json:"f154" validate:"gte=0"`
+	F155   []string      `env:"B200_F155" json:"f155" validate:"max=10"`
+	F156   string        `env:"B200_F156" json:"f156" validate:"required"`
+	F157   int           `env:"B200_F157" json:"f157" validate:"min=0"`
+	F158   bool          `env:"B200_F158" json:"f158"`
+	F159   time.Duration `env:"B200_F159" json:"f159" validate:"min=0"`
+	F160   float64       `env:"B200_F160_${ENV}" json:"f160"`
+	F161   []string      `env:"B200_F161" json:"f161" validate:"max=10"`
+	F162   string        `env:"B200_F162" json:"f162" validate:"required"`
+	F163   int           `env:"B200_F163" json:"f163" validate:"min=0"`
+	F164   bool          `env:"B200_F164" json:"f164"`
+	F165   time.Duration `env:"B200_F165" json:"f165" validate:"min=0"`
+	F166   float64       `env:"B200_F166" json:"f166" validate:"gte=0"`
+	F167   []string      `env:"B200_F167" json:"f167" validate:"max=10"`
+	F168   string        `env:"B200_F168" json:"f168" validate:"required"`
+	F169   int           `env:"B200_F169" json:"f169" validate:"min=0"`
+	F170   bool          `env:"B200_F170_${ENV}" json:"f170"`
+	F171   time.Duration `env:"B200_F171" json:"f171" validate:"min=0"`
+	F172   float64       `env:"B200_F172" json:"f172" validate:"gte=0"`
+	F173   []string      `env:"B200_F173" json:"f173" validate:"max=10"`
+	F174   string        `env:"B200_F174" json:"f174" validate:"required"`
+	F175   int           `env:"B200_F175" json:"f175" validate:"min=0"`
+	F176   bool          `env:"B200_F176" json:"f176"`
+	F177   time.Duration `env:"B200_F177" json:"f177" validate:"min=0"`
+	F178   float64       `env:"B200_F178" json:"f178" validate:"gte=0"`
+	F179   []string      `env:"B200_F179" json:"f179" validate:"max=10"`
+	F180   string        `env:"B200_F180_${ENV}" json:"f180"`
+	F181   int           `env:"B200_F181" json:"f181" validate:"min=0"`
+	F182   bool          `env:"B200_F182" json:"f182"`
+	F183   time.Duration `env:"B200_F183" json:"f183" validate:"min=0"`
+	F184   float64       `env:"B200_F184" json:"f184" validate:"gte=0"`
+	F185   []string      `env:"B200_F185" json:"f185" validate:"max=10"`
+	F186   string        `env:"B200_F186" json:"f186" validate:"required"`
+	F187   int           `env:"B200_F187" json:"f187" validate:"min=0"`
+	F188   bool          `env:"B200_F188" json:"f188"`
+	F189   time.Duration `env:"B200_F189" json:"f189" validate:"min=0"`
+	F190   float64       `env:"B200_F190_${ENV}" json:"f190"`
+	F191   []string      `env:"B200_F191" json:"f191" validate:"max=10"`
+	F192   string        `env:"B200_F192" json:"f192" validate:"required"`
+	F193   int           `env:"B200_F193" json:"f193" validate:"min=0"`
+	F194   bool          `env:"B200_F194" json:"f194"`
+	F195   time.Duration `env:"B200_F195" json:"f195" validate:"min=0"`
+	F196   float64       `env:"B200_F196" json:"f196" validate:"gte=0"`
+	F197   []string      `env:"B200_F197" json:"f197" validate:"max=10"`
+	F198   string        `env:"B200_F198" json:"f198" validate:"required"`
+	F199   int           `env:"B200_F199" json:"f199" validate:"min=0"`
+}
+
+// config500 has 500 fields.
+type config500 struct {
+	Env    string        `env:"B500_ENV" json:"env" config:"availableAs=ENV" validate:"required"`
+	Region string        `env:"B500_REGION" json:"region" config:"availableAs=REGION" validate:"required"`
+	F002   bool          `env:"B500_F002" json:"f002"`
+	F003   time.Duration `env:"B500_F003" json:"f003" validate:"min=0"`
+	F004   float64       `env:"B500_F004" json:"f004" validate:"gte=0"`
+	F005   []string      `env:"B500_F005" json:"f005" validate:"max=10"`
+	F006   string        `env:"B500_F006" json:"f006" validate:"required"`
+	F007   int           `env:"B500_F007" json:"f007" validate:"min=0"`
+	F008   bool          `env:"B500_F008" json:"f008"`
+	F009   time.Duration `env:"B500_F009" json:"f009" validate:"min=0"`
+	F010   float64       `env:"B500_F010_${ENV}" json:"f010"`
+	F011   []string      `env:"B500_F011" json:"f011" validate:"max=10"`
+	F012   string        `env:"B500_F012" json:"f012" validate:"required"`
+	F013   int           `env:"B500_F013" json:"f013" validate:"min=0"`
+	F014   bool          `env:"B500_F014" json:"f014"`
+	F015   time.Duration `env:"B500_F015" json:"f015" validate:"min=0"`
+	F016   float64       `env:"B500_F016" json:"f016" validate:"gte=0"`
+	F017   []string      `env:"B500_F017" json:"f017" validate:"max=10"`
+	F018   string        `env:"B500_F018" json:"f018" validate:"required"`
+	F019   int           `env:"B500_F019" json:"f019" validate:"min=0"`
+	F020   bool          `env:"B500_F020_${ENV}" json:"f020"`
+	F021   time.Duration `env:"B500_F021" json:"f021" validate:"min=0"`
+	F022   float64       `env:"B500_F022" json:"f022" validate:"gte=0"`
+	F023   []string      `env:"B500_F023" json:"f023" validate:"max=10"`
+	F024   string        `env:"B500_F024" json:"f024" validate:"required"`
+	F025   int           `env:"B500_F025" json:"f025" validate:"min=0"`
+	F026   bool          `env:"B500_F026" json:"f026"`
+	F027   time.Duration `env:"B500_F027" json:"f027" validate:"min=0"`
+	F028   float64       `env:"B500_F028" json:"f028" validate:"gte=0"`
+	F029   []string      `env:"B500_F029" json:"f029" validate:"max=10"`
+	F030   string        `env:"B500_F030_${ENV}" json:"f030"`
+	F031   int           `env:"B500_F031" json:"f031" validate:"min=0"`
+	F032   bool          `env:"B500_F032" json:"f032"`
+	F033   time.Duration `env:"B500_F033" json:"f033" validate:"min=0"`
+	F034   float64       `env:"B500_F034" json:"f034" validate:"gte=0"`
+	F035   []string      `env:"B500_F035" json:"f035" validate:"max=10"`
+	F036   string        `env:"B500_F036" json:"f036" validate:"required"`
+	F037   int           `env:"B500_F037" json:"f037" validate:"min=0"`
+	F038   bool          `env:"B500_F038" json:"f038"`
+	F039   time.Duration `env:"B500_F039" json:"f039" validate:"min=0"`
+	F040   float64       `env:"B500_F040_${ENV}" json:"f040"`
+	F041   []string      `env:"B500_F041" json:"f041" validate:"max=10"`
+	F042   string        `env:"B500_F042" json:"f042" validate:"required"`
+	F043   int           `env:"B500_F043" json:"f043" validate:"min=0"`
+	F044   bool          `env:"B500_F044" json:"f044"`
+	F045   time.Duration `env:"B500_F045" json:"f045" validate:"min=0"`
+	F046   float64       `env:"B500_F046" json:"f046" validate:"gte=0"`
+	F047   []string      `env:"B500_F047" json:"f047" validate:"max=10"`
+	F048   string        `env:"B500_F048" json:"f048" validate:"required"`
+	F049   int           `env:"B500_F049" json:"f049" validate:"min=0"`
+	F050   bool          `env:"B500_F050_${ENV}" json:"f050"`
+	F051   time.Duration `env:"B500_F051" json:"f051" validate:"min=0"`
+	F052   float64       `env:"B500_F052" json:"f052" validate:"gte=0"`
+	F053   []string      `env:"B500_F053" json:"f053" validate:"max=10"`
+	F054   string        `env:"B500_F054" json:"f054" validate:"required"`
+	F055   int           `env:"B500_F055" json:"f055" validate:"min=0"`
+	F056   bool          `env:"B500_F056" json:"f056"`
+	F057   time.Duration `env:"B500_F057" json:"f057" validate:"min=0"`
+	F058   float64       `env:"B500_F058" json:"f058" validate:"gte=0"`
+	F059   []string      `env:"B500_F059" json:"f059" validate:"max=10"`
+	F060   string        `env:"B500_F060_${ENV}" json:"f060"`
+	F061   int           `env:"B500_F061" json:"f061" validate:"min=0"`
+	F062   bool          `env:"B500_F062" json:"f062"`
+	F063   time.Duration `env:"B500_F063" json:"f063" validate:"min=0"`
+	F064   float64       `env:"B500_F064" json:"f064" validate:"gte=0"`
+	F065   []string      `env:"B500_F065" json:"f065" validate:"max=10"`
+	F066   string        `env:"B500_F066" json:"f066" validate:"required"`
+	F067   int           `env:"B500_F067" json:"f067" validate:"min=0"`
+	F068   bool          `env:"B500_F068" json:"f068"`
+	F069   time.Duration `env:"B500_F069" json:"f069" validate:"min=0"`
+	F070   float64       `env:"B500_F070_${ENV}" json:"f070"`
+	F071   []string      `env:"B500_F071" json:"f071" validate:"max=10"`
+	F072   string        `env:"B500_F072" json:"f072" validate:"required"`
+	F073   int           `env:"B500_F073" json:"f073" validate:"min=0"`
+	F074   bool          `env:"B500_F074" json:"f074"`
+	F075   time.Duration `env:"B500_F075" json:"f075" validate:"min=0"`
+	F076   float64       `env:"B500_F076" json:"f076" validate:"gte=0"`
+	F077   []string      `env:"B500_F077" json:"f077" validate:"max=10"`
+	F078   string        `env:"B500_F078" json:"f078" validate:"required"`
+	F079   int           `env:"B500_F079" json:"f079" validate:"min=0"`
+	F080   bool          `env:"B500_F080_${ENV}" json:"f080"`
+	F081   time.Duration `env:"B500_F081" json:"f081" validate:"min=0"`
+	F082   float64       `env:"B500_F082" json:"f082" validate:"gte=0"`
+	F083   []string      `env:"B500_F083" json:"f083" validate:"max=10"`
+	F084   string        `env:"B500_F084" json:"f084" validate:"required"`
+	F085   int           `env:"B500_F085" json:"f085" validate:"min=0"`
+	F086   bool          `env:"B500_F086" json:"f086"`
+	F087   time.Duration `env:"B500_F087" json:"f087" validate:"min=0"`
+	F088   float64       `env:"B500_F088" json:"f088" validate:"gte=0"`
+	F089   []string      `env:"B500_F089" json:"f089" validate:"max=10"`
+	F090   string        `env:"B500_F090_${ENV}" json:"f090"`
+	F091   int           `env:"B500_F091" json:"f091" validate:"min=0"`
+	F092   bool          `env:"B500_F092" json:"f092"`
+	F093   time.Duration `env:"B500_F093" json:"f093" validate:"min=0"`
+	F094   float64       `env:"B500_F094" json:"f094" validate:"gte=0"`
+	F095   []string      `env:"B500_F095" json:"f095" validate:"max=10"`
+	F096   string        `env:"B500_F096" json:"f096" validate:"required"`
+	F097   int           `env:"B500_F097" json:"f097" validate:"min=0"`
+	F098   bool          `env:"B500_F098" json:"f098"`
+	F099   time.Duration `env:"B500_F099" json:"f099" validate:"min=0"`
+	F100   float64       `env:"B500_F100_${ENV}" json:"f100"`
+	F101   []string      `env:"B500_F101" json:"f101" validate:"max=10"`
+	F102   string        `env:"B500_F102" json:"f102" validate:"required"`
+	F103   int           `env:"B500_F103" json:"f103" validate:"min=0"`
+	F104   bool          `env:"B500_F104" json:"f104"`
+	F105   time.Duration `env:"B500_F105" json:"f105" validate:"min=0"`
+	F106   float64       `env:"B500_F106" json:"f106" validate:"gte=0"`
+	F107   []string      `env:"B500_F107" json:"f107" validate:"max=10"`
+	F108   string        `env:"B500_F108" json:"f108" validate:"required"`
+	F109   int           `env:"B500_F109" json:"f109" validate:"min=0"`
+	F110   bool          `env:"B500_F110_${ENV}" json:"f110"`
+	F111   time.Duration `env:"B500_F111" json:"f111" validate:"min=0"`
+	F112   float64       `env:"B500_F112" json:"f112" validate:"gte=0"`
+	F113   []string      `env:"B500_F113" json:"f113" validate:"max=10"`
+	F114   string        `env:"B500_F114" json:"f114" validate:"required"`
+	F115   int           `env:"B500_F115" json:"f115" validate:"min=0"`
+	F116   bool          `env:"B500_F116" json:"f116"`
+	F117   time.Duration `env:"B500_F117" json:"f117" validate:"min=0"`
+	F118   float64       `env:"B500_F118" json:"f118" validate:"gte=0"`
+	F119   []string      `env:"B500_F119" json:"f119" validate:"max=10"`
+	F120   string        `env:"B500_F120_${ENV}" json:"f120"`
+	F121   int           `env:"B500_F121" json:"f121" validate:"min=0"`
+	F122   bool          `env:"B500_F122" json:"f122"`
+	F123   time.Duration `env:"B500_F123" json:"f123" validate:"min=0"`
+	F124   float64       `env:"B500_F124" json:"f124" validate:"gte=0"`
+	F125   []string      `env:"B500_F125" json:"f125" validate:"max=10"`
+	F126   string        `env:"B500_F126" json:"f126" validate:"required"`
+	F127   int           `env:"B500_F127" json:"f127" validate:"min=0"`
+	F128   bool          `env:"B500_F128" json:"f128"`
+	F129   time.Duration `env:"B500_F129" json:"f129" validate:"min=0"`
+	F130   float64       `env:"B500_F130_${ENV}" json:"f130"`
+	F131   []string      `env:"B500_F131" json:"f131" validate:"max=10"`
+	F132   string        `env:"B500_F132" json:"f132" validate:"required"`
+	F133   int           `env:"B500_F133" json:"f133" validate:"min=0"`
+	F134   bool          `env:"B500_F134" json:"f134"`
+	F135   time.Duration `env:"B500_F135" json:"f135" validate:"min=0"`
+	F136   float64       `env:"B500_F136" json:"f136" validate:"gte=0"`
+	F137   []string      `env:"B500_F137" json:"f137" validate:"max=10"`
+	F138   string        `env:"B500_F138" json:"f138" validate:"required"`
+	F139   int           `env:"B500_F139" json:"f139" validate:"min=0"`
+	F140   bool          `env:"B500_F140_${ENV}" json:"f140"`
+	F141   time.Duration `env:"B500_F141" json:"f141" validate:"min=0"`
+	F142   float64       `env:"B500_F142" json:"f142" validate:"gte=0"`
+	F143   []string      `env:"B500_F143" json:"f143" validate:"max=10"`
+	F144   string        `env:"B500_F144" json:"f144" validate:"required"`
+	F145   int           `env:"B500_F145" json:"f145" validate:"min=0"`
+	F146   bool          `env:"B500_F146" json:"f146"`
+	F147   time.Duration `env:"B500_F147" json:"f147" validate:"min=0"`
+	F148   float64       `env:"B500_F148" json:"f148" validate:"gte=0"`
+	F149   []string      `env:"B500_F149" json:"f149" validate:"max=10"`
+	F150   string        `env:"B500_F150_${ENV}" json:"f150"`
+	F151   int           `env:"B500_F151" json:"f151" validate:"min=0"`
+	F152   bool          `env:"B500_F152" json:"f152"`
+	F153   time.Duration `env:"B500_F153" json:"f153" validate:"min=0"`
+	F154   float64       `env:"B500_F154" json:"f154" validate:"gte=0"`
+	F155   []string      `env:"B500_F155" json:"f155" validate:"max=10"`
+	F156   string        `env:"B500_F156" json:"f156" validate:"required"`
+	F157   int           `env:"B500_F157" json:"f157" validate:"min=0"`
+	F158   bool          `env:"B500_F158" json:"f158"`
+	F159   time.Duration `env:"B500_F159" json:"f159" validate:"min=0"`
+	F160   float64       `env:"B500_F160_${ENV}" json:"f160"`
+	F161   []string      `env:"B500_F161" json:"f161" validate:"max=10"`
+	F162   string        `env:"B500_F162" json:"f162" validate:"required"`
+	F163   int           `env:"B500_F163" json:"f163" validate:"min=0"`
+	F164   bool          `env:"B500_F164" json:"f164"`
+	F165   time.Duration `env:"B500_F165" json:"f165" validate:"min=0"`
+	F166   float64       `env:"B500_F166" json:"f166" validate:"gte=0"`
+	F167   []string      `env:"B500_F167" json:"f167" validate:"max=10"`
+	F168   string        `env:"B500_F168" json:"f168" validate:"required"`
+	F169   int           `env:"B500_F169" json:"f169" validate:"min=0"`
+	F170   bool          `env:"B500_F170_${ENV}" json:"f170"`
+	F171   time.Duration `env:"B500_F171" json:"f171" validate:"min=0"`
+	F172   float64       `env:"B500_F172" json:"f172" validate:"gte=0"`
+	F173   []string      `env:"B500_F173" json:"f173" validate:"max=10"`
+	F174   string        `env:"B500_F174" json:"f174" validate:"required"`
+	F175   int           `env:"B500_F175" json:"f175" validate:"min=0"`
+	F176   bool          `env:"B500_F176" json:"f176"`
+	F177   time.Duration `env:"B500_F177" json:"f177" validate:"min=0"`
+	F178   float64       `env:"B500_F178" json:"f178" validate:"gte=0"`
+	F179   []string      `env:"B500_F179" json:"f179" validate:"max=10"`
+	F180   string        `env:"B500_F180_${ENV}" json:"f180"`
+	F181   int           `env:"B500_F181" json:"f181" validate:"min=0"`
+	F182   bool          `env:"B500_F182" json:"f182"`
+	F183   time.Duration `env:"B500_F183" json:"f183" validate:"min=0"`
+	F184   float64       `env:"B500_F184" json:"f184" validate:"gte=0"`
+	F185   []string      `env:"B500_F185" json:"f185" validate:"max=10"`
+	F186   string        `env:"B500_F186" json:"f186" validate:"required"`
+	F187   int           `env:"B500_F187" json:"f187" validate:"min=0"`
+	F188   bool          `env:"B500_F188" json:"f188"`
+	F189   time.Duration `env:"B500_F189" json:"f189" validate:"min=0"`
+	F190   float64       `env:"B500_F190_${ENV}" json:"f190"`
+	F191   []string      `env:"B500_F191" json:"f191" validate:"max=10"`
+	F192   string        `env:"B500_F192" json:"f192" validate:"required"`
+	F193   int           `env:"B500_F193" json:"f193" validate:"min=0"`
+	F194   bool          `env:"B500_F194" json:"f194"`
+	F195   time.Duration `env:"B500_F195" json:"f195" validate:"min=0"`
+	F196   float64       `env:"B500_F196" json:"f196" validate:"gte=0"`
+	F197   []string      `env:"B500_F197" json:"f197" validate:"max=10"`
+	F198   string        `env:"B500_F198" json:"f198" validate:"required"`
+	F199   int           `env:"B500_F199" json:"f199" validate:"min=0"`
+	F200   bool          `env:"B500_F200_${ENV}" json:"f200"`
+	F201   time.Duration `env:"B500_F201" json:"f201" validate:"min=0"`
+	F202   float64       `env:"B500_F202" json:"f202" validate:"gte=0"`
+	F203   []string      `env:"B500_F203" json:"f203" validate:"max=10"`
+	F204   string        `env:"B500_F204" json:"f204" validate:"required"`
+	F205   int           `env:"B500_F205" json:"f205" validate:"min=0"`
+	F206   bool          `env:"B500_F206" json:"f206"`
+	F207   time.Duration `env:"B500_F207" json:"f207" validate:"min=0"`
+	F208   float64       `env:"B500_F208" json:"f208" validate:"gte=0"`
+	F209   []string      `env:"B500_F209" json:"f209" validate:"max=10"`
+	F210   string        `env:"B500_F210_${ENV}" json:"f210"`
+	F211   int           `env:"B500_F211" json:"f211" validate:"min=0"`
+	F212   bool          `env:"B500_F212" json:"f212"`
+	F213   time.Duration `env:"B500_F213" json:"f213" validate:"min=0"`
+	F214   float64       `env:"B500_F214" json:"f214" validate:"gte=0"`
+	F215   []string      `env:"B500_F215" json:"f215" validate:"max=10"`
+	F216   string        `env:"B500_F216" json:"f216" validate:"required"`
+	F217   int           `env:"B500_F217" json:"f217" validate:"min=0"`
+	F218   bool          `env:"B500_F218" json:"f218"`
+	F219   time.Duration `env:"B500_F219" json:"f219" validate:"min=0"`
+	F220   float64       `env:"B500_F220_${ENV}" json:"f220"`
+	F221   []string      `env:"B500_F221" json:"f221" validate:"max=10"`
+	F222   string        `env:"B500_F222" json:"f222" validate:"required"`
+	F223   int           `env:"B500_F223" json:"f223" validate:"min=0"`
+	F224   bool          `env:"B500_F224" json:"f224"`
+	F225   time.Duration `env:"B500_F225" json:"f225" validate:"min=0"`
+	F226   float64       `env:"B500_F226" json:"f226" validate:"gte=0"`
+	F227   []string      `env:"B500_F227" json:"f227" validate:"max=10"`
+	F228   string        `env:"B500_F228" json:"f228" validate:"required"`
+	F229   int           `env:"B500_F229" json:"f229" validate:"min=0"`
+	F230   bool          `env:"B500_F230_${ENV}" json:"f230"`
+	F231   time.Duration `env:"B500_F231" json:"f231" validate:"min=0"`
+	F232   float64       `env:"B500_F232" json:"f232" validate:"gte=0"`
+	F233   []string      `env:"B500_F233" json:"f233" validate:"max=10"`
+	F234   string        `env:"B500_F234" json:"f234" validate:"required"`
+	F235   int           `env:"B500_F235" json:"f235" validate:"min=0"`
+	F236   bool          `env:"B500_F236" json:"f236"`
+	F237   time.Duration `env:"B500_F237" json:"f237" validate:"min=0"`
+	F238   float64       `env:"B500_F238" json:"f238" validate:"gte=0"`
+	F239   []string      `env:"B500_F239" json:"f239" validate:"max=10"`
+	F240   string        `env:"B500_F240_${ENV}" json:"f240"`
+	F241   int           `env:"B500_F241" json:"f241" validate:"min=0"`
+	F242   bool          `env:"B500_F242" json:"f242"`
+	F243   time.Duration `env:"B500_F243" json:"f243" validate:"min=0"`
+	F244   float64       `env:"B500_F244" json:"f244" validate:"gte=0"`
+	F245   []string      `env:"B500_F245" json:"f245" validate:"max=10"`
+	F246   string        `env:"B500_F246" json:"f246" validate:"required"`
+	F247   int           `env:"B500_F247" json:"f247" validate:"min=0"`
+	F248   bool          `env:"B500_F248" json:"f248"`
+	F249   time.Duration `env:"B500_F249" json:"f249" validate:"min=0"`
+	F250   float64       `env:"B500_F250_${ENV}" json:"f250"`
+	F251   []string      `env:"B500_F251" json:"f251" validate:"max=10"`
+	F252   string        `env:"B500_F252" json:"f252" validate:"required"`
+	F253   int           `env:"B500_F253" json:"f253" validate:"min=0"`
+	F254   bool          `env:"B500_F254" json:"f254"`
+	F255   time.Duration `env:"B500_F255" json:"f255" validate:"min=0"`
+	F256   float64       `env:"B500_F256" json:"f256" validate:"gte=0"`
+	F257   []string      `env:"B500_F257" json:"f257" validate:"max=10"`
+	F258   string        `env:"B500_F258" json:"f258" validate:"required"`
+	F259   int           `env:"B500_F259" json:"f259" validate:"min=0"`
+	F260   bool          `env:"B500_F260_${ENV}" json:"f260"`
+	F261   time.Duration `env:"B500_F261" json:"f261" validate:"min=0"`
+	F262   float64       `env:"B500_F262" json:"f262" validate:"gte=0"`
+	F263   []string      `env:"B500_F263" json:"f263" validate:"max=10"`
+	F264   string        `env:"B500_F264" json:"f264" validate:"required"`
+	F265   int           `env:"B500_F265" json:"f265" validate:"min=0"`
+	F266   bool          `env:"B500_F266" json:"f266"`
+	F267   time.Duration `env:"B500_F267" json:"f267" validate:"min=0"`
+	F268   float64       `env:"B500_F268" json:"f268" validate:"gte=0"`
+	F269   []string      `env:"B500_F269" json:"f269" validate:"max=10"`
+	F270   string        `env:"B500_F270_${ENV}" json:"f270"`
+	F271   int           `env:"B500_F271" json:"f271" validate:"min=0"`
+	F272   bool          `env:"B500_F272" json:"f272"`
+	F273   time.Duration `env:"B500_F273" json:"f273" validate:"min=0"`
+	F274   float64       `env:"B500_F274" json:"f274" validate:"gte=0"`
+	F275   []string      `env:"B500_F275" json:"f275" validate:"max=10"`
+	F276   string        `env:"B500_F276" json:"f276" validate:"required"`
+	F277   int           `env:"B500_F277" json:"f277" validate:"min=0"`
+	F278   bool          `env:"B500_F278" json:"f278"`
+	F279   time.Duration `env:"B500_F279" json:"f279" validate:"min=0"`
+	F280   float64       `env:"B500_F280_${ENV}" json:"f280"`
+	F281   []string      `env:"B500_F281" json:"f281" validate:"max=10"`
+	F282   string        `env:"B500_F282" json:"f282" validate:"required"`
+	F283   int           `env:"B500_F283" json:"f283" validate:"min=0"`
+	F284   bool          `env:"B500_F284" json:"f284"`
+	F285   time.Duration `env:"B500_F285" json:"f285" validate:"min=0"`
+	F286   float64       `env:"B500_F286" json:"f286" validate:"gte=0"`
+	F287   []string      `env:"B500_F287" json:"f287" validate:"max=10"`
+	F288   string        `env:"B500_F288" json:"f288" validate:"required"`
+	F289   int           `env:"B500_F289" json:"f289" validate:"min=0"`
+	F290   bool          `env:"B500_F290_${ENV}" json:"f290"`
+	F291   time.Duration `env:"B500_F291" json:"f291" validate:"min=0"`
+	F292   float64       `env:"B500_F292" json:"f292" validate:"gte=0"`
+	F293   []string      `env:"B500_F293" json:"f293" validate:"max=10"`
+	F294   string        `env:"B500_F294" json:"f294" validate:"required"`
+	F295   int           `env:"B500_F295" json:"f295" validate:"min=0"`
+	F296   bool          `env:"B500_F296" json:"f296"`
+	F297   time.Duration `env:"B500_F297" json:"f297" validate:"min=0"`
+	F298   float64       `env:"B500_F298" json:"f298" validate:"gte=0"`
+	F299   []string      `env:"B500_F299" json:"f299" validate:"max=10"`
+	F300   string        `env:"B500_F300_${ENV}" json:"f300"`
+	F301   int           `env:"B500_F301" json:"f301" validate:"min=0"`
+	F302   bool          `env:"B500_F302" json:"f302"`
+	F303   time.Duration `env:"B500_F303" json:"f303" validate:"min=0"`
+	F304   float64       `env:"B500_F304" json:"f304" validate:"gte=0"`
+	F305   []string      `env:"B500_F305" json:"f305" validate:"max=10"`
+	F306   string        `env:"B500_F306" json:"f306" validate:"required"`
+	F307   int           `env:"B500_F307" json:"f307" validate:"min=0"`
+	F308   bool          `env:"B500_F308" json:"f308"`
+	F309   time.Duration `env:"B500_F309" json:"f309" validate:"min=0"`
+	F310   float64       `env:"B500_F310_${ENV}" json:"f310"`
+	F311   []string      `env:"B500_F311" json:"f311" validate:"max=10"`
+	F312   string        `env:"B500_F312" json:"f312" validate:"required"`
+	F313   int           `env:"B500_F313" json:"f313" validate:"min=0"`
+	F314   bool          `env:"B500_F314" json:"f314"`
+	F315   time.Duration `env:"B500_F315" json:"f315" validate:"min=0"`
+	F316   float64       `env:"B500_F316" json:"f316" validate:"gte=0"`
+	F317   []string      `env:"B500_F317" json:"f317" validate:"max=10"`
+	F318   string        `env:"B500_F318" json:"f318" validate:"required"`
+	F319   int           `env:"B500_F319" json:"f319" validate:"min=0"`
+	F320   bool          `env:"B500_F320_${ENV}" json:"f320"`
+	F321   time.Duration `env:"B500_F321" json:"f321" validate:"min=0"`
+	F322   float64       `env:"B500_F322" json:"f322" validate:"gte=0"`
+	F323   []string      `env:"B500_F323" json:"f323" validate:"max=10"`
+	F324   string        `env:"B500_F324" json:"f324" validate:"required"`
+	F325   int           `env:"B500_F325" json:"f325" validate:"min=0"`
+	F326   bool          `env:"B500_F326" json:"f326"`
+	F327   time.Duration `env:"B500_F327" json:"f327" validate:"min=0"`
+	F328   float64       `env:"B500_F328" json:"f328" validate:"gte=0"`
+	F329   []string      `env:"B500_F329" json:"f329" validate:"max=10"`
+	F330   string        `env:"B500_F330_${ENV}" json:"f330"`
+	F331   int           `env:"B500_F331" json:"f331" validate:"min=0"`
+	F332   bool          `env:"B500_F332" json:"f332"`
+	F333   time.Duration `env:"B500_F333" json:"f333" validate:"min=0"`
+	F334   float64       `env:"B500_F334" json:"f334" validate:"gte=0"`
+	F335   []string      `env:"B500_F335" json:"f335" validate:"max=10"`
+	F336   string        `env:"B500_F336" json:"f336" validate:"required"`
+	F337   int           `env:"B500_F337" json:"f337" validate:"min=0"`
+	F338   bool          `env:"B500_F338" json:"f338"`
+	F339   time.Duration `env:"B500_F339" json:"f339" validate:"min=0"`
+	F340   float64       `env:"B500_F340_${ENV}" json:"f340"`
+	F341   []string      `env:"B500_F341" json:"f341" validate:"max=10"`
+	F342   string        `env:"B500_F342" json:"f342" validate:"required"`
+	F343   int           `env:"B500_F343" json:"f343" validate:"min=0"`
+	F344   bool          `env:"B500_F344" json:"f344"`
+	F345   time.Duration `env:"B500_F345" json:"f345" validate:"min=0"`
+	F346   float64       `env:"B500_F346" json:"f346" validate:"gte=0"`
+	F347   []string      `env:"B500_F347" json:"f347" validate:"max=10"`
+	F348   string        `env:"B500_F348" json:"f348" validate:"required"`
+	F349   int           `env:"B500_F349" json:"f349" validate:"min=0"`
+	F350   bool          `env:"B500_F350_${ENV}" json:"f350"`
+	F351   time.Duration `env:"B500_F351" json:"f351" validate:"min=0"`
+	F352   float64       `env:"B500_F352" json:"f352" validate:"gte=0"`
+	F353   []string      `env:"B500_F353" json:"f353" validate:"max=10"`
+	F354   string        `env:"B500_F354" json:"f354" validate:"required"`
+	F355   int           `env:"B500_F355" json:"f355" validate:"min=0"`
+	F356   bool          `env:"B500_F356" json:"f356"`
+	F357   time.Duration `env:"B500_F357" json:"f357" validate:"min=0"`
+	F358   float64       `env:"B500_F358" json:"f358" validate:"gte=0"`
+	F359   []string      `env:"B500_F359" json:"f359" validate:"max=10"`
+	F360   string        `env:"B500_F360_${ENV}" json:"f360"`
+	F361   int           `env:"B500_F361" json:"f361" validate:"min=0"`
+	F362   bool          `env:"B500_F362" json:"f362"`
+	F363   time.Duration `env:"B500_F363" json:"f363" validate:"min=0"`
+	F364   float64       `env:"B500_F364" json:"f364" validate:"gte=0"`
+	F365   []string      `env:"B500_F365" json:"f365" validate:"max=10"`
+	F366   string        `env:"B500_F366" json:"f366" validate:"required"`
+	F367   int           `env:"B500_F367" json:"f367" validate:"min=0"`
+	F368   bool          `env:"B500_F368" json:"f368"`
+	F369   time.Duration `env:"B500_F369" json:"f369" validate:"min=0"`
+	F370   float64       `env:"B500_F370_${ENV}" json:"f370"`
+	F371   []string      `env:"B500_F371" json:"f371" validate:"max=10"`
+	F372   string        `env:"B500_F372" json:"f372" validate:"required"`
+	F373   int           `env:"B500_F373" json:"f373" validate:"min=0"`
+	F374   bool          `env:"B500_F374" json:"f374"`
+	F375   time.Duration `env:"B500_F375" json:"f375" validate:"min=0"`
+	F376   float64       `env:"B500_F376" json:"f376" validate:"gte=0"`
+	F377   []string      `env:"B500_F377" json:"f377" validate:"max=10"`
+	F378   string        `env:"B500_F378" json:"f378" validate:"required"`
+	F379   int           `env:"B500_F379" json:"f379" validate:"min=0"`
+	F380   bool          `env:"B500_F380_${ENV}" json:"f380"`
+	F381   time.Duration `env:"B500_F381" json:"f381" validate:"min=0"`
+	F382   float64       `env:"B500_F382" json:"f382" validate:"gte=0"`
+	F383   []string      `env:"B500_F383" json:"f383" validate:"max=10"`
+	F384   string        `env:"B500_F384" json:"f384" validate:"required"`
+	F385   int           `env:"B500_F385" json:"f385" validate:"min=0"`
+	F386   bool          `env:"B500_F386" json:"f386"`
+	F387   time.Duration `env:"B500_F387" json:"f387" validate:"min=0"`
+	F388   float64       `env:"B500_F388" json:"f388" validate:"gte=0"`
+	F389   []string      `env:"B500_F389" json:"f389" validate:"max=10"`
+	F390   string        `env:"B500_F390_${ENV}" json:"f390"`
+	F391   int           `env:"B500_F391" json:"f391" validate:"min=0"`
+	F392   bool          `env:"B500_F392" json:"f392"`
+	F393   time.Duration `env:"B500_F393" json:"f393" validate:"min=0"`
+	F394   float64       `env:"B500_F394" json:"f394" validate:"gte=0"`
+	F395   []string      `env:"B500_F395" json:"f395" validate:"max=10"`
+	F396   string        `env:"B500_F396" json:"f396" validate:"required"`
+	F397   int           `env:"B500_F397" json:"f397" validate:"min=0"`
+	F398   bool          `env:"B500_F398" json:"f398"`
+	F399   time.Duration `env:"B500_F399" json:"f399" validate:"min=0"`
+	F400   float64       `env:"B500_F400_${ENV}" json:"f400"`
+	F401   []string      `env:"B500_F401" json:"f401" validate:"max=10"`
+	F402   string        `env:"B500_F402" json:"f402" validate:"required"`
+	F403   int           `env:"B500_F403" json:"f403" validate:"min=0"`
+	F404   bool          `env:"B500_F404" json:"f404"`
+	F405   time.Duration `env:"B500_F405" json:"f405" validate:"min=0"`
+	F406   float64       `env:"B500_F406" json:"f406" validate:"gte=0"`
+	F407   []string      `env:"B500_F407" json:"f407" validate:"max=10"`
+	F408   string        `env:"B500_F408" json:"f408" validate:"required"`
+	F409   int           `env:"B500_F409" json:"f409" validate:"min=0"`
+	F410   bool          `env:"B500_F410_${ENV}" json:"f410"`
+	F411   time.Duration `env:"B500_F411" json:"f411" validate:"min=0"`
+	F412   float64       `env:"B500_F412" json:"f412" validate:"gte=0"`
+	F413   []string      `env:"B500_F413" json:"f413" validate:"max=10"`
+	F414   string        `env:"B500_F414" json:"f414" validate:"required"`
+	F415   int           `env:"B500_F415" json:"f415" validate:"min=0"`
+	F416   bool          `env:"B500_F416" json:"f416"`
+	F417   time.Duration `env:"B500_F417" json:"f417" validate:"min=0"`
+	F418   float64       `env:"B500_F418" json:"f418" validate:"gte=0"`
+	F419   []string      `env:"B500_F419" json:"f419" validate:"max=10"`
+	F420   string        `env:"B500_F420_${ENV}" json:"f420"`
+	F421   int           `env:"B500_F421" json:"f421" validate:"min=0"`
+	F422   bool          `env:"B500_F422" json:"f422"`
+	F423   time.Duration `env:"B500_F423" json:"f423" validate:"min=0"`
+	F424   float64       `env:"B500_F424" json:"f424" validate:"gte=0"`
+	F425   []string      `env:"B500_F425" json:"f425" validate:"max=10"`
+	F426   string        `env:"B500_F426" json:"f426" validate:"required"`
+	F427   int           `env:"B500_F427" json:"f427" validate:"min=0"`
+	F428   bool          `env:"B500_F428" json:"f428"`
+	F429   time.Duration `env:"B500_F429" json:"f429" validate:"min=0"`
+	F430   float64       `env:"B500_F430_${ENV}" json:"f430"`
+	F431   []string      `env:"B500_F431" json:"f431" validate:"max=10"`
+	F432   string        `env:"B500_F432" json:"f432" validate:"required"`
+	F433   int           `env:"B500_F433" json:"f433" validate:"min=0"`
+	F434   bool          `env:"B500_F434" json:"f434"`
+	F435   time.Duration `env:"B500_F435" json:"f435" validate:"min=0"`
+	F436   float64       `env:"B500_F436" json:"f436" validate:"gte=0"`
+	F437   []string      `env:"B500_F437" json:"f437" validate:"max=10"`
+	F438   string        `env:"B500_F438" json:"f438" validate:"required"`
+	F439   int           `env:"B500_F439" json:"f439" validate:"min=0"`
+	F440   bool          `env:"B500_F440_${ENV}" json:"f440"`
+	F441   time.Duration `env:"B500_F441" json:"f441" validate:"min=0"`
+	F442   float64       `env:"B500_F442" json:"f442" validate:"gte=0"`
+	F443   []string      `env:"B500_F443" json:"f443" validate:"max=10"`
+	F444   string        `env:"B500_F444" json:"f444" validate:"required"`
+	F445   int           `env:"B500_F445" json:"f445" validate:"min=0"`
+	F446   bool          `env:"B500_F446" json:"f446"`
+	F447   time.Duration `env:"B500_F447" json:"f447" validate:"min=0"`
+	F448   float64       `env:"B500_F448" json:"f448" validate:"gte=0"`
+	F449   []string      `env:"B500_F449" json:"f449" validate:"max=10"`
+	F450   string        `env:"B500_F450_${ENV}" json:"f450"`
+	F451   int           `env:"B500_F451" json:"f451" validate:"min=0"`
+	F452   bool          `env:"B500_F452" json:"f452"`
+	F453   time.Duration `env:"B500_F453" json:"f453" validate:"min=0"`
+	F454   float64       `env:"B500_F454" json:"f454" validate:"gte=0"`
+	F455   []string      `env:"B500_F455" json:"f455" validate:"max=10"`
+	F456   string        `env:"B500_F456" json:"f456" validate:"required"`
+	F457   int           `env:"B500_F457" json:"f457" validate:"min=0"`
+	F458   bool          `env:"B500_F458" json:"f458"`
+	F459   time.Duration `env:"B500_F459" json:"f459" validate:"min=0"`
+	F460   float64       `env:"B500_F460_${ENV}" json:"f460"`
+	F461   []string      `env:"B500_F461" json:"f461" validate:"max=10"`
+	F462   string        `env:"B500_F462" json:"f462" validate:"required"`
+	F463   int           `env:"B500_F463" json:"f463" validate:"min=0"`
+	F464   bool          `env:"B500_F464" json:"f464"`
+	F465   time.Duration `env:"B500_F465" json:"f465" validate:"min=0"`
+	F466   float64       `env:"B500_F466" json:"f466" validate:"gte=0"`
+	F467   []string      `env:"B500_F467" json:"f467" validate:"max=10"`
+	F468   string        `env:"B500_F468" json:"f468" validate:"required"`
+	F469   int           `env:"B500_F469" json:"f469" validate:"min=0"`
+	F470   bool          `env:"B500_F470_${ENV}" json:"f470"`
+	F471   time.Duration `env:"B500_F471" json:"f471" validate:"min=0"`
+	F472   float64       `env:"B500_F472" json:"f472" validate:"gte=0"`
+	F473   []string      `env:"B500_F473" json:"f473" validate:"max=10"`
+	F474   string        `env:"B500_F474" json:"f474" validate:"required"`
+	F475   int           `env:"B500_F475" json:"f475" validate:"min=0"`
+	F476   bool          `env:"B500_F476" json:"f476"`
+	F477   time.Duration `env:"B500_F477" json:"f477" validate:"min=0"`
+	F478   float64       `env:"B500_F478" json:"f478" validate:"gte=0"`
+	F479   []string      `env:"B500_F479" json:"f479" validate:"max=10"`
+	F480   string        `env:"B500_F480_${ENV}" json:"f480"`
+	F481   int           `env:"B500_F481" json:"f481" validate:"min=0"`
+	F482   bool          `env:"B500_F482" json:"f482"`
+	F483   time.Duration `env:"B500_F483" json:"f483" validate:"min=0"`
+	F484   float64       `env:"B500_F484" json:"f484" validate:"gte=0"`
+	F485   []string      `env:"B500_F485" json:"f485" validate:"max=10"`
+	F486   string        `env:"B500_F486" json:"f486" validate:"required"`
+	F487   int           `env:"B500_F487" json:"f487" validate:"min=0"`
+	F488   bool          `env:"B500_F488" json:"f488"`
+	F489   time.Duration `env:"B500_F489" json:"f489" validate:"min=0"`
+	F490   float64       `env:"B500_F490_${ENV}" json:"f490"`
+	F491   []string      `env:"B500_F491" json:"f491" validate:"max=10"`
+	F492   string        `env:"B500_F492" json:"f492" validate:"required"`
+	F493   int           `env:"B500_F493" json:"f493" validate:"min=0"`
+	F494   bool          `env:"B500_F494" json:"f494"`
+	F495   time.Duration `env:"B500_F495" json:"f495" validate:"min=0"`
+	F496   float64       `env:"B500_F496" json:"f496" validate:"gte=0"`
+	F497   []string      `env:"B500_F497" json:"f497" validate:"max=10"`
+	F498   string        `env:"B500_F498" json:"f498" validate:"required"`
+	F499   int           `env:"B500_F499" json:"f499" validate:"min=0"`
+}
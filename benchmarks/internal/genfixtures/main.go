@@ -0,0 +1,71 @@
+// Command genfixtures writes the configuration structs used by the benchmarks.
+//
+// Each struct starts with two availableAs fields and cycles through string, int, bool,
+// duration, float and slice fields. Every tenth field references ${ENV} so loading is
+// staged, and every field has env, json and validate tags.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+)
+
+// sizes are the field counts of the generated structs.
+var sizes = []int{50, 200, 500}
+
+// kinds are the field types cycled through, with a validate rule for each.
+var kinds = []struct {
+	goType   string
+	validate string
+}{
+	{"string", "required"},
+	{"int", "min=0"},
+	{"bool", ""},
+	{"time.Duration", "min=0"},
+	{"float64", "gte=0"},
+	{"[]string", "max=10"},
+}
+
+func main() {
+	out := flag.String("out", "fixtures_test.go", "output file")
+	flag.Parse()
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by genfixtures. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package benchmarks")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `import "time"`)
+
+	for _, size := range sizes {
+		prefix := fmt.Sprintf("B%03d", size)
+		fmt.Fprintf(&buf, "\n// config%d has %d fields.\ntype config%d struct {\n", size, size, size)
+		fmt.Fprintf(&buf, "\tEnv string `env:\"%s_ENV\" json:\"env\" config:\"availableAs=ENV\" validate:\"required\"`\n", prefix)
+		fmt.Fprintf(&buf, "\tRegion string `env:\"%s_REGION\" json:\"region\" config:\"availableAs=REGION\" validate:\"required\"`\n", prefix)
+		for i := 2; i < size; i++ {
+			kind := kinds[i%len(kinds)]
+			envName := fmt.Sprintf("%s_F%03d", prefix, i)
+			if i%10 == 0 {
+				envName += "_${ENV}"
+			}
+			tag := fmt.Sprintf("env:%q json:\"f%03d\"", envName, i)
+			if kind.validate != "" && i%10 != 0 {
+				tag += fmt.Sprintf(" validate:%q", kind.validate)
+			}
+			fmt.Fprintf(&buf, "\tF%03d %s `%s`\n", i, kind.goType, tag)
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
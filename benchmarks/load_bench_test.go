@@ -0,0 +1,156 @@
+//go:build !tinygo
+
+package benchmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/gymshark/go-easy-config"
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+// setEnvironment sets a valid value for every environment variable named by the env tags
+// of C, except interpolated names.
+func setEnvironment[C any](tb testing.TB) {
+	t := reflect.TypeOf((*C)(nil)).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if name == "" || strings.Contains(name, "${") {
+			continue
+		}
+		tb.Setenv(name, sampleValue(field.Type, i))
+	}
+}
+
+// jsonDocument returns a JSON document setting every third field of C.
+func jsonDocument[C any](tb testing.TB) []byte {
+	t := reflect.TypeOf((*C)(nil)).Elem()
+	doc := make(map[string]any)
+	for i := 0; i < t.NumField(); i += 3 {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		value := reflect.New(field.Type).Elem()
+		switch field.Type.Kind() {
+		case reflect.Slice:
+			value = reflect.ValueOf([]string{"a", "b"})
+		case reflect.Bool:
+			value.SetBool(true)
+		case reflect.String:
+			value.SetString(sampleValue(field.Type, i))
+		case reflect.Int64, reflect.Int:
+			value.SetInt(int64(i))
+		case reflect.Float64:
+			value.SetFloat(float64(i) / 2)
+		}
+		doc[name] = value.Interface()
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+// sampleValue returns a valid environment value for a field of type t.
+func sampleValue(t reflect.Type, i int) string {
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return fmt.Sprintf("%ds", i)
+	case t.Kind() == reflect.Slice:
+		return "a,b,c"
+	case t.Kind() == reflect.Bool:
+		return "true"
+	case t.Kind() == reflect.Int:
+		return fmt.Sprint(i)
+	case t.Kind() == reflect.Float64:
+		return fmt.Sprintf("%d.5", i)
+	default:
+		return fmt.Sprintf("value-%d", i)
+	}
+}
+
+// newHandler returns a handler loading C from the environment, and from a JSON document
+// when mixed is set.
+func newHandler[C any](tb testing.TB, mixed bool) *config.Handler[C] {
+	setEnvironment[C](tb)
+	loaders := []config.Loader[C]{&generic.EnvironmentLoader[C]{}}
+	if mixed {
+		loaders = append(loaders, &generic.JSONLoader[C]{Source: jsonDocument[C](tb)})
+	}
+	return config.NewConfigHandler[C](config.WithLoaders(loaders...))
+}
+
+// loadOnce loads and validates a fresh C.
+func loadOnce[C any](tb testing.TB, handler *config.Handler[C]) {
+	var cfg C
+	if err := handler.LoadAndValidate(&cfg); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func benchmarkLoad[C any](mixed bool) func(*testing.B) {
+	return func(b *testing.B) {
+		handler := newHandler[C](b, mixed)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			loadOnce(b, handler)
+		}
+	}
+}
+
+// BenchmarkLoadAndValidate loads and validates structs of each size from the environment
+// alone and from the environment and a JSON document.
+func BenchmarkLoadAndValidate(b *testing.B) {
+	b.Run("fields=50/env", benchmarkLoad[config50](false))
+	b.Run("fields=50/env+json", benchmarkLoad[config50](true))
+	b.Run("fields=200/env", benchmarkLoad[config200](false))
+	b.Run("fields=200/env+json", benchmarkLoad[config200](true))
+	b.Run("fields=500/env", benchmarkLoad[config500](false))
+	b.Run("fields=500/env+json", benchmarkLoad[config500](true))
+}
+
+func benchmarkAnalyze[C any](b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var cfg C
+		if err := config.NewInterpolationEngine[C]().Analyze(&cfg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAnalyze measures tag analysis and dependency graph construction.
+func BenchmarkAnalyze(b *testing.B) {
+	b.Run("fields=50", benchmarkAnalyze[config50])
+	b.Run("fields=200", benchmarkAnalyze[config200])
+	b.Run("fields=500", benchmarkAnalyze[config500])
+}
+
+func benchmarkValidate[C any](b *testing.B) {
+	handler := newHandler[C](b, false)
+	var cfg C
+	if err := handler.Load(&cfg); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := handler.Validate(&cfg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidate measures validation of loaded structs.
+func BenchmarkValidate(b *testing.B) {
+	b.Run("fields=50", benchmarkValidate[config50])
+	b.Run("fields=200", benchmarkValidate[config200])
+	b.Run("fields=500", benchmarkValidate[config500])
+}
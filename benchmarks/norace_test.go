@@ -0,0 +1,6 @@
+//go:build !race
+
+package benchmarks
+
+// raceEnabled reports whether the race detector, which adds allocations, is on.
+const raceEnabled = false
@@ -0,0 +1,104 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogInfo identifies the service a catalog descriptor belongs to.
+type CatalogInfo struct {
+	Name        string // Service name; the descriptor is named "<Name>-config" (required)
+	Owner       string // Owning team or user, e.g. "group:platform" (required)
+	System      string // Optional system the service belongs to
+	Lifecycle   string // Lifecycle stage (defaults to "production")
+	Description string // Optional description (defaults to "Configuration of <Name>")
+}
+
+// CatalogDefinitionType is the spec.type of descriptors written by WriteCatalogDescriptor.
+const CatalogDefinitionType = "go-easy-config"
+
+// catalogEntity is a Backstage catalog entity.
+type catalogEntity struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   catalogMeta    `yaml:"metadata"`
+	Spec       catalogAPISpec `yaml:"spec"`
+}
+
+type catalogMeta struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Tags        []string `yaml:"tags"`
+}
+
+type catalogAPISpec struct {
+	Type       string `yaml:"type"`
+	Lifecycle  string `yaml:"lifecycle"`
+	Owner      string `yaml:"owner"`
+	System     string `yaml:"system,omitempty"`
+	Definition string `yaml:"definition"`
+}
+
+// WriteCatalogDescriptor writes a Backstage catalog descriptor (catalog-info.yaml) describing
+// the configuration surface of C, so a developer portal can document it from the code.
+//
+// The descriptor is an API entity of type CatalogDefinitionType whose definition is a YAML
+// document with a "variables" list, one entry per Parameter returned by DescribeParameters.
+// Generate it in CI and commit or publish it alongside the service's own catalog-info.yaml.
+//
+// Example:
+//
+//	f, _ := os.Create("catalog-info.config.yaml")
+//	defer f.Close()
+//	err := config.WriteCatalogDescriptor[AppConfig](f, config.CatalogInfo{
+//	    Name:  "checkout",
+//	    Owner: "group:payments",
+//	})
+func WriteCatalogDescriptor[C any](w io.Writer, info CatalogInfo) error {
+	if info.Name == "" || info.Owner == "" {
+		return errors.New("catalog descriptor requires a Name and an Owner")
+	}
+
+	params := DescribeParameters[C]()
+	if params == nil {
+		params = []Parameter{}
+	}
+	definition, err := yaml.Marshal(map[string][]Parameter{"variables": params})
+	if err != nil {
+		return err
+	}
+
+	entity := catalogEntity{
+		APIVersion: "backstage.io/v1alpha1",
+		Kind:       "API",
+		Metadata: catalogMeta{
+			Name:        info.Name + "-config",
+			Description: info.Description,
+			Tags:        []string{"configuration"},
+		},
+		Spec: catalogAPISpec{
+			Type:       CatalogDefinitionType,
+			Lifecycle:  info.Lifecycle,
+			Owner:      info.Owner,
+			System:     info.System,
+			Definition: string(definition),
+		},
+	}
+	if entity.Metadata.Description == "" {
+		entity.Metadata.Description = "Configuration of " + info.Name
+	}
+	if entity.Spec.Lifecycle == "" {
+		entity.Spec.Lifecycle = "production"
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(entity); err != nil {
+		return err
+	}
+	return enc.Close()
+}
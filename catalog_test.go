@@ -0,0 +1,62 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteCatalogDescriptor(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCatalogDescriptor[specTestConfig](&buf, CatalogInfo{Name: "checkout", Owner: "group:payments", System: "shop"})
+	if err != nil {
+		t.Fatalf("WriteCatalogDescriptor failed: %v", err)
+	}
+
+	var entity struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+		Metadata   struct {
+			Name        string `yaml:"name"`
+			Description string `yaml:"description"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Type       string `yaml:"type"`
+			Lifecycle  string `yaml:"lifecycle"`
+			Owner      string `yaml:"owner"`
+			System     string `yaml:"system"`
+			Definition string `yaml:"definition"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &entity); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, buf.String())
+	}
+	if entity.APIVersion != "backstage.io/v1alpha1" || entity.Kind != "API" || entity.Metadata.Name != "checkout-config" {
+		t.Errorf("unexpected entity header: %+v", entity)
+	}
+	if entity.Metadata.Description != "Configuration of checkout" || entity.Spec.Lifecycle != "production" {
+		t.Errorf("expected defaults for description and lifecycle, got %+v", entity)
+	}
+	if entity.Spec.Type != CatalogDefinitionType || entity.Spec.Owner != "group:payments" || entity.Spec.System != "shop" {
+		t.Errorf("unexpected spec: %+v", entity.Spec)
+	}
+
+	var definition struct {
+		Variables []Parameter `yaml:"variables"`
+	}
+	if err := yaml.Unmarshal([]byte(entity.Spec.Definition), &definition); err != nil {
+		t.Fatalf("invalid definition: %v", err)
+	}
+	if len(definition.Variables) != 6 || definition.Variables[0].Name != "PORT" || definition.Variables[0].Description != "HTTP listen port" {
+		t.Errorf("unexpected variables: %+v", definition.Variables)
+	}
+}
+
+func TestWriteCatalogDescriptor_RequiresNameAndOwner(t *testing.T) {
+	if err := WriteCatalogDescriptor[specTestConfig](&bytes.Buffer{}, CatalogInfo{Name: "checkout"}); err == nil {
+		t.Error("expected error without an owner")
+	}
+}
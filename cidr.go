@@ -0,0 +1,185 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// CIDRSet is a list of IP ranges, such as the internal networks allowed to reach an admin
+// endpoint. It loads from a comma-separated list (environment variables, flags, overrides)
+// or an array (JSON, YAML). Each entry is a CIDR prefix such as "10.0.0.0/8" or a single
+// address, which is treated as a /32 or /128 prefix.
+//
+// Entries are validated as they load: a prefix with host bits set, such as "10.0.0.1/8",
+// is rejected because it is usually a typo for a narrower or wider range.
+//
+// CIDRSet is a []netip.Prefix, so it can be passed to code expecting one.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    AdminNetworks config.CIDRSet `env:"ADMIN_NETWORKS" envDefault:"10.0.0.0/8,fd00::/8"`
+//	}
+//
+//	if !cfg.AdminNetworks.ContainsString(remoteIP) {
+//	    http.Error(w, "forbidden", http.StatusForbidden)
+//	}
+type CIDRSet []netip.Prefix
+
+// NewCIDRSet returns a CIDRSet of the given entries, or an error naming the first invalid one.
+func NewCIDRSet(entries ...string) (CIDRSet, error) {
+	var set CIDRSet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		p, err := parseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, p)
+	}
+	return set, nil
+}
+
+// ParseCIDRSet parses a comma-separated list of entries.
+func ParseCIDRSet(s string) (CIDRSet, error) {
+	return NewCIDRSet(strings.Split(s, ",")...)
+}
+
+// parseCIDR parses one entry as a prefix or a single address.
+func parseCIDR(entry string) (netip.Prefix, error) {
+	if !strings.Contains(entry, "/") {
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR entry %q: not an IP address or prefix", entry)
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	p, err := netip.ParsePrefix(entry)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR entry %q: %w", entry, err)
+	}
+	if masked := p.Masked(); masked != p {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR entry %q: host bits are set, did you mean %s?", entry, masked)
+	}
+	return p, nil
+}
+
+// Contains reports whether addr is in any of the ranges. IPv4-mapped IPv6 addresses, such
+// as "::ffff:10.0.0.1", match IPv4 ranges.
+func (s CIDRSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range s {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsString reports whether the address in ip is in any of the ranges. It returns false
+// if ip is not a valid address. Zones, as in "fe80::1%eth0", are ignored.
+func (s CIDRSet) ContainsString(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	return s.Contains(addr.WithZone(""))
+}
+
+// Overlaps reports whether any range in s overlaps the prefix p.
+func (s CIDRSet) Overlaps(p netip.Prefix) bool {
+	for _, q := range s {
+		if q.Overlaps(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero reports whether the set has no ranges.
+func (s CIDRSet) IsZero() bool {
+	return len(s) == 0
+}
+
+// Entries returns the ranges in CIDR notation.
+func (s CIDRSet) Entries() []string {
+	entries := make([]string, len(s))
+	for i, p := range s {
+		entries[i] = p.String()
+	}
+	return entries
+}
+
+// String returns the ranges in CIDR notation, comma-separated.
+func (s CIDRSet) String() string {
+	return strings.Join(s.Entries(), ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s CIDRSet) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for comma-separated entries.
+func (s *CIDRSet) UnmarshalText(text []byte) error {
+	parsed, err := ParseCIDRSet(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts an array of entries or a comma-separated string.
+func (s *CIDRSet) UnmarshalJSON(data []byte) error {
+	var entries []string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("CIDR set must be an array or a comma-separated string")
+		}
+		return s.UnmarshalText([]byte(text))
+	}
+	parsed, err := NewCIDRSet(entries...)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// MarshalJSON encodes the ranges as an array.
+func (s CIDRSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Entries())
+}
+
+// UnmarshalYAML accepts a sequence of entries or a comma-separated string. It uses the
+// yaml.v2-style signature, which gopkg.in/yaml.v3 also supports.
+func (s *CIDRSet) UnmarshalYAML(unmarshal func(any) error) error {
+	var entries []string
+	if err := unmarshal(&entries); err != nil {
+		var text string
+		if err := unmarshal(&text); err != nil {
+			return fmt.Errorf("CIDR set must be a sequence or a comma-separated string")
+		}
+		return s.UnmarshalText([]byte(text))
+	}
+	parsed, err := NewCIDRSet(entries...)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (CIDRSet) ParameterFormat() string {
+	return "cidr-set"
+}
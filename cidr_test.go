@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestCIDRSet_Contains(t *testing.T) {
+	set, err := NewCIDRSet("10.0.0.0/8", "192.168.1.10", "fd00::/8")
+	if err != nil {
+		t.Fatalf("NewCIDRSet() error = %v", err)
+	}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.1.2.3", true},
+		{"11.0.0.1", false},
+		{"192.168.1.10", true},
+		{"192.168.1.11", false},
+		{"::ffff:10.0.0.1", true},
+		{"fd12::1", true},
+		{"fe80::1%eth0", false},
+		{"2001:db8::1", false},
+		{"not-an-ip", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := set.ContainsString(tt.ip); got != tt.want {
+			t.Errorf("ContainsString(%q) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+
+	if !set.Overlaps(netip.MustParsePrefix("10.20.0.0/16")) || set.Overlaps(netip.MustParsePrefix("172.16.0.0/12")) {
+		t.Error("unexpected Overlaps result")
+	}
+	if !(CIDRSet{}).IsZero() || set.IsZero() {
+		t.Error("unexpected IsZero result")
+	}
+}
+
+func TestCIDRSet_Parse(t *testing.T) {
+	set, err := ParseCIDRSet(" 10.0.0.0/8 ,, 2001:db8::1 ")
+	if err != nil {
+		t.Fatalf("ParseCIDRSet() error = %v", err)
+	}
+	if got := set.String(); got != "10.0.0.0/8,2001:db8::1/128" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, entry := range []string{"10.0.0.1/8", "10.0.0.0/33", "10.0.0", "example.com", "10.0.0.0/"} {
+		if _, err := ParseCIDRSet(entry); err == nil {
+			t.Errorf("ParseCIDRSet(%q) expected error", entry)
+		}
+	}
+}
+
+type cidrSetTestConfig struct {
+	Admin CIDRSet        `env:"ADMIN_NETWORKS" json:"admin" yaml:"admin"`
+	Plain []netip.Prefix `env:"PLAIN_NETWORKS" json:"plain" yaml:"plain"`
+}
+
+func TestCIDRSet_Loaders(t *testing.T) {
+	t.Setenv("ADMIN_NETWORKS", "10.0.0.0/8,fd00::/8")
+	t.Setenv("PLAIN_NETWORKS", "172.16.0.0/12")
+	var cfg cidrSetTestConfig
+	if err := (&generic.EnvironmentLoader[cidrSetTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	if len(cfg.Admin) != 2 || len(cfg.Plain) != 1 {
+		t.Errorf("environment entries = %v, %v", cfg.Admin, cfg.Plain)
+	}
+
+	sources := map[string]interface {
+		Load(*cidrSetTestConfig) error
+	}{
+		"yaml sequence": &generic.YAMLLoader[cidrSetTestConfig]{Source: []byte("admin:\n  - 10.0.0.0/8\n  - 192.168.0.0/16\n")},
+		"yaml string":   &generic.YAMLLoader[cidrSetTestConfig]{Source: []byte("admin: 10.0.0.0/8, 192.168.0.0/16\n")},
+		"json array":    &generic.JSONLoader[cidrSetTestConfig]{Source: []byte(`{"admin": ["10.0.0.0/8", "192.168.0.0/16"]}`)},
+		"json string":   &generic.JSONLoader[cidrSetTestConfig]{Source: []byte(`{"admin": "10.0.0.0/8,192.168.0.0/16"}`)},
+	}
+	for name, ldr := range sources {
+		t.Run(name, func(t *testing.T) {
+			var cfg cidrSetTestConfig
+			if err := ldr.Load(&cfg); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !cfg.Admin.ContainsString("192.168.3.4") || len(cfg.Admin) != 2 {
+				t.Errorf("entries = %v", cfg.Admin)
+			}
+		})
+	}
+
+	if err := (&generic.JSONLoader[cidrSetTestConfig]{Source: []byte(`{"admin": ["10.0.0.1/8"]}`)}).Load(&cfg); err == nil {
+		t.Error("JSONLoader expected error for host bits set")
+	}
+
+	data, err := json.Marshal(cidrSetTestConfig{Admin: cfg.Admin})
+	if err != nil || string(data) != `{"admin":["10.0.0.0/8","fd00::/8"],"plain":null}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
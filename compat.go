@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindingKind classifies a CompatibilityFinding.
+type FindingKind string
+
+const (
+	FindingRemoved             FindingKind = "removed"              // Parameter no longer read, so existing deployments lose the setting
+	FindingRenamed             FindingKind = "renamed"              // Parameter read under a new name, so the old name is silently ignored
+	FindingNewlyRequired       FindingKind = "newly-required"       // Parameter must now be set and has no default
+	FindingTypeChanged         FindingKind = "type-changed"         // Existing values may no longer parse
+	FindingValidationTightened FindingKind = "validation-tightened" // Existing values may no longer pass validation
+	FindingDefaultChanged      FindingKind = "default-changed"      // Deployments relying on the default change behaviour
+)
+
+// CompatibilityFinding describes a configuration change that can break existing deployments.
+type CompatibilityFinding struct {
+	Kind      FindingKind
+	Parameter string // Parameter name in the old spec (the new spec for added parameters)
+	Message   string
+}
+
+// String returns the parameter, kind and message.
+func (f CompatibilityFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Parameter, f.Kind, f.Message)
+}
+
+// CheckCompatibility compares two versions of a configuration spec and returns the changes
+// that can break existing deployments, so CI can block them unless they are intended:
+//
+//   - removed or renamed parameters
+//   - parameters that became required (or were added as required) without a default
+//   - type changes
+//   - validate rules that were added or changed
+//   - changed defaults
+//
+// Loosening changes, such as removing a validate rule or adding an optional parameter, are
+// not reported. Findings follow the order of DiffParameters.
+//
+// Example:
+//
+//	previous, _ := config.ReadEnvSpec(f)
+//	for _, finding := range config.CheckCompatibility(previous, config.DescribeParameters[AppConfig]()) {
+//	    fmt.Println(finding)
+//	    failed = true
+//	}
+func CheckCompatibility(old, current []Parameter) []CompatibilityFinding {
+	oldByName := make(map[string]Parameter, len(old))
+	for _, p := range old {
+		oldByName[p.Name] = p
+	}
+	currentByName := make(map[string]Parameter, len(current))
+	for _, p := range current {
+		currentByName[p.Name] = p
+	}
+
+	var findings []CompatibilityFinding
+	for _, change := range DiffParameters(old, current) {
+		switch change.Kind {
+		case ChangeAdded:
+			if p := currentByName[change.Name]; p.Required && p.Default == "" {
+				findings = append(findings, CompatibilityFinding{Kind: FindingNewlyRequired, Parameter: p.Name, Message: "new parameter is required and has no default"})
+			}
+		case ChangeRemoved:
+			findings = append(findings, CompatibilityFinding{Kind: FindingRemoved, Parameter: change.Name, Message: "parameter was removed"})
+		case ChangeRenamed:
+			findings = append(findings, CompatibilityFinding{Kind: FindingRenamed, Parameter: change.OldName, Message: fmt.Sprintf("parameter was renamed to %s", change.Name)})
+			findings = append(findings, attributeFindings(oldByName[change.OldName], currentByName[change.Name])...)
+		case ChangeModified:
+			findings = append(findings, attributeFindings(oldByName[change.Name], currentByName[change.Name])...)
+		}
+	}
+	return findings
+}
+
+// attributeFindings returns the breaking differences between two versions of a parameter.
+func attributeFindings(old, current Parameter) []CompatibilityFinding {
+	var findings []CompatibilityFinding
+	add := func(kind FindingKind, format string, args ...any) {
+		findings = append(findings, CompatibilityFinding{Kind: kind, Parameter: old.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if current.Required && !old.Required && current.Default == "" {
+		add(FindingNewlyRequired, "parameter is now required and has no default")
+	}
+	if old.Type != current.Type || old.Format != current.Format {
+		add(FindingTypeChanged, "type changed from %s to %s", parameterTypeName(old), parameterTypeName(current))
+	}
+	if tightened := addedRules(old.Validate, current.Validate); len(tightened) > 0 {
+		add(FindingValidationTightened, "validate rules added or changed: %s", strings.Join(tightened, ","))
+	}
+	if old.Default != current.Default {
+		add(FindingDefaultChanged, "default changed from %q to %q", old.Default, current.Default)
+	}
+	return findings
+}
+
+// parameterTypeName returns the type of p, qualified by its format if it has one.
+func parameterTypeName(p Parameter) string {
+	if p.Format != "" {
+		return p.Type + "(" + p.Format + ")"
+	}
+	return p.Type
+}
+
+// addedRules returns the rules in the current validate tag that are not in the old one.
+// A rule whose parameter changed (e.g. max=100 to max=50) counts as added. The required
+// rule is left to the newly-required check, which takes defaults into account.
+func addedRules(old, current string) []string {
+	existing := make(map[string]bool)
+	for _, rule := range strings.Split(old, ",") {
+		existing[strings.TrimSpace(rule)] = true
+	}
+
+	var added []string
+	for _, rule := range strings.Split(current, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule != "" && rule != "required" && !existing[rule] {
+			added = append(added, rule)
+		}
+	}
+	return added
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	old := []Parameter{
+		{Name: "PORT", Field: "Port", Type: "integer", Default: "8080", Validate: "max=65535"},
+		{Name: "DB_HOST", Field: "DBHost", Type: "string"},
+		{Name: "LEGACY", Field: "Legacy", Type: "string"},
+		{Name: "TIMEOUT", Field: "Timeout", Type: "integer"},
+		{Name: "REGION", Field: "Region", Type: "string", Validate: "oneof=eu us apac"},
+		{Name: "TOKEN", Field: "Token", Type: "string"},
+	}
+	current := []Parameter{
+		{Name: "PORT", Field: "Port", Type: "integer", Default: "9090", Validate: "min=1,max=1024"},
+		{Name: "DATABASE_HOST", Field: "DBHost", Type: "string", Required: true, Validate: "required"},
+		{Name: "TIMEOUT", Field: "Timeout", Type: "string", Format: "duration"},
+		{Name: "REGION", Field: "Region", Type: "string"},
+		{Name: "TOKEN", Field: "Token", Type: "string", Required: true, Default: "anonymous"},
+		{Name: "NEW_REQUIRED", Field: "NewRequired", Type: "string", Required: true},
+		{Name: "NEW_OPTIONAL", Field: "NewOptional", Type: "string"},
+	}
+
+	want := []CompatibilityFinding{
+		{Kind: FindingNewlyRequired, Parameter: "NEW_REQUIRED", Message: "new parameter is required and has no default"},
+		{Kind: FindingRemoved, Parameter: "LEGACY", Message: "parameter was removed"},
+		{Kind: FindingRenamed, Parameter: "DB_HOST", Message: "parameter was renamed to DATABASE_HOST"},
+		{Kind: FindingNewlyRequired, Parameter: "DB_HOST", Message: "parameter is now required and has no default"},
+		{Kind: FindingValidationTightened, Parameter: "PORT", Message: "validate rules added or changed: min=1,max=1024"},
+		{Kind: FindingDefaultChanged, Parameter: "PORT", Message: `default changed from "8080" to "9090"`},
+		{Kind: FindingTypeChanged, Parameter: "TIMEOUT", Message: "type changed from integer to string(duration)"},
+		{Kind: FindingDefaultChanged, Parameter: "TOKEN", Message: `default changed from "" to "anonymous"`},
+	}
+	got := CheckCompatibility(old, current)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected findings:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestCheckCompatibility_NoBreakingChanges(t *testing.T) {
+	old := []Parameter{{Name: "PORT", Field: "Port", Type: "integer", Validate: "min=1,max=65535"}}
+	current := []Parameter{
+		{Name: "PORT", Field: "Port", Type: "integer", Validate: "min=1", Description: "listen port", Sensitive: true},
+		{Name: "EXTRA", Field: "Extra", Type: "string", Required: true, Default: "x"},
+	}
+	if findings := CheckCompatibility(old, current); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
+
+func TestCompatibilityFinding_String(t *testing.T) {
+	f := CompatibilityFinding{Kind: FindingRemoved, Parameter: "LEGACY", Message: "parameter was removed"}
+	if got := f.String(); got != "LEGACY: removed: parameter was removed" {
+		t.Errorf("unexpected string: %s", got)
+	}
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// WithFeatures enables features for `config:"if=feature:<name>"` conditions. Features usually
+// come from build tags, with a file per build declaring the features it includes:
+//
+//	//go:build enterprise
+//
+//	package main
+//
+//	var features = []string{"sso"}
+//
+//	handler := config.NewConfigHandler[AppConfig](config.WithFeatures[AppConfig](features...))
+func WithFeatures[C any](features ...string) Option[C] {
+	return func(h *Handler[C]) {
+		if h.features == nil {
+			h.features = make(map[string]bool)
+		}
+		for _, feature := range features {
+			h.features[feature] = true
+		}
+	}
+}
+
+// conditionTerm is one alternative of an if= condition: an operating system or architecture
+// name such as "linux" or "arm64", or "feature:<name>", optionally negated with "!".
+var conditionTerm = regexp.MustCompile(`^!?(feature:)?[A-Za-z0-9_-]+$`)
+
+// fieldActive reports whether a field with the given config tag is expected on this platform
+// and with these features. The if= option holds alternatives separated by "|", e.g.
+// `config:"if=linux|darwin"`, `config:"if=!windows"` or `config:"if=feature:sso"`; the field
+// is active if any of them holds. Fields without an if= option are always active.
+func fieldActive(configTag string, features map[string]bool) (bool, error) {
+	condition, ok := utils.TagOptionValue(configTag, "if")
+	if !ok {
+		return true, nil
+	}
+	terms := strings.Split(condition, "|")
+	for _, term := range terms {
+		if !conditionTerm.MatchString(term) {
+			return false, errors.New("invalid if= condition " + condition)
+		}
+	}
+	for _, term := range terms {
+		name, negated := strings.CutPrefix(term, "!")
+		var holds bool
+		if feature, ok := strings.CutPrefix(name, "feature:"); ok {
+			holds = features[feature]
+		} else {
+			holds = name == runtime.GOOS || name == runtime.GOARCH
+		}
+		if holds != negated {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// inactiveFields returns the paths of the fields of t, and of its sections, whose if=
+// condition does not hold. Paths omit slice and map indexes, e.g. "Upstreams.URL".
+func inactiveFields(t reflect.Type, features map[string]bool) ([]FieldPath, error) {
+	var inactive []FieldPath
+	var errs []error
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type, prefix FieldPath)
+	walk = func(t reflect.Type, prefix FieldPath) {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if !isSection(t) || seen[t] {
+			return
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			path := prefix.Child(field.Name)
+			active, err := fieldActive(field.Tag.Get("config"), features)
+			if err != nil {
+				errs = append(errs, &TagParseError{FieldName: string(path), TagKey: "config", Issue: err.Error()})
+				continue
+			}
+			if !active {
+				inactive = append(inactive, path)
+				continue
+			}
+			walk(field.Type, path)
+		}
+	}
+	walk(t, "")
+	return inactive, errors.Join(errs...)
+}
+
+// isInactive reports whether the field at path, which may index into slices and maps as in
+// "Upstreams[2].URL", is or is inside one of the inactive fields.
+func isInactive(path FieldPath, inactive []FieldPath) bool {
+	path = path.TypePath()
+	for _, field := range inactive {
+		if path.Within(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropInactive removes the errors about inactive fields from a Validate error tree: validator
+// errors and *ValidationError values whose field is inactive, and joined errors left empty.
+func dropInactive(err error, inactive []FieldPath) error {
+	if err == nil {
+		return nil
+	}
+	if validationErr, ok := err.(*ValidationError); ok && validationErr.FieldName != "<multiple>" {
+		if isInactive(FieldPath(validationErr.FieldName), inactive) {
+			return nil
+		}
+		return err
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, inner := range joined.Unwrap() {
+			if inner = dropInactive(inner, inactive); inner != nil {
+				errs = append(errs, inner)
+			}
+		}
+		return errors.Join(errs...)
+	}
+	return dropInactiveValidatorErrors(err, inactive)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFieldActive(t *testing.T) {
+	otherOS := "windows"
+	if runtime.GOOS == otherOS {
+		otherOS = "linux"
+	}
+	features := map[string]bool{"sso": true}
+	tests := []struct {
+		tag     string
+		want    bool
+		wantErr bool
+	}{
+		{"", true, false},
+		{"sensitive", true, false},
+		{"if=" + runtime.GOOS, true, false},
+		{"if=" + runtime.GOARCH, true, false},
+		{"if=" + otherOS, false, false},
+		{"if=!" + otherOS, true, false},
+		{"if=" + otherOS + "|" + runtime.GOOS, true, false},
+		{"if=feature:sso", true, false},
+		{"if=feature:billing", false, false},
+		{"if=!feature:billing,sensitive", true, false},
+		{"if=", false, true},
+		{"if=linux||darwin", false, true},
+	}
+	for _, tt := range tests {
+		got, err := fieldActive(tt.tag, features)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("fieldActive(%q) = %v, %v, want %v", tt.tag, got, err, tt.want)
+		}
+	}
+}
+
+type conditionalUpstream struct {
+	URL      string `validate:"required"`
+	CertFile string `validate:"required" config:"if=feature:mtls"`
+}
+
+type conditionalTestConfig struct {
+	Host      string                `validate:"required"`
+	SSOClient string                `validate:"required" config:"if=feature:sso"`
+	Socket    string                `validate:"required" config:"if=!linux|linux"`
+	Plugins   *conditionalUpstream  `config:"if=feature:plugins"`
+	Upstreams []conditionalUpstream `validate:"dive"`
+}
+
+func TestHandler_Validate_ConditionalFields(t *testing.T) {
+	cfg := conditionalTestConfig{
+		Plugins:   &conditionalUpstream{},
+		Upstreams: []conditionalUpstream{{URL: "a"}, {}},
+	}
+
+	handler := NewConfigHandler[conditionalTestConfig]()
+	var got []string
+	for _, violation := range Violations(handler.Validate(&cfg)) {
+		got = append(got, string(violation.Path))
+	}
+	want := []string{"Host", "Socket", "Upstreams[1].URL"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+
+	cfg = conditionalTestConfig{Host: "h", Socket: "s"}
+	if err := NewConfigHandler[conditionalTestConfig]().Validate(&cfg); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	handler = NewConfigHandler[conditionalTestConfig](WithFeatures[conditionalTestConfig]("sso", "mtls"))
+	cfg.Upstreams = []conditionalUpstream{{URL: "a"}}
+	got = nil
+	for _, violation := range Violations(handler.Validate(&cfg)) {
+		got = append(got, string(violation.Path))
+	}
+	if strings.Join(got, ",") != "SSOClient,Upstreams[0].CertFile" {
+		t.Errorf("violations with features = %v", got)
+	}
+}
+
+func TestHandler_Validate_InvalidCondition(t *testing.T) {
+	type config struct {
+		Host string `config:"if=linux darwin"`
+	}
+	err := NewConfigHandler[config]().Validate(&config{})
+	var tagErr *TagParseError
+	if !errors.As(err, &tagErr) || tagErr.FieldName != "Host" {
+		t.Errorf("Validate() error = %v, want a TagParseError for Host", err)
+	}
+}
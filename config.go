@@ -4,15 +4,16 @@
 package config
 
 import (
-	"os"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/crazywolf132/secretfetch"
-	"github.com/go-playground/validator/v10"
-	"github.com/gymshark/go-easy-config/loader/generic"
-)
-
-var (
-	defaultSecretFetchOpts = &secretfetch.Options{}
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // Option is a functional option for configuring a Handler.
@@ -20,9 +21,46 @@ type Option[C any] func(*Handler[C])
 
 // Handler manages configuration loading and validation for a specific configuration type.
 type Handler[C any] struct {
-	Validator   *validator.Validate
-	Loaders     []Loader[C]
-	chainLoader *InterpolatingChainLoader[C] // Internal chain loader with interpolation support
+	Validator       *StructValidator
+	Loaders         []Loader[C]
+	chainLoader     *InterpolatingChainLoader[C] // Internal chain loader with interpolation support
+	pathBaseDir     string                       // Base directory for relative `config:"path"` fields
+	docSchema       *schema.Schema               // JSON Schema applied to file loaders, set by WithSchema
+	layers          *LayeredLoader[C]            // Hierarchy loaded after the other loaders, set by WithLayers
+	decoders        *utils.Decoders              // Handler-specific decoders for custom types, set by WithDecoder
+	isSet           *utils.IsSetFuncs            // Handler-specific "is set" functions, set by WithIsSet and WithFieldIsSet
+	partialStrategy PartialStrategy              // How partial loads run loaders that cannot select fields, set by WithPartialStrategy
+
+	progress           func(ProgressEvent) // Staged loading progress hook, set by WithProgress
+	stageTimeout       time.Duration       // Per-stage loading timeout, set by WithStageTimeout
+	interpolationGuard *InterpolationGuard // Checks on ${VAR} values, set by WithInterpolationGuard
+	sourceCache        *loader.SourceCache // Cache shared with prefetching loaders, set by WithSourceCache
+	sourcePool         *loader.SourcePool  // Pool the source cache was acquired from, set by WithSourcePool
+	ownsSourceCache    bool                // sourceCache was created by Prefetch and is cleared on Shutdown
+	closeOnce          sync.Once           // Releases the source cache once in Shutdown
+	life               lifecycle           // Work in progress, stopped by Shutdown
+
+	checks   []func(cfg any) error // Extra checks run by Validate, set by WithPreset
+	features map[string]bool       // Enabled features for if=feature: conditions, set by WithFeatures
+
+	events *EventBus   // Bus receiving load events, set by WithEventBus
+	loaded atomic.Bool // Set after the first successful load, to tell reloads apart
+
+	conditionsOnce sync.Once   // Finds the inactive fields on first Validate
+	inactive       []FieldPath // Paths of fields whose if= condition does not hold
+	conditionErr   error       // Malformed if= conditions
+
+	decrypters []valueDecrypter // Decrypters of inline encrypted values, set by WithValueDecryption and WithValueDecryptionContext
+
+	loaderPolicies []loaderPolicy[C] // Error policies of optional loaders, set by WithLoaderPolicy
+
+	trackProvenance bool // Record provenance on every load, set by WithProvenance
+	provenanceMu    sync.Mutex
+	provenance      map[FieldPath]Provenance // Provenance of the fields set by the last recorded load
+
+	persistOverride func(Override) error // Hook storing runtime overrides, set by WithOverridePersistence
+	overrideMu      sync.Mutex
+	overrides       []Override // Overrides applied through ApplyOverride
 }
 
 // NewConfigHandler creates a new configuration handler with default loaders and validator.
@@ -40,12 +78,42 @@ func NewConfigHandler[C any](options ...Option[C]) *Handler[C] {
 			opt(handler)
 		}
 	}
-	handler.chainLoader = &InterpolatingChainLoader[C]{Loaders: handler.Loaders}
+	if handler.layers != nil {
+		handler.layers.Guard = handler.interpolationGuard
+		handler.layers.IsSet = handler.isSet
+		// Copy rather than append into the caller's slice passed to WithLoaders
+		loaders := make([]Loader[C], 0, len(handler.Loaders)+1)
+		handler.Loaders = append(append(loaders, handler.Loaders...), handler.layers)
+	}
+	if handler.docSchema != nil {
+		applySchema(handler.Loaders, handler.docSchema)
+	}
+	if handler.decoders != nil {
+		applyDecoders(handler.Loaders, handler.decoders)
+	}
+	if handler.sourcePool != nil {
+		handler.sourceCache = handler.sourcePool.Acquire()
+	}
+	if handler.sourceCache != nil {
+		applySourceCache(handler.Loaders, handler.sourceCache)
+	}
+	if handler.events != nil {
+		applyEventBus(handler.Loaders, handler.events)
+	}
+	handler.chainLoader = &InterpolatingChainLoader[C]{
+		Loaders:      handler.Loaders,
+		Progress:     handler.progress,
+		StageTimeout: handler.stageTimeout,
+		Guard:        handler.interpolationGuard,
+		IsSet:        handler.isSet,
+		Policies:     handler.policies(),
+		spawn:        handler.life.spawn,
+	}
 	return handler
 }
 
 // WithValidator sets a custom validator for the configuration handler.
-func WithValidator[C any](v *validator.Validate) Option[C] {
+func WithValidator[C any](v *StructValidator) Option[C] {
 	return func(h *Handler[C]) {
 		if v == nil {
 			v = DefaultConfigValidator()
@@ -68,15 +136,128 @@ func WithLoaders[C any](loaders ...Loader[C]) Option[C] {
 	}
 }
 
+// WithProgress sets a hook called when each loading stage starts and finishes, e.g. to log
+// or record metrics on where a slow startup spends its time.
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithProgress[AppConfig](func(e config.ProgressEvent) {
+//	        if e.Done {
+//	            slog.Info("config stage loaded", "stage", e.Stage, "of", e.Stages,
+//	                "resolved", e.Resolved, "total", e.Total, "elapsed", e.Elapsed)
+//	        }
+//	    }),
+//	)
+func WithProgress[C any](progress func(ProgressEvent)) Option[C] {
+	return func(h *Handler[C]) {
+		h.progress = progress
+	}
+}
+
+// WithStageTimeout limits the time each loading stage may take. A stage that runs longer
+// fails the load with a *StageTimeoutError naming its fields, and the configuration keeps
+// the values from earlier stages. See InterpolatingChainLoader.StageTimeout.
+func WithStageTimeout[C any](timeout time.Duration) Option[C] {
+	return func(h *Handler[C]) {
+		h.stageTimeout = timeout
+	}
+}
+
 // Load populates the configuration struct using all configured loaders in sequence.
+// Values encrypted inline are decrypted (see WithValueDecryption) and fields marked with
+// `config:"path"` are expanded once all loaders have run, and then dynamic fields such as
+// DynamicLogLevel update their bound handles. A Regexp or Template that does not parse
+// fails the load with a *ValidationError naming the field.
+// LoadResult does the same and also describes the load.
 func (c *Handler[C]) Load(cfg *C) error {
-	return c.chainLoader.Load(cfg)
+	return c.load(context.Background(), cfg, nil)
+}
+
+// LoadContext is like Load, but passes ctx to the loaders implementing ContextLoader, such
+// as the AWS loaders, so a deadline or cancellation stops a slow or unreachable source
+// instead of waiting for it. No further loader runs once ctx is done, and the load fails
+// with ctx's error. Shutdown cancels the context of loads in progress the same way.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := handler.LoadContext(ctx, &cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Handler[C]) LoadContext(ctx context.Context, cfg *C) error {
+	return c.load(ctx, cfg, nil)
 }
 
-// Validate validates the configuration struct using the configured validator.
-// Returns ValidationError wrapping any validator errors for consistent error handling.
+// load implements Load, LoadContext and LoadResult, recording in trace, if not nil, the
+// loader that last set each field and the loaders skipped. With WithProvenance, it records
+// the loaders of the fields when trace is nil too.
+func (c *Handler[C]) load(ctx context.Context, cfg *C, trace *loadTrace) error {
+	err := c.loadUnpublished(ctx, cfg, trace)
+	c.publishLoad(cfg, err)
+	return err
+}
+
+// loadUnpublished is load without publishing the Loaded, Reloaded or ReloadFailed event, for
+// callers that publish it once they know whether the configuration is accepted.
+func (c *Handler[C]) loadUnpublished(ctx context.Context, cfg *C, trace *loadTrace) error {
+	lifeCtx, err := c.life.begin()
+	if err != nil {
+		return err
+	}
+	defer c.life.active.Done()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(lifeCtx, cancel)
+	defer stop()
+
+	if trace == nil {
+		trace = newLoadTrace(c.trackProvenance)
+	}
+	err = c.loadFields(ctx, cfg, trace)
+	if err == nil && trace.sources != nil {
+		c.recordProvenance(cfg, trace.sources)
+	}
+	c.publishSkipped(trace.skipped)
+	return err
+}
+
+// loadFields runs the loaders and completes the loaded fields.
+func (c *Handler[C]) loadFields(ctx context.Context, cfg *C, trace *loadTrace) error {
+	if err := c.chainLoader.load(ctx, cfg, trace); err != nil {
+		return parseFailureError(cfg, err)
+	}
+	if err := c.decryptValues(ctx, cfg); err != nil {
+		return err
+	}
+	if err := ExpandPaths(cfg, c.pathBaseDir); err != nil {
+		return err
+	}
+	publishDynamicFields(cfg)
+	return nil
+}
+
+// Validate validates the configuration struct using the configured validator, then checks
+// the field groups declared with `config:"group=..."` (see ValidateGroups) and runs the
+// checks of a preset installed with WithPreset. Errors about fields whose `config:"if=..."`
+// condition does not hold on this platform, or with the features set by WithFeatures, are
+// left out.
+// Returns ValidationError wrapping any validator and *GroupError errors for consistent error handling.
 func (c *Handler[C]) Validate(cfg *C) error {
-	err := c.Validator.Struct(cfg)
+	err := errors.Join(c.Validator.Struct(cfg), ValidateGroups(cfg))
+	for _, check := range c.checks {
+		if checkErr := check(cfg); checkErr != nil {
+			err = errors.Join(err, checkErr)
+		}
+	}
+	c.conditionsOnce.Do(func() {
+		c.inactive, c.conditionErr = inactiveFields(reflect.TypeOf(cfg), c.features)
+	})
+	if len(c.inactive) > 0 {
+		err = dropInactive(err, c.inactive)
+	}
+	err = errors.Join(err, c.conditionErr)
 	if err != nil {
 		// Wrap validator error in ValidationError for consistency
 		return &ValidationError{
@@ -102,15 +283,3 @@ func (c *Handler[C]) LoadAndValidate(cfg *C) error {
 
 	return nil
 }
-
-func DefaultConfigValidator() *validator.Validate {
-	defaultValidator := NewValidator()
-	return &defaultValidator
-}
-
-func DefaultConfigLoaders[T any]() []Loader[T] {
-	return []Loader[T]{
-		&generic.EnvironmentLoader[T]{},
-		&generic.CommandLineLoader[T]{Args: os.Args[1:]},
-	}
-}
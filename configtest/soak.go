@@ -0,0 +1,250 @@
+// Package configtest provides utilities for testing code built on go-easy-config.
+package configtest
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/gymshark/go-easy-config"
+)
+
+// Defaults used by Soak for zero SoakOptions fields.
+const (
+	DefaultSoakDuration    = time.Second
+	DefaultSoakReaders     = 8
+	DefaultSoakSubscribers = 4
+	DefaultMaxHeapGrowth   = 16 << 20
+	DefaultMaxGoroutines   = 2
+)
+
+// SoakOptions configures Soak. Zero values select the defaults above.
+type SoakOptions[C any] struct {
+	Duration      time.Duration        // How long to keep reloading
+	Change        func(generation int) // Changes the sources before each reload; required
+	Check         func(cfg *C) error   // Invariant every snapshot must satisfy, e.g. all fields from one generation
+	Readers       int                  // Goroutines reading snapshots concurrently
+	Bus           *config.EventBus     // Bus the handler publishes on (see WithEventBus); nil skips the event checks
+	Subscribers   int                  // Goroutines subscribing to and unsubscribing from Bus concurrently
+	MaxHeapGrowth uint64               // Heap growth in bytes allowed once the run has ended
+	MaxGoroutines int                  // Goroutines allowed to remain once the run has ended
+}
+
+// SoakReport summarises a Soak run.
+type SoakReport struct {
+	Reloads       int    // Successful reloads
+	FailedReloads int    // Reloads that returned an error; the previous snapshot was kept
+	Reads         int64  // Snapshots read and checked by the readers
+	Subscriptions int64  // Subscriptions opened and closed during the run
+	Events        int64  // Events received by all subscriptions
+	Dropped       uint64 // Events dropped by subscriptions that fell behind
+	HeapGrowth    int64  // Change in live heap bytes between the start and the end of the run
+	Goroutines    int    // Change in the number of goroutines between the start and the end of the run
+}
+
+// snapshot is a loaded configuration with the fingerprint it had when it was published.
+type snapshot[C any] struct {
+	cfg         *C
+	fingerprint string
+}
+
+// Soak hammers the reload path of handler to check that reloading is safe under load. It
+// calls Change and reloads into a fresh struct with LoadAndValidate, as fast as it can for
+// Duration, and publishes each successful result as an atomically swapped snapshot.
+// Meanwhile Readers goroutines read the current snapshot and fail the test if it does not
+// satisfy Check or has changed since it was published (a torn read), and Subscribers
+// goroutines repeatedly subscribe to Bus, read events and unsubscribe.
+//
+// Once the run has ended, Soak fails the test if a subscription is left open on Bus, if the
+// handler's last event does not describe the last snapshot, if the live heap has grown by
+// more than MaxHeapGrowth, or if more than MaxGoroutines goroutines have been left behind.
+// Failed reloads are counted but do not fail the test, so Change may break the sources on
+// purpose.
+//
+// Example:
+//
+//	var generation atomic.Int64
+//	var bus config.EventBus
+//	handler := config.NewConfigHandler[Config](
+//	    config.WithLoaders[Config](versionedLoader(&generation)),
+//	    config.WithEventBus[Config](&bus),
+//	)
+//	report := configtest.Soak(t, handler, configtest.SoakOptions[Config]{
+//	    Duration: 5 * time.Second,
+//	    Change:   func(g int) { generation.Store(int64(g)) },
+//	    Check:    func(c *Config) error { return c.sameGeneration() },
+//	    Bus:      &bus,
+//	})
+//	t.Logf("%d reloads, %d reads", report.Reloads, report.Reads)
+func Soak[C any](t testing.TB, handler *config.Handler[C], opts SoakOptions[C]) SoakReport {
+	t.Helper()
+	if opts.Change == nil {
+		t.Fatal("configtest.Soak: Change is required")
+	}
+	opts = opts.withDefaults()
+
+	var report SoakReport
+	goroutinesBefore := runtime.NumGoroutine()
+	heapBefore := liveHeap()
+	subscribersBefore := 0
+	if opts.Bus != nil {
+		subscribersBefore = opts.Bus.Subscribers()
+	}
+
+	var current atomic.Pointer[snapshot[C]]
+	var listener *config.Subscription
+	if opts.Bus != nil {
+		listener = opts.Bus.Subscribe(1)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var failures sync.Map // Reported once per message, so a broken invariant does not flood the log
+	fail := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		if _, seen := failures.LoadOrStore(msg, true); !seen {
+			t.Errorf("configtest.Soak: %s", msg)
+		}
+	}
+
+	for i := 0; i < opts.Readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for reads := int64(1); ; reads++ {
+				select {
+				case <-stop:
+					atomic.AddInt64(&report.Reads, reads-1)
+					return
+				default:
+				}
+				snap := current.Load()
+				if snap == nil {
+					runtime.Gosched()
+					continue
+				}
+				if opts.Check != nil {
+					if err := opts.Check(snap.cfg); err != nil {
+						fail("snapshot failed Check: %v", err)
+					}
+				}
+				if reads%64 == 0 && config.Fingerprint(snap.cfg) != snap.fingerprint {
+					fail("snapshot changed after it was published")
+				}
+			}
+		}()
+	}
+
+	if opts.Bus != nil {
+		for i := 0; i < opts.Subscribers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					sub := opts.Bus.Subscribe(1)
+					atomic.AddInt64(&report.Subscriptions, 1)
+					select {
+					case <-sub.Events():
+						atomic.AddInt64(&report.Events, 1)
+					case <-stop:
+					}
+					sub.Close()
+					for range sub.Events() {
+						atomic.AddInt64(&report.Events, 1)
+					}
+					atomic.AddUint64(&report.Dropped, sub.Dropped())
+				}
+			}()
+		}
+	}
+
+	deadline := time.Now().Add(opts.Duration)
+	for generation := 1; time.Now().Before(deadline); generation++ {
+		opts.Change(generation)
+		cfg := new(C)
+		if err := handler.LoadAndValidate(cfg); err != nil {
+			report.FailedReloads++
+			continue
+		}
+		current.Store(&snapshot[C]{cfg: cfg, fingerprint: config.Fingerprint(cfg)})
+		report.Reloads++
+	}
+	close(stop)
+	wg.Wait()
+
+	if listener != nil {
+		// listener holds only the last event, since each new event drops the one before
+		listener.Close()
+		var latest *config.Event
+		for event := range listener.Events() {
+			latest = &event
+		}
+		if n := opts.Bus.Subscribers(); n != subscribersBefore {
+			fail("%d subscriptions left open, want %d", n, subscribersBefore)
+		}
+		if snap := current.Load(); snap != nil && latest != nil && latest.Type != config.EventReloadFailed && latest.Fingerprint != snap.fingerprint {
+			fail("last %s event has fingerprint %s, want %s of the last snapshot", latest.Type, latest.Fingerprint, snap.fingerprint)
+		}
+	}
+
+	current.Store(nil)
+	report.HeapGrowth = int64(liveHeap()) - int64(heapBefore)
+	if report.HeapGrowth > int64(opts.MaxHeapGrowth) {
+		fail("live heap grew by %d bytes, want at most %d", report.HeapGrowth, opts.MaxHeapGrowth)
+	}
+	report.Goroutines = settledGoroutines(goroutinesBefore+opts.MaxGoroutines) - goroutinesBefore
+	if report.Goroutines > opts.MaxGoroutines {
+		fail("%d goroutines left running, want at most %d", report.Goroutines, opts.MaxGoroutines)
+	}
+	if report.Reloads == 0 {
+		fail("no reload succeeded")
+	}
+	return report
+}
+
+// withDefaults returns opts with zero fields set to their defaults.
+func (opts SoakOptions[C]) withDefaults() SoakOptions[C] {
+	if opts.Duration <= 0 {
+		opts.Duration = DefaultSoakDuration
+	}
+	if opts.Readers <= 0 {
+		opts.Readers = DefaultSoakReaders
+	}
+	if opts.Subscribers <= 0 {
+		opts.Subscribers = DefaultSoakSubscribers
+	}
+	if opts.MaxHeapGrowth == 0 {
+		opts.MaxHeapGrowth = DefaultMaxHeapGrowth
+	}
+	if opts.MaxGoroutines <= 0 {
+		opts.MaxGoroutines = DefaultMaxGoroutines
+	}
+	return opts
+}
+
+// liveHeap returns the bytes of live heap objects after a garbage collection.
+func liveHeap() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// settledGoroutines waits up to a second for the number of goroutines to drop to limit,
+// giving exiting goroutines time to finish, and returns the number.
+func settledGoroutines(limit int) int {
+	n := runtime.NumGoroutine()
+	for wait := time.Millisecond; n > limit && wait < time.Second; wait *= 2 {
+		time.Sleep(wait)
+		n = runtime.NumGoroutine()
+	}
+	return n
+}
@@ -0,0 +1,125 @@
+package configtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/gymshark/go-easy-config"
+)
+
+type soakConfig struct {
+	Primary   string
+	Secondary string
+	Version   int
+}
+
+// generationLoader sets every field from the current generation.
+type generationLoader struct {
+	generation *atomic.Int64
+}
+
+func (l generationLoader) Load(c *soakConfig) error {
+	g := l.generation.Load()
+	if g%7 == 0 {
+		return fmt.Errorf("source unavailable at generation %d", g)
+	}
+	c.Primary = "p" + strconv.FormatInt(g, 10)
+	c.Secondary = "s" + strconv.FormatInt(g, 10)
+	c.Version = int(g)
+	return nil
+}
+
+func sameGeneration(c *soakConfig) error {
+	if c.Primary[1:] != c.Secondary[1:] || c.Primary[1:] != strconv.Itoa(c.Version) {
+		return fmt.Errorf("torn snapshot %+v", *c)
+	}
+	return nil
+}
+
+func TestSoak(t *testing.T) {
+	var generation atomic.Int64
+	var bus config.EventBus
+	handler := config.NewConfigHandler[soakConfig](
+		config.WithLoaders[soakConfig](generationLoader{&generation}),
+		config.WithEventBus[soakConfig](&bus),
+	)
+
+	report := Soak(t, handler, SoakOptions[soakConfig]{
+		Duration: 200 * time.Millisecond,
+		Change:   func(g int) { generation.Store(int64(g)) },
+		Check:    sameGeneration,
+		Bus:      &bus,
+	})
+	if report.Reloads == 0 || report.FailedReloads == 0 || report.Reads == 0 || report.Subscriptions == 0 {
+		t.Errorf("expected reloads, failed reloads, reads and subscriptions, got %+v", report)
+	}
+	if bus.Subscribers() != 0 {
+		t.Errorf("Subscribers() = %d after Soak, want 0", bus.Subscribers())
+	}
+}
+
+// recordingTB records failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSoak_ReportsBrokenInvariant(t *testing.T) {
+	var generation atomic.Int64
+	handler := config.NewConfigHandler[soakConfig](config.WithLoaders[soakConfig](generationLoader{&generation}))
+
+	recorder := &recordingTB{TB: t}
+	Soak(recorder, handler, SoakOptions[soakConfig]{
+		Duration: 50 * time.Millisecond,
+		Change:   func(g int) { generation.Store(int64(g)) },
+		Check: func(c *soakConfig) error {
+			if c.Version > 1 {
+				return fmt.Errorf("unexpected version")
+			}
+			return nil
+		},
+	})
+	if len(recorder.errors) != 1 || !strings.Contains(recorder.errors[0], "snapshot failed Check") {
+		t.Errorf("expected one Check failure, got %q", recorder.errors)
+	}
+}
+
+func TestSoak_ReportsLeakedSubscription(t *testing.T) {
+	var generation atomic.Int64
+	var bus config.EventBus
+	handler := config.NewConfigHandler[soakConfig](
+		config.WithLoaders[soakConfig](generationLoader{&generation}),
+		config.WithEventBus[soakConfig](&bus),
+	)
+
+	var leaked *config.Subscription
+	recorder := &recordingTB{TB: t}
+	Soak(recorder, handler, SoakOptions[soakConfig]{
+		Duration: 50 * time.Millisecond,
+		Change: func(g int) {
+			generation.Store(int64(g))
+			if leaked == nil {
+				leaked = bus.Subscribe(1)
+			}
+		},
+		Bus: &bus,
+	})
+	leaked.Close()
+	if len(recorder.errors) != 1 || !strings.Contains(recorder.errors[0], "1 subscriptions left open") {
+		t.Errorf("expected a leaked subscription to be reported, got %q", recorder.errors)
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import "context"
+
+// contextKey is the context key for a configuration of type C. Each C gets its own key
+// type, so configurations of different types can travel in the same context.
+type contextKey[C any] struct{}
+
+// NewContext returns a copy of ctx carrying cfg, such as a per-tenant or per-request
+// configuration snapshot, for FromContext to retrieve further down the call chain.
+// cfg is shared, not copied, so treat it as read-only once stored.
+//
+// Example (net/http middleware):
+//
+//	func withConfig(next http.Handler, cfg *AppConfig) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        next.ServeHTTP(w, r.WithContext(config.NewContext(r.Context(), cfg)))
+//	    })
+//	}
+func NewContext[C any](ctx context.Context, cfg *C) context.Context {
+	return context.WithValue(ctx, contextKey[C]{}, cfg)
+}
+
+// FromContext returns the configuration of type C stored in ctx by NewContext, and false
+// if there is none.
+//
+// Example:
+//
+//	cfg, ok := config.FromContext[AppConfig](ctx)
+//	if !ok {
+//	    return errors.New("no configuration in context")
+//	}
+func FromContext[C any](ctx context.Context) (*C, bool) {
+	cfg, ok := ctx.Value(contextKey[C]{}).(*C)
+	return cfg, ok && cfg != nil
+}
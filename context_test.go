@@ -0,0 +1,42 @@
+package config
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewContextAndFromContext(t *testing.T) {
+	type tenantConfig struct{ Tenant string }
+	type otherConfig struct{ Tenant string }
+
+	cfg := &tenantConfig{Tenant: "acme"}
+	ctx := NewContext(context.Background(), cfg)
+
+	got, ok := FromContext[tenantConfig](ctx)
+	if !ok || got != cfg {
+		t.Errorf("expected the stored configuration, got %v, %v", got, ok)
+	}
+	if _, ok := FromContext[otherConfig](ctx); ok {
+		t.Error("expected no configuration of another type")
+	}
+
+	// A nested context overrides the configuration for its call chain only
+	nested := NewContext(ctx, &tenantConfig{Tenant: "globex"})
+	if got, _ := FromContext[tenantConfig](nested); got.Tenant != "globex" {
+		t.Errorf("expected the nested configuration, got %q", got.Tenant)
+	}
+	if got, _ := FromContext[tenantConfig](ctx); got.Tenant != "acme" {
+		t.Errorf("expected the parent configuration unchanged, got %q", got.Tenant)
+	}
+}
+
+func TestFromContext_Missing(t *testing.T) {
+	type tenantConfig struct{ Tenant string }
+
+	if _, ok := FromContext[tenantConfig](context.Background()); ok {
+		t.Error("expected no configuration in an empty context")
+	}
+	if _, ok := FromContext[tenantConfig](NewContext[tenantConfig](context.Background(), nil)); ok {
+		t.Error("expected a nil configuration to be reported as missing")
+	}
+}
@@ -0,0 +1,151 @@
+// Package dbconfig maps a database configuration section onto database/sql: it builds the
+// data source name from its parts, applies the connection pool settings and opens the
+// connection, retrying while the database is unreachable.
+//
+// Example:
+//
+//	import _ "github.com/jackc/pgx/v5/stdlib"
+//
+//	type AppConfig struct {
+//	    Database dbconfig.Config `envPrefix:"DB_"` // DB_HOST, DB_PASSWORD, DB_MAX_OPEN_CONNS, ...
+//	}
+//
+//	db, err := dbconfig.Open(ctx, cfg.Database)
+package dbconfig
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	config "github.com/gymshark/go-easy-config"
+)
+
+// Config configures a database connection and its pool. Zero pool settings keep the
+// database/sql defaults.
+type Config struct {
+	Driver   string            `env:"DRIVER" envDefault:"postgres" json:"driver" yaml:"driver" validate:"required" description:"database/sql driver name"`
+	DSN      string            `env:"DSN" json:"dsn" yaml:"dsn" config:"sensitive" description:"Full data source name, used instead of the parts below when set"`
+	Host     string            `env:"HOST" json:"host" yaml:"host" validate:"required_without=DSN" description:"Database host"`
+	Port     int               `env:"PORT" json:"port" yaml:"port" validate:"gte=0,lte=65535" description:"Database port, the driver default when zero"`
+	User     string            `env:"USER" json:"user" yaml:"user" description:"Database user"`
+	Password string            `env:"PASSWORD" json:"password" yaml:"password" config:"sensitive" description:"Database password"`
+	Name     string            `env:"NAME" json:"name" yaml:"name" description:"Database name"`
+	Params   map[string]string `env:"PARAMS" json:"params" yaml:"params" description:"Driver parameters, e.g. sslmode:require"`
+
+	MaxOpenConns    int           `env:"MAX_OPEN_CONNS" json:"maxOpenConns" yaml:"maxOpenConns" validate:"gte=0" description:"Maximum open connections, unlimited when zero"`
+	MaxIdleConns    int           `env:"MAX_IDLE_CONNS" json:"maxIdleConns" yaml:"maxIdleConns" validate:"gte=0" description:"Maximum idle connections"`
+	ConnMaxLifetime time.Duration `env:"CONN_MAX_LIFETIME" json:"connMaxLifetime" yaml:"connMaxLifetime" validate:"gte=0" description:"Maximum time a connection may be reused"`
+	ConnMaxIdleTime time.Duration `env:"CONN_MAX_IDLE_TIME" json:"connMaxIdleTime" yaml:"connMaxIdleTime" validate:"gte=0" description:"Maximum time a connection may be idle"`
+
+	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT" envDefault:"5s" json:"connectTimeout" yaml:"connectTimeout" validate:"gte=0" description:"Timeout for each connection attempt made by Open"`
+	ConnectRetries int           `env:"CONNECT_RETRIES" json:"connectRetries" yaml:"connectRetries" validate:"gte=0" description:"Attempts Open makes after the first one fails"`
+	RetryBackoff   time.Duration `env:"RETRY_BACKOFF" envDefault:"1s" json:"retryBackoff" yaml:"retryBackoff" validate:"gte=0" description:"Delay before the first retry, doubled after each one"`
+}
+
+// DataSourceName returns DSN if set, and otherwise builds one from the parts for the
+// driver: a URL for "postgres", "pgx" and "cockroach", and the go-sql-driver format for
+// "mysql". Other drivers must set DSN.
+func (c Config) DataSourceName() (string, error) {
+	if c.DSN != "" {
+		return c.DSN, nil
+	}
+
+	host := c.Host
+	if c.Port != 0 {
+		host = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	}
+	query := url.Values{}
+	for k, v := range c.Params {
+		query.Set(k, v)
+	}
+
+	switch c.Driver {
+	case "postgres", "pgx", "cockroach":
+		u := url.URL{Scheme: "postgres", Host: host, Path: "/" + c.Name, RawQuery: query.Encode()}
+		if c.User != "" {
+			u.User = url.UserPassword(c.User, c.Password)
+		}
+		return u.String(), nil
+	case "mysql":
+		dsn := ""
+		if c.User != "" {
+			dsn = c.User + ":" + c.Password + "@"
+		}
+		dsn += "tcp(" + host + ")/" + c.Name
+		if len(query) > 0 {
+			dsn += "?" + query.Encode()
+		}
+		return dsn, nil
+	default:
+		return "", fmt.Errorf("cannot build a data source name for driver %q, set DSN", c.Driver)
+	}
+}
+
+// Apply applies the pool settings in cfg to db.
+func Apply(db *sql.DB, cfg Config) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+}
+
+// Open validates cfg with the default validator, opens the database, applies the pool
+// settings and pings it. A failed ping is retried up to ConnectRetries times, waiting
+// RetryBackoff before the first retry and twice as long before each further one, until
+// ctx is done. The returned error wraps the last ping error.
+func Open(ctx context.Context, cfg Config) (*sql.DB, error) {
+	if err := config.DefaultConfigValidator().Struct(cfg); err != nil {
+		return nil, err
+	}
+	dsn, err := cfg.DataSourceName()
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(cfg.Driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open %s database: %w", cfg.Driver, err)
+	}
+	Apply(db, cfg)
+
+	backoff := cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if err = ping(ctx, db, cfg.ConnectTimeout); err == nil {
+			return db, nil
+		}
+		if attempt == cfg.ConnectRetries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			db.Close()
+			return nil, fmt.Errorf("connect to %s database: %w", cfg.Driver, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	db.Close()
+	return nil, fmt.Errorf("connect to %s database after %d attempts: %w", cfg.Driver, cfg.ConnectRetries+1, err)
+}
+
+// ping pings db, bounded by timeout when it is positive.
+func ping(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return db.PingContext(ctx)
+}
@@ -0,0 +1,148 @@
+package dbconfig
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyDriver fails the first failures connection attempts and then succeeds.
+type flakyDriver struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	dsn      string
+}
+
+func (d *flakyDriver) Open(dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.attempts++
+	d.dsn = dsn
+	if d.attempts <= d.failures {
+		return nil, errors.New("connection refused")
+	}
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+var (
+	flaky        = &flakyDriver{}
+	registerOnce sync.Once
+)
+
+// useFlakyDriver registers flaky as "flaky" and resets it to fail failures times.
+func useFlakyDriver(failures int) *flakyDriver {
+	registerOnce.Do(func() { sql.Register("flaky", flaky) })
+	flaky.mu.Lock()
+	defer flaky.mu.Unlock()
+	flaky.failures, flaky.attempts, flaky.dsn = failures, 0, ""
+	return flaky
+}
+
+func TestDataSourceName(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"explicit DSN", Config{Driver: "postgres", DSN: "postgres://override", Host: "db"}, "postgres://override"},
+		{"postgres", Config{Driver: "postgres", Host: "db", Port: 5432, User: "app", Password: "p@ss/word", Name: "orders", Params: map[string]string{"sslmode": "require"}},
+			"postgres://app:p%40ss%2Fword@db:5432/orders?sslmode=require"},
+		{"pgx without credentials", Config{Driver: "pgx", Host: "db", Name: "orders"}, "postgres://db/orders"},
+		{"mysql", Config{Driver: "mysql", Host: "db", Port: 3306, User: "app", Password: "secret", Name: "orders", Params: map[string]string{"parseTime": "true"}},
+			"app:secret@tcp(db:3306)/orders?parseTime=true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cfg.DataSourceName()
+			if err != nil {
+				t.Fatalf("DataSourceName() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DataSourceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (Config{Driver: "sqlite3", Host: "db"}).DataSourceName(); err == nil {
+		t.Error("DataSourceName() expected error for a driver without a DSN format")
+	}
+}
+
+func TestApply(t *testing.T) {
+	useFlakyDriver(0)
+	db, err := sql.Open("flaky", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	Apply(db, Config{MaxOpenConns: 7})
+	if got := db.Stats().MaxOpenConnections; got != 7 {
+		t.Errorf("MaxOpenConnections = %d, want 7", got)
+	}
+}
+
+func TestOpen_RetriesUntilConnected(t *testing.T) {
+	d := useFlakyDriver(2)
+	db, err := Open(context.Background(), Config{
+		Driver:         "flaky",
+		DSN:            "flaky://db",
+		MaxOpenConns:   3,
+		ConnectRetries: 2,
+		RetryBackoff:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	if d.attempts != 3 {
+		t.Errorf("attempts = %d, want 3", d.attempts)
+	}
+	if d.dsn != "flaky://db" {
+		t.Errorf("dsn = %q, want flaky://db", d.dsn)
+	}
+	if got := db.Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("MaxOpenConnections = %d, want 3", got)
+	}
+}
+
+func TestOpen_GivesUpAfterRetries(t *testing.T) {
+	d := useFlakyDriver(10)
+	_, err := Open(context.Background(), Config{Driver: "flaky", DSN: "flaky://db", ConnectRetries: 1, RetryBackoff: time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Open() error = %v, want the last connection error after 2 attempts", err)
+	}
+	if d.attempts != 2 {
+		t.Errorf("attempts = %d, want 2", d.attempts)
+	}
+}
+
+func TestOpen_StopsWhenContextDone(t *testing.T) {
+	useFlakyDriver(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := Open(ctx, Config{Driver: "flaky", DSN: "flaky://db", ConnectRetries: 100, RetryBackoff: time.Hour})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Open() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestOpen_ValidatesConfig(t *testing.T) {
+	if _, err := Open(context.Background(), Config{Driver: "flaky"}); err == nil || !strings.Contains(err.Error(), "Host") {
+		t.Errorf("Open() error = %v, want a validation error for Host", err)
+	}
+}
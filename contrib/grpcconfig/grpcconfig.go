@@ -0,0 +1,225 @@
+// Package grpcconfig turns gRPC configuration sections loaded by go-easy-config into
+// grpc.ServerOption and grpc.DialOption slices.
+//
+// It is a separate module so that applications not using gRPC do not depend on it.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    GRPC grpcconfig.ServerConfig `envPrefix:"GRPC_"` // GRPC_TLS_CERT_FILE, GRPC_KEEPALIVE_TIME, ...
+//	}
+//
+//	opts, err := grpcconfig.ServerOptions(cfg.GRPC, grpcconfig.ServerInterceptors{
+//	    Unary: map[string]grpc.UnaryServerInterceptor{"logging": loggingInterceptor},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	server := grpc.NewServer(opts...)
+package grpcconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// KeepaliveConfig configures HTTP/2 keepalive pings. Zero values keep the gRPC defaults.
+type KeepaliveConfig struct {
+	Time                  time.Duration `env:"TIME" json:"time" yaml:"time" validate:"gte=0"`                                                       // Ping after this long without activity
+	Timeout               time.Duration `env:"TIMEOUT" json:"timeout" yaml:"timeout" validate:"gte=0"`                                              // Close the connection if a ping is not answered in time
+	PermitWithoutStream   bool          `env:"PERMIT_WITHOUT_STREAM" json:"permitWithoutStream" yaml:"permitWithoutStream"`                         // Ping (clients) or allow pings (servers) without active streams
+	MinTime               time.Duration `env:"MIN_TIME" json:"minTime" yaml:"minTime" validate:"gte=0"`                                             // Servers only: minimum interval between client pings
+	MaxConnectionIdle     time.Duration `env:"MAX_CONNECTION_IDLE" json:"maxConnectionIdle" yaml:"maxConnectionIdle" validate:"gte=0"`              // Servers only: close idle connections after this long
+	MaxConnectionAge      time.Duration `env:"MAX_CONNECTION_AGE" json:"maxConnectionAge" yaml:"maxConnectionAge" validate:"gte=0"`                 // Servers only: close connections after this long
+	MaxConnectionAgeGrace time.Duration `env:"MAX_CONNECTION_AGE_GRACE" json:"maxConnectionAgeGrace" yaml:"maxConnectionAgeGrace" validate:"gte=0"` // Servers only: grace period for RPCs on aged connections
+}
+
+// ServerConfig configures a gRPC server.
+type ServerConfig struct {
+	TLS                  TLSConfig       `envPrefix:"TLS_" json:"tls" yaml:"tls"`
+	Keepalive            KeepaliveConfig `envPrefix:"KEEPALIVE_" json:"keepalive" yaml:"keepalive"`
+	ConnectionTimeout    time.Duration   `env:"CONNECTION_TIMEOUT" json:"connectionTimeout" yaml:"connectionTimeout" validate:"gte=0"` // Deadline for new connections to complete the handshake
+	MaxRecvMsgSize       int             `env:"MAX_RECV_MSG_SIZE" json:"maxRecvMsgSize" yaml:"maxRecvMsgSize" validate:"gte=0"`
+	MaxSendMsgSize       int             `env:"MAX_SEND_MSG_SIZE" json:"maxSendMsgSize" yaml:"maxSendMsgSize" validate:"gte=0"`
+	MaxConcurrentStreams uint32          `env:"MAX_CONCURRENT_STREAMS" json:"maxConcurrentStreams" yaml:"maxConcurrentStreams"`
+	Interceptors         []string        `env:"INTERCEPTORS" json:"interceptors" yaml:"interceptors"` // Names of the interceptors to enable, in order
+}
+
+// ClientConfig configures a gRPC client connection.
+type ClientConfig struct {
+	TLS                TLSConfig       `envPrefix:"TLS_" json:"tls" yaml:"tls"`
+	Keepalive          KeepaliveConfig `envPrefix:"KEEPALIVE_" json:"keepalive" yaml:"keepalive"`
+	ConnectTimeout     time.Duration   `env:"CONNECT_TIMEOUT" json:"connectTimeout" yaml:"connectTimeout" validate:"gte=0"` // Minimum time allowed for each connection attempt
+	CallTimeout        time.Duration   `env:"CALL_TIMEOUT" json:"callTimeout" yaml:"callTimeout" validate:"gte=0"`          // Deadline for unary calls without one
+	MaxCallRecvMsgSize int             `env:"MAX_CALL_RECV_MSG_SIZE" json:"maxCallRecvMsgSize" yaml:"maxCallRecvMsgSize" validate:"gte=0"`
+	MaxCallSendMsgSize int             `env:"MAX_CALL_SEND_MSG_SIZE" json:"maxCallSendMsgSize" yaml:"maxCallSendMsgSize" validate:"gte=0"`
+	Interceptors       []string        `env:"INTERCEPTORS" json:"interceptors" yaml:"interceptors"` // Names of the interceptors to enable, in order
+}
+
+// ServerInterceptors are the server interceptors that ServerConfig.Interceptors can enable,
+// by name. A name may have a unary interceptor, a stream interceptor or both.
+type ServerInterceptors struct {
+	Unary  map[string]grpc.UnaryServerInterceptor
+	Stream map[string]grpc.StreamServerInterceptor
+}
+
+// ClientInterceptors are the client interceptors that ClientConfig.Interceptors can enable,
+// by name. A name may have a unary interceptor, a stream interceptor or both.
+type ClientInterceptors struct {
+	Unary  map[string]grpc.UnaryClientInterceptor
+	Stream map[string]grpc.StreamClientInterceptor
+}
+
+// ServerOptions returns the server options for cfg. Interceptors named in cfg.Interceptors
+// are chained in that order; an unknown name is an error, so a typo in configuration does
+// not silently disable an interceptor.
+func ServerOptions(cfg ServerConfig, interceptors ServerInterceptors) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if cfg.TLS.Enabled {
+		tlsCfg, err := serverTLS(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	ka := cfg.Keepalive
+	if ka.Time > 0 || ka.Timeout > 0 || ka.MaxConnectionIdle > 0 || ka.MaxConnectionAge > 0 || ka.MaxConnectionAgeGrace > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     ka.MaxConnectionIdle,
+			MaxConnectionAge:      ka.MaxConnectionAge,
+			MaxConnectionAgeGrace: ka.MaxConnectionAgeGrace,
+			Time:                  ka.Time,
+			Timeout:               ka.Timeout,
+		}))
+	}
+	if ka.MinTime > 0 || ka.PermitWithoutStream {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             ka.MinTime,
+			PermitWithoutStream: ka.PermitWithoutStream,
+		}))
+	}
+
+	if cfg.ConnectionTimeout > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(cfg.ConnectionTimeout))
+	}
+	if cfg.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if cfg.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(cfg.MaxConcurrentStreams))
+	}
+
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	for _, name := range cfg.Interceptors {
+		u, hasUnary := interceptors.Unary[name]
+		s, hasStream := interceptors.Stream[name]
+		if !hasUnary && !hasStream {
+			return nil, fmt.Errorf("unknown server interceptor %q", name)
+		}
+		if hasUnary {
+			unary = append(unary, u)
+		}
+		if hasStream {
+			stream = append(stream, s)
+		}
+	}
+	if len(unary) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(unary...))
+	}
+	if len(stream) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(stream...))
+	}
+	return opts, nil
+}
+
+// DialOptions returns the dial options for cfg. Without TLS the connection uses insecure
+// credentials. Interceptors named in cfg.Interceptors are chained in that order, after the
+// call timeout interceptor when CallTimeout is set; an unknown name is an error.
+func DialOptions(cfg ClientConfig, interceptors ClientInterceptors) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+	if cfg.TLS.Enabled {
+		tlsCfg, err := clientTLS(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	ka := cfg.Keepalive
+	if ka.Time > 0 || ka.Timeout > 0 || ka.PermitWithoutStream {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                ka.Time,
+			Timeout:             ka.Timeout,
+			PermitWithoutStream: ka.PermitWithoutStream,
+		}))
+	}
+
+	if cfg.ConnectTimeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: cfg.ConnectTimeout,
+		}))
+	}
+	var callOpts []grpc.CallOption
+	if cfg.MaxCallRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(cfg.MaxCallRecvMsgSize))
+	}
+	if cfg.MaxCallSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(cfg.MaxCallSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	var unary []grpc.UnaryClientInterceptor
+	var stream []grpc.StreamClientInterceptor
+	if cfg.CallTimeout > 0 {
+		unary = append(unary, callTimeout(cfg.CallTimeout))
+	}
+	for _, name := range cfg.Interceptors {
+		u, hasUnary := interceptors.Unary[name]
+		s, hasStream := interceptors.Stream[name]
+		if !hasUnary && !hasStream {
+			return nil, fmt.Errorf("unknown client interceptor %q", name)
+		}
+		if hasUnary {
+			unary = append(unary, u)
+		}
+		if hasStream {
+			stream = append(stream, s)
+		}
+	}
+	if len(unary) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(unary...))
+	}
+	if len(stream) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(stream...))
+	}
+	return opts, nil
+}
+
+// callTimeout returns a unary interceptor applying timeout to calls without a deadline.
+func callTimeout(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
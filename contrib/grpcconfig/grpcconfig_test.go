@@ -0,0 +1,121 @@
+package grpcconfig
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestServerOptions(t *testing.T) {
+	cfg := ServerConfig{
+		Keepalive:            KeepaliveConfig{Time: time.Minute, MinTime: 10 * time.Second},
+		ConnectionTimeout:    5 * time.Second,
+		MaxRecvMsgSize:       1 << 20,
+		MaxConcurrentStreams: 100,
+		Interceptors:         []string{"logging", "recovery"},
+	}
+	noopUnary := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ctx, req)
+	}
+	noopStream := func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+
+	opts, err := ServerOptions(cfg, ServerInterceptors{
+		Unary:  map[string]grpc.UnaryServerInterceptor{"logging": noopUnary, "recovery": noopUnary},
+		Stream: map[string]grpc.StreamServerInterceptor{"recovery": noopStream},
+	})
+	if err != nil {
+		t.Fatalf("ServerOptions() error = %v", err)
+	}
+	// keepalive params, enforcement policy, connection timeout, max recv, max streams, unary chain, stream chain
+	if len(opts) != 7 {
+		t.Errorf("ServerOptions() returned %d options, want 7", len(opts))
+	}
+	grpc.NewServer(opts...).Stop()
+}
+
+func TestServerOptions_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ServerConfig
+		wantErr string
+	}{
+		{"unknown interceptor", ServerConfig{Interceptors: []string{"auth"}}, `unknown server interceptor "auth"`},
+		{"TLS without certificate", ServerConfig{TLS: TLSConfig{Enabled: true}}, "requires CertFile and KeyFile"},
+		{"missing certificate file", ServerConfig{TLS: TLSConfig{Enabled: true, CertFile: "missing.pem", KeyFile: "missing.key"}}, "load TLS key pair"},
+		{"unsupported TLS version", ServerConfig{TLS: TLSConfig{Enabled: true, CertFile: "a", KeyFile: "b", MinVersion: "1.1"}}, "unsupported TLS MinVersion"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ServerOptions(tt.cfg, ServerInterceptors{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ServerOptions() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDialOptions(t *testing.T) {
+	cfg := ClientConfig{
+		Keepalive:          KeepaliveConfig{Time: 30 * time.Second},
+		ConnectTimeout:     2 * time.Second,
+		CallTimeout:        time.Second,
+		MaxCallRecvMsgSize: 1 << 20,
+		Interceptors:       []string{"metrics"},
+	}
+	noopUnary := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	opts, err := DialOptions(cfg, ClientInterceptors{Unary: map[string]grpc.UnaryClientInterceptor{"metrics": noopUnary}})
+	if err != nil {
+		t.Fatalf("DialOptions() error = %v", err)
+	}
+	// insecure credentials, keepalive, connect params, call options, unary chain
+	if len(opts) != 5 {
+		t.Errorf("DialOptions() returned %d options, want 5", len(opts))
+	}
+
+	conn, err := grpc.NewClient("localhost:0", opts...)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	conn.Close()
+
+	if _, err := DialOptions(ClientConfig{Interceptors: []string{"tracing"}}, ClientInterceptors{}); err == nil {
+		t.Error("DialOptions() expected error for unknown interceptor")
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	interceptor := callTimeout(time.Second)
+
+	var hasDeadline bool
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	}
+	if err := interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+	if !hasDeadline {
+		t.Error("callTimeout did not set a deadline")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	invoker = func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		if got, _ := ctx.Deadline(); !got.Equal(want) {
+			t.Errorf("deadline = %v, want existing %v", got, want)
+		}
+		return nil
+	}
+	if err := interceptor(ctx, "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package grpcconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSConfig configures transport security. Relative file paths are resolved against the
+// working directory.
+type TLSConfig struct {
+	Enabled            bool   `env:"ENABLED" json:"enabled" yaml:"enabled"`
+	CertFile           string `env:"CERT_FILE" json:"certFile" yaml:"certFile" validate:"required_with=KeyFile"`         // PEM certificate; required on servers, optional client certificate on clients
+	KeyFile            string `env:"KEY_FILE" json:"keyFile" yaml:"keyFile" validate:"required_with=CertFile"`           // PEM private key for CertFile
+	CAFile             string `env:"CA_FILE" json:"caFile" yaml:"caFile"`                                                // PEM CAs: client CAs for mutual TLS on servers, root CAs on clients
+	ServerName         string `env:"SERVER_NAME" json:"serverName" yaml:"serverName"`                                    // Clients only: name to verify the server certificate against
+	MinVersion         string `env:"MIN_VERSION" json:"minVersion" yaml:"minVersion" validate:"omitempty,oneof=1.2 1.3"` // Minimum TLS version, 1.2 by default
+	InsecureSkipVerify bool   `env:"INSECURE_SKIP_VERIFY" json:"insecureSkipVerify" yaml:"insecureSkipVerify"`           // Clients only: skip server certificate verification (testing only)
+}
+
+// serverTLS returns the TLS configuration for a server. With CAFile set, clients must
+// present a certificate signed by one of its CAs.
+func serverTLS(cfg TLSConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("TLS requires CertFile and KeyFile on servers")
+	}
+	tlsCfg, err := baseTLS(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
+
+// clientTLS returns the TLS configuration for a client. CertFile and KeyFile, if set, are
+// presented for mutual TLS.
+func clientTLS(cfg TLSConfig) (*tls.Config, error) {
+	tlsCfg, err := baseTLS(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CAFile != "" {
+		if tlsCfg.RootCAs, err = loadCertPool(cfg.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	tlsCfg.ServerName = cfg.ServerName
+	tlsCfg.InsecureSkipVerify = cfg.InsecureSkipVerify
+	return tlsCfg, nil
+}
+
+// baseTLS returns the settings shared by servers and clients.
+func baseTLS(cfg TLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch cfg.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsCfg.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS MinVersion %q", cfg.MinVersion)
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load TLS key pair: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+// loadCertPool reads the PEM certificates in path.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,122 @@
+// Package httpconfig builds a configured *http.Server from an HTTP server configuration
+// section. It is a reference integration: the section is an ordinary configuration struct,
+// loaded by any of the loaders and validated with the same rules as the rest of the
+// configuration.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    HTTP httpconfig.ServerConfig `envPrefix:"HTTP_"` // HTTP_ADDR, HTTP_READ_TIMEOUT, HTTP_TLS_CERT_FILE, ...
+//	}
+//
+//	server, err := httpconfig.NewServer(cfg.HTTP, mux)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Fatal(httpconfig.ListenAndServe(server))
+package httpconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	config "github.com/gymshark/go-easy-config"
+)
+
+// ServerConfig configures an HTTP server. Zero timeouts and header limits keep the
+// net/http defaults.
+type ServerConfig struct {
+	Addr              string        `env:"ADDR" envDefault:":8080" json:"addr" yaml:"addr" validate:"required" description:"Address to listen on"`
+	ReadTimeout       time.Duration `env:"READ_TIMEOUT" json:"readTimeout" yaml:"readTimeout" validate:"gte=0" description:"Maximum duration for reading a request, including the body"`
+	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT" envDefault:"10s" json:"readHeaderTimeout" yaml:"readHeaderTimeout" validate:"gte=0" description:"Maximum duration for reading request headers"`
+	WriteTimeout      time.Duration `env:"WRITE_TIMEOUT" json:"writeTimeout" yaml:"writeTimeout" validate:"gte=0" description:"Maximum duration before timing out writes of the response"`
+	IdleTimeout       time.Duration `env:"IDLE_TIMEOUT" json:"idleTimeout" yaml:"idleTimeout" validate:"gte=0" description:"Maximum time to wait for the next request on keep-alive connections"`
+	MaxHeaderBytes    int           `env:"MAX_HEADER_BYTES" json:"maxHeaderBytes" yaml:"maxHeaderBytes" validate:"gte=0" description:"Maximum size of request headers"`
+	TLS               TLSConfig     `envPrefix:"TLS_" json:"tls" yaml:"tls"`
+}
+
+// TLSConfig configures the server certificate and, optionally, client certificate
+// verification. TLS is enabled when CertFile and KeyFile are set.
+type TLSConfig struct {
+	CertFile     string `env:"CERT_FILE" json:"certFile" yaml:"certFile" validate:"required_with=KeyFile" description:"PEM certificate"`
+	KeyFile      string `env:"KEY_FILE" json:"keyFile" yaml:"keyFile" validate:"required_with=CertFile" description:"PEM private key for CertFile"`
+	ClientCAFile string `env:"CLIENT_CA_FILE" json:"clientCaFile" yaml:"clientCaFile" description:"PEM CAs that client certificates must be signed by, enabling mutual TLS"`
+	MinVersion   string `env:"MIN_VERSION" json:"minVersion" yaml:"minVersion" validate:"omitempty,oneof=1.2 1.3" description:"Minimum TLS version, 1.2 by default"`
+}
+
+// Enabled reports whether the server should serve TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// NewServer validates cfg with the default validator and returns a server serving handler
+// with its settings. When TLS is enabled the certificate is loaded into the server's
+// TLSConfig, so it is served with ListenAndServe or ListenAndServeTLS("", "").
+func NewServer(cfg ServerConfig, handler http.Handler) (*http.Server, error) {
+	if err := config.DefaultConfigValidator().Struct(cfg); err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+	if cfg.TLS.Enabled() {
+		tlsCfg, err := serverTLS(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		server.TLSConfig = tlsCfg
+	}
+	return server, nil
+}
+
+// ListenAndServe serves server with TLS if NewServer configured it, and plain HTTP
+// otherwise.
+func ListenAndServe(server *http.Server) error {
+	if server.TLSConfig != nil && len(server.TLSConfig.Certificates) > 0 {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
+
+// serverTLS loads the certificate and client CAs in cfg.
+func serverTLS(cfg TLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch cfg.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsCfg.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS MinVersion %q", cfg.MinVersion)
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load TLS key pair: %w", err)
+	}
+	tlsCfg.Certificates = []tls.Certificate{cert}
+
+	if cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.ClientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,131 @@
+package httpconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate and key to dir and returns their paths.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestNewServer(t *testing.T) {
+	handler := http.NewServeMux()
+	cfg := ServerConfig{
+		Addr:              ":9090",
+		ReadTimeout:       5 * time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       time.Minute,
+		MaxHeaderBytes:    1 << 16,
+	}
+
+	server, err := NewServer(cfg, handler)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	if server.Addr != ":9090" || server.Handler != handler {
+		t.Errorf("Addr/Handler = %q/%v, want :9090 and the given handler", server.Addr, server.Handler)
+	}
+	if server.ReadTimeout != 5*time.Second || server.ReadHeaderTimeout != 2*time.Second ||
+		server.WriteTimeout != 10*time.Second || server.IdleTimeout != time.Minute {
+		t.Errorf("timeouts = %v/%v/%v/%v, want 5s/2s/10s/1m", server.ReadTimeout, server.ReadHeaderTimeout, server.WriteTimeout, server.IdleTimeout)
+	}
+	if server.MaxHeaderBytes != 1<<16 {
+		t.Errorf("MaxHeaderBytes = %d, want %d", server.MaxHeaderBytes, 1<<16)
+	}
+	if server.TLSConfig != nil {
+		t.Error("TLSConfig set without TLS configuration")
+	}
+}
+
+func TestNewServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	server, err := NewServer(ServerConfig{
+		Addr: ":8443",
+		TLS:  TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile, MinVersion: "1.3"},
+	}, http.NewServeMux())
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	if server.TLSConfig == nil || len(server.TLSConfig.Certificates) != 1 {
+		t.Fatal("TLSConfig does not hold the certificate")
+	}
+	if server.TLSConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("MinVersion = %x, want TLS 1.3", server.TLSConfig.MinVersion)
+	}
+	if server.TLSConfig.ClientAuth != tls.RequireAndVerifyClientCert || server.TLSConfig.ClientCAs == nil {
+		t.Error("ClientCAFile did not enable client certificate verification")
+	}
+}
+
+func TestNewServer_Errors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	tests := []struct {
+		name    string
+		cfg     ServerConfig
+		wantErr string
+	}{
+		{"missing address", ServerConfig{}, "Addr"},
+		{"negative timeout", ServerConfig{Addr: ":80", ReadTimeout: -time.Second}, "ReadTimeout"},
+		{"certificate without key", ServerConfig{Addr: ":80", TLS: TLSConfig{CertFile: certFile}}, "KeyFile"},
+		{"unsupported TLS version", ServerConfig{Addr: ":80", TLS: TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.1"}}, "MinVersion"},
+		{"missing certificate file", ServerConfig{Addr: ":80", TLS: TLSConfig{CertFile: "missing.pem", KeyFile: keyFile}}, "load TLS key pair"},
+		{"invalid client CA file", ServerConfig{Addr: ":80", TLS: TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile}}, "no certificates found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewServer(tt.cfg, http.NewServeMux())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewServer() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
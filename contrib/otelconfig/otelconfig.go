@@ -0,0 +1,165 @@
+// Package otelconfig turns a telemetry configuration section loaded by go-easy-config into
+// OpenTelemetry SDK resource, sampler and OTLP trace exporter options.
+//
+// TelemetryConfig fields are tagged with the standard OTEL_* environment variable names,
+// so the environment loader reads the same variables the SDK would. Values set in the
+// section are passed to the SDK explicitly and take precedence over its own environment
+// lookup; unset values leave the SDK defaults (including its environment handling) in
+// place. Either way the section and the SDK cannot disagree about a value.
+//
+// It is a separate module so that applications not using OpenTelemetry do not depend on it.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    Telemetry otelconfig.TelemetryConfig
+//	}
+//
+//	opts, err := otelconfig.TracerProviderOptions(ctx, cfg.Telemetry)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	provider := sdktrace.NewTracerProvider(opts...)
+//	defer provider.Shutdown(ctx)
+//	otel.SetTracerProvider(provider)
+package otelconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Protocols supported by TelemetryConfig.Protocol.
+const (
+	ProtocolGRPC         = "grpc"
+	ProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// TelemetryConfig configures trace export. Field tags use the environment variable names
+// defined by the OpenTelemetry specification.
+type TelemetryConfig struct {
+	Disabled           bool              `env:"OTEL_SDK_DISABLED" json:"disabled" yaml:"disabled" description:"Disable telemetry export"`
+	ServiceName        string            `env:"OTEL_SERVICE_NAME" json:"serviceName" yaml:"serviceName" description:"service.name resource attribute"`
+	ResourceAttributes map[string]string `env:"OTEL_RESOURCE_ATTRIBUTES" envKeyValSeparator:"=" json:"resourceAttributes" yaml:"resourceAttributes" description:"Additional resource attributes, e.g. deployment.environment=prod"`
+	Endpoint           string            `env:"OTEL_EXPORTER_OTLP_ENDPOINT" json:"endpoint" yaml:"endpoint" validate:"omitempty,url" description:"OTLP endpoint URL"`
+	Protocol           string            `env:"OTEL_EXPORTER_OTLP_PROTOCOL" envDefault:"grpc" json:"protocol" yaml:"protocol" validate:"omitempty,oneof=grpc http/protobuf" description:"OTLP protocol"`
+	Headers            map[string]string `env:"OTEL_EXPORTER_OTLP_HEADERS" envKeyValSeparator:"=" json:"headers" yaml:"headers" config:"sensitive" description:"Headers sent with every export, e.g. api-key=..."`
+	Insecure           bool              `env:"OTEL_EXPORTER_OTLP_INSECURE" json:"insecure" yaml:"insecure" description:"Export without TLS"`
+	Compression        string            `env:"OTEL_EXPORTER_OTLP_COMPRESSION" json:"compression" yaml:"compression" validate:"omitempty,oneof=gzip none" description:"Export compression"`
+	TimeoutMillis      int               `env:"OTEL_EXPORTER_OTLP_TIMEOUT" json:"timeoutMillis" yaml:"timeoutMillis" validate:"gte=0" description:"Export timeout in milliseconds"`
+	SamplingRatio      *float64          `env:"OTEL_TRACES_SAMPLER_ARG" json:"samplingRatio" yaml:"samplingRatio" validate:"omitempty,gte=0,lte=1" description:"Fraction of root traces sampled, 1 when unset"`
+}
+
+// Resource returns the resource for cfg: the SDK and environment-detected attributes,
+// overridden by ResourceAttributes and then ServiceName.
+func Resource(ctx context.Context, cfg TelemetryConfig) (*resource.Resource, error) {
+	var attrs []attribute.KeyValue
+	for k, v := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	if cfg.ServiceName != "" {
+		attrs = append(attrs, attribute.String("service.name", cfg.ServiceName))
+	}
+	return resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(attrs...),
+	)
+}
+
+// Sampler returns a parent-based sampler sampling SamplingRatio of root traces, or every
+// root trace when it is unset.
+func Sampler(cfg TelemetryConfig) sdktrace.Sampler {
+	if cfg.SamplingRatio == nil {
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(*cfg.SamplingRatio))
+}
+
+// GRPCTraceOptions returns the otlptracegrpc options for the fields set in cfg.
+func GRPCTraceOptions(cfg TelemetryConfig) []otlptracegrpc.Option {
+	var opts []otlptracegrpc.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+	}
+	if cfg.TimeoutMillis > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(time.Duration(cfg.TimeoutMillis)*time.Millisecond))
+	}
+	return opts
+}
+
+// HTTPTraceOptions returns the otlptracehttp options for the fields set in cfg.
+func HTTPTraceOptions(cfg TelemetryConfig) []otlptracehttp.Option {
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if cfg.TimeoutMillis > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(time.Duration(cfg.TimeoutMillis)*time.Millisecond))
+	}
+	return opts
+}
+
+// NewTraceExporter returns an OTLP trace exporter using cfg.Protocol. The exporter does
+// not connect until it first exports.
+func NewTraceExporter(ctx context.Context, cfg TelemetryConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Protocol {
+	case "", ProtocolGRPC:
+		return otlptracegrpc.New(ctx, GRPCTraceOptions(cfg)...)
+	case ProtocolHTTPProtobuf:
+		return otlptracehttp.New(ctx, HTTPTraceOptions(cfg)...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", cfg.Protocol)
+	}
+}
+
+// TracerProviderOptions returns the options for sdktrace.NewTracerProvider: the resource,
+// the sampler and a batching OTLP exporter. When Disabled is set only the resource and a
+// sampler that drops every span are returned, so no exporter is created.
+func TracerProviderOptions(ctx context.Context, cfg TelemetryConfig) ([]sdktrace.TracerProviderOption, error) {
+	res, err := Resource(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build telemetry resource: %w", err)
+	}
+	if cfg.Disabled {
+		return []sdktrace.TracerProviderOption{
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sdktrace.NeverSample()),
+		}, nil
+	}
+
+	exporter, err := NewTraceExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create trace exporter: %w", err)
+	}
+	return []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(Sampler(cfg)),
+		sdktrace.WithBatcher(exporter),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package otelconfig
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestResource(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments,deployment.environment=dev")
+
+	res, err := Resource(context.Background(), TelemetryConfig{
+		ServiceName:        "checkout",
+		ResourceAttributes: map[string]string{"deployment.environment": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("Resource() error = %v", err)
+	}
+
+	want := map[attribute.Key]string{
+		"service.name":           "checkout",
+		"deployment.environment": "prod", // the section overrides the environment
+		"team":                   "payments",
+	}
+	set := res.Set()
+	for k, v := range want {
+		got, ok := set.Value(k)
+		if !ok || got.AsString() != v {
+			t.Errorf("attribute %s = %q, want %q", k, got.AsString(), v)
+		}
+	}
+}
+
+func TestSampler(t *testing.T) {
+	if got := Sampler(TelemetryConfig{}).Description(); !strings.Contains(got, "AlwaysOnSampler") {
+		t.Errorf("Sampler() without ratio = %s, want AlwaysOnSampler root", got)
+	}
+	ratio := 0.25
+	if got := Sampler(TelemetryConfig{SamplingRatio: &ratio}).Description(); !strings.Contains(got, "TraceIDRatioBased{0.25}") {
+		t.Errorf("Sampler() = %s, want TraceIDRatioBased{0.25} root", got)
+	}
+}
+
+func TestTraceOptions(t *testing.T) {
+	cfg := TelemetryConfig{
+		Endpoint:      "https://collector:4317",
+		Headers:       map[string]string{"api-key": "secret"},
+		Compression:   "gzip",
+		TimeoutMillis: 5000,
+	}
+	if got := len(GRPCTraceOptions(cfg)); got != 4 {
+		t.Errorf("GRPCTraceOptions() returned %d options, want 4", got)
+	}
+	if got := len(HTTPTraceOptions(cfg)); got != 4 {
+		t.Errorf("HTTPTraceOptions() returned %d options, want 4", got)
+	}
+	if got := len(GRPCTraceOptions(TelemetryConfig{})); got != 0 {
+		t.Errorf("GRPCTraceOptions() for an empty section returned %d options, want 0", got)
+	}
+}
+
+func TestTracerProviderOptions(t *testing.T) {
+	ctx := context.Background()
+	for _, protocol := range []string{ProtocolGRPC, ProtocolHTTPProtobuf} {
+		opts, err := TracerProviderOptions(ctx, TelemetryConfig{Protocol: protocol, Endpoint: "http://localhost:4317"})
+		if err != nil {
+			t.Fatalf("TracerProviderOptions(%s) error = %v", protocol, err)
+		}
+		if len(opts) != 3 {
+			t.Errorf("TracerProviderOptions(%s) returned %d options, want 3", protocol, len(opts))
+		}
+	}
+
+	opts, err := TracerProviderOptions(ctx, TelemetryConfig{Disabled: true})
+	if err != nil || len(opts) != 2 {
+		t.Errorf("TracerProviderOptions(disabled) = %d options, %v; want 2 options without an exporter", len(opts), err)
+	}
+
+	if _, err := TracerProviderOptions(ctx, TelemetryConfig{Protocol: "thrift"}); err == nil {
+		t.Error("TracerProviderOptions() expected error for an unsupported protocol")
+	}
+}
@@ -0,0 +1,102 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// Cross-field comparison rules for durations and other numbers. Unlike the built-in
+// ltfield family, a zero value on either side passes, since a zero timeout or budget
+// usually means none is configured.
+var crossFieldRules = map[string]func(a, b float64) bool{
+	"ltfield_dur":  func(a, b float64) bool { return a < b },
+	"ltefield_dur": func(a, b float64) bool { return a <= b },
+	"gtfield_dur":  func(a, b float64) bool { return a > b },
+	"gtefield_dur": func(a, b float64) bool { return a >= b },
+}
+
+// registerCrossFieldRules adds the ltfield_dur family and sum_max to validate.
+func registerCrossFieldRules(validate *validator.Validate) {
+	for tag, compare := range crossFieldRules {
+		_ = validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			value := numericValue(fl.Field(), fl.FieldName())
+			other := numericValue(siblingField(fl), fl.Param())
+			return value == 0 || other == 0 || compare(value, other)
+		})
+	}
+
+	_ = validate.RegisterValidation("sum_max", validateSumMax)
+}
+
+// validateSumMax checks that the fields of a struct tagged sum_max=<Budget> add up to at
+// most the Budget field. Slice fields contribute the sum of their elements. The check is
+// reported on the first tagged field only, and passes when the budget is zero.
+func validateSumMax(fl validator.FieldLevel) bool {
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	budget := numericValue(siblingField(fl), fl.Param())
+	if budget == 0 {
+		return true
+	}
+
+	t := parent.Type()
+	var sum float64
+	first := ""
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if param, ok := utils.TagOptionValue(field.Tag.Get("validate"), "sum_max"); !ok || param != fl.Param() {
+			continue
+		}
+		if first == "" {
+			first = field.Name
+		}
+		sum += numericValue(parent.Field(i), field.Name)
+	}
+	return first != fl.StructFieldName() || sum <= budget
+}
+
+// siblingField returns the field of the parent struct named by the rule parameter.
+func siblingField(fl validator.FieldLevel) reflect.Value {
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	field := parent.FieldByName(fl.Param())
+	if !field.IsValid() {
+		panic(fmt.Sprintf("%s: no field %q for the cross-field rule", fl.FieldName(), fl.Param()))
+	}
+	return field
+}
+
+// numericValue returns an integer, float or duration value, or the sum of a slice of them,
+// as a float64. Nil pointers are zero. It panics on other kinds, like the built-in rules.
+func numericValue(v reflect.Value, name string) float64 {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		var sum float64
+		for i := 0; i < v.Len(); i++ {
+			sum += numericValue(v.Index(i), name)
+		}
+		return sum
+	}
+	panic(fmt.Sprintf("%s: cross-field rules need a numeric or duration field, got %s", name, v.Type()))
+}
@@ -0,0 +1,64 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type crossFieldTestConfig struct {
+	ConnectTimeout time.Duration   `validate:"ltfield_dur=RequestTimeout,sum_max=RequestTimeout"`
+	ReadTimeout    time.Duration   `validate:"ltefield_dur=RequestTimeout,sum_max=RequestTimeout"`
+	Retries        []time.Duration `validate:"sum_max=RequestTimeout"`
+	RequestTimeout time.Duration
+	MaxConns       int `validate:"gtefield_dur=MinConns"`
+	MinConns       *int
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	v := NewValidator()
+	handler := &Handler[crossFieldTestConfig]{Validator: &v}
+	two := 2
+
+	valid := []crossFieldTestConfig{
+		{ConnectTimeout: time.Second, ReadTimeout: 5 * time.Second, RequestTimeout: 10 * time.Second, MaxConns: 2, MinConns: &two},
+		{ConnectTimeout: time.Second, Retries: []time.Duration{time.Second, 2 * time.Second}, RequestTimeout: 5 * time.Second},
+		{ConnectTimeout: time.Minute, ReadTimeout: time.Hour},
+		{MaxConns: 1},
+	}
+	for i, cfg := range valid {
+		if err := handler.Validate(&cfg); err != nil {
+			t.Errorf("case %d: unexpected error: %v", i, err)
+		}
+	}
+
+	err := handler.Validate(&crossFieldTestConfig{
+		ConnectTimeout: time.Second,
+		ReadTimeout:    8 * time.Second,
+		Retries:        []time.Duration{time.Second, time.Second},
+		RequestTimeout: 10 * time.Second,
+		MaxConns:       1,
+		MinConns:       &two,
+	})
+	got := make([]string, 0)
+	for _, violation := range Violations(err) {
+		got = append(got, violation.String())
+	}
+	want := []string{
+		"ConnectTimeout: fields tagged sum_max=RequestTimeout must total at most RequestTimeout",
+		"MaxConns: must be at least MinConns",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected violations:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestCrossFieldRules_Comparison(t *testing.T) {
+	v := NewValidator()
+	err := v.Struct(crossFieldTestConfig{ConnectTimeout: 10 * time.Second, RequestTimeout: 10 * time.Second})
+	if violations := Violations(err); len(violations) != 1 || violations[0].String() != "ConnectTimeout: must be less than RequestTimeout" {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
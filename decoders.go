@@ -0,0 +1,60 @@
+package config
+
+import "github.com/gymshark/go-easy-config/utils"
+
+// decoderSetter is implemented by loaders that parse strings into fields and can use a
+// handler's decoders (EnvironmentLoader, CommandLineLoader, MapLoader, TOMLLoader, FileLoader,
+// DocumentLoader, DirectoryLoader, GCSLoader, TerraformLoader, DockerSecretsLoader,
+// KeyringLoader, KVLoader, LambdaExtensionLoader and AppConfigLoader).
+type decoderSetter interface {
+	SetDecoders(d *utils.Decoders)
+}
+
+// RegisterDecoder registers fn as the process-wide decoder for T in utils.DefaultDecoders.
+// Environment variables and their envDefault values, command-line flags, ApplyOverride
+// and loaders setting fields from strings then parse fields of type T, and slices of T
+// from comma-separated lists, with fn instead of needing a workaround in each loader.
+// Register decoders before loading, typically in an init function.
+//
+// Example:
+//
+//	type SKU string
+//
+//	func init() {
+//	    config.RegisterDecoder(func(s string) (SKU, error) {
+//	        if !skuPattern.MatchString(s) {
+//	            return "", fmt.Errorf("invalid SKU %q", s)
+//	        }
+//	        return SKU(strings.ToUpper(s)), nil
+//	    })
+//	}
+func RegisterDecoder[T any](fn func(s string) (T, error)) {
+	utils.RegisterDecoder(utils.DefaultDecoders, fn)
+}
+
+// WithDecoder registers fn as the decoder for T for this handler only, taking precedence
+// over a decoder registered with RegisterDecoder. It applies to the loaders configured
+// when the handler is created that support decoders, and to ApplyOverride.
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithDecoder[AppConfig](ParseAccountID),
+//	)
+func WithDecoder[C, T any](fn func(s string) (T, error)) Option[C] {
+	return func(h *Handler[C]) {
+		if h.decoders == nil {
+			h.decoders = utils.NewDecoders(utils.DefaultDecoders)
+		}
+		utils.RegisterDecoder(h.decoders, fn)
+	}
+}
+
+// applyDecoders sets d on every loader that supports decoders.
+func applyDecoders[C any](loaders []Loader[C], d *utils.Decoders) {
+	for _, l := range loaders {
+		if setter, ok := l.(decoderSetter); ok {
+			setter.SetDecoders(d)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type decoderSKU string
+
+func parseDecoderSKU(s string) (decoderSKU, error) {
+	if !strings.HasPrefix(strings.ToUpper(s), "SKU-") {
+		return "", fmt.Errorf("invalid SKU %q", s)
+	}
+	return decoderSKU(strings.ToUpper(s)), nil
+}
+
+type decoderConfig struct {
+	SKU      decoderSKU   `env:"DECODER_SKU"`
+	Featured []decoderSKU `env:"DECODER_FEATURED"`
+}
+
+func TestWithDecoder(t *testing.T) {
+	t.Setenv("DECODER_SKU", "sku-1")
+	t.Setenv("DECODER_FEATURED", "sku-2,sku-3")
+	handler := NewConfigHandler[decoderConfig](
+		WithLoaders[decoderConfig](&generic.EnvironmentLoader[decoderConfig]{}),
+		WithDecoder[decoderConfig](parseDecoderSKU),
+	)
+
+	var cfg decoderConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.SKU != "SKU-1" || len(cfg.Featured) != 2 || cfg.Featured[1] != "SKU-3" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	if err := handler.ApplyOverride(&cfg, "SKU", "sku-9"); err != nil || cfg.SKU != "SKU-9" {
+		t.Errorf("ApplyOverride() = %q, %v, want SKU-9", cfg.SKU, err)
+	}
+	if err := handler.ApplyOverride(&cfg, "SKU", "9"); err == nil || !strings.Contains(err.Error(), "invalid SKU") {
+		t.Errorf("ApplyOverride() error = %v, want the decoder's error", err)
+	}
+
+	// Handlers without the decoder parse the type by kind
+	plain := NewConfigHandler[decoderConfig](WithLoaders[decoderConfig](&generic.EnvironmentLoader[decoderConfig]{}))
+	cfg = decoderConfig{}
+	if err := plain.Load(&cfg); err != nil || cfg.SKU != "sku-1" {
+		t.Errorf("Load() without decoder = %q, %v, want the raw value", cfg.SKU, err)
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	type region string
+	RegisterDecoder(func(s string) (region, error) { return region(strings.ToLower(s)), nil })
+	type regionConfig struct {
+		Region region `env:"DECODER_REGION"`
+	}
+	t.Setenv("DECODER_REGION", "EU-WEST-1")
+
+	var cfg regionConfig
+	handler := NewConfigHandler[regionConfig](WithLoaders[regionConfig](&generic.EnvironmentLoader[regionConfig]{}))
+	if err := handler.Load(&cfg); err != nil || cfg.Region != "eu-west-1" {
+		t.Errorf("Load() = %q, %v, want the globally decoded value", cfg.Region, err)
+	}
+}
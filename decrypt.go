@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// valueDecrypter decrypts loaded strings starting with prefix, set by WithValueDecryption
+// or WithValueDecryptionContext.
+type valueDecrypter struct {
+	prefix  string
+	decrypt func(ctx context.Context, ciphertext string) (string, error)
+}
+
+// WithValueDecryption decrypts inline encrypted values once the loaders have run: every
+// loaded string starting with prefix, e.g. "kms:", is replaced by the result of decrypt for
+// the rest of the string, so encrypted values can sit in otherwise plaintext YAML files or
+// environment variables. Strings in nested structs, []string elements and
+// map[string]string values are decrypted too. A failure fails the load with a
+// *DecryptionError naming the field.
+//
+// Decryption happens after the loaders, so availableAs variables see the encrypted text.
+// Decrypters that call a remote service should use WithValueDecryptionContext instead, so
+// they stop when the load is cancelled.
+func WithValueDecryption[C any](prefix string, decrypt func(ciphertext string) (string, error)) Option[C] {
+	return WithValueDecryptionContext[C](prefix, func(_ context.Context, ciphertext string) (string, error) {
+		return decrypt(ciphertext)
+	})
+}
+
+// WithValueDecryptionContext is like WithValueDecryption, but passes decrypt the context of
+// the load: the one given to LoadContext, or a background context for Load, cancelled when
+// the handler is shut down. aws.KMSDecrypter decrypts values encrypted with AWS KMS:
+//
+//	kms := &aws.KMSDecrypter{}
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithValueDecryptionContext[AppConfig](aws.KMSValuePrefix, kms.DecryptContext),
+//	)
+func WithValueDecryptionContext[C any](prefix string, decrypt func(ctx context.Context, ciphertext string) (string, error)) Option[C] {
+	return func(h *Handler[C]) {
+		h.decrypters = append(h.decrypters, valueDecrypter{prefix: prefix, decrypt: decrypt})
+	}
+}
+
+// decryptValues replaces the encrypted strings in cfg with their plaintext, passing ctx to
+// the decrypters.
+func (c *Handler[C]) decryptValues(ctx context.Context, cfg *C) error {
+	if len(c.decrypters) == 0 {
+		return nil
+	}
+	return c.decryptValue(ctx, reflect.ValueOf(cfg).Elem(), "")
+}
+
+// decryptValue replaces the encrypted strings in v, the value of the field at path.
+func (c *Handler[C]) decryptValue(ctx context.Context, v reflect.Value, path FieldPath) error {
+	switch v.Kind() {
+	case reflect.String:
+		plaintext, ok, err := c.decrypt(ctx, v.String())
+		if err != nil {
+			return &DecryptionError{Path: path, Err: err}
+		}
+		if ok {
+			v.SetString(plaintext)
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return c.decryptValue(ctx, v.Elem(), path)
+		}
+	case reflect.Struct:
+		if !isSection(v.Type()) {
+			return nil
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				if err := c.decryptValue(ctx, v.Field(i), path.Child(t.Field(i).Name)); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := c.decryptValue(ctx, v.Index(i), path.Index(strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			plaintext, ok, err := c.decrypt(ctx, iter.Value().String())
+			if err != nil {
+				return &DecryptionError{Path: path.Index(iter.Key().String()), Err: err}
+			}
+			if ok {
+				v.SetMapIndex(iter.Key(), reflect.ValueOf(plaintext).Convert(v.Type().Elem()))
+			}
+		}
+	}
+	return nil
+}
+
+// decrypt returns the plaintext of s and true if it starts with the prefix of a decrypter.
+func (c *Handler[C]) decrypt(ctx context.Context, s string) (string, bool, error) {
+	for _, d := range c.decrypters {
+		if ciphertext, ok := strings.CutPrefix(s, d.prefix); ok {
+			plaintext, err := d.decrypt(ctx, ciphertext)
+			return plaintext, err == nil, err
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type decryptTestDB struct {
+	Password string
+}
+
+type decryptTestConfig struct {
+	APIKey  string
+	Plain   string
+	DB      decryptTestDB
+	Cache   *decryptTestDB
+	Tokens  []string
+	Headers map[string]string
+}
+
+// decryptTestLoader sets the configuration to a copy of cfg.
+type decryptTestLoader struct {
+	cfg decryptTestConfig
+}
+
+func (l *decryptTestLoader) Load(c *decryptTestConfig) error {
+	*c = l.cfg
+	return nil
+}
+
+// reverseDecrypt "decrypts" by reversing the ciphertext, failing for "bad".
+func reverseDecrypt(ciphertext string) (string, error) {
+	if ciphertext == "bad" {
+		return "", errors.New("invalid ciphertext")
+	}
+	runes := []rune(ciphertext)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes), nil
+}
+
+func TestWithValueDecryption(t *testing.T) {
+	handler := NewConfigHandler[decryptTestConfig](
+		WithLoaders[decryptTestConfig](&decryptTestLoader{cfg: decryptTestConfig{
+			APIKey:  "enc:yek",
+			Plain:   "plain",
+			DB:      decryptTestDB{Password: "enc:drowssap"},
+			Cache:   &decryptTestDB{Password: "enc:ehcac"},
+			Tokens:  []string{"enc:eno", "two"},
+			Headers: map[string]string{"Authorization": "enc:reraeb"},
+		}}),
+		WithValueDecryption[decryptTestConfig]("enc:", reverseDecrypt),
+	)
+
+	cfg := &decryptTestConfig{}
+	if err := handler.Load(cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.APIKey != "key" || cfg.Plain != "plain" {
+		t.Errorf("APIKey, Plain = %q, %q, want key, plain", cfg.APIKey, cfg.Plain)
+	}
+	if cfg.DB.Password != "password" || cfg.Cache.Password != "cache" {
+		t.Errorf("DB.Password, Cache.Password = %q, %q, want password, cache", cfg.DB.Password, cfg.Cache.Password)
+	}
+	if strings.Join(cfg.Tokens, ",") != "one,two" {
+		t.Errorf("Tokens = %v, want [one two]", cfg.Tokens)
+	}
+	if cfg.Headers["Authorization"] != "bearer" {
+		t.Errorf("Headers[Authorization] = %q, want bearer", cfg.Headers["Authorization"])
+	}
+}
+
+func TestWithValueDecryption_Error(t *testing.T) {
+	handler := NewConfigHandler[decryptTestConfig](
+		WithLoaders[decryptTestConfig](&decryptTestLoader{cfg: decryptTestConfig{
+			Headers: map[string]string{"Authorization": "enc:bad"},
+		}}),
+		WithValueDecryption[decryptTestConfig]("enc:", reverseDecrypt),
+	)
+
+	err := handler.Load(&decryptTestConfig{})
+	var decErr *DecryptionError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("expected *DecryptionError, got %v", err)
+	}
+	if decErr.Path != "Headers[Authorization]" {
+		t.Errorf("Path = %q, want Headers[Authorization]", decErr.Path)
+	}
+	if !strings.Contains(err.Error(), "invalid ciphertext") {
+		t.Errorf("error %q does not include the cause", err)
+	}
+}
+
+func TestWithValueDecryptionContext(t *testing.T) {
+	type ctxKey struct{}
+	handler := NewConfigHandler[decryptTestConfig](
+		WithLoaders[decryptTestConfig](&decryptTestLoader{cfg: decryptTestConfig{APIKey: "enc:yek"}}),
+		WithValueDecryptionContext[decryptTestConfig]("enc:", func(ctx context.Context, ciphertext string) (string, error) {
+			if ctx.Value(ctxKey{}) != "load" {
+				return "", errors.New("decrypter was not passed the load context")
+			}
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			return reverseDecrypt(ciphertext)
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "load")
+	cfg := &decryptTestConfig{}
+	if err := handler.LoadContext(ctx, cfg); err != nil {
+		t.Fatalf("LoadContext failed: %v", err)
+	}
+	if cfg.APIKey != "key" {
+		t.Errorf("APIKey = %q, want key", cfg.APIKey)
+	}
+
+	// A cancelled load cancels decryption too
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := handler.LoadContext(ctx, &decryptTestConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadContext() error = %v, want context.Canceled", err)
+	}
+}
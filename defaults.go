@@ -0,0 +1,29 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"os"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+// StructValidator is the validator used by Handler.
+// It is go-playground/validator, except in TinyGo builds where it is LiteValidator.
+type StructValidator = validator.Validate
+
+// DefaultConfigValidator returns a validator with the custom rules from NewValidator registered.
+func DefaultConfigValidator() *StructValidator {
+	defaultValidator := NewValidator()
+	return &defaultValidator
+}
+
+// DefaultConfigLoaders returns the default loader chain: environment variables
+// followed by command-line arguments.
+func DefaultConfigLoaders[T any]() []Loader[T] {
+	return []Loader[T]{
+		&generic.EnvironmentLoader[T]{},
+		&generic.CommandLineLoader[T]{Args: os.Args[1:]},
+	}
+}
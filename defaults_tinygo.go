@@ -0,0 +1,45 @@
+//go:build tinygo
+
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+// StructValidator is the validator used by Handler.
+// In TinyGo builds it is LiteValidator, since go-playground/validator relies on
+// reflection features TinyGo does not support.
+type StructValidator = LiteValidator
+
+// DefaultConfigValidator returns a LiteValidator.
+func DefaultConfigValidator() *StructValidator {
+	return &LiteValidator{}
+}
+
+// DefaultConfigLoaders returns a MapLoader populated from the process environment,
+// matching fields by their `env` tag. Command-line parsing is not available in TinyGo builds.
+func DefaultConfigLoaders[T any]() []Loader[T] {
+	values := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			values[key] = value
+		}
+	}
+	return []Loader[T]{
+		&generic.MapLoader[T]{Values: values},
+	}
+}
+
+// validatorViolations returns nil, as LiteValidator reports ValidationError values directly.
+func validatorViolations(err error) []FieldViolation {
+	return nil
+}
+
+// dropInactiveValidatorErrors returns err, as LiteValidator reports ValidationError values,
+// which dropInactive filters itself.
+func dropInactiveValidatorErrors(err error, inactive []FieldPath) error {
+	return err
+}
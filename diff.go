@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldChange describes a field whose value differs between two configurations, as
+// reported by Diff.
+type FieldChange struct {
+	Path      FieldPath // Field path, e.g. "Database.Port"
+	Old       string    // Previous value formatted with fmt, or [REDACTED] for sensitive fields
+	New       string    // New value formatted with fmt, or [REDACTED] for sensitive fields
+	Sensitive bool      // The field is sensitive, so its values are redacted
+}
+
+// String returns a one-line summary, e.g. "Database.Port: 5432 -> 5433".
+func (c FieldChange) String() string {
+	if c.Sensitive {
+		return fmt.Sprintf("%s: changed %s", c.Path, redactedValue)
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+// Diff returns the fields whose values differ between old and current, in field order,
+// e.g. to log what a reload changed. Nested sections, including those behind pointers, are
+// compared field by field; slices, maps and value types such as URLSet are compared as a
+// whole. Sensitive fields (see Secret) and fields read from a secret store with a `secret`
+// tag, which Dump masks too, are reported with their values redacted. A nil old
+// or current is compared as a zero configuration, so Diff(nil, cfg) lists every field that
+// is set.
+//
+// Example:
+//
+//	watcher.Subscribe(func(old, new *AppConfig) {
+//	    for _, change := range config.Diff(old, new) {
+//	        slog.Info("config changed", "change", change)
+//	    }
+//	})
+func Diff[T any](old, current *T) []FieldChange {
+	if old == nil {
+		old = new(T)
+	}
+	if current == nil {
+		current = new(T)
+	}
+	var changes []FieldChange
+	diffValues(reflect.ValueOf(old).Elem(), reflect.ValueOf(current).Elem(), "", false, &changes)
+	return changes
+}
+
+// diffValues appends the changes between old and current, the values of the field at path,
+// to changes. Values of sensitive fields, or of fields in a sensitive section, are redacted.
+func diffValues(old, current reflect.Value, path FieldPath, sensitive bool, changes *[]FieldChange) {
+	t := old.Type()
+	switch {
+	case t.Kind() == reflect.Ptr && isSection(t.Elem()):
+		if old.IsNil() && current.IsNil() {
+			return
+		}
+		if old.IsNil() {
+			old = reflect.New(t.Elem())
+		}
+		if current.IsNil() {
+			current = reflect.New(t.Elem())
+		}
+		diffValues(old.Elem(), current.Elem(), path, sensitive, changes)
+	case t.Kind() == reflect.Struct && isSection(t):
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.IsExported() {
+				diffValues(old.Field(i), current.Field(i), path.Child(field.Name), sensitive || isRedacted(field), changes)
+			}
+		}
+	case !reflect.DeepEqual(old.Interface(), current.Interface()):
+		change := FieldChange{Path: path, Old: redactedValue, New: redactedValue, Sensitive: sensitive}
+		if !sensitive {
+			change.Old, change.New = fmt.Sprint(old.Interface()), fmt.Sprint(current.Interface())
+		}
+		*changes = append(*changes, change)
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+type diffDatabase struct {
+	Host     string
+	Password string `config:"sensitive"`
+}
+
+type diffTestConfig struct {
+	Port     int
+	Timeout  time.Duration
+	Tags     []string
+	Token    Secret
+	Database diffDatabase
+	Cache    *diffDatabase
+	Auth     diffDatabase `sensitive:"true"`
+	APIKey   string       `secret:"aws=/myapp/api-key"`
+}
+
+func TestDiff(t *testing.T) {
+	old := &diffTestConfig{
+		Port:     8080,
+		Timeout:  time.Second,
+		Tags:     []string{"a"},
+		Token:    "one",
+		Database: diffDatabase{Host: "db1", Password: "p1"},
+		Auth:     diffDatabase{Host: "auth"},
+		APIKey:   "key1",
+	}
+	updated := *old
+	updated.Port = 9090
+	updated.Tags = []string{"a", "b"}
+	updated.Token = "two"
+	updated.Database = diffDatabase{Host: "db2", Password: "p2"}
+	updated.Cache = &diffDatabase{Host: "cache"}
+	updated.Auth = diffDatabase{Host: "auth2"}
+	updated.APIKey = "key2"
+
+	want := []string{
+		"Port: 8080 -> 9090",
+		"Tags: [a] -> [a b]",
+		"Token: changed [REDACTED]",
+		"Database.Host: db1 -> db2",
+		"Database.Password: changed [REDACTED]",
+		"Cache.Host:  -> cache",
+		"Auth.Host: changed [REDACTED]",
+		"APIKey: changed [REDACTED]",
+	}
+	changes := Diff(old, &updated)
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %v, want %v", changes, want)
+	}
+	for i, change := range changes {
+		if change.String() != want[i] {
+			t.Errorf("changes[%d] = %q, want %q", i, change, want[i])
+		}
+		if change.Sensitive && (change.Old != redactedValue || change.New != redactedValue) {
+			t.Errorf("changes[%d] leaks values: %+v", i, change)
+		}
+	}
+
+	if changes := Diff(old, old); len(changes) != 0 {
+		t.Errorf("Diff(old, old) = %v, want none", changes)
+	}
+	if changes := Diff(nil, &diffTestConfig{Port: 1}); len(changes) != 1 || changes[0].Path != "Port" || changes[0].Old != "0" {
+		t.Errorf("Diff(nil, cfg) = %v", changes)
+	}
+}
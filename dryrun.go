@@ -0,0 +1,61 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// FetchPlan lists the remote reads reported by Handler.DryRun.
+type FetchPlan struct {
+	Fetches []loader.Fetch
+}
+
+// Actions returns the distinct IAM actions and HTTP methods of the fetches, sorted, e.g. to
+// derive the policy a service needs.
+func (p *FetchPlan) Actions() []string {
+	actions := make([]string, len(p.Fetches))
+	for i, fetch := range p.Fetches {
+		actions[i] = fetch.Action
+	}
+	slices.Sort(actions)
+	return slices.Compact(actions)
+}
+
+// String returns one line per fetch.
+func (p *FetchPlan) String() string {
+	lines := make([]string, len(p.Fetches))
+	for i, fetch := range p.Fetches {
+		lines[i] = fetch.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DryRun reports the secrets, parameters and URLs the configured loaders would read for
+// cfg, without making any network calls, so a CI pipeline can validate paths and derive IAM
+// policies. Loaders take part by implementing loader.FetchPlanner; others are skipped. Set
+// DryRun on the loaders as well to run the rest of the pipeline without reading remote
+// sources.
+//
+// Tags are resolved with the availableAs fields already set on cfg, as in VerifySources.
+// Unresolvable references are returned as an error alongside the plan covering the
+// remaining fields.
+//
+// Example:
+//
+//	cfg := &AppConfig{Env: "prod"}
+//	plan, err := handler.DryRun(cfg)
+//	fmt.Println(plan)
+//	fmt.Println(plan.Actions()) // [secretsmanager:GetSecretValue ssm:GetParameters]
+func (c *Handler[C]) DryRun(cfg *C) (*FetchPlan, error) {
+	fields, resolveErr := resolvedFields(cfg, c.interpolationGuard)
+
+	plan := &FetchPlan{}
+	for _, l := range c.Loaders {
+		if planner, ok := l.(loader.FetchPlanner); ok {
+			plan.Fetches = append(plan.Fetches, planner.PlanFetches(fields)...)
+		}
+	}
+	return plan, resolveErr
+}
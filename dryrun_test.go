@@ -0,0 +1,41 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// stubPlanner reports a fetch per `secret` tag.
+type stubPlanner[T any] struct{}
+
+func (stubPlanner[T]) Load(c *T) error { return nil }
+
+func (stubPlanner[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	var fetches []loader.Fetch
+	for _, field := range fields {
+		if name := field.Tag.Get("secret"); name != "" {
+			fetches = append(fetches, loader.Fetch{LoaderType: "stub", Field: field.Name, Source: name, Action: "secretsmanager:GetSecretValue"})
+		}
+	}
+	return fetches
+}
+
+func TestHandler_DryRun(t *testing.T) {
+	handler := NewConfigHandler[verifyTestConfig](WithLoaders[verifyTestConfig](stubPlanner[verifyTestConfig]{}, &plainTestLoader[verifyTestConfig]{}))
+
+	plan, err := handler.DryRun(&verifyTestConfig{Env: "prod"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Fetches) != 2 || plan.Fetches[0].Source != "/prod/db/password" || plan.Fetches[1].Field != "APIKey" {
+		t.Fatalf("unexpected fetches: %v", plan.Fetches)
+	}
+	if actions := plan.Actions(); !reflect.DeepEqual(actions, []string{"secretsmanager:GetSecretValue"}) {
+		t.Errorf("unexpected actions %v", actions)
+	}
+	if want := "stub secretsmanager:GetSecretValue /prod/db/password (field DBPassword)\nstub secretsmanager:GetSecretValue /prod/api-key (field APIKey)"; plan.String() != want {
+		t.Errorf("String() = %q, want %q", plan.String(), want)
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DumpFormat is the serialization used by Handler.Dump.
+type DumpFormat int
+
+const (
+	DumpJSON DumpFormat = iota // Indented JSON, using json tags
+	DumpYAML                   // YAML, using yaml tags
+)
+
+// DumpOptions controls how Handler.Dump serializes a configuration.
+type DumpOptions struct {
+	Format DumpFormat // Output format (defaults to DumpJSON)
+	Redact bool       // Mask the values of sensitive fields
+}
+
+// Dump serializes the effective configuration cfg, e.g. to log it at startup. With Redact,
+// the values of sensitive fields are masked: fields marked `config:"sensitive"` or
+// `sensitive:"true"`, fields read from a secret store with a `secret` tag, and Secret
+// fields, which encode as "***" regardless. Masked strings, and the elements of masked
+// []string and map[string]string values, read [REDACTED]; masked values of other types are
+// dumped as their zero value. Empty values are left as they are, so the dump still shows
+// which secrets are unset. cfg is not modified.
+//
+// Example:
+//
+//	dump, err := handler.Dump(&cfg, config.DumpOptions{Format: config.DumpYAML, Redact: true})
+//	if err == nil {
+//	    slog.Info("effective configuration\n" + string(dump))
+//	}
+func (c *Handler[C]) Dump(cfg *C, opts DumpOptions) ([]byte, error) {
+	value := reflect.ValueOf(cfg).Elem()
+	if opts.Redact {
+		value = redactedCopy(value)
+	}
+
+	switch opts.Format {
+	case DumpJSON:
+		return json.MarshalIndent(value.Interface(), "", "  ")
+	case DumpYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(value.Interface()); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported dump format %d", opts.Format)
+	}
+}
+
+// isRedacted reports whether Dump masks the value of field: it is sensitive, or read from
+// a secret store with a `secret` tag.
+func isRedacted(field reflect.StructField) bool {
+	_, ok := field.Tag.Lookup("secret")
+	return ok || isSensitive(field)
+}
+
+// redactedCopy returns a copy of v with the values of sensitive fields masked. Sections,
+// including those behind pointers and in slices and maps, are copied rather than shared, so
+// v is left unchanged.
+func redactedCopy(v reflect.Value) reflect.Value {
+	switch {
+	case v.Kind() == reflect.Ptr && isSection(v.Type().Elem()):
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(redactedCopy(v.Elem()))
+		return copied
+	case v.Kind() == reflect.Struct && isSection(v.Type()):
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if isRedacted(field) {
+				copied.Field(i).Set(redactedValueOf(v.Field(i)))
+			} else {
+				copied.Field(i).Set(redactedCopy(v.Field(i)))
+			}
+		}
+		return copied
+	case v.Kind() == reflect.Slice && containsSection(v.Type().Elem()):
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(redactedCopy(v.Index(i)))
+		}
+		return copied
+	case v.Kind() == reflect.Map && containsSection(v.Type().Elem()):
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), redactedCopy(iter.Value()))
+		}
+		return copied
+	}
+	return v
+}
+
+// redactedValueOf returns v masked: strings and the string elements of slices and maps
+// replaced by [REDACTED], and other non-zero values by their zero value.
+func redactedValueOf(v reflect.Value) reflect.Value {
+	if v.IsZero() {
+		return v
+	}
+	masked := reflect.ValueOf(redactedValue)
+	switch {
+	case v.Kind() == reflect.String:
+		return masked.Convert(v.Type())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(masked.Convert(v.Type().Elem()))
+		}
+		return copied
+	case v.Kind() == reflect.Map && v.Type().Elem().Kind() == reflect.String:
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), masked.Convert(v.Type().Elem()))
+		}
+		return copied
+	}
+	return reflect.Zero(v.Type())
+}
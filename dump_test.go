@@ -0,0 +1,83 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type dumpDatabase struct {
+	Host     string `json:"host" yaml:"host"`
+	Password string `json:"password" yaml:"password" secret:"aws=prod/db"`
+}
+
+type dumpTestConfig struct {
+	Name     string            `json:"name" yaml:"name"`
+	APIKey   string            `json:"apiKey" yaml:"apiKey" config:"sensitive"`
+	Token    string            `json:"token" yaml:"token" sensitive:"true"`
+	Unset    string            `json:"unset" yaml:"unset" config:"sensitive"`
+	PIN      int               `json:"pin" yaml:"pin" config:"sensitive"`
+	Headers  map[string]string `json:"headers" yaml:"headers" config:"sensitive"`
+	Database dumpDatabase      `json:"database" yaml:"database"`
+	Replicas []*dumpDatabase   `json:"replicas" yaml:"replicas"`
+}
+
+func TestHandler_Dump(t *testing.T) {
+	handler := NewConfigHandler[dumpTestConfig]()
+	cfg := &dumpTestConfig{
+		Name:     "api",
+		APIKey:   "key",
+		Token:    "token",
+		PIN:      1234,
+		Headers:  map[string]string{"Authorization": "Bearer abc"},
+		Database: dumpDatabase{Host: "db", Password: "hunter2"},
+		Replicas: []*dumpDatabase{{Host: "replica", Password: "hunter3"}},
+	}
+
+	out, err := handler.Dump(cfg, DumpOptions{Redact: true})
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	var got dumpTestConfig
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Dump() = %s: %v", out, err)
+	}
+	want := dumpTestConfig{
+		Name:     "api",
+		APIKey:   redactedValue,
+		Token:    redactedValue,
+		Headers:  map[string]string{"Authorization": redactedValue},
+		Database: dumpDatabase{Host: "db", Password: redactedValue},
+	}
+	if got.Name != want.Name || got.APIKey != want.APIKey || got.Token != want.Token || got.Unset != "" || got.PIN != 0 ||
+		got.Headers["Authorization"] != redactedValue || got.Database != want.Database {
+		t.Errorf("Dump() = %s", out)
+	}
+	if len(got.Replicas) != 1 || *got.Replicas[0] != (dumpDatabase{Host: "replica", Password: redactedValue}) {
+		t.Errorf("Replicas = %s", out)
+	}
+	if cfg.Database.Password != "hunter2" || cfg.Replicas[0].Password != "hunter3" || cfg.Headers["Authorization"] != "Bearer abc" {
+		t.Errorf("Dump modified cfg: %+v", cfg)
+	}
+
+	out, err = handler.Dump(cfg, DumpOptions{Format: DumpYAML, Redact: true})
+	if err != nil {
+		t.Fatalf("Dump(YAML) error = %v", err)
+	}
+	for _, line := range []string{"name: api", "apiKey: '[REDACTED]'", "  host: db", "  password: '[REDACTED]'"} {
+		if !strings.Contains(string(out), line+"\n") {
+			t.Errorf("Dump(YAML) = %s, missing %q", out, line)
+		}
+	}
+	if strings.Contains(string(out), "hunter") {
+		t.Errorf("Dump(YAML) leaked a secret: %s", out)
+	}
+
+	out, err = handler.Dump(cfg, DumpOptions{})
+	if err != nil || !strings.Contains(string(out), `"apiKey": "key"`) {
+		t.Errorf("Dump() without Redact = %s, %v", out, err)
+	}
+	if _, err := handler.Dump(cfg, DumpOptions{Format: DumpFormat(9)}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/gymshark/go-easy-config/loader"
 )
@@ -179,3 +181,220 @@ func (e *DependencyGraphError) Error() string {
 	return fmt.Sprintf("dependency graph error during %s: %s",
 		e.Operation, e.Message)
 }
+
+// PathExpansionError represents a failure to expand a field marked with `config:"path"`.
+// It is returned by Handler.Load and ExpandPaths when the home directory or working
+// directory cannot be determined, or when the option is used on a non-string field.
+//
+// Example - Inspecting path expansion errors:
+//
+//	var pathErr *PathExpansionError
+//	if errors.As(err, &pathErr) {
+//	    fmt.Printf("Could not expand path for field '%s': %v\n", pathErr.FieldName, pathErr.Err)
+//	}
+type PathExpansionError struct {
+	FieldName string // Name of the field marked with the path option, with its path for nested fields (e.g. "TLS.CertFile")
+	Path      string // Value that failed to expand, if any
+	Err       error  // Underlying error
+}
+
+// Error returns a formatted error message with path expansion context.
+func (e *PathExpansionError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("path expansion failed for field '%s' (path: %s): %v",
+			e.FieldName, e.Path, e.Err)
+	}
+	return fmt.Sprintf("path expansion failed for field '%s': %v", e.FieldName, e.Err)
+}
+
+// Unwrap returns the underlying error, enabling error chain traversal.
+func (e *PathExpansionError) Unwrap() error {
+	return e.Err
+}
+
+// SourceVerificationError is returned by SourceReport.Err when one or more sources are missing
+// or inaccessible.
+type SourceVerificationError struct {
+	Failed []loader.SourceCheck
+}
+
+// Error lists every failed check.
+func (e *SourceVerificationError) Error() string {
+	lines := make([]string, len(e.Failed))
+	for i, check := range e.Failed {
+		lines[i] = "  " + check.String()
+	}
+	return fmt.Sprintf("%d configuration source(s) failed verification:\n%s", len(e.Failed), strings.Join(lines, "\n"))
+}
+
+// Unwrap returns the errors of the failed checks.
+func (e *SourceVerificationError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, check := range e.Failed {
+		errs[i] = check.Err
+	}
+	return errs
+}
+
+// OverrideError is returned by Handler.ApplyOverride when an override cannot be applied:
+// the path does not name a settable field, the value does not parse, the resulting
+// configuration fails validation, or the persistence hook fails. The configuration is
+// left unchanged.
+//
+// Example - Inspecting override errors:
+//
+//	var overrideErr *OverrideError
+//	if errors.As(err, &overrideErr) {
+//	    http.Error(w, overrideErr.Error(), http.StatusBadRequest)
+//	}
+type OverrideError struct {
+	Path FieldPath // Field path given to ApplyOverride
+	Err  error     // Underlying error
+}
+
+// Error returns a formatted error message with the override path.
+func (e *OverrideError) Error() string {
+	return fmt.Sprintf("override of '%s' failed: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error, enabling error chain traversal.
+func (e *OverrideError) Unwrap() error {
+	return e.Err
+}
+
+// DecryptionError is returned by Handler.Load when an inline encrypted value cannot be
+// decrypted by the decrypter set with WithValueDecryption. The field keeps its encrypted
+// value.
+//
+// Example - Inspecting decryption errors:
+//
+//	var decryptErr *DecryptionError
+//	if errors.As(err, &decryptErr) {
+//	    log.Fatalf("cannot decrypt %s: %v", decryptErr.Path, decryptErr.Err)
+//	}
+type DecryptionError struct {
+	Path FieldPath // Path of the field holding the encrypted value
+	Err  error     // Underlying error
+}
+
+// Error returns a formatted error message with the field path.
+func (e *DecryptionError) Error() string {
+	return fmt.Sprintf("decryption of '%s' failed: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error, enabling error chain traversal.
+func (e *DecryptionError) Unwrap() error {
+	return e.Err
+}
+
+// FieldViolation is a single failed validation rule on a single field.
+type FieldViolation struct {
+	Path    FieldPath // Field path from the config root, e.g. "Endpoints[2].URL" or "Targets[eu].Port"
+	Rule    string    // Failed rule, e.g. "required", "url" or "min"
+	Param   string    // Rule parameter, e.g. "1" for min=1
+	Message string    // Description of the failure, e.g. "invalid url"
+}
+
+// String returns the path and message, e.g. "Endpoints[2].URL: invalid url".
+func (v FieldViolation) String() string {
+	return string(v.Path) + ": " + v.Message
+}
+
+// Violations lists the individual field failures in an error returned by Handler.Validate,
+// Handler.LoadAndValidate or a StructValidator, with paths that index into slices and maps.
+// A *GroupError is reported once, with the member paths joined by "|" and the group name as
+// the Param. It returns nil if err contains no validation failures.
+//
+// Example:
+//
+//	if err := handler.LoadAndValidate(&cfg); err != nil {
+//	    for _, v := range config.Violations(err) {
+//	        fmt.Println(v) // Endpoints[2].URL: invalid url
+//	    }
+//	}
+func Violations(err error) []FieldViolation {
+	if err == nil {
+		return nil
+	}
+	violations := validatorViolations(err)
+	var walk func(err error)
+	walk = func(err error) {
+		if groupErr, ok := err.(*GroupError); ok {
+			violations = append(violations, FieldViolation{
+				Path:    FieldPath(strings.Join(groupErr.Members, "|")),
+				Rule:    groupErr.Rule,
+				Param:   groupErr.Group,
+				Message: groupErr.violationMessage(),
+			})
+			return
+		}
+		if validationErr, ok := err.(*ValidationError); ok && validationErr.FieldName != "<multiple>" {
+			violations = append(violations, FieldViolation{
+				Path:    FieldPath(validationErr.FieldName),
+				Rule:    validationErr.Rule,
+				Message: ruleMessage(validationErr.Rule, "", reflect.Invalid),
+			})
+			return
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			if inner := e.Unwrap(); inner != nil {
+				walk(inner)
+			}
+		}
+	}
+	walk(err)
+	return violations
+}
+
+// ruleMessage describes a failed rule. kind is the kind of the field, used to word length rules.
+func ruleMessage(rule, param string, kind reflect.Kind) string {
+	isCollection := kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+	switch rule {
+	case "required":
+		return "is required"
+	case "url", "email", "uri", "hostname", "ip", "cidr", "uuid":
+		return "invalid " + rule
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", param)
+	case "min", "max", "len":
+		bound := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[rule]
+		switch {
+		case isCollection:
+			return fmt.Sprintf("must have %s %s elements", bound, param)
+		case kind == reflect.String:
+			return fmt.Sprintf("must be %s %s characters", bound, param)
+		case rule == "len":
+			return fmt.Sprintf("must be %s", param)
+		default:
+			return fmt.Sprintf("must be %s %s", bound, param)
+		}
+	case "size_min":
+		return "must be at least " + param
+	case "size_max":
+		return "must be at most " + param
+	case "ltfield_dur", "ltefield_dur", "gtfield_dur", "gtefield_dur":
+		bound := map[string]string{"ltfield_dur": "less than", "ltefield_dur": "at most", "gtfield_dur": "greater than", "gtefield_dur": "at least"}[rule]
+		return fmt.Sprintf("must be %s %s", bound, param)
+	case "sum_max":
+		return fmt.Sprintf("fields tagged sum_max=%s must total at most %s", param, param)
+	case "minentropy":
+		return fmt.Sprintf("must have at least %s bits of entropy", param)
+	case "no_common_passwords":
+		return "is a commonly used password"
+	case "tls_required":
+		return "must use TLS in production"
+	case "insecure_in_production":
+		return "must not be enabled in production"
+	case "sensitive_tag":
+		return `looks sensitive but is not tagged config:"sensitive"`
+	}
+	if param != "" {
+		return fmt.Sprintf("failed rule %s=%s", rule, param)
+	}
+	return fmt.Sprintf("failed rule %s", rule)
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// EventType identifies what an Event reports.
+type EventType int
+
+const (
+	EventLoaded         EventType = iota + 1 // The first successful load of a handler
+	EventReloaded                            // A later successful load
+	EventReloadFailed                        // A load failed after an earlier one succeeded
+	EventSourceDegraded                      // A loader fell back, e.g. to a stale cache, or was skipped because its source failed
+)
+
+// String returns the name of the event type, e.g. "Reloaded".
+func (t EventType) String() string {
+	switch t {
+	case EventLoaded:
+		return "Loaded"
+	case EventReloaded:
+		return "Reloaded"
+	case EventReloadFailed:
+		return "ReloadFailed"
+	case EventSourceDegraded:
+		return "SourceDegraded"
+	}
+	return "Unknown"
+}
+
+// Event is a notification published on an EventBus.
+type Event struct {
+	Type        EventType
+	Time        time.Time
+	Fingerprint string // Fingerprint of the loaded configuration, for Loaded and Reloaded
+	Loader      string // Loader type, for SourceDegraded
+	Source      string // Source that failed, for SourceDegraded
+	Err         error  // Cause, for ReloadFailed and SourceDegraded
+}
+
+// DefaultEventBuffer is the buffer size of a subscription created with a size below 1.
+const DefaultEventBuffer = 16
+
+// EventBus delivers configuration events to subscribers without ever blocking the
+// publisher: each subscription has a buffer, and when it is full the oldest event is
+// dropped to make room. Subscribers that fall behind therefore see the latest state, and
+// can tell from Dropped that they missed events.
+//
+// The zero value is ready to use. Install a bus with WithEventBus to receive the events of
+// a handler:
+//
+//	var bus config.EventBus
+//	sub := bus.Subscribe(8)
+//	defer sub.Close()
+//	handler := config.NewConfigHandler[AppConfig](config.WithEventBus[AppConfig](&bus))
+//	go func() {
+//	    for event := range sub.Events() {
+//	        if event.Type == config.EventReloaded {
+//	            pool.Resize(current().MaxConns)
+//	        }
+//	    }
+//	}()
+type EventBus struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+// Subscription receives the events published on an EventBus after it was created.
+type Subscription struct {
+	bus     *EventBus
+	mu      sync.Mutex
+	events  chan Event
+	closed  bool
+	dropped atomic.Uint64
+}
+
+// Subscribe returns a subscription buffering up to buffer events, or DefaultEventBuffer if
+// buffer is below 1.
+func (b *EventBus) Subscribe(buffer int) *Subscription {
+	if buffer < 1 {
+		buffer = DefaultEventBuffer
+	}
+	s := &Subscription{bus: b, events: make(chan Event, buffer)}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[*Subscription]struct{})
+	}
+	b.subs[s] = struct{}{}
+	return s
+}
+
+// Subscribers returns the number of open subscriptions.
+func (b *EventBus) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Publish delivers e to every subscription, dropping the oldest buffered event of those
+// that are full. It sets e.Time to the current time if it is zero.
+func (b *EventBus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs {
+		s.deliver(e)
+	}
+}
+
+// deliver adds e to the buffer, dropping the oldest event if it is full.
+func (s *Subscription) deliver(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	for {
+		select {
+		case s.events <- e:
+			return
+		default:
+		}
+		select {
+		case <-s.events:
+			s.dropped.Add(1)
+		default:
+			// A receiver made room in the meantime
+		}
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close unsubscribes and closes the events channel. Buffered events can still be received.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	delete(s.bus.subs, s)
+	s.bus.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
+
+// WithEventBus publishes the handler's events on bus: Loaded after the first successful
+// Load, Reloaded after later ones, ReloadFailed when a Load fails after one succeeded, and
+// SourceDegraded when a loader implementing loader.DegradationReporter, such as a
+// CachingLoader serving a stale entry, reports that its source failed.
+func WithEventBus[C any](bus *EventBus) Option[C] {
+	return func(h *Handler[C]) {
+		h.events = bus
+	}
+}
+
+// applyEventBus reports the degradations of loaders to bus.
+func applyEventBus[C any](loaders []Loader[C], bus *EventBus) {
+	for _, l := range loaders {
+		if reporter, ok := l.(loader.DegradationReporter); ok {
+			name := loaderName(l)
+			reporter.SetDegradationHandler(func(source string, err error) {
+				bus.Publish(Event{Type: EventSourceDegraded, Loader: name, Source: source, Err: err})
+			})
+		}
+	}
+}
+
+// publishSkipped publishes a SourceDegraded event for each loader skipped by a load.
+func (c *Handler[C]) publishSkipped(skipped []SkippedLoader) {
+	if c.events == nil {
+		return
+	}
+	for _, s := range skipped {
+		c.events.Publish(Event{Type: EventSourceDegraded, Loader: s.Loader, Source: s.Source, Err: s.Err})
+	}
+}
+
+// publishLoad publishes the event for a load of cfg that ended with err.
+func (c *Handler[C]) publishLoad(cfg *C, err error) {
+	if c.events == nil {
+		return
+	}
+	switch {
+	case err != nil:
+		if c.loaded.Load() {
+			c.events.Publish(Event{Type: EventReloadFailed, Err: err})
+		}
+	case c.loaded.Swap(true):
+		c.events.Publish(Event{Type: EventReloaded, Fingerprint: Fingerprint(cfg)})
+	default:
+		c.events.Publish(Event{Type: EventLoaded, Fingerprint: Fingerprint(cfg)})
+	}
+}
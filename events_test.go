@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestEventBus_DropsOldest(t *testing.T) {
+	var bus EventBus
+	sub := bus.Subscribe(2)
+	for _, eventType := range []EventType{EventLoaded, EventReloaded, EventReloadFailed} {
+		bus.Publish(Event{Type: eventType})
+	}
+	if sub.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", sub.Dropped())
+	}
+	if e := <-sub.Events(); e.Type != EventReloaded || e.Time.IsZero() {
+		t.Errorf("first event = %v at %v, want Reloaded with a time", e.Type, e.Time)
+	}
+	if e := <-sub.Events(); e.Type != EventReloadFailed {
+		t.Errorf("second event = %v, want ReloadFailed", e.Type)
+	}
+
+	if bus.Subscribers() != 1 {
+		t.Errorf("Subscribers() = %d, want 1", bus.Subscribers())
+	}
+	sub.Close()
+	sub.Close()
+	if bus.Subscribers() != 0 {
+		t.Errorf("Subscribers() after Close = %d, want 0", bus.Subscribers())
+	}
+	bus.Publish(Event{Type: EventLoaded})
+	if _, ok := <-sub.Events(); ok {
+		t.Error("expected the channel to be closed")
+	}
+}
+
+func TestEventBus_PublishNeverBlocks(t *testing.T) {
+	var bus EventBus
+	slow := bus.Subscribe(1)
+	defer slow.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bus.Publish(Event{Type: EventReloaded})
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish blocked on a subscriber that does not receive")
+	}
+	if slow.Dropped() != 399 {
+		t.Errorf("Dropped() = %d, want 399", slow.Dropped())
+	}
+}
+
+type eventTestConfig struct {
+	Host string `env:"EVENT_HOST" json:"host"`
+}
+
+func TestWithEventBus_LoadEvents(t *testing.T) {
+	var bus EventBus
+	sub := bus.Subscribe(0)
+	defer sub.Close()
+
+	failing := &FailingLoader[eventTestConfig]{}
+	env := &generic.EnvironmentLoader[eventTestConfig]{}
+	handler := NewConfigHandler[eventTestConfig](WithLoaders[eventTestConfig](env), WithEventBus[eventTestConfig](&bus))
+
+	var cfg eventTestConfig
+	t.Setenv("EVENT_HOST", "a")
+	_ = handler.Load(&cfg)
+	t.Setenv("EVENT_HOST", "b")
+	_ = handler.Load(&cfg)
+	handler.chainLoader.Loaders = append(handler.chainLoader.Loaders, failing)
+	_ = handler.Load(&cfg)
+
+	want := []EventType{EventLoaded, EventReloaded, EventReloadFailed}
+	var fingerprints []string
+	for i, eventType := range want {
+		e := <-sub.Events()
+		if e.Type != eventType {
+			t.Fatalf("event %d = %v, want %v", i, e.Type, eventType)
+		}
+		fingerprints = append(fingerprints, e.Fingerprint)
+		if eventType == EventReloadFailed && e.Err == nil {
+			t.Error("expected ReloadFailed to carry the error")
+		}
+	}
+	if fingerprints[0] == "" || fingerprints[0] == fingerprints[1] {
+		t.Errorf("expected differing fingerprints, got %q", fingerprints)
+	}
+
+	// A failed first load publishes nothing
+	other := NewConfigHandler[eventTestConfig](WithLoaders[eventTestConfig](failing), WithEventBus[eventTestConfig](&bus))
+	_ = other.Load(&cfg)
+	select {
+	case e := <-sub.Events():
+		t.Errorf("unexpected event %v", e.Type)
+	default:
+	}
+}
+
+func TestWithEventBus_SourceDegraded(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("EVENT_HOST", "cached")
+	env := &generic.EnvironmentLoader[eventTestConfig]{}
+	if err := (&generic.CachingLoader[eventTestConfig]{Loader: env, Key: "hosts", Dir: dir}).Load(&eventTestConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var bus EventBus
+	sub := bus.Subscribe(0)
+	defer sub.Close()
+	unreachable := errors.New("unreachable")
+	caching := &generic.CachingLoader[eventTestConfig]{Loader: &FailingLoader[eventTestConfig]{ErrorToReturn: unreachable}, Key: "hosts", Dir: dir, StaleIfError: true}
+	handler := NewConfigHandler[eventTestConfig](WithLoaders[eventTestConfig](caching), WithEventBus[eventTestConfig](&bus))
+
+	var cfg eventTestConfig
+	if err := handler.Load(&cfg); err != nil || cfg.Host != "cached" {
+		t.Fatalf("Load() = %+v, %v", cfg, err)
+	}
+	e := <-sub.Events()
+	if e.Type != EventSourceDegraded || e.Loader != "CachingLoader" || e.Source != "hosts" || !errors.Is(e.Err, unreachable) {
+		t.Errorf("event = %+v, want SourceDegraded from CachingLoader", e)
+	}
+	if e := <-sub.Events(); e.Type != EventLoaded {
+		t.Errorf("event = %v, want Loaded", e.Type)
+	}
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LoadReport describes a load traced by Handler.Explain: what each loader set, how fields
+// were staged for interpolation, the tags the ${VAR} references resolved to, and the
+// outcome of loading and validating. It holds no field values, so it can be printed
+// without exposing secrets.
+type LoadReport struct {
+	Loaders       []LoaderReport           // Every configured loader, in the order they ran
+	Stages        [][]string               // Top-level fields loaded in each interpolation stage, sorted by name
+	Tags          []ResolvedField          // Fields whose tags reference variables, with the references resolved
+	Provenance    map[FieldPath]Provenance // Where each loaded field came from, as by Handler.Provenance
+	Skipped       []SkippedLoader          // Failures of loaders with the ContinueOnError policy
+	Elapsed       time.Duration            // Time taken to load and validate
+	LoadErr       error                    // Error returned by the load, if it failed
+	ValidationErr error                    // Error returned by Validate, if the load succeeded and validation failed
+}
+
+// LoaderReport lists the fields one loader set in a traced load.
+type LoaderReport struct {
+	Loader string      // Type of the loader, e.g. "EnvironmentLoader"
+	Source string      // Source the loader read, if it reports one, e.g. a file path
+	Fields []FieldPath // Fields whose loaded value the loader set, sorted; later loaders may have replaced others
+}
+
+// Err returns the load or validation error, or nil if the configuration loaded and is
+// valid.
+func (r *LoadReport) Err() error {
+	return errors.Join(r.LoadErr, r.ValidationErr)
+}
+
+// String returns the report as indented text, one loader, stage or tag per line, e.g. for
+// printing from an --explain-config flag.
+func (r *LoadReport) String() string {
+	var b strings.Builder
+	b.WriteString("Loaders:\n")
+	for i, l := range r.Loaders {
+		fmt.Fprintf(&b, "  %d. %s", i+1, l.Loader)
+		if l.Source != "" {
+			fmt.Fprintf(&b, " (%s)", l.Source)
+		}
+		if len(l.Fields) == 0 {
+			b.WriteString(": no fields\n")
+			continue
+		}
+		fields := make([]string, len(l.Fields))
+		for j, field := range l.Fields {
+			fields[j] = string(field)
+		}
+		fmt.Fprintf(&b, ": %s\n", strings.Join(fields, ", "))
+	}
+
+	b.WriteString("Stages:\n")
+	for i, stage := range r.Stages {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, strings.Join(stage, ", "))
+	}
+
+	if len(r.Tags) > 0 {
+		b.WriteString("Resolved tags:\n")
+		for _, field := range r.Tags {
+			fmt.Fprintf(&b, "  %s: %s (%s)\n", field.FieldName, field.Tag, strings.Join(field.Variables, ", "))
+		}
+	}
+
+	if len(r.Skipped) > 0 {
+		b.WriteString("Skipped loaders:\n")
+		for _, s := range r.Skipped {
+			fmt.Fprintf(&b, "  %d. %s\n", s.Index+1, s)
+		}
+	}
+
+	switch {
+	case r.LoadErr != nil:
+		fmt.Fprintf(&b, "Load: failed: %v\n", r.LoadErr)
+	case r.ValidationErr != nil:
+		fmt.Fprintf(&b, "Validation: failed: %v\n", r.ValidationErr)
+	default:
+		b.WriteString("Validation: ok\n")
+	}
+	fmt.Fprintf(&b, "Elapsed: %s", r.Elapsed.Round(time.Microsecond))
+	return b.String()
+}
+
+// Explain loads cfg like Load, validates it if the load succeeds, and returns a report
+// tracing the load, for diagnosing where values came from and why a configuration fails.
+// A failed load or validation is recorded in the report, see LoadReport.Err. Explain
+// records provenance as LoadResult does, so Handler.Provenance reflects the traced load.
+//
+// Example:
+//
+//	if *explainConfig {
+//	    report := handler.Explain(&cfg)
+//	    fmt.Println(report)
+//	    if report.Err() != nil {
+//	        os.Exit(1)
+//	    }
+//	}
+func (c *Handler[C]) Explain(cfg *C) *LoadReport {
+	start := time.Now()
+	report := &LoadReport{}
+
+	trace := newLoadTrace(true)
+	report.LoadErr = c.load(context.Background(), cfg, trace)
+	report.Skipped = trace.skipped
+	if report.LoadErr == nil {
+		report.ValidationErr = c.Validate(cfg)
+	}
+	report.Elapsed = time.Since(start)
+
+	engine := NewInterpolationEngine[C]()
+	engine.SetGuard(c.interpolationGuard)
+	if err := engine.Analyze(cfg); err == nil {
+		report.Stages = explainStages(engine)
+		if vars, err := engine.availableAsContext(cfg); err == nil {
+			resolved, _ := engine.ResolveAll(vars)
+			for _, field := range resolved {
+				if len(field.Variables) > 0 {
+					report.Tags = append(report.Tags, field)
+				}
+			}
+		}
+	}
+
+	if report.LoadErr == nil {
+		report.Provenance = c.provenanceOf(cfg, trace.sources)
+	}
+	report.Loaders = make([]LoaderReport, len(c.chainLoader.Loaders))
+	for i, l := range c.chainLoader.Loaders {
+		report.Loaders[i] = LoaderReport{Loader: loaderName(l), Source: sourceOf(unwrapLoader(l), nil, "")}
+	}
+	for path, source := range trace.sources {
+		report.Loaders[source.index].Fields = append(report.Loaders[source.index].Fields, path)
+	}
+	for i := range report.Loaders {
+		slices.Sort(report.Loaders[i].Fields)
+	}
+	return report
+}
+
+// explainStages returns the names of the top-level fields loaded in each stage by engine,
+// sorted within each stage so reports are stable, or of every field in declaration order
+// in a single stage when no field uses interpolation.
+func explainStages[C any](engine *InterpolationEngine[C]) [][]string {
+	if !engine.HasInterpolation() {
+		names := make([]string, len(engine.fieldNames))
+		for i := range names {
+			names[i] = engine.fieldNames[i]
+		}
+		return [][]string{names}
+	}
+	stages := make([][]string, 0, len(engine.GetDependencyStages()))
+	for _, stage := range engine.GetDependencyStages() {
+		names := make([]string, len(stage))
+		for i, fieldIndex := range stage {
+			names[i] = engine.fieldNames[fieldIndex]
+		}
+		slices.Sort(names)
+		stages = append(stages, names)
+	}
+	return stages
+}
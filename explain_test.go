@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type explainTestConfig struct {
+	Env  string       `env:"EXPLAIN_ENV" config:"availableAs=ENV"`
+	Name string       `json:"name" validate:"required"`
+	DB   provenanceDB `json:"db" store:"${ENV}/db"`
+}
+
+// explainStoreLoader sets DB.Password once Env is known, like storeLoader.
+type explainStoreLoader struct {
+	storeLoader
+}
+
+func (l *explainStoreLoader) Load(c *explainTestConfig) error {
+	if c.Env != "" {
+		c.DB.Password = "secret"
+	}
+	return nil
+}
+
+// joinPaths joins paths with commas.
+func joinPaths(paths []FieldPath) string {
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = string(path)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestHandler_Explain(t *testing.T) {
+	t.Setenv("EXPLAIN_ENV", "prod")
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"name": "api", "db": {"host": "db.internal"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewConfigHandler[explainTestConfig](WithLoaders[explainTestConfig](
+		&generic.JSONLoader[explainTestConfig]{Source: path},
+		&generic.EnvironmentLoader[explainTestConfig]{},
+		&explainStoreLoader{},
+	))
+
+	report := handler.Explain(&explainTestConfig{})
+	if err := report.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if got := joinPaths(report.Loaders[0].Fields); got != "DB.Host,Name" || report.Loaders[0].Source != path {
+		t.Errorf("Loaders[0] = %+v", report.Loaders[0])
+	}
+	if got := joinPaths(report.Loaders[2].Fields); report.Loaders[2].Loader != "explainStoreLoader" || got != "DB.Password" {
+		t.Errorf("Loaders[2] = %+v", report.Loaders[2])
+	}
+	if len(report.Stages) != 2 || strings.Join(report.Stages[0], ",") != "Env,Name" || strings.Join(report.Stages[1], ",") != "DB" {
+		t.Errorf("Stages = %v", report.Stages)
+	}
+	if len(report.Tags) != 1 || report.Tags[0].Tag.Get("store") != "prod/db" {
+		t.Errorf("Tags = %+v", report.Tags)
+	}
+	if p := report.Provenance["DB.Password"]; p.Loader != "explainStoreLoader" || p.Source != "prod/db" {
+		t.Errorf("Provenance[DB.Password] = %v", p)
+	}
+
+	text := report.String()
+	for _, want := range []string{
+		"1. JSONLoader (" + path + "): DB.Host, Name",
+		"2. EnvironmentLoader: Env",
+		"2. DB\n",
+		`DB: json:"db" store:"prod/db" (ENV)`,
+		"Validation: ok",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("String() = %q, missing %q", text, want)
+		}
+	}
+}
+
+func TestHandler_Explain_ValidationError(t *testing.T) {
+	handler := NewConfigHandler[explainTestConfig](WithLoaders[explainTestConfig](
+		&generic.JSONLoader[explainTestConfig]{Source: []byte(`{}`)},
+	))
+
+	report := handler.Explain(&explainTestConfig{})
+	var validationErr *ValidationError
+	if report.LoadErr != nil || !errors.As(report.Err(), &validationErr) {
+		t.Fatalf("LoadErr, Err() = %v, %v, want a *ValidationError", report.LoadErr, report.Err())
+	}
+	if !strings.Contains(report.String(), "Validation: failed") {
+		t.Errorf("String() = %q", report.String())
+	}
+}
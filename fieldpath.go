@@ -0,0 +1,226 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldPath addresses a field of a configuration from its root: Go field names joined by
+// dots, with slice elements and map entries selected in brackets, e.g.
+// "Database.Pool.MaxOpen", "Endpoints[2].URL" or "Targets[eu].Port".
+//
+// It is the path used throughout the package: by Result.Sources and Warning, by
+// ApplyOverride and Override, by For, by FieldViolation and OverrideError, by Snapshot and
+// by Get. Map keys containing ".", "[" or "]" cannot be addressed.
+type FieldPath string
+
+// String returns the path.
+func (p FieldPath) String() string {
+	return string(p)
+}
+
+// Child returns the path of the field name inside p, e.g. "Database.Port" for "Database"
+// and "Port".
+func (p FieldPath) Child(name string) FieldPath {
+	if p == "" {
+		return FieldPath(name)
+	}
+	return p + "." + FieldPath(name)
+}
+
+// Index returns the path of the slice element or map entry key of p, e.g. "Targets[eu]".
+func (p FieldPath) Index(key string) FieldPath {
+	return p + "[" + FieldPath(key) + "]"
+}
+
+// Elements splits p into field names and bracketed indexes, e.g. "Endpoints", "[2]" and
+// "URL" for "Endpoints[2].URL".
+func (p FieldPath) Elements() []string {
+	var elements []string
+	rest := string(p)
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			elements = append(elements, rest[:end+1])
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			elements = append(elements, rest[:end])
+			rest = rest[end:]
+		}
+	}
+	return elements
+}
+
+// Parent returns the path without its last element, or "" for a top-level field.
+func (p FieldPath) Parent() FieldPath {
+	i := strings.LastIndexAny(string(p), ".[")
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+// TypePath returns p without indexes, naming the field in the configuration type rather
+// than in a value, e.g. "Endpoints.URL" for "Endpoints[2].URL".
+func (p FieldPath) TypePath() FieldPath {
+	if !strings.Contains(string(p), "[") {
+		return p
+	}
+	var b strings.Builder
+	for _, element := range p.Elements() {
+		if strings.HasPrefix(element, "[") {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(element)
+	}
+	return FieldPath(b.String())
+}
+
+// Within reports whether p is ancestor or addresses a field, element or entry inside it.
+func (p FieldPath) Within(ancestor FieldPath) bool {
+	if ancestor == "" || p == ancestor {
+		return true
+	}
+	return strings.HasPrefix(string(p), string(ancestor)) &&
+		(p[len(ancestor)] == '.' || p[len(ancestor)] == '[')
+}
+
+// errPathIndex is returned when an index is given where only field names are supported.
+var errPathIndex = errors.New("indexes are not supported")
+
+// Get returns the value at path in cfg, a struct or a pointer to one, as a T.
+//
+// Field names are matched as by ApplyOverride: case-insensitively, or by the field's env
+// tag. Indexes select slice and array elements and the entries of maps with string keys,
+// and nil pointers are followed as zero values. It returns an error if path does not
+// address a value or the value is not a T.
+//
+// Example:
+//
+//	maxOpen, err := config.Get[int](&cfg, "Database.Pool.MaxOpen")
+//	region, err := config.Get[string](&cfg, "Targets[eu].Region")
+func Get[T any](cfg any, path FieldPath) (T, error) {
+	var zero T
+	v, _, err := resolveFieldPath(reflect.ValueOf(cfg), path, false)
+	if err != nil {
+		return zero, fmt.Errorf("get %q: %w", path, err)
+	}
+	value, ok := v.Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("get %q: field is %s, not %T", path, v.Type(), zero)
+	}
+	return value, nil
+}
+
+// resolveFieldPath returns the value at path in v. If settable is set, v must be
+// addressable, path may only name fields, and any pointer-to-struct passed through is
+// copied so the original configuration is not modified; the value returned is then
+// settable. It also reports whether the field is marked `config:"sensitive"`.
+func resolveFieldPath(v reflect.Value, path FieldPath, settable bool) (reflect.Value, bool, error) {
+	var sensitive bool
+	var walked FieldPath
+	elements := path.Elements()
+	if len(elements) == 0 {
+		return reflect.Value{}, false, errors.New("empty field path")
+	}
+	for _, element := range elements {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if settable && v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+				clone := reflect.New(v.Type().Elem())
+				if !v.IsNil() {
+					clone.Elem().Set(v.Elem())
+				}
+				v.Set(clone)
+				v = clone.Elem()
+				break
+			}
+			if v.IsNil() {
+				if v.Kind() == reflect.Interface {
+					return reflect.Value{}, false, fmt.Errorf("%s is nil", pathOrRoot(walked))
+				}
+				v = reflect.Zero(v.Type().Elem())
+				continue
+			}
+			v = v.Elem()
+		}
+
+		if key, ok := strings.CutPrefix(element, "["); ok {
+			if settable {
+				return reflect.Value{}, false, errPathIndex
+			}
+			key = strings.TrimSuffix(key, "]")
+			next, err := indexValue(v, key)
+			if err != nil {
+				return reflect.Value{}, false, fmt.Errorf("%s: %w", pathOrRoot(walked), err)
+			}
+			v = next
+			walked = walked.Index(key)
+			continue
+		}
+
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false, fmt.Errorf("%s is not a struct", pathOrRoot(walked))
+		}
+		t := v.Type()
+		index := fieldIndex(t, element)
+		if index == -1 {
+			return reflect.Value{}, false, fmt.Errorf("no field matches %q", element)
+		}
+		sensitive = isSensitive(t.Field(index))
+		v = v.Field(index)
+		walked = walked.Child(t.Field(index).Name)
+	}
+	return v, sensitive, nil
+}
+
+// fieldIndex returns the index of the exported field of the struct type t named name,
+// case-insensitively, or by its env tag, or -1 if there is none.
+func fieldIndex(t reflect.Type, name string) int {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		envName, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if field.IsExported() && (strings.EqualFold(field.Name, name) || (envName != "" && envName == name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexValue returns the element of the slice or array v at index key, or the entry of the
+// map v with key.
+func indexValue(v reflect.Value, key string) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("index [%s] out of range for length %d", key, v.Len())
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, fmt.Errorf("map keys are %s, not strings", v.Type().Key())
+		}
+		entry := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !entry.IsValid() {
+			return reflect.Value{}, fmt.Errorf("no entry [%s]", key)
+		}
+		return entry, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s cannot be indexed", v.Type())
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFieldPath(t *testing.T) {
+	path := FieldPath("").Child("Targets").Index("eu").Child("Endpoints").Index("2").Child("URL")
+	if path != "Targets[eu].Endpoints[2].URL" {
+		t.Fatalf("built path = %q", path)
+	}
+	if got := path.Elements(); !reflect.DeepEqual(got, []string{"Targets", "[eu]", "Endpoints", "[2]", "URL"}) {
+		t.Errorf("Elements() = %q", got)
+	}
+	if got := path.TypePath(); got != "Targets.Endpoints.URL" {
+		t.Errorf("TypePath() = %q", got)
+	}
+	if got := path.Parent(); got != "Targets[eu].Endpoints[2]" {
+		t.Errorf("Parent() = %q", got)
+	}
+	if got := FieldPath("Port").Parent(); got != "" {
+		t.Errorf("Parent() of a top-level field = %q", got)
+	}
+
+	tests := []struct {
+		path, ancestor FieldPath
+		want           bool
+	}{
+		{"Database.Port", "Database", true},
+		{"Targets[eu].Port", "Targets", true},
+		{"Database", "Database", true},
+		{"DatabaseURL", "Database", false},
+		{"Database", "Database.Port", false},
+		{"Port", "", true},
+	}
+	for _, tt := range tests {
+		if got := tt.path.Within(tt.ancestor); got != tt.want {
+			t.Errorf("%q.Within(%q) = %v, want %v", tt.path, tt.ancestor, got, tt.want)
+		}
+	}
+}
+
+type getTestEndpoint struct {
+	URL string
+}
+
+type getTestConfig struct {
+	Port     int `env:"PORT"`
+	Database *struct {
+		Pool struct {
+			MaxOpen int
+		}
+	}
+	Endpoints []getTestEndpoint
+	Targets   map[string]*getTestEndpoint
+}
+
+func TestGet(t *testing.T) {
+	cfg := getTestConfig{
+		Port:      8080,
+		Endpoints: []getTestEndpoint{{URL: "a"}, {URL: "b"}},
+		Targets:   map[string]*getTestEndpoint{"eu": {URL: "eu.example.com"}},
+	}
+
+	if port, err := Get[int](&cfg, "PORT"); err != nil || port != 8080 {
+		t.Errorf("Get(PORT) = %d, %v", port, err)
+	}
+	if url, err := Get[string](cfg, "Endpoints[1].URL"); err != nil || url != "b" {
+		t.Errorf("Get(Endpoints[1].URL) = %q, %v", url, err)
+	}
+	if url, err := Get[string](&cfg, "targets[eu].url"); err != nil || url != "eu.example.com" {
+		t.Errorf("Get(targets[eu].url) = %q, %v", url, err)
+	}
+	if maxOpen, err := Get[int](&cfg, "Database.Pool.MaxOpen"); err != nil || maxOpen != 0 {
+		t.Errorf("Get through a nil pointer = %d, %v, want the zero value", maxOpen, err)
+	}
+	if endpoint, err := Get[getTestEndpoint](&cfg, "Endpoints[0]"); err != nil || endpoint.URL != "a" {
+		t.Errorf("Get(Endpoints[0]) = %+v, %v", endpoint, err)
+	}
+
+	for path, want := range map[FieldPath]string{
+		"Missing":          `no field matches "Missing"`,
+		"Endpoints[5].URL": "out of range",
+		"Targets[us]":      "no entry [us]",
+		"Port[0]":          "cannot be indexed",
+		"Port.Value":       "Port is not a struct",
+		"Endpoints":        "not string",
+		"":                 "empty field path",
+	} {
+		_, err := Get[string](&cfg, path)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Get(%q) error = %v, want %q", path, err, want)
+		}
+	}
+}
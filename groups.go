@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// Field group rules, set with `config:"group=<name>,<rule>"`.
+const (
+	GroupExactlyOne = "exactlyOne" // Exactly one member must be set
+	GroupAtMostOne  = "atMostOne"  // Members are mutually exclusive, none or one may be set
+	GroupAtLeastOne = "atLeastOne" // One or more members must be set
+)
+
+// GroupError reports a field group whose members break the group's rule, e.g. two
+// authentication mechanisms configured where exactly one is allowed.
+type GroupError struct {
+	Group   string   // Group name from the group= option
+	Rule    string   // GroupExactlyOne, GroupAtMostOne or GroupAtLeastOne
+	Members []string // Paths of all fields in the group, in declaration order
+	Set     []string // Paths of the members that are set
+}
+
+// Error returns a message listing the group members and those that are set.
+func (e *GroupError) Error() string {
+	return fmt.Sprintf("group '%s' %s [%s], got %s", e.Group, groupRequirement(e.Rule),
+		strings.Join(e.Members, ", "), groupSetDescription(e.Set))
+}
+
+// violationMessage describes the error for a FieldViolation, whose Path lists the members.
+func (e *GroupError) violationMessage() string {
+	quantity := map[string]string{GroupAtMostOne: "at most one", GroupAtLeastOne: "at least one"}[e.Rule]
+	if quantity == "" {
+		quantity = "exactly one"
+	}
+	return fmt.Sprintf("%s of group '%s' must be set, got %s", quantity, e.Group, groupSetDescription(e.Set))
+}
+
+// groupRequirement describes a group rule.
+func groupRequirement(rule string) string {
+	switch rule {
+	case GroupAtMostOne:
+		return "allows at most one of"
+	case GroupAtLeastOne:
+		return "requires at least one of"
+	default:
+		return "requires exactly one of"
+	}
+}
+
+// groupSetDescription describes the set members of a group.
+func groupSetDescription(set []string) string {
+	if len(set) == 0 {
+		return "none"
+	}
+	return strconv.Itoa(len(set)) + " (" + strings.Join(set, ", ") + ")"
+}
+
+// ValidateGroups checks the field groups declared in cfg with `config:"group=<name>,<rule>"`
+// and returns a *GroupError for each group that breaks its rule, joined with errors.Join.
+// Members are set when they are not the zero value. The rule may be given on any member and
+// defaults to GroupExactlyOne. Groups are scoped to the struct that declares them, and nested
+// sections and list sections are checked with member paths such as "Sinks[1].URL".
+// Handler.Validate calls ValidateGroups after the validator.
+//
+// Example:
+//
+//	type AuthConfig struct {
+//	    APIKey     string `env:"API_KEY" config:"group=auth,exactlyOne,sensitive"`
+//	    OAuthToken string `env:"OAUTH_TOKEN" config:"group=auth,sensitive"`
+//	    ClientCert string `env:"CLIENT_CERT" config:"group=auth,path"`
+//	}
+//	// group 'auth' requires exactly one of [APIKey, OAuthToken, ClientCert], got 2 (APIKey, OAuthToken)
+func ValidateGroups(cfg any) error {
+	var errs []error
+	validateGroupsIn(reflect.ValueOf(cfg), "", &errs)
+	return errors.Join(errs...)
+}
+
+// fieldGroup collects the members of one group while a struct is checked.
+type fieldGroup struct {
+	name    string
+	rule    string
+	members []string
+	set     []string
+}
+
+func validateGroupsIn(v reflect.Value, prefix string, errs *[]error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if elem := v.Type().Elem(); elem.Kind() != reflect.Struct && elem.Kind() != reflect.Ptr {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			validateGroupsIn(v.Index(i), prefix+"["+strconv.Itoa(i)+"]", errs)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	var groups []*fieldGroup
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := field.Name
+		if prefix != "" {
+			path = prefix + "." + field.Name
+		}
+
+		tag := field.Tag.Get("config")
+		if name, ok := utils.TagOptionValue(tag, "group"); ok && name != "" {
+			group := findGroup(&groups, name)
+			group.members = append(group.members, path)
+			if !v.Field(i).IsZero() {
+				group.set = append(group.set, path)
+			}
+			for _, rule := range []string{GroupExactlyOne, GroupAtMostOne, GroupAtLeastOne} {
+				if group.rule == "" && utils.HasTagOption(tag, rule) {
+					group.rule = rule
+				}
+			}
+		}
+
+		validateGroupsIn(v.Field(i), path, errs)
+	}
+
+	for _, group := range groups {
+		if group.rule == "" {
+			group.rule = GroupExactlyOne
+		}
+		broken := false
+		switch group.rule {
+		case GroupExactlyOne:
+			broken = len(group.set) != 1
+		case GroupAtMostOne:
+			broken = len(group.set) > 1
+		case GroupAtLeastOne:
+			broken = len(group.set) == 0
+		}
+		if broken {
+			*errs = append(*errs, &GroupError{Group: group.name, Rule: group.rule, Members: group.members, Set: group.set})
+		}
+	}
+}
+
+// findGroup returns the group with the given name, adding it if it is new.
+func findGroup(groups *[]*fieldGroup, name string) *fieldGroup {
+	for _, group := range *groups {
+		if group.name == name {
+			return group
+		}
+	}
+	group := &fieldGroup{name: name}
+	*groups = append(*groups, group)
+	return group
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type groupAuthConfig struct {
+	APIKey     string `config:"group=auth,exactlyOne,sensitive"`
+	OAuthToken string `config:"group=auth,sensitive"`
+	ClientCert string `config:"group=auth,path"`
+}
+
+type groupSink struct {
+	URL   string `config:"group=target,atMostOne"`
+	Queue string `config:"group=target"`
+}
+
+type groupTestConfig struct {
+	Auth    groupAuthConfig
+	Sinks   []groupSink
+	Primary string `config:"group=region,atLeastOne"`
+	Backup  *int   `config:"group=region"`
+}
+
+func TestValidateGroups(t *testing.T) {
+	valid := groupTestConfig{
+		Auth:    groupAuthConfig{OAuthToken: "token"},
+		Sinks:   []groupSink{{URL: "https://a"}, {}},
+		Primary: "eu-west-1",
+	}
+	if err := ValidateGroups(&valid); err != nil {
+		t.Errorf("ValidateGroups() error = %v", err)
+	}
+
+	one := 1
+	invalid := groupTestConfig{
+		Auth:   groupAuthConfig{APIKey: "key", OAuthToken: "token"},
+		Sinks:  []groupSink{{URL: "https://a"}, {URL: "https://b", Queue: "q"}},
+		Backup: &one,
+	}
+	err := ValidateGroups(&invalid)
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("expected GroupError, got %v", err)
+	}
+	want := []string{
+		"group 'auth' requires exactly one of [Auth.APIKey, Auth.OAuthToken, Auth.ClientCert], got 2 (Auth.APIKey, Auth.OAuthToken)",
+		"group 'target' allows at most one of [Sinks[1].URL, Sinks[1].Queue], got 2 (Sinks[1].URL, Sinks[1].Queue)",
+	}
+	if got := err.Error(); got != strings.Join(want, "\n") {
+		t.Errorf("error =\n%s", got)
+	}
+
+	invalid.Auth = groupAuthConfig{}
+	invalid.Sinks = nil
+	invalid.Backup = nil
+	want = []string{
+		"group 'auth' requires exactly one of [Auth.APIKey, Auth.OAuthToken, Auth.ClientCert], got none",
+		"group 'region' requires at least one of [Primary, Backup], got none",
+	}
+	if got := ValidateGroups(&invalid).Error(); got != strings.Join(want, "\n") {
+		t.Errorf("error =\n%s", got)
+	}
+}
+
+func TestHandler_ValidateGroups(t *testing.T) {
+	type config struct {
+		Name   string `validate:"required"`
+		Token  string `config:"group=auth"`
+		Secret string `config:"group=auth"`
+	}
+
+	handler := NewConfigHandler[config]()
+	err := handler.Validate(&config{Token: "a", Secret: "b"})
+
+	got := make([]string, 0)
+	for _, violation := range Violations(err) {
+		got = append(got, violation.String())
+	}
+	want := []string{
+		"Name: is required",
+		"Token|Secret: exactly one of group 'auth' must be set, got 2 (Token, Secret)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected violations:\n%s", strings.Join(got, "\n"))
+	}
+
+	if err := handler.Validate(&config{Name: "app", Token: "a"}); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // InterpolatingChainLoader wraps a chain of loaders and adds variable interpolation support.
@@ -32,7 +38,27 @@ import (
 type InterpolatingChainLoader[T any] struct {
 	Loaders      []Loader[T]
 	engine       *InterpolationEngine[T]
-	ShortCircuit bool // Enable short-circuit behavior within stages
+	ShortCircuit bool                // Enable short-circuit behavior within stages
+	Progress     func(ProgressEvent) // Optional hook called when each stage starts and finishes
+	StageTimeout time.Duration       // Optional limit on the time each stage may take to load
+	Guard        *InterpolationGuard // Optional checks on ${VAR} values (defaults to none)
+	IsSet        *utils.IsSetFuncs   // Decides which fields count as set (defaults to utils.DefaultIsSetFuncs)
+	Policies     []LoaderPolicy      // Error policy of the loader at the same index (defaults to FailOnError)
+	spawn        func(func())        // Starts the stage goroutine under StageTimeout; set by Handler so Shutdown waits for it
+}
+
+// ProgressEvent reports the progress of a staged load. Each stage produces one event when
+// it starts and one when it finishes, so a slow startup shows which stage, and which
+// fields, it is waiting on. Loads without interpolation run as a single stage.
+type ProgressEvent struct {
+	Stage    int           // Stage number, starting at 1
+	Stages   int           // Total number of stages
+	Fields   []string      // Fields loaded in this stage
+	Resolved int           // Fields set so far across all stages; counted when the stage finishes
+	Total    int           // Fields across all stages
+	Done     bool          // False when the stage starts, true when it finishes
+	Elapsed  time.Duration // Time the stage took; zero when it starts
+	Err      error         // Error that ended the stage, including a *StageTimeoutError
 }
 
 // Load executes loaders in dependency-aware stages when interpolation is needed,
@@ -50,6 +76,18 @@ type InterpolatingChainLoader[T any] struct {
 //   - Any loader fails during execution
 //   - Type conversion fails for availableAs fields
 func (l *InterpolatingChainLoader[T]) Load(c *T) error {
+	return l.load(context.Background(), c, nil)
+}
+
+// LoadContext is like Load, but passes ctx to the loaders implementing ContextLoader, and
+// stops before the next loader once ctx is done, returning its error.
+func (l *InterpolatingChainLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	return l.load(ctx, c, nil)
+}
+
+// load runs LoadContext, recording in trace, if not nil, the loader and stage that last
+// changed each field and the errors of the loaders it skipped.
+func (l *InterpolatingChainLoader[T]) load(ctx context.Context, c *T, trace *loadTrace) error {
 	if l.Loaders == nil {
 		return fmt.Errorf("InterpolatingChainLoader.Loaders is nil")
 	}
@@ -58,6 +96,7 @@ func (l *InterpolatingChainLoader[T]) Load(c *T) error {
 	if l.engine == nil {
 		l.engine = NewInterpolationEngine[T]()
 	}
+	l.engine.SetGuard(l.Guard)
 
 	// Analyze the struct to detect interpolation needs
 	if err := l.engine.Analyze(c); err != nil {
@@ -67,33 +106,23 @@ func (l *InterpolatingChainLoader[T]) Load(c *T) error {
 	// Fast path: no interpolation needed
 	// Execute loaders in sequence without staged loading
 	if !l.engine.HasInterpolation() {
-		return l.loadWithoutInterpolation(c)
+		return l.loadWithoutInterpolation(ctx, c, trace)
 	}
 
 	// Slow path: staged loading with interpolation
-	return l.loadWithInterpolation(c)
+	return l.loadWithInterpolation(ctx, c, trace)
 }
 
 // loadWithoutInterpolation executes loaders in sequence without staged loading.
-// This is the fast path when no interpolation is needed.
+// This is the fast path when no interpolation is needed; it is reported and timed
+// as a single stage.
 // If ShortCircuit is enabled, stops loading when all fields are populated.
-func (l *InterpolatingChainLoader[T]) loadWithoutInterpolation(c *T) error {
-	for i, loader := range l.Loaders {
-		if loader == nil {
-			return fmt.Errorf("loader at index %d is nil", i)
-		}
-
-		// Apply short-circuit logic if enabled
-		if l.ShortCircuit && l.isStageFullyPopulated(c) {
-			break
-		}
-
-		if err := loader.Load(c); err != nil {
-			return fmt.Errorf("error in loader at index %d: %w", i, err)
-		}
+func (l *InterpolatingChainLoader[T]) loadWithoutInterpolation(ctx context.Context, c *T, trace *loadTrace) error {
+	fields := make([]int, 0, len(l.engine.fieldNames))
+	for i := 0; i < len(l.engine.fieldNames); i++ {
+		fields = append(fields, i)
 	}
-
-	return nil
+	return l.runStage(ctx, c, [][]int{fields}, 0, trace)
 }
 
 // loadWithInterpolation performs staged loading with variable interpolation.
@@ -102,7 +131,7 @@ func (l *InterpolatingChainLoader[T]) loadWithoutInterpolation(c *T) error {
 //
 // The interpolation context is built progressively as fields are loaded,
 // making variable values available for subsequent stages.
-func (l *InterpolatingChainLoader[T]) loadWithInterpolation(c *T) error {
+func (l *InterpolatingChainLoader[T]) loadWithInterpolation(ctx context.Context, c *T, trace *loadTrace) error {
 	stages := l.engine.GetDependencyStages()
 
 	// Process each dependency stage
@@ -115,7 +144,7 @@ func (l *InterpolatingChainLoader[T]) loadWithInterpolation(c *T) error {
 
 		// Load fields in this stage using all loaders
 		// Loaders execute in sequence, maintaining precedence within the stage
-		if err := l.loadStage(c); err != nil {
+		if err := l.runStage(ctx, c, stages, stageNum, trace); err != nil {
 			return fmt.Errorf("failed to load stage %d: %w", stageNum, err)
 		}
 
@@ -129,6 +158,99 @@ func (l *InterpolatingChainLoader[T]) loadWithInterpolation(c *T) error {
 	return nil
 }
 
+// runStage loads stage stageNum of stages, reporting progress and applying StageTimeout.
+//
+// With a timeout, the loaders run on a copy of the configuration in a separate goroutine,
+// and the copy replaces c only if they finish in time. On timeout, the context passed to
+// ContextLoaders is cancelled; other loaders have no way to cancel a load, so they are
+// left to finish in the background and their result is discarded. The copy is shallow, so
+// maps and pointers are shared with c.
+func (l *InterpolatingChainLoader[T]) runStage(ctx context.Context, c *T, stages [][]int, stageNum int, trace *loadTrace) error {
+	event := ProgressEvent{
+		Stage:  stageNum + 1,
+		Stages: len(stages),
+		Fields: make([]string, 0, len(stages[stageNum])),
+	}
+	for _, stage := range stages {
+		event.Total += len(stage)
+	}
+	for _, fieldIndex := range stages[stageNum] {
+		event.Fields = append(event.Fields, l.engine.fieldNames[fieldIndex])
+	}
+	l.report(event)
+
+	start := time.Now()
+	err := l.loadStageWithTimeout(ctx, c, event.Stage, trace)
+	if errors.Is(err, errStageTimeout) {
+		err = &StageTimeoutError{Stage: event.Stage, Stages: event.Stages, Fields: event.Fields, Timeout: l.StageTimeout}
+	}
+
+	event.Done, event.Elapsed, event.Err = true, time.Since(start), err
+	configValue := reflect.ValueOf(c).Elem()
+	for _, stage := range stages[:stageNum+1] {
+		for _, fieldIndex := range stage {
+			if l.IsSet.IsFieldSet(configValue, fieldIndex) {
+				event.Resolved++
+			}
+		}
+	}
+	l.report(event)
+	return err
+}
+
+// errStageTimeout is returned by loadStageWithTimeout for runStage to describe.
+var errStageTimeout = errors.New("stage timed out")
+
+// loadStageWithTimeout runs loadStage, on a copy of c if StageTimeout is set.
+func (l *InterpolatingChainLoader[T]) loadStageWithTimeout(ctx context.Context, c *T, stage int, trace *loadTrace) error {
+	if l.StageTimeout <= 0 {
+		return l.loadStage(ctx, c, stage, trace)
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, l.StageTimeout, errStageTimeout)
+	defer cancel()
+
+	scratch := new(T)
+	*scratch = *c
+	var scratchTrace *loadTrace
+	if trace != nil {
+		scratchTrace = newLoadTrace(trace.sources != nil)
+	}
+	done := make(chan error, 1)
+	run := func() {
+		done <- l.loadStage(ctx, scratch, stage, scratchTrace)
+	}
+	if l.spawn != nil {
+		l.spawn(run)
+	} else {
+		go run()
+	}
+
+	select {
+	case err := <-done:
+		if err != nil && context.Cause(ctx) == errStageTimeout {
+			return errStageTimeout // A ContextLoader stopped at the timeout
+		}
+		if err != nil {
+			return err
+		}
+		*c = *scratch
+		trace.merge(scratchTrace)
+		return nil
+	case <-ctx.Done():
+		if context.Cause(ctx) == errStageTimeout {
+			return errStageTimeout
+		}
+		return ctx.Err()
+	}
+}
+
+// report passes event to the Progress hook, if set.
+func (l *InterpolatingChainLoader[T]) report(event ProgressEvent) {
+	if l.Progress != nil {
+		l.Progress(event)
+	}
+}
+
 // loadStage executes all loaders for the current stage.
 // Loaders are executed in sequence, maintaining the loader precedence within the stage.
 // Later loaders can override values set by earlier loaders.
@@ -137,9 +259,19 @@ func (l *InterpolatingChainLoader[T]) loadWithInterpolation(c *T) error {
 // but ensures that dependency fields (those with availableAs) are always loaded before
 // dependent fields. Short-circuit logic is applied within each stage, not across stages.
 //
+// Loaders implementing ContextLoader are passed ctx, carrying a loader.VariableSource with
+// the guarded values of the availableAs fields set so far, and no further loader runs once
+// ctx is done. A loader whose policy is ContinueOnError may fail without failing the stage;
+// any fields it set before failing, including through pointers, maps and slices, are
+// reverted, its error is recorded in trace and the next loader runs.
+//
 // Note: Since struct tags cannot be modified at runtime, loaders see the original tags.
 // Future enhancements may include interpolation-aware loader wrappers or code generation.
-func (l *InterpolatingChainLoader[T]) loadStage(c *T) error {
+func (l *InterpolatingChainLoader[T]) loadStage(ctx context.Context, c *T, stage int, trace *loadTrace) error {
+	ctx = loader.WithVariableSource(ctx, func() (map[string]string, error) {
+		return l.engine.availableAsContext(c)
+	})
+
 	// Execute all loaders in sequence
 	// Each loader processes the entire struct, but the staged approach ensures
 	// that dependencies are satisfied before dependent fields are used
@@ -148,80 +280,100 @@ func (l *InterpolatingChainLoader[T]) loadStage(c *T) error {
 			return fmt.Errorf("loader at index %d is nil", i)
 		}
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// Apply short-circuit logic within the stage if enabled
 		if l.ShortCircuit && l.isStageFullyPopulated(c) {
 			break
 		}
 
-		if err := loader.Load(c); err != nil {
-			return fmt.Errorf("error in loader at index %d: %w", i, err)
+		optional := i < len(l.Policies) && l.Policies[i] == ContinueOnError
+		recording := trace != nil && trace.sources != nil
+		var before T
+		if recording || optional {
+			reflect.ValueOf(&before).Elem().Set(deepCopy(reflect.ValueOf(c).Elem(), make(map[uintptr]reflect.Value)))
 		}
-	}
-
-	return nil
-}
-
-// isStageFullyPopulated checks if all exported fields in the configuration are populated.
-// This is used for short-circuit behavior within stages.
-func (l *InterpolatingChainLoader[T]) isStageFullyPopulated(c *T) bool {
-	if c == nil {
-		return false
-	}
-	configValue := reflect.ValueOf(c).Elem()
-	configType := configValue.Type()
-
-	for i := 0; i < configValue.NumField(); i++ {
-		structField := configType.Field(i)
-		// Skip unexported fields
-		if structField.PkgPath != "" {
+		if err := loadWith(ctx, loader, c); err != nil {
+			if !optional || ctx.Err() != nil {
+				return fmt.Errorf("error in loader at index %d: %w", i, err)
+			}
+			*c = before
+			trace.skip(SkippedLoader{Loader: loaderName(loader), Source: sourceOf(unwrapLoader(loader), nil, ""), Index: i, Stage: stage, Err: err})
 			continue
 		}
-
-		fieldValue := configValue.Field(i)
-		if isZeroValue(fieldValue) {
-			return false
+		if recording {
+			source := fieldSource{loader: unwrapLoader(loader), index: i, stage: stage}
+			recordSources(trace.sources, source, reflect.ValueOf(&before).Elem(), reflect.ValueOf(c).Elem(), "")
 		}
 	}
 
-	return true
+	return nil
 }
 
-// isZeroValue checks if a reflect.Value is a zero value for its type.
-func isZeroValue(v reflect.Value) bool {
+// deepCopy returns a copy of v sharing no pointers, maps or slices with it, so changes a
+// loader makes through them can be reverted and recorded. Unexported fields, functions and
+// channels are copied as by assignment, and a pointer reached twice is copied once.
+func deepCopy(v reflect.Value, copies map[uintptr]reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
 	switch v.Kind() {
-	case reflect.Chan, reflect.Map, reflect.Slice, reflect.Ptr:
-		return v.IsNil()
-	case reflect.Interface:
+	case reflect.Ptr:
 		if v.IsNil() {
-			return true
+			return out
+		}
+		if copied, ok := copies[v.Pointer()]; ok && copied.Type() == v.Type() {
+			return copied
 		}
-		underlying := v.Elem()
-		if (underlying.Kind() == reflect.Ptr || underlying.Kind() == reflect.Interface) && underlying.IsNil() {
-			return true
+		ptr := reflect.New(v.Type().Elem())
+		copies[v.Pointer()] = ptr
+		ptr.Elem().Set(deepCopy(v.Elem(), copies))
+		return ptr
+	case reflect.Struct:
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				out.Field(i).Set(deepCopy(v.Field(i), copies))
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Cap()))
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i), copies))
 		}
-		return isZeroValue(underlying)
 	case reflect.Array:
 		for i := 0; i < v.Len(); i++ {
-			if !isZeroValue(v.Index(i)) {
-				return false
-			}
+			out.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copies))
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			out.Set(deepCopy(v.Elem(), copies))
 		}
-		return true
-	case reflect.Bool:
-		return !v.Bool()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return v.Int() == 0
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		return v.Uint() == 0
-	case reflect.Float32, reflect.Float64:
-		return v.Float() == 0
-	case reflect.Complex64, reflect.Complex128:
-		return v.Complex() == 0
-	case reflect.String:
-		return v.String() == ""
 	default:
+		out.Set(v)
+	}
+	return out
+}
+
+// isStageFullyPopulated checks if all exported fields in the configuration are set
+// according to IsSet. This is used for short-circuit behavior within stages.
+func (l *InterpolatingChainLoader[T]) isStageFullyPopulated(c *T) bool {
+	if c == nil {
 		return false
 	}
+	return l.IsSet.FullyPopulated(reflect.ValueOf(c).Elem())
 }
 
 // updateContextForStage updates the interpolation context with values from fields
@@ -235,12 +387,9 @@ func (l *InterpolatingChainLoader[T]) updateContextForStage(c *T, stageFields []
 	configValue := reflect.ValueOf(c).Elem()
 
 	for _, fieldIndex := range stageFields {
-		// Get the field value
-		fieldValue := configValue.Field(fieldIndex)
-
-		// Update context with this field's value
-		// The engine checks if this field has availableAs and converts the value
-		if err := l.engine.UpdateContext(fieldIndex, fieldValue.Interface()); err != nil {
+		// Update context with this field's value, which may be an unexported embedded
+		// struct. The engine checks if this field has availableAs and converts the value
+		if err := l.engine.updateContextValue(fieldIndex, configValue.Field(fieldIndex)); err != nil {
 			return err
 		}
 	}
@@ -1,10 +1,15 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/gymshark/go-easy-config/loader"
 	"github.com/gymshark/go-easy-config/loader/generic"
 )
 
@@ -601,3 +606,290 @@ func TestInterpolatingChainLoader_MultipleStages_DifferentTypes(t *testing.T) {
 		t.Errorf("expected context DEBUG='true', got '%s'", context["DEBUG"])
 	}
 }
+
+// Test progress events for each stage
+func TestInterpolatingChainLoader_Progress(t *testing.T) {
+	type Config struct {
+		Env        string `env:"ENV" config:"availableAs=ENV"`
+		DBPassword string `secret:"aws=/myapp/${ENV}/db/password"`
+	}
+
+	var events []ProgressEvent
+	ldr := &InterpolatingChainLoader[Config]{
+		Loaders: []Loader[Config]{&mockLoader[Config]{loadFunc: func(c *Config) error {
+			c.Env = "prod"
+			return nil
+		}}},
+		Progress: func(e ProgressEvent) { events = append(events, e) },
+	}
+	var cfg Config
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("expected start and finish events for 2 stages, got %+v", events)
+	}
+	first, last := events[1], events[3]
+	if !first.Done || first.Stage != 1 || first.Stages != 2 || first.Fields[0] != "Env" || first.Resolved != 1 || first.Total != 2 {
+		t.Errorf("unexpected first stage event: %+v", first)
+	}
+	if events[2].Done || events[2].Stage != 2 || events[2].Fields[0] != "DBPassword" {
+		t.Errorf("unexpected second stage start event: %+v", events[2])
+	}
+	if !last.Done || last.Stage != 2 || last.Resolved != 1 || last.Err != nil {
+		t.Errorf("unexpected last stage event: %+v", last)
+	}
+}
+
+// Test that the fast path reports a single stage
+func TestInterpolatingChainLoader_Progress_FastPath(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int
+	}
+
+	var events []ProgressEvent
+	ldr := &InterpolatingChainLoader[Config]{
+		Loaders:  []Loader[Config]{&mockLoader[Config]{}},
+		Progress: func(e ProgressEvent) { events = append(events, e) },
+	}
+	if err := ldr.Load(&Config{}); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(events) != 2 || events[1].Stages != 1 || events[1].Total != 2 || len(events[1].Fields) != 2 {
+		t.Errorf("expected a single stage covering every field, got %+v", events)
+	}
+}
+
+// Test per-stage timeouts
+func TestInterpolatingChainLoader_StageTimeout(t *testing.T) {
+	type Config struct {
+		Env        string `env:"ENV" config:"availableAs=ENV"`
+		DBPassword string `secret:"aws=/myapp/${ENV}/db/password"`
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	var calls int
+	slow := &mockLoader[Config]{loadFunc: func(c *Config) error {
+		calls++
+		if calls == 1 {
+			c.Env = "prod"
+			return nil
+		}
+		<-release
+		c.DBPassword = "late"
+		return nil
+	}}
+
+	var events []ProgressEvent
+	ldr := &InterpolatingChainLoader[Config]{
+		Loaders:      []Loader[Config]{slow},
+		StageTimeout: 20 * time.Millisecond,
+		Progress:     func(e ProgressEvent) { events = append(events, e) },
+	}
+	var cfg Config
+	err := ldr.Load(&cfg)
+
+	var timeoutErr *StageTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected StageTimeoutError, got %v", err)
+	}
+	if timeoutErr.Stage != 2 || timeoutErr.Stages != 2 || timeoutErr.Fields[0] != "DBPassword" {
+		t.Errorf("unexpected timeout error: %+v", timeoutErr)
+	}
+	if cfg.Env != "prod" || cfg.DBPassword != "" {
+		t.Errorf("expected values from completed stages only, got %+v", cfg)
+	}
+	if last := events[len(events)-1]; !last.Done || !errors.As(last.Err, &timeoutErr) {
+		t.Errorf("expected final event to report the timeout, got %+v", last)
+	}
+}
+
+// Test that handler options reach the chain loader
+func TestWithProgressAndStageTimeout(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	var events int
+	handler := NewConfigHandler[Config](
+		WithStageTimeout[Config](time.Second),
+		WithProgress[Config](func(ProgressEvent) { events++ }),
+		WithLoaders[Config](&mockLoader[Config]{}),
+	)
+	if handler.chainLoader.StageTimeout != time.Second {
+		t.Errorf("expected stage timeout to be set, got %v", handler.chainLoader.StageTimeout)
+	}
+	if err := handler.Load(&Config{}); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if events != 2 {
+		t.Errorf("expected 2 progress events, got %d", events)
+	}
+}
+
+// Test that variables declared and referenced in nested and embedded structs are staged
+func TestInterpolatingChainLoader_NestedStructs(t *testing.T) {
+	type database struct {
+		Password string `secret:"aws=/myapp/${ENV}/db/password"`
+	}
+	type Config struct {
+		embeddedBase
+		Database database
+	}
+
+	var envAtDatabase []string
+	ldr := &mockLoader[Config]{
+		loadFunc: func(c *Config) error {
+			envAtDatabase = append(envAtDatabase, c.Env)
+			if c.Env == "" {
+				c.Env = "prod"
+			} else {
+				c.Database.Password = "secret-" + c.Env
+			}
+			return nil
+		},
+	}
+
+	chain := &InterpolatingChainLoader[Config]{Loaders: []Loader[Config]{ldr}}
+	cfg := &Config{}
+	if err := chain.Load(cfg); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if ldr.callCount != 2 {
+		t.Errorf("expected 2 stages, got %d loads", ldr.callCount)
+	}
+	if cfg.Database.Password != "secret-prod" {
+		t.Errorf("expected Database.Password loaded after ENV, got %q", cfg.Database.Password)
+	}
+	if got := chain.GetInterpolationContext()["ENV"]; got != "prod" {
+		t.Errorf("expected ENV=prod in context, got %q (loads saw %v)", got, envAtDatabase)
+	}
+}
+
+// contextLoader implements ContextLoader, blocking until its context is done.
+type contextLoader[T any] struct {
+	plainCalls int
+	started    chan struct{} // Optionally receives a value when LoadContext starts
+	err        error         // Error LoadContext returned
+}
+
+func (l *contextLoader[T]) Load(c *T) error {
+	l.plainCalls++
+	return nil
+}
+
+func (l *contextLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if l.started != nil {
+		l.started <- struct{}{}
+	}
+	<-ctx.Done()
+	l.err = ctx.Err()
+	return l.err
+}
+
+// Test that ContextLoaders are stopped when a stage times out
+func TestInterpolatingChainLoader_StageTimeout_ContextLoader(t *testing.T) {
+	type Config struct {
+		Name string
+	}
+
+	slow := &contextLoader[Config]{}
+	ldr := &InterpolatingChainLoader[Config]{
+		Loaders:      []Loader[Config]{slow},
+		StageTimeout: 20 * time.Millisecond,
+	}
+	err := ldr.LoadContext(context.Background(), &Config{})
+
+	var timeoutErr *StageTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected StageTimeoutError, got %v", err)
+	}
+	if slow.plainCalls != 0 {
+		t.Errorf("expected LoadContext to be preferred over Load, got %d Load calls", slow.plainCalls)
+	}
+}
+
+func TestHandler_LoadContext(t *testing.T) {
+	type Config struct {
+		Name string
+	}
+
+	slow := &contextLoader[Config]{}
+	next := &mockLoader[Config]{}
+	handler := NewConfigHandler[Config](WithLoaders[Config](slow, next))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := handler.LoadContext(ctx, &Config{})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(slow.err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to stop the load, got %v", err)
+	}
+	if next.callCount != 0 {
+		t.Errorf("expected no loader to run after the deadline, got %d calls", next.callCount)
+	}
+
+	// Shutdown cancels loads in progress
+	slow.started = make(chan struct{})
+	done := make(chan error, 1)
+	go func() { done <- handler.LoadContext(context.Background(), &Config{}) }()
+	<-slow.started
+	if err := handler.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Shutdown to cancel the load, got %v", err)
+	}
+}
+
+// variablesLoader implements ContextLoader, recording the ${VAR} values it is passed.
+type variablesLoader[T any] struct {
+	values map[string]string
+	err    error
+}
+
+func (l *variablesLoader[T]) Load(c *T) error {
+	return l.LoadContext(context.Background(), c)
+}
+
+func (l *variablesLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	l.values, l.err = loader.Variables(ctx, reflect.ValueOf(c).Elem())
+	return nil
+}
+
+// Test that ContextLoaders are passed the guarded values of nested and scoped variables
+func TestInterpolatingChainLoader_VariableSource(t *testing.T) {
+	type database struct {
+		Host string `config:"availableAs=HOST"`
+	}
+	type Config struct {
+		Env     string `config:"availableAs=ENV"`
+		Primary database
+	}
+
+	set := &mockLoader[Config]{loadFunc: func(c *Config) error {
+		c.Env, c.Primary.Host = "prod", "primary.db"
+		return nil
+	}}
+	vars := &variablesLoader[Config]{}
+	chain := &InterpolatingChainLoader[Config]{Loaders: []Loader[Config]{set, vars}}
+	if err := chain.Load(&Config{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if vars.err != nil || vars.values["ENV"] != "prod" || vars.values["Primary.HOST"] != "primary.db" {
+		t.Errorf("expected ENV and Primary.HOST, got %v, %v", vars.values, vars.err)
+	}
+
+	set.loadFunc = func(c *Config) error {
+		c.Env = "prod/../other-team"
+		return nil
+	}
+	chain = &InterpolatingChainLoader[Config]{Loaders: []Loader[Config]{set, vars}, Guard: &InterpolationGuard{}}
+	_ = chain.Load(&Config{}) // The stage also fails once its values enter the context
+	var unsafeErr *UnsafeInterpolationValueError
+	if !errors.As(vars.err, &unsafeErr) || unsafeErr.VariableName != "ENV" {
+		t.Errorf("expected the guard to reject ENV, got %v, %v", vars.values, vars.err)
+	}
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // InterpolationEngine manages variable interpolation for configuration structs.
@@ -30,9 +34,15 @@ import (
 //	    }
 //	}
 type InterpolationEngine[T any] struct {
-	// availableAsMap maps variable names to field indices
+	// availableAsMap maps variable names to field indices. For variables declared on a
+	// nested field or map element, the index is that of the top-level field containing it.
 	availableAsMap map[string]int
 
+	// availableAsPaths maps variables declared below the top level to the path from
+	// their top-level field, and availableAsNames to their full field name for errors
+	availableAsPaths map[string][]pathStep
+	availableAsNames map[string]string
+
 	// dependencies maps field index to list of variable names it depends on
 	dependencies map[int][]string
 
@@ -54,12 +64,17 @@ type InterpolationEngine[T any] struct {
 
 	// hasInterpolation tracks whether any interpolation is needed
 	hasInterpolation bool
+
+	// guard checks values before they enter the interpolation context; nil checks nothing
+	guard *InterpolationGuard
 }
 
 // NewInterpolationEngine creates a new InterpolationEngine for the given configuration type.
 func NewInterpolationEngine[T any]() *InterpolationEngine[T] {
 	return &InterpolationEngine[T]{
 		availableAsMap:       make(map[string]int),
+		availableAsPaths:     make(map[string][]pathStep),
+		availableAsNames:     make(map[string]string),
 		dependencies:         make(map[int][]string),
 		dependencyStages:     make([][]int, 0),
 		interpolationContext: make(map[string]string),
@@ -76,8 +91,9 @@ func NewInterpolationEngine[T any]() *InterpolationEngine[T] {
 // detects cycles, and performs topological sort to create dependency stages.
 //
 // Returns an error if:
-//   - Duplicate availableAs declarations are found
+//   - Duplicate availableAs declarations are found (nested sections may share a name)
 //   - Undefined variables are referenced
+//   - A name shared by nested sections is referenced without its scope
 //   - Circular dependencies are detected
 //   - Non-exported fields have availableAs declarations
 //   - Variable names are invalid
@@ -87,6 +103,7 @@ func (e *InterpolationEngine[T]) Analyze(cfg *T) error {
 
 	// First pass: collect availableAs declarations and detect duplicates
 	availableAsFields := make(map[string][]string) // varName -> []fieldName
+	nestedFields := make(map[string][]string)      // varName -> []fieldName for nested declarations
 	for i := 0; i < configType.NumField(); i++ {
 		field := configType.Field(i)
 		e.fieldNames[i] = field.Name
@@ -96,7 +113,7 @@ func (e *InterpolationEngine[T]) Analyze(cfg *T) error {
 
 		// Check for config tag with availableAs
 		configTag := field.Tag.Get("config")
-		if configTag != "" {
+		if configTag != "" && !hasOnlyConfigTagOptions(configTag) {
 			varName, err := ParseConfigTag(configTag)
 			if err != nil {
 				// Update TagParseError with actual field name
@@ -121,17 +138,49 @@ func (e *InterpolationEngine[T]) Analyze(cfg *T) error {
 			e.availableAsMap[varName] = i
 			e.hasInterpolation = true
 		}
+
+		// Nested struct fields, embedded structs and map elements may declare variables too.
+		// The exported fields of an unexported embedded struct are promoted, so they count.
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		declarations, err := nestedAvailableAs(field.Type, field.Name, nil, map[reflect.Type]bool{})
+		if err != nil {
+			return err
+		}
+		for _, decl := range declarations {
+			// Every nested variable can be referenced by its scoped name, e.g. ${Primary.HOST},
+			// and by its plain name unless another section declares it too
+			for _, varName := range []string{scopedVariableName(decl.name, decl.varName), decl.varName} {
+				e.availableAsMap[varName] = i
+				e.availableAsPaths[varName] = decl.path
+				e.availableAsNames[varName] = decl.name
+			}
+			nestedFields[decl.varName] = append(nestedFields[decl.varName], decl.name)
+			e.hasInterpolation = true
+		}
 	}
 
-	// Check for duplicate availableAs declarations
+	// Check for duplicate availableAs declarations. Top-level names are global, so they may
+	// not be declared again at any level; nested sections may share a name, which is then
+	// ambiguous and must be referenced by its scoped name.
 	for varName, fields := range availableAsFields {
-		if len(fields) > 1 {
+		if len(fields) > 1 || len(nestedFields[varName]) > 0 {
 			return &DuplicateAvailableAsError{
 				VariableName: varName,
-				Fields:       fields,
+				Fields:       append(fields, nestedFields[varName]...),
 			}
 		}
 	}
+	ambiguous := make(map[string][]string)
+	for varName, fields := range nestedFields {
+		if len(fields) > 1 {
+			ambiguous[varName] = fields
+			delete(e.availableAsMap, varName)
+			delete(e.availableAsPaths, varName)
+			delete(e.availableAsNames, varName)
+		}
+	}
 
 	// Second pass: find variable references in all tags
 	for i := 0; i < configType.NumField(); i++ {
@@ -153,16 +202,27 @@ func (e *InterpolationEngine[T]) Analyze(cfg *T) error {
 			}
 		}
 
+		// Tags of nested struct fields and of slice, array and map elements count as
+		// references of the containing top-level field, so sections load after the
+		// variables they use
+		for _, ref := range nestedVariableReferences(field.Type, field.Name, map[reflect.Type]bool{}) {
+			if err := e.checkReference(ref.fieldPath, ref.varName, ambiguous); err != nil {
+				return err
+			}
+			if !seenVars[ref.varName] {
+				allVars = append(allVars, ref.varName)
+				seenVars[ref.varName] = true
+				e.hasInterpolation = true
+			}
+		}
+
 		if len(allVars) > 0 {
 			e.dependencies[i] = allVars
 
 			// Validate that all referenced variables are defined
 			for _, varName := range allVars {
-				if _, exists := e.availableAsMap[varName]; !exists {
-					return &UndefinedVariableError{
-						FieldName:    field.Name,
-						VariableName: varName,
-					}
+				if err := e.checkReference(field.Name, varName, ambiguous); err != nil {
+					return err
 				}
 			}
 		}
@@ -194,6 +254,88 @@ func (e *InterpolationEngine[T]) Analyze(cfg *T) error {
 	return nil
 }
 
+// checkReference returns an error if the variable referenced by fieldName is undefined, or
+// declared by several nested sections as listed in ambiguous.
+func (e *InterpolationEngine[T]) checkReference(fieldName, varName string, ambiguous map[string][]string) error {
+	if _, exists := e.availableAsMap[varName]; exists {
+		return nil
+	}
+	if declarations, ok := ambiguous[varName]; ok {
+		scoped := make([]string, len(declarations))
+		for i, name := range declarations {
+			scoped[i] = scopedVariableName(name, varName)
+		}
+		return &AmbiguousVariableError{FieldName: fieldName, VariableName: varName, Candidates: scoped}
+	}
+	return &UndefinedVariableError{FieldName: fieldName, VariableName: varName}
+}
+
+// scopedVariableName returns the scoped name of varName declared by the nested field name:
+// the path of the section containing the field, with map keys as path elements, followed by
+// varName. For example "Primary.Host" declaring HOST gives "Primary.HOST", and
+// "Regions[eu].Name" declaring REGION gives "Regions.eu.REGION".
+func scopedVariableName(name, varName string) string {
+	name = strings.NewReplacer("[", ".", "]", "").Replace(name)
+	section := name[:strings.LastIndex(name, ".")]
+	return section + "." + varName
+}
+
+// nestedReference is a ${VAR} reference in the tag of a field below the top level.
+type nestedReference struct {
+	fieldPath string // e.g. "Database.Password" or "Endpoints[].URL"
+	varName   string
+}
+
+// nestedVariableReferences returns the variable references in the tags of the fields of t,
+// if t is a struct (or pointer to one), and of its element struct, if t is a slice, array
+// or map of structs, at any depth. Time values and other structs without tagged fields
+// contribute nothing, so no struct is treated specially.
+func nestedVariableReferences(t reflect.Type, path string, visiting map[reflect.Type]bool) []nestedReference {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		path += "."
+	case reflect.Slice, reflect.Array, reflect.Map:
+		t = t.Elem()
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		path += "[]."
+	default:
+		return nil
+	}
+	if visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var refs []nestedReference
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		fieldPath := path + field.Name
+		for _, varName := range FindVariableReferences(string(field.Tag)) {
+			refs = append(refs, nestedReference{fieldPath: fieldPath, varName: varName})
+		}
+		refs = append(refs, nestedVariableReferences(field.Type, fieldPath, visiting)...)
+	}
+	return refs
+}
+
+// SetGuard sets the guard that checks and normalizes values before they are added to the
+// interpolation context. A nil guard, the default, substitutes values unchecked.
+func (e *InterpolationEngine[T]) SetGuard(guard *InterpolationGuard) {
+	e.guard = guard
+}
+
 // HasInterpolation returns true if any fields use variable interpolation.
 // This can be used to implement a fast path that bypasses interpolation entirely.
 func (e *InterpolationEngine[T]) HasInterpolation() bool {
@@ -248,8 +390,98 @@ func (e *InterpolationEngine[T]) InterpolateTags(fieldIndices []int) error {
 	return nil
 }
 
+// ResolvedField is a field's struct tag with every ${VAR} reference replaced.
+type ResolvedField struct {
+	FieldName string            // Name of the struct field
+	Index     int               // Index of the field within the struct
+	Tag       reflect.StructTag // Tag after interpolation; use Tag.Get to read individual keys
+	Variables []string          // Variables referenced by the original tag, in order of first use
+}
+
+// ResolveAll applies context to the tags of every field without loading anything.
+// It is intended for pre-deployment checks, e.g. verifying that all secret paths resolve
+// for a known environment. Analyze must be called first.
+//
+// The returned slice holds, in declaration order, every field whose tag resolves. A field
+// referencing a variable missing from context is left out, and each missing variable is
+// reported as an *UndefinedVariableError, joined with errors.Join; the fields that resolve
+// are still returned alongside the error.
+func (e *InterpolationEngine[T]) ResolveAll(context map[string]string) ([]ResolvedField, error) {
+	if !e.configValue.IsValid() {
+		return nil, fmt.Errorf("ResolveAll called before Analyze")
+	}
+
+	configType := e.configValue.Type()
+	resolved := make([]ResolvedField, 0, configType.NumField())
+	var errs []error
+
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		originalTag := e.originalTags[i]
+
+		var missing bool
+		for _, varName := range e.dependencies[i] {
+			if _, ok := context[varName]; !ok {
+				errs = append(errs, &UndefinedVariableError{FieldName: field.Name, VariableName: varName})
+				missing = true
+			}
+		}
+		if missing {
+			continue
+		}
+
+		tag, err := InterpolateString(string(originalTag), context)
+		if err != nil {
+			errs = append(errs, &InterpolationError{FieldName: field.Name, Message: err.Error()})
+			continue
+		}
+
+		resolved = append(resolved, ResolvedField{
+			FieldName: field.Name,
+			Index:     i,
+			Tag:       reflect.StructTag(tag),
+			Variables: e.dependencies[i],
+		})
+	}
+
+	return resolved, errors.Join(errs...)
+}
+
+// availableAsContext returns an interpolation context built from the availableAs fields
+// already set on cfg. Zero-valued fields are left out. Analyze must be called first.
+func (e *InterpolationEngine[T]) availableAsContext(cfg *T) (map[string]string, error) {
+	cfgValue := reflect.ValueOf(cfg).Elem()
+	context := make(map[string]string)
+	for varName, fieldIndex := range e.availableAsMap {
+		value, ok := valueAtPath(cfgValue.Field(fieldIndex), e.availableAsPaths[varName])
+		if !ok || value.IsZero() {
+			continue
+		}
+		str, err := e.convertToString(value.Interface())
+		if err != nil {
+			return nil, &InterpolationError{FieldName: e.providerName(varName), Message: err.Error()}
+		}
+		if str, err = e.guard.sanitize(varName, str); err != nil {
+			return nil, &UnsafeInterpolationValueError{FieldName: e.providerName(varName), VariableName: varName, Reason: err.Error()}
+		}
+		context[varName] = str
+	}
+	return context, nil
+}
+
+// providerName returns the name of the field declaring varName, including its path for
+// nested declarations (e.g. "Regions[primary].Name").
+func (e *InterpolationEngine[T]) providerName(varName string) string {
+	if name, ok := e.availableAsNames[varName]; ok {
+		return name
+	}
+	return e.fieldNames[e.availableAsMap[varName]]
+}
+
 // UpdateContext adds a field's value to the interpolation context.
 // The field value is converted to a string representation based on its type.
+// For a field containing nested availableAs declarations, value is the whole top-level
+// field and each declared variable present in it is added; missing map entries are skipped.
 //
 // Supported types:
 //   - string: used directly
@@ -260,32 +492,182 @@ func (e *InterpolationEngine[T]) InterpolateTags(fieldIndices []int) error {
 //
 // Returns an error if the field type is not supported for interpolation.
 func (e *InterpolationEngine[T]) UpdateContext(fieldIndex int, value interface{}) error {
-	// Find the variable name for this field
-	var varName string
-	for name, idx := range e.availableAsMap {
-		if idx == fieldIndex {
-			varName = name
-			break
+	return e.updateContextValue(fieldIndex, reflect.ValueOf(value))
+}
+
+// updateContextValue is UpdateContext for the reflected value of the top-level field, which
+// may be an unexported embedded struct whose promoted fields declare variables.
+func (e *InterpolationEngine[T]) updateContextValue(fieldIndex int, value reflect.Value) error {
+	for varName, idx := range e.availableAsMap {
+		if idx != fieldIndex {
+			continue
+		}
+
+		var provided interface{}
+		if path, nested := e.availableAsPaths[varName]; nested {
+			v, ok := valueAtPath(value, path)
+			if !ok {
+				continue
+			}
+			provided = v.Interface()
+		} else if value.IsValid() {
+			provided = value.Interface()
 		}
+
+		// Convert value to string
+		strValue, err := e.convertToString(provided)
+		if err != nil {
+			return &InterpolationError{
+				FieldName: e.providerName(varName),
+				Message:   fmt.Sprintf("failed to convert value to string: %v", err),
+			}
+		}
+		if strValue, err = e.guard.sanitize(varName, strValue); err != nil {
+			return &UnsafeInterpolationValueError{FieldName: e.providerName(varName), VariableName: varName, Reason: err.Error()}
+		}
+
+		e.interpolationContext[varName] = strValue
 	}
+	return nil
+}
 
-	// If this field doesn't have availableAs, nothing to update
-	if varName == "" {
-		return nil
+// pathStep is one step from a top-level field towards a nested availableAs field:
+// a struct field index, or a map key if isKey is set.
+type pathStep struct {
+	field int
+	key   string
+	isKey bool
+}
+
+// nestedDeclaration is an availableAs declaration below the top level of the config.
+type nestedDeclaration struct {
+	varName string
+	name    string // e.g. "Database.Region" or "Regions[primary].Name"
+	path    []pathStep
+}
+
+// nestedAvailableAs finds availableAs declarations in the fields of t, if t is a struct,
+// and in the element fields of t, if t is a map of structs. Map element declarations name
+// the entry they read with a key option, e.g. `config:"availableAs=PRIMARY_REGION,key=primary"`.
+// Tags without availableAs are ignored, so nested types may use config tags for other purposes.
+func nestedAvailableAs(t reflect.Type, name string, path []pathStep, visiting map[reflect.Type]bool) ([]nestedDeclaration, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
 
-	// Convert value to string
-	strValue, err := e.convertToString(value)
+	switch t.Kind() {
+	case reflect.Struct:
+		// Guard against self-referencing types
+		if visiting[t] {
+			return nil, nil
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		var declarations []nestedDeclaration
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldName := name + "." + field.Name
+			fieldPath := append(append([]pathStep(nil), path...), pathStep{field: i})
+
+			decl, found, err := parseNestedDeclaration(field, fieldName, fieldPath)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				declarations = append(declarations, decl)
+			}
+			if field.IsExported() || field.Anonymous {
+				nested, err := nestedAvailableAs(field.Type, fieldName, fieldPath, visiting)
+				if err != nil {
+					return nil, err
+				}
+				declarations = append(declarations, nested...)
+			}
+		}
+		return declarations, nil
+
+	case reflect.Map:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if t.Key().Kind() != reflect.String || elem.Kind() != reflect.Struct {
+			return nil, nil
+		}
+
+		var declarations []nestedDeclaration
+		for i := 0; i < elem.NumField(); i++ {
+			field := elem.Field(i)
+			if !strings.Contains(field.Tag.Get("config"), "availableAs=") {
+				continue
+			}
+			key, _ := utils.TagOptionValue(field.Tag.Get("config"), "key")
+			if key == "" {
+				return nil, &TagParseError{
+					FieldName: name + "[]." + field.Name,
+					TagKey:    "config",
+					Issue:     "availableAs in a map element requires a key option naming the entry (e.g. key=primary)",
+				}
+			}
+			fieldName := fmt.Sprintf("%s[%s].%s", name, key, field.Name)
+			fieldPath := append(append([]pathStep(nil), path...), pathStep{key: key, isKey: true}, pathStep{field: i})
+
+			decl, found, err := parseNestedDeclaration(field, fieldName, fieldPath)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				declarations = append(declarations, decl)
+			}
+		}
+		return declarations, nil
+	}
+	return nil, nil
+}
+
+// parseNestedDeclaration parses an availableAs declaration on a nested field, if it has one.
+func parseNestedDeclaration(field reflect.StructField, name string, path []pathStep) (nestedDeclaration, bool, error) {
+	configTag := field.Tag.Get("config")
+	if !strings.Contains(configTag, "availableAs=") {
+		return nestedDeclaration{}, false, nil
+	}
+	varName, err := ParseConfigTag(configTag)
 	if err != nil {
-		fieldName := e.fieldNames[fieldIndex]
-		return &InterpolationError{
-			FieldName: fieldName,
-			Message:   fmt.Sprintf("failed to convert value to string: %v", err),
+		if tagErr, ok := err.(*TagParseError); ok {
+			tagErr.FieldName = name
+		}
+		return nestedDeclaration{}, false, err
+	}
+	if !field.IsExported() {
+		return nestedDeclaration{}, false, &InterpolationError{
+			FieldName: name,
+			Message:   "field with availableAs must be exported (starts with uppercase)",
 		}
 	}
+	return nestedDeclaration{varName: varName, name: name, path: path}, true, nil
+}
 
-	e.interpolationContext[varName] = strValue
-	return nil
+// valueAtPath follows path from v, dereferencing pointers. It returns false if a pointer
+// on the way is nil or a map has no entry for the key.
+func valueAtPath(v reflect.Value, path []pathStep) (reflect.Value, bool) {
+	for _, step := range path {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		if step.isKey {
+			v = v.MapIndex(reflect.ValueOf(step.key).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return reflect.Value{}, false
+			}
+			continue
+		}
+		v = v.Field(step.field)
+	}
+	return v, v.IsValid()
 }
 
 // convertToString converts a value to its string representation for interpolation.
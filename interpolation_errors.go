@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // InterpolationError represents errors during variable interpolation.
@@ -129,6 +130,42 @@ func (e *UndefinedVariableError) Error() string {
 	return fmt.Sprintf("undefined variable '${%s}' referenced in field '%s'", e.VariableName, e.FieldName)
 }
 
+// AmbiguousVariableError represents an unqualified reference to a variable declared by
+// more than one nested section. Such variables must be referenced by their scoped names,
+// which prefix the variable with the path of the declaring section.
+//
+// Fields:
+//   - FieldName: Name of the field making the reference
+//   - VariableName: Name of the ambiguous variable
+//   - Candidates: Scoped names of each declaration, e.g. "Primary.HOST"
+//
+// Example scenario that causes this error:
+//
+//	type Database struct {
+//	    Host string `env:"HOST" config:"availableAs=HOST"`
+//	}
+//	type Config struct {
+//	    Primary  Database `envPrefix:"PRIMARY_"`
+//	    Replica  Database `envPrefix:"REPLICA_"`
+//	    Password string   `secret:"aws=/db/${HOST}/password"`
+//	}
+//	// Error: ambiguous variable '${HOST}' referenced in field 'Password', use one of ${Primary.HOST}, ${Replica.HOST}
+type AmbiguousVariableError struct {
+	FieldName    string
+	VariableName string
+	Candidates   []string
+}
+
+// Error implements the error interface for AmbiguousVariableError.
+func (e *AmbiguousVariableError) Error() string {
+	scoped := make([]string, len(e.Candidates))
+	for i, name := range e.Candidates {
+		scoped[i] = "${" + name + "}"
+	}
+	return fmt.Sprintf("ambiguous variable '${%s}' referenced in field '%s', use one of %s",
+		e.VariableName, e.FieldName, strings.Join(scoped, ", "))
+}
+
 // DuplicateAvailableAsError represents duplicate variable declarations.
 // It includes the variable name and all fields that declared it,
 // helping identify which declarations need to be renamed.
@@ -175,3 +212,74 @@ type DuplicateAvailableAsError struct {
 func (e *DuplicateAvailableAsError) Error() string {
 	return fmt.Sprintf("duplicate availableAs='%s' declared in fields: %s", e.VariableName, strings.Join(e.Fields, ", "))
 }
+
+// StageTimeoutError reports a loading stage that did not finish within
+// InterpolatingChainLoader.StageTimeout, typically because a remote source is slow or
+// unreachable.
+//
+// Fields:
+//   - Stage: Number of the stage that timed out, starting at 1
+//   - Stages: Total number of stages
+//   - Fields: Fields loaded in the stage
+//   - Timeout: The configured per-stage timeout
+//
+// Operations that return StageTimeoutError:
+//   - InterpolatingChainLoader.Load() - When a stage exceeds StageTimeout
+//
+// Example - Inspecting stage timeouts:
+//
+//	handler := config.NewConfigHandler[AppConfig](config.WithStageTimeout[AppConfig](30 * time.Second))
+//	var cfg AppConfig
+//	if err := handler.Load(&cfg); err != nil {
+//	    var timeoutErr *StageTimeoutError
+//	    if errors.As(err, &timeoutErr) {
+//	        fmt.Printf("Stuck loading %v\n", timeoutErr.Fields)
+//	    }
+//	}
+type StageTimeoutError struct {
+	Stage   int
+	Stages  int
+	Fields  []string
+	Timeout time.Duration
+}
+
+// Error implements the error interface for StageTimeoutError.
+func (e *StageTimeoutError) Error() string {
+	return fmt.Sprintf("stage %d of %d (fields %s) did not finish loading within %s",
+		e.Stage, e.Stages, strings.Join(e.Fields, ", "), e.Timeout)
+}
+
+// UnsafeInterpolationValueError reports a value an InterpolationGuard refused to substitute
+// for a ${VAR} reference, for example one containing "/" or "..".
+//
+// Fields:
+//   - FieldName: Field declaring the variable with availableAs
+//   - VariableName: The variable whose value was rejected
+//   - Reason: Why the value was rejected
+//
+// The value itself is left out, since it may come from an attacker or be sensitive.
+//
+// Operations that return UnsafeInterpolationValueError:
+//   - InterpolatingChainLoader.Load() - When a loaded availableAs value is rejected
+//   - Handler.Prefetch(), Handler.VerifySources() and LayeredLoader.Load() - When an
+//     availableAs value already set on the configuration is rejected
+//
+// Example - Inspecting rejected values:
+//
+//	if err := handler.Load(&cfg); err != nil {
+//	    var unsafeErr *UnsafeInterpolationValueError
+//	    if errors.As(err, &unsafeErr) {
+//	        fmt.Printf("Refusing ${%s} from field '%s': %s\n",
+//	            unsafeErr.VariableName, unsafeErr.FieldName, unsafeErr.Reason)
+//	    }
+//	}
+type UnsafeInterpolationValueError struct {
+	FieldName    string
+	VariableName string
+	Reason       string
+}
+
+// Error implements the error interface for UnsafeInterpolationValueError.
+func (e *UnsafeInterpolationValueError) Error() string {
+	return fmt.Sprintf("unsafe value for variable '${%s}' from field '%s': %s", e.VariableName, e.FieldName, e.Reason)
+}
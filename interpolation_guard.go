@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultInterpolationCharset is the punctuation an InterpolationGuard allows besides ASCII
+// letters and digits when its Charset is empty.
+const DefaultInterpolationCharset = "-_.+"
+
+// InterpolationGuard restricts the values availableAs fields may substitute for ${VAR}
+// references, so a compromised low-trust source, such as an environment variable, cannot
+// redirect a secret or parameter lookup. A value like "prod/../../admin" or
+// "prod/other-team" would otherwise turn "/myapp/${ENV}/db/password" into a path the
+// attacker chose.
+//
+// Values are normalized to Unicode NFKC first, so look-alike characters such as a
+// full-width solidus are checked, and substituted, as their ASCII forms. They must then
+// consist of ASCII letters, digits and the Charset punctuation, must not contain "..", and
+// must be listed in Allowlist when it has an entry for the variable. Empty values pass.
+//
+// The guard is opt-in: without one, values are substituted unchecked, so existing values
+// containing "/" or ":", such as ARNs, paths and URLs, keep working. Set one with
+// WithInterpolationGuard, or the Guard field of InterpolatingChainLoader, LayeredLoader or
+// SetGuard of InterpolationEngine; a pointer to the zero value applies the checks above.
+// InterpolatingChainLoader passes the checked values on through a loader.VariableSource to
+// loaders expanding ${VAR} in their own settings, such as DynamoDBLoader and GraphQLLoader.
+//
+// Example:
+//
+//	guard := &config.InterpolationGuard{
+//	    Allowlist: map[string][]string{"ENV": {"dev", "staging", "prod"}},
+//	}
+//	handler := config.NewConfigHandler[AppConfig](config.WithInterpolationGuard[AppConfig](guard))
+type InterpolationGuard struct {
+	Charset   string              // Punctuation allowed besides ASCII letters and digits (defaults to DefaultInterpolationCharset)
+	Allowlist map[string][]string // Optional allowed values per variable name (scoped or plain), compared after normalization
+	Disabled  bool                // Substitute values unchecked, as with no guard
+}
+
+// WithInterpolationGuard sets the guard checking values substituted for ${VAR} references
+// while loading, prefetching, verifying sources and expanding layers.
+func WithInterpolationGuard[C any](guard *InterpolationGuard) Option[C] {
+	return func(h *Handler[C]) {
+		h.interpolationGuard = guard
+	}
+}
+
+// sanitize returns the normalized value of variable, or an error describing why the guard
+// rejects it. A nil guard returns value unchecked.
+func (g *InterpolationGuard) sanitize(variable, value string) (string, error) {
+	if g == nil || g.Disabled || value == "" {
+		return value, nil
+	}
+
+	normalized := norm.NFKC.String(value)
+	if strings.Contains(normalized, "..") {
+		return "", errors.New(`contains ".."`)
+	}
+	charset := g.Charset
+	if charset == "" {
+		charset = DefaultInterpolationCharset
+	}
+	for _, r := range normalized {
+		isAlnum := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+		if !isAlnum && (r > 127 || !strings.ContainsRune(charset, r)) {
+			return "", fmt.Errorf("contains %q, allowed are letters, digits and %q", r, charset)
+		}
+	}
+	allowed, ok := g.Allowlist[variable]
+	if !ok {
+		// Scoped names such as "Primary.HOST" fall back to the entry for the variable name
+		allowed, ok = g.Allowlist[variable[strings.LastIndex(variable, ".")+1:]]
+	}
+	if ok {
+		for _, a := range allowed {
+			if normalized == a {
+				return normalized, nil
+			}
+		}
+		return "", fmt.Errorf("is not one of the allowed values %v", allowed)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestInterpolationGuard_Sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		guard   *InterpolationGuard
+		value   string
+		want    string
+		wantErr string
+	}{
+		{"no guard checks nothing", nil, "arn:aws:iam::1/x/../y", "arn:aws:iam::1/x/../y", ""},
+		{"default allows a plain value", &InterpolationGuard{}, "eu-west-1", "eu-west-1", ""},
+		{"empty passes", &InterpolationGuard{}, "", "", ""},
+		{"slash", &InterpolationGuard{}, "prod/other-team", "", `contains '/'`},
+		{"traversal", &InterpolationGuard{}, "prod..admin", "", `contains ".."`},
+		{"nested reference", &InterpolationGuard{}, "${SECRET}", "", `contains '$'`},
+		{"whitespace", &InterpolationGuard{}, "prod\n", "", `contains '\n'`},
+		{"full-width letters are normalized", &InterpolationGuard{}, "ｐｒｏｄ", "prod", ""},
+		{"full-width solidus is caught", &InterpolationGuard{}, "prod／admin", "", `contains '/'`},
+		{"custom charset", &InterpolationGuard{Charset: "-/"}, "team/prod", "team/prod", ""},
+		{"custom charset still rejects traversal", &InterpolationGuard{Charset: "-/."}, "prod/../admin", "", `contains ".."`},
+		{"allowlisted", &InterpolationGuard{Allowlist: map[string][]string{"ENV": {"dev", "prod"}}}, "prod", "prod", ""},
+		{"not allowlisted", &InterpolationGuard{Allowlist: map[string][]string{"ENV": {"dev", "prod"}}}, "qa", "", "not one of the allowed values"},
+		{"disabled", &InterpolationGuard{Disabled: true}, "../x y", "../x y", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.guard.sanitize("ENV", tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("sanitize(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("sanitize(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolationGuard_ScopedAllowlist(t *testing.T) {
+	guard := &InterpolationGuard{Allowlist: map[string][]string{"HOST": {"primary.db"}, "Replica.HOST": {"replica.db"}}}
+	if _, err := guard.sanitize("Primary.HOST", "other.db"); err == nil {
+		t.Error("expected the HOST allowlist to apply to Primary.HOST")
+	}
+	if _, err := guard.sanitize("Replica.HOST", "replica.db"); err != nil {
+		t.Errorf("sanitize(Replica.HOST) error = %v", err)
+	}
+}
+
+type guardTestConfig struct {
+	Env        string `env:"GUARD_ENV" config:"availableAs=ENV"`
+	DBPassword string `secret:"aws=/myapp/${ENV}/db/password" config:"sensitive"`
+}
+
+func TestInterpolationGuard_Load(t *testing.T) {
+	t.Setenv("GUARD_ENV", "arn:aws:iam::123456789012:role/app")
+	handler := NewConfigHandler[guardTestConfig](WithLoaders[guardTestConfig](&generic.EnvironmentLoader[guardTestConfig]{}))
+
+	var cfg guardTestConfig
+	if err := handler.Load(&cfg); err != nil || cfg.Env != "arn:aws:iam::123456789012:role/app" {
+		t.Fatalf("Load() without a guard = %q, %v, want the value unchecked", cfg.Env, err)
+	}
+
+	t.Setenv("GUARD_ENV", "prod/../../other-team/prod")
+	handler = NewConfigHandler[guardTestConfig](
+		WithLoaders[guardTestConfig](&generic.EnvironmentLoader[guardTestConfig]{}),
+		WithInterpolationGuard[guardTestConfig](&InterpolationGuard{}),
+	)
+	err := handler.Load(&cfg)
+	var unsafeErr *UnsafeInterpolationValueError
+	if !errors.As(err, &unsafeErr) {
+		t.Fatalf("Load() error = %v, want an UnsafeInterpolationValueError", err)
+	}
+	if unsafeErr.FieldName != "Env" || unsafeErr.VariableName != "ENV" {
+		t.Errorf("error = %+v", unsafeErr)
+	}
+	if strings.Contains(err.Error(), "other-team") {
+		t.Errorf("error includes the rejected value: %v", err)
+	}
+
+	t.Setenv("GUARD_ENV", "staging")
+	handler = NewConfigHandler[guardTestConfig](
+		WithLoaders[guardTestConfig](&generic.EnvironmentLoader[guardTestConfig]{}),
+		WithInterpolationGuard[guardTestConfig](&InterpolationGuard{Allowlist: map[string][]string{"ENV": {"dev", "prod"}}}),
+	)
+	if err := handler.Load(&cfg); !errors.As(err, &unsafeErr) {
+		t.Errorf("Load() with allowlist error = %v, want an UnsafeInterpolationValueError", err)
+	}
+}
+
+func TestInterpolationGuard_Layers(t *testing.T) {
+	dir := t.TempDir()
+	writeLayer(t, dir, "prod.json", `{"host":"prod"}`)
+	ldr := &LayeredLoader[layeringTestConfig]{
+		Loader: jsonLayer,
+		Layers: []Layer[layeringTestConfig]{{Name: "environment", Source: filepath.Join(dir, "${ENV}.json")}},
+		Guard:  &InterpolationGuard{},
+	}
+
+	cfg := layeringTestConfig{Env: "../prod"}
+	var unsafeErr *UnsafeInterpolationValueError
+	if err := ldr.Load(&cfg); !errors.As(err, &unsafeErr) {
+		t.Errorf("Load() error = %v, want an UnsafeInterpolationValueError", err)
+	}
+
+	guard := &InterpolationGuard{Allowlist: map[string][]string{"ENV": {"prod"}}}
+	handler := NewConfigHandler[layeringTestConfig](
+		WithLayers[layeringTestConfig](jsonLayer, ldr.Layers...),
+		WithInterpolationGuard[layeringTestConfig](guard),
+	)
+	if handler.layers.Guard != guard {
+		t.Error("expected WithInterpolationGuard to apply to the layers")
+	}
+}
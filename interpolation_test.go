@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -978,3 +980,371 @@ func TestInterpolationEngine_Analyze_TagParseError_MultipleFields(t *testing.T)
 		})
 	}
 }
+
+func TestInterpolationEngine_Analyze_ConfigTagOptionsOnly(t *testing.T) {
+	type Config struct {
+		Password string `config:"sensitive"`
+		Env      string `config:"availableAs=ENV,sensitive"`
+		Path     string `json:"${ENV}.json"`
+	}
+
+	engine := NewInterpolationEngine[Config]()
+	if err := engine.Analyze(&Config{}); err != nil {
+		t.Fatalf("expected config tag options to be accepted, got: %v", err)
+	}
+
+	if _, ok := engine.availableAsMap["ENV"]; !ok {
+		t.Error("expected ENV to be declared alongside the sensitive option")
+	}
+	if len(engine.availableAsMap) != 1 {
+		t.Errorf("expected exactly one variable, got %v", engine.availableAsMap)
+	}
+}
+
+func TestInterpolationEngine_ResolveAll(t *testing.T) {
+	type Config struct {
+		Env        string `env:"ENV" config:"availableAs=ENV"`
+		Region     string `env:"REGION" config:"availableAs=REGION"`
+		DBPassword string `secret:"aws=/myapp/${ENV}/${REGION}/db/password"`
+		APIKey     string `secret:"aws=/myapp/${ENV}/api-key"`
+	}
+
+	engine := NewInterpolationEngine[Config]()
+	if err := engine.Analyze(&Config{}); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	resolved, err := engine.ResolveAll(map[string]string{"ENV": "prod", "REGION": "eu-west-1"})
+	if err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+	if len(resolved) != 4 {
+		t.Fatalf("expected 4 resolved fields, got %d", len(resolved))
+	}
+
+	if got := resolved[2].Tag.Get("secret"); got != "aws=/myapp/prod/eu-west-1/db/password" {
+		t.Errorf("unexpected DBPassword tag: %s", got)
+	}
+	if !reflect.DeepEqual(resolved[2].Variables, []string{"ENV", "REGION"}) {
+		t.Errorf("unexpected DBPassword variables: %v", resolved[2].Variables)
+	}
+	if resolved[3].FieldName != "APIKey" || resolved[3].Tag.Get("secret") != "aws=/myapp/prod/api-key" {
+		t.Errorf("unexpected APIKey resolution: %+v", resolved[3])
+	}
+}
+
+func TestInterpolationEngine_ResolveAll_ReportsEveryMissingVariable(t *testing.T) {
+	type Config struct {
+		Env        string `env:"ENV" config:"availableAs=ENV"`
+		Region     string `env:"REGION" config:"availableAs=REGION"`
+		DBPassword string `secret:"aws=/myapp/${ENV}/${REGION}/db/password"`
+		APIKey     string `secret:"aws=/myapp/${ENV}/api-key"`
+	}
+
+	engine := NewInterpolationEngine[Config]()
+	if err := engine.Analyze(&Config{}); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	resolved, err := engine.ResolveAll(map[string]string{"REGION": "eu-west-1"})
+	if err == nil {
+		t.Fatal("expected error for missing ENV")
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Fatalf("expected two joined errors, got %v", err)
+	}
+	for _, e := range joined.Unwrap() {
+		undefinedErr, ok := e.(*UndefinedVariableError)
+		if !ok || undefinedErr.VariableName != "ENV" {
+			t.Errorf("expected UndefinedVariableError for ENV, got %v", e)
+		}
+	}
+	if len(resolved) != 2 {
+		t.Errorf("expected the two fields without missing variables to resolve, got %d", len(resolved))
+	}
+}
+
+func TestInterpolationEngine_ResolveAll_BeforeAnalyze(t *testing.T) {
+	type Config struct {
+		Env string `config:"availableAs=ENV"`
+	}
+
+	if _, err := NewInterpolationEngine[Config]().ResolveAll(nil); err == nil {
+		t.Error("expected error when ResolveAll is called before Analyze")
+	}
+}
+
+func TestInterpolationEngine_Analyze_ElementTags(t *testing.T) {
+	type endpoint struct {
+		URL    string `secret:"/${ENV}/endpoint/url"`
+		Routes []struct {
+			Path string `ssm:"/${REGION}/routes"`
+		}
+	}
+	type config struct {
+		Env       string `config:"availableAs=ENV"`
+		Region    string `config:"availableAs=REGION"`
+		Endpoints []endpoint
+		Targets   map[string]*endpoint
+	}
+
+	engine := NewInterpolationEngine[config]()
+	if err := engine.Analyze(&config{}); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if deps := engine.dependencies[2]; len(deps) != 2 || deps[0] != "ENV" || deps[1] != "REGION" {
+		t.Errorf("expected Endpoints to depend on ENV and REGION, got %v", deps)
+	}
+	if deps := engine.dependencies[3]; len(deps) != 2 {
+		t.Errorf("expected Targets to depend on element variables, got %v", deps)
+	}
+
+	type undefined struct {
+		Endpoints []endpoint
+	}
+	err := NewInterpolationEngine[undefined]().Analyze(&undefined{})
+	var undefinedErr *UndefinedVariableError
+	if !errors.As(err, &undefinedErr) || undefinedErr.FieldName != "Endpoints[].URL" || undefinedErr.VariableName != "ENV" {
+		t.Errorf("expected undefined variable error for element field, got %v", err)
+	}
+}
+
+func TestInterpolationEngine_Analyze_NestedStructTags(t *testing.T) {
+	type credentials struct {
+		Password string `secret:"aws=/${ENV}/db/password"`
+	}
+	type database struct {
+		Host        string `ssm:"/${ENV}/db/host"`
+		Credentials *credentials
+	}
+	type config struct {
+		Env      string `config:"availableAs=ENV"`
+		Database database
+	}
+
+	engine := NewInterpolationEngine[config]()
+	if err := engine.Analyze(&config{}); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if deps := engine.dependencies[1]; len(deps) != 1 || deps[0] != "ENV" {
+		t.Errorf("expected Database to depend on ENV, got %v", deps)
+	}
+	if stages := engine.GetDependencyStages(); len(stages) != 2 {
+		t.Errorf("expected 2 loading stages, got %v", stages)
+	}
+
+	type undefined struct {
+		Database database
+	}
+	err := NewInterpolationEngine[undefined]().Analyze(&undefined{})
+	var undefinedErr *UndefinedVariableError
+	if !errors.As(err, &undefinedErr) || undefinedErr.FieldName != "Database.Host" {
+		t.Errorf("expected undefined variable error for nested field, got %v", err)
+	}
+}
+
+type embeddedBase struct {
+	Env string `config:"availableAs=ENV"`
+}
+
+func TestInterpolationEngine_EmbeddedStructs(t *testing.T) {
+	type secrets struct {
+		APIKey string `secret:"aws=/${ENV}/api-key"`
+	}
+	type config struct {
+		embeddedBase
+		secrets
+	}
+
+	engine := NewInterpolationEngine[config]()
+	if err := engine.Analyze(&config{}); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if index, ok := engine.availableAsMap["ENV"]; !ok || index != 0 {
+		t.Errorf("expected ENV to be declared by the embedded field, got %v", engine.availableAsMap)
+	}
+	if deps := engine.dependencies[1]; len(deps) != 1 || deps[0] != "ENV" {
+		t.Errorf("expected the embedded secrets to depend on ENV, got %v", deps)
+	}
+
+	cfg := &config{embeddedBase: embeddedBase{Env: "prod"}}
+	if err := engine.updateContextValue(0, reflect.ValueOf(cfg).Elem().Field(0)); err != nil {
+		t.Fatalf("updateContextValue failed: %v", err)
+	}
+	if got := engine.interpolationContext["ENV"]; got != "prod" {
+		t.Errorf("expected ENV=prod, got %q", got)
+	}
+}
+
+func TestInterpolationEngine_NestedAvailableAs(t *testing.T) {
+	type region struct {
+		Name string `config:"availableAs=PRIMARY_REGION,key=primary"`
+	}
+	type database struct {
+		Cluster string `config:"availableAs=CLUSTER"`
+	}
+	type config struct {
+		Regions  map[string]region
+		Database *database
+		Secret   string `secret:"/${CLUSTER}/${PRIMARY_REGION}/password"`
+	}
+
+	engine := NewInterpolationEngine[config]()
+	cfg := &config{
+		Regions:  map[string]region{"primary": {Name: "eu-west-1"}, "backup": {Name: "us-east-1"}},
+		Database: &database{Cluster: "orders"},
+	}
+	if err := engine.Analyze(cfg); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if deps := engine.dependencies[2]; len(deps) != 2 {
+		t.Errorf("expected Secret to depend on nested variables, got %v", deps)
+	}
+	if stages := engine.GetDependencyStages(); len(stages) != 2 {
+		t.Errorf("expected 2 loading stages, got %v", stages)
+	}
+
+	if err := engine.UpdateContext(0, cfg.Regions); err != nil {
+		t.Fatalf("UpdateContext failed: %v", err)
+	}
+	if err := engine.UpdateContext(1, cfg.Database); err != nil {
+		t.Fatalf("UpdateContext failed: %v", err)
+	}
+	if got := engine.interpolationContext["PRIMARY_REGION"]; got != "eu-west-1" {
+		t.Errorf("expected PRIMARY_REGION=eu-west-1, got %q", got)
+	}
+	if got := engine.interpolationContext["CLUSTER"]; got != "orders" {
+		t.Errorf("expected CLUSTER=orders, got %q", got)
+	}
+
+	context, err := engine.availableAsContext(&config{Regions: map[string]region{"backup": {Name: "us-east-1"}}})
+	if err != nil {
+		t.Fatalf("availableAsContext failed: %v", err)
+	}
+	if len(context) != 0 {
+		t.Errorf("expected missing map entry and nil pointer to be unset, got %v", context)
+	}
+}
+
+func TestInterpolationEngine_NestedAvailableAs_Errors(t *testing.T) {
+	type noKey struct {
+		Regions map[string]struct {
+			Name string `config:"availableAs=REGION"`
+		}
+	}
+	var tagErr *TagParseError
+	if err := NewInterpolationEngine[noKey]().Analyze(&noKey{}); !errors.As(err, &tagErr) || tagErr.FieldName != "Regions[].Name" {
+		t.Errorf("expected TagParseError for map element without key, got %v", err)
+	}
+
+	type inner struct {
+		Region string `config:"availableAs=REGION"`
+	}
+	type duplicate struct {
+		Region string `config:"availableAs=REGION"`
+		Inner  inner
+	}
+	var dupErr *DuplicateAvailableAsError
+	if err := NewInterpolationEngine[duplicate]().Analyze(&duplicate{}); !errors.As(err, &dupErr) {
+		t.Errorf("expected DuplicateAvailableAsError, got %v", err)
+	}
+
+	type unexported struct {
+		Inner struct {
+			region string `config:"availableAs=REGION"`
+		}
+	}
+	var interpErr *InterpolationError
+	if err := NewInterpolationEngine[unexported]().Analyze(&unexported{}); !errors.As(err, &interpErr) || interpErr.FieldName != "Inner.region" {
+		t.Errorf("expected InterpolationError for unexported nested field, got %v", err)
+	}
+}
+
+func TestInterpolationEngine_ScopedAvailableAs(t *testing.T) {
+	type database struct {
+		Host string `config:"availableAs=HOST"`
+		Port int    `config:"availableAs=PORT"`
+	}
+	type region struct {
+		Name string `config:"availableAs=REGION,key=eu"`
+	}
+	type config struct {
+		Primary  database
+		Replica  *database
+		Regions  map[string]region
+		Password string `secret:"aws=/db/${Primary.HOST}/${Replica.HOST}/${Primary.PORT}/password"`
+		Bucket   string `secret:"aws=/${REGION}/${Regions.eu.REGION}"`
+	}
+
+	engine := NewInterpolationEngine[config]()
+	cfg := &config{
+		Primary: database{Host: "primary.db", Port: 5432},
+		Replica: &database{Host: "replica.db", Port: 5433},
+		Regions: map[string]region{"eu": {Name: "eu-west-1"}},
+	}
+	if err := engine.Analyze(cfg); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if stages := engine.GetDependencyStages(); len(stages) != 2 {
+		t.Errorf("expected 2 loading stages, got %v", stages)
+	}
+
+	for i, value := range []interface{}{cfg.Primary, cfg.Replica, cfg.Regions} {
+		if err := engine.UpdateContext(i, value); err != nil {
+			t.Fatalf("UpdateContext failed: %v", err)
+		}
+	}
+	resolved, err := engine.ResolveAll(engine.interpolationContext)
+	if err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+	want := map[string]string{
+		"Password": "aws=/db/primary.db/replica.db/5432/password",
+		"Bucket":   "aws=/eu-west-1/eu-west-1",
+	}
+	for _, field := range resolved {
+		if w, ok := want[field.FieldName]; ok {
+			if got := field.Tag.Get("secret"); got != w {
+				t.Errorf("%s secret tag = %q, want %q", field.FieldName, got, w)
+			}
+			delete(want, field.FieldName)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("fields not resolved: %v", want)
+	}
+}
+
+func TestInterpolationEngine_ScopedAvailableAs_Ambiguous(t *testing.T) {
+	type database struct {
+		Host string `config:"availableAs=HOST"`
+	}
+	type config struct {
+		Primary  database
+		Replica  database
+		Password string `secret:"aws=/db/${HOST}/password"`
+	}
+
+	err := NewInterpolationEngine[config]().Analyze(&config{})
+	var ambErr *AmbiguousVariableError
+	if !errors.As(err, &ambErr) {
+		t.Fatalf("expected AmbiguousVariableError, got %v", err)
+	}
+	if ambErr.FieldName != "Password" || strings.Join(ambErr.Candidates, ",") != "Primary.HOST,Replica.HOST" {
+		t.Errorf("unexpected error %+v", ambErr)
+	}
+	if !strings.Contains(err.Error(), "${Primary.HOST}, ${Replica.HOST}") {
+		t.Errorf("expected the error to suggest scoped names, got %q", err.Error())
+	}
+
+	type undefined struct {
+		Primary  database
+		Password string `secret:"aws=/db/${Replica.HOST}/password"`
+	}
+	var undefErr *UndefinedVariableError
+	if err := NewInterpolationEngine[undefined]().Analyze(&undefined{}); !errors.As(err, &undefErr) {
+		t.Errorf("expected UndefinedVariableError, got %v", err)
+	}
+}
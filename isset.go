@@ -0,0 +1,58 @@
+package config
+
+import "github.com/gymshark/go-easy-config/utils"
+
+// RegisterIsSet registers fn as the process-wide function deciding whether values of type
+// T count as set, in utils.DefaultIsSetFuncs. By default a field is set when it is
+// non-zero. Short-circuiting and layer merging use fn instead for fields of type T: a field
+// fn reports as unset does not end a short-circuiting chain and is filled from layers, and
+// one it reports as set is not overridden by layers.
+//
+// Example:
+//
+//	func init() {
+//	    // Treat empty slices as unset, as well as nil ones
+//	    config.RegisterIsSet(func(s []string) bool { return len(s) > 0 })
+//	}
+func RegisterIsSet[T any](fn func(v T) bool) {
+	utils.RegisterIsSet(utils.DefaultIsSetFuncs, fn)
+}
+
+// WithIsSet registers fn as the function deciding whether values of type T count as set
+// for this handler only, taking precedence over a function registered with RegisterIsSet.
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithIsSet[AppConfig](func(d time.Duration) bool { return d > 0 }),
+//	)
+func WithIsSet[C, T any](fn func(v T) bool) Option[C] {
+	return func(h *Handler[C]) {
+		utils.RegisterIsSet(h.isSetFuncs(), fn)
+	}
+}
+
+// WithFieldIsSet registers fn as the function deciding whether the top-level field named
+// field of C counts as set for this handler, taking precedence over functions registered
+// for the field's type. utils.AlwaysSet makes the zero value a valid setting that layers
+// do not override.
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](
+//	    // Port 0 asks the OS for a free port
+//	    config.WithFieldIsSet[AppConfig]("Port", utils.AlwaysSet),
+//	)
+func WithFieldIsSet[C any](field string, fn utils.IsSetFunc) Option[C] {
+	return func(h *Handler[C]) {
+		utils.RegisterFieldIsSet[C](h.isSetFuncs(), field, fn)
+	}
+}
+
+// isSetFuncs returns the handler's IsSetFuncs, creating it on first use.
+func (c *Handler[C]) isSetFuncs() *utils.IsSetFuncs {
+	if c.isSet == nil {
+		c.isSet = utils.NewIsSetFuncs(utils.DefaultIsSetFuncs)
+	}
+	return c.isSet
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+func TestInterpolatingChainLoader_ShortCircuit_IsSet(t *testing.T) {
+	type Config struct {
+		Port  int
+		Hosts []string
+	}
+
+	first := &mockLoader[Config]{loadFunc: func(c *Config) error {
+		c.Hosts = []string{}
+		return nil
+	}}
+	second := &mockLoader[Config]{loadFunc: func(c *Config) error {
+		c.Hosts = []string{"db-1"}
+		return nil
+	}}
+	isSet := utils.NewIsSetFuncs(nil)
+	utils.RegisterFieldIsSet[Config](isSet, "Port", utils.AlwaysSet)
+	utils.RegisterIsSet(isSet, func(s []string) bool { return len(s) > 0 })
+
+	chain := &InterpolatingChainLoader[Config]{
+		Loaders:      []Loader[Config]{first, second, &mockLoader[Config]{}},
+		ShortCircuit: true,
+		IsSet:        isSet,
+	}
+	cfg := &Config{}
+	if err := chain.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.callCount != 1 {
+		t.Errorf("expected the empty slice to count as unset and the second loader to run, got %d calls", second.callCount)
+	}
+	if third := chain.Loaders[2].(*mockLoader[Config]); third.callCount != 0 {
+		t.Errorf("expected Port 0 to count as set and the chain to short-circuit, got %d calls", third.callCount)
+	}
+}
+
+func TestWithFieldIsSet_KeepsZeroFromLayers(t *testing.T) {
+	dir := t.TempDir()
+	writeLayer(t, dir, "global.json", `{"host":"global","port":443}`)
+
+	handler := NewConfigHandler[layeringTestConfig](
+		WithLoaders[layeringTestConfig](&presetLoader{env: "prod"}),
+		WithLayers[layeringTestConfig](jsonLayer, Layer[layeringTestConfig]{Name: "global", Source: filepath.Join(dir, "global.json")}),
+		WithFieldIsSet[layeringTestConfig]("Port", utils.AlwaysSet),
+	)
+	var cfg layeringTestConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 0 || cfg.Host != "global" {
+		t.Errorf("expected Port to keep its zero value and Host to come from the layer, got %+v", cfg)
+	}
+}
+
+func TestWithIsSet_FillsFromLayers(t *testing.T) {
+	dir := t.TempDir()
+	writeLayer(t, dir, "global.json", `{"timeout":"30s"}`)
+
+	handler := NewConfigHandler[layeringTestConfig](
+		WithLoaders[layeringTestConfig](&presetLoader{env: "prod"}, &mockLoader[layeringTestConfig]{loadFunc: func(c *layeringTestConfig) error {
+			c.Timeout = "none"
+			return nil
+		}}),
+		WithLayers[layeringTestConfig](jsonLayer, Layer[layeringTestConfig]{Name: "global", Source: filepath.Join(dir, "global.json")}),
+		WithIsSet[layeringTestConfig](func(s string) bool { return s != "" && s != "none" }),
+	)
+	var cfg layeringTestConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Timeout != "30s" {
+		t.Errorf("expected Timeout %q to count as unset and be filled from the layer, got %q", "none", cfg.Timeout)
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// Layer is one level of a configuration hierarchy, such as global, environment, region,
+// cluster or instance.
+type Layer[C any] struct {
+	Name     string                        // Layer name used in errors (e.g., "region")
+	Source   string                        // Source template, e.g. "config/${ENV}/${REGION}.yaml" or "/myapp/${ENV}/"
+	Loader   func(source string) Loader[C] // Optional loader factory overriding LayeredLoader.Loader for this layer
+	Required bool                          // Fail instead of skipping the layer when its source or a variable is missing
+}
+
+// LayeredLoader loads an ordered hierarchy of layers, where later (more specific) layers
+// override earlier ones.
+//
+// Each layer's Source is expanded with the availableAs fields already set on the config,
+// so place LayeredLoader after the loaders that provide them (e.g. ENV and REGION from
+// environment variables). The expanded source is passed to the layer's loader factory.
+// A layer is skipped when its source references a variable that is not set (e.g. no
+// instance ID outside production) or its loader reports fs.ErrNotExist, unless Required.
+//
+// Layers are merged into a fresh config; the merged values then fill only the fields still
+// unset on c, so values from earlier loaders such as environment variables and flags take
+// precedence over every layer.
+//
+// Example:
+//
+//	yamlLayer := func(source string) config.Loader[AppConfig] {
+//	    return &generic.YAMLLoader[AppConfig]{Source: source}
+//	}
+//	ldr := &config.LayeredLoader[AppConfig]{
+//	    Loader: yamlLayer,
+//	    Layers: []config.Layer[AppConfig]{
+//	        {Name: "global", Source: "config/global.yaml", Required: true},
+//	        {Name: "environment", Source: "config/${ENV}.yaml"},
+//	        {Name: "region", Source: "config/${ENV}/${REGION}.yaml"},
+//	        {Name: "instance", Source: "config/${ENV}/${REGION}/${INSTANCE}.yaml"},
+//	    },
+//	}
+type LayeredLoader[C any] struct {
+	Layers []Layer[C]                    // Layers from least to most specific
+	Loader func(source string) Loader[C] // Loader factory for layers without their own
+	Guard  *InterpolationGuard           // Optional checks on ${VAR} values (set by WithInterpolationGuard with WithLayers)
+	IsSet  *utils.IsSetFuncs             // Decides which fields count as set (defaults to utils.DefaultIsSetFuncs)
+	schema *schema.Schema                // Set by WithSchema and passed on to file loaders
+
+	filesMu sync.Mutex
+	files   []string // Files read by the layer loaders of the last load
+}
+
+// SetSchema passes s on to the loaders created for each layer that support schema validation.
+func (l *LayeredLoader[C]) SetSchema(s *schema.Schema) {
+	l.schema = s
+}
+
+// Load expands, loads and merges every layer, then fills the zero fields of c.
+func (l *LayeredLoader[C]) Load(c *C) error {
+	return l.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the layer loaders implementing ContextLoader.
+func (l *LayeredLoader[C]) LoadContext(ctx context.Context, c *C) error {
+	engine := NewInterpolationEngine[C]()
+	engine.SetGuard(l.Guard)
+	if err := engine.Analyze(c); err != nil {
+		return fmt.Errorf("interpolation analysis failed: %w", err)
+	}
+	vars, err := engine.availableAsContext(c)
+	if err != nil {
+		return err
+	}
+
+	var merged C
+	var files []string
+	defer func() {
+		l.filesMu.Lock()
+		l.files = files
+		l.filesMu.Unlock()
+	}()
+	for _, layer := range l.Layers {
+		source, err := InterpolateString(layer.Source, vars)
+		if err != nil {
+			if !layer.Required {
+				continue
+			}
+			return &loader.LoaderError{LoaderType: "LayeredLoader", Operation: fmt.Sprintf("expand %s layer", layer.Name), Source: layer.Source, Err: err}
+		}
+
+		newLoader := layer.Loader
+		if newLoader == nil {
+			newLoader = l.Loader
+		}
+		if newLoader == nil {
+			return &loader.LoaderError{LoaderType: "LayeredLoader", Operation: fmt.Sprintf("create %s layer", layer.Name), Source: source, Err: errors.New("no loader factory")}
+		}
+		layerLoader := newLoader(source)
+		if source, ok := layerLoader.(loader.FileSource); ok {
+			files = append(files, source.SourceFiles()...)
+		}
+		if setter, ok := layerLoader.(schemaSetter); ok && l.schema != nil {
+			setter.SetSchema(l.schema)
+		}
+
+		if err := loadWith(ctx, layerLoader, &merged); err != nil {
+			if !layer.Required && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return &loader.LoaderError{LoaderType: "LayeredLoader", Operation: fmt.Sprintf("load %s layer", layer.Name), Source: source, Err: err}
+		}
+	}
+
+	fillUnsetFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&merged).Elem(), l.IsSet)
+	return nil
+}
+
+// SourceFiles returns the files read by the layers of the last load, including optional
+// layers whose files were missing, so the configuration is reloaded when one is created.
+func (l *LayeredLoader[C]) SourceFiles() []string {
+	l.filesMu.Lock()
+	defer l.filesMu.Unlock()
+	return slices.Clone(l.files)
+}
+
+// fillUnsetFields copies each exported field of src that is set into dst where dst is still
+// unset, as decided by isSet.
+func fillUnsetFields(dst, src reflect.Value, isSet *utils.IsSetFuncs) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Field(i)
+		if field.CanSet() && !isSet.IsFieldSet(dst, i) && isSet.IsFieldSet(src, i) {
+			field.Set(src.Field(i))
+		}
+	}
+}
+
+// WithLayers adds a LayeredLoader for layers after the handler's other loaders, whatever the
+// order of options, so the layers can use availableAs values those loaders provide.
+// newLoader builds the loader for each expanded source unless a layer sets its own.
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithLayers[AppConfig](
+//	        func(source string) config.Loader[AppConfig] {
+//	            return &generic.YAMLLoader[AppConfig]{Source: source}
+//	        },
+//	        config.Layer[AppConfig]{Name: "global", Source: "config/global.yaml"},
+//	        config.Layer[AppConfig]{Name: "environment", Source: "config/${ENV}.yaml"},
+//	    ),
+//	)
+func WithLayers[C any](newLoader func(source string) Loader[C], layers ...Layer[C]) Option[C] {
+	return func(h *Handler[C]) {
+		h.layers = &LayeredLoader[C]{Layers: layers, Loader: newLoader}
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type layeringTestConfig struct {
+	Env      string `json:"-" config:"availableAs=ENV"`
+	Region   string `json:"-" config:"availableAs=REGION"`
+	Instance string `json:"-" config:"availableAs=INSTANCE"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Timeout  string `json:"timeout"`
+	LogLevel string `json:"logLevel"`
+}
+
+// presetLoader sets the availableAs fields, standing in for the environment loader.
+type presetLoader struct {
+	env, region string
+}
+
+func (p *presetLoader) Load(c *layeringTestConfig) error {
+	c.Env, c.Region = p.env, p.region
+	return nil
+}
+
+func writeLayer(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func jsonLayer(source string) Loader[layeringTestConfig] {
+	return &generic.JSONLoader[layeringTestConfig]{Source: source}
+}
+
+func TestLayeredLoader_MergesLayersInOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeLayer(t, dir, "global.json", `{"host":"global","port":80,"timeout":"30s","logLevel":"info"}`)
+	writeLayer(t, dir, "prod.json", `{"host":"prod","port":443}`)
+	writeLayer(t, dir, "prod/eu-west-1.json", `{"host":"prod-eu"}`)
+
+	ldr := &LayeredLoader[layeringTestConfig]{
+		Loader: jsonLayer,
+		Layers: []Layer[layeringTestConfig]{
+			{Name: "global", Source: filepath.Join(dir, "global.json"), Required: true},
+			{Name: "environment", Source: filepath.Join(dir, "${ENV}.json")},
+			{Name: "region", Source: filepath.Join(dir, "${ENV}", "${REGION}.json")},
+			{Name: "instance", Source: filepath.Join(dir, "${ENV}", "${REGION}", "${INSTANCE}.json")},
+		},
+	}
+
+	cfg := &layeringTestConfig{Env: "prod", Region: "eu-west-1", LogLevel: "debug"}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := layeringTestConfig{Env: "prod", Region: "eu-west-1", Host: "prod-eu", Port: 443, Timeout: "30s", LogLevel: "debug"}
+	if *cfg != want {
+		t.Errorf("unexpected config:\n got %+v\nwant %+v", *cfg, want)
+	}
+}
+
+func TestLayeredLoader_RequiredLayers(t *testing.T) {
+	dir := t.TempDir()
+	var loaderErr *loader.LoaderError
+
+	missingFile := &LayeredLoader[layeringTestConfig]{
+		Loader: jsonLayer,
+		Layers: []Layer[layeringTestConfig]{{Name: "environment", Source: filepath.Join(dir, "${ENV}.json"), Required: true}},
+	}
+	err := missingFile.Load(&layeringTestConfig{Env: "prod"})
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "load environment layer" || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected missing required layer error, got %v", err)
+	}
+
+	missingVar := &LayeredLoader[layeringTestConfig]{
+		Loader: jsonLayer,
+		Layers: []Layer[layeringTestConfig]{{Name: "environment", Source: filepath.Join(dir, "${ENV}.json"), Required: true}},
+	}
+	if err := missingVar.Load(&layeringTestConfig{}); !errors.As(err, &loaderErr) || loaderErr.Operation != "expand environment layer" {
+		t.Errorf("expected undefined variable error, got %v", err)
+	}
+
+	noFactory := &LayeredLoader[layeringTestConfig]{Layers: []Layer[layeringTestConfig]{{Name: "global", Source: "global.json"}}}
+	if err := noFactory.Load(&layeringTestConfig{}); !errors.As(err, &loaderErr) || loaderErr.Operation != "create global layer" {
+		t.Errorf("expected missing factory error, got %v", err)
+	}
+}
+
+func TestWithLayers_RunsAfterOtherLoaders(t *testing.T) {
+	dir := t.TempDir()
+	writeLayer(t, dir, "staging/us-east-1.json", `{"host":"staging-us","port":8443}`)
+
+	// WithLayers comes first to check the layers still run after the preset loader
+	handler := NewConfigHandler[layeringTestConfig](
+		WithLayers[layeringTestConfig](jsonLayer, Layer[layeringTestConfig]{Name: "region", Source: filepath.Join(dir, "${ENV}", "${REGION}.json")}),
+		WithLoaders[layeringTestConfig](&presetLoader{env: "staging", region: "us-east-1"}),
+	)
+
+	var cfg layeringTestConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "staging-us" || cfg.Port != 8443 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
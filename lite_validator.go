@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// SelfValidator can be implemented by configuration structs to express validation
+// logic in code rather than in `validate` tags.
+type SelfValidator interface {
+	Validate() error
+}
+
+// LiteValidator is a minimal validator for environments where go-playground/validator
+// is unavailable, such as TinyGo builds, where it is the default StructValidator.
+//
+// It enforces the "required" rule from `validate` tags on exported fields, recursing into
+// nested structs and the struct elements of slices and maps (reported as e.g.
+// "Endpoints[2].URL" or "Targets[eu].URL"), and then calls Validate on the struct if it
+// implements SelfValidator.
+// All other tag rules are ignored.
+type LiteValidator struct{}
+
+// Struct validates s, which must be a struct or a pointer to one.
+// Missing required fields are reported as a joined error of ValidationError values.
+func (v *LiteValidator) Struct(s interface{}) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Errorf("validator: nil %T", s)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validator: expected struct, got %T", s)
+	}
+
+	var errs []error
+	checkRequired(rv, "", &errs)
+
+	if sv, ok := s.(SelfValidator); ok {
+		if err := sv.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkRequired appends a ValidationError for every zero field marked required.
+func checkRequired(v reflect.Value, prefix string, errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if strings.TrimSpace(rule) == "required" && utils.IsZero(fieldValue) {
+				*errs = append(*errs, &ValidationError{FieldName: prefix + field.Name, Rule: "required"})
+				break
+			}
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			checkRequired(fieldValue, prefix+field.Name+".", errs)
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < fieldValue.Len(); j++ {
+				elem := fieldValue.Index(j)
+				if elem.Kind() == reflect.Ptr && !elem.IsNil() {
+					elem = elem.Elem()
+				}
+				if elem.Kind() == reflect.Struct {
+					checkRequired(elem, fmt.Sprintf("%s%s[%d].", prefix, field.Name, j), errs)
+				}
+			}
+		case reflect.Map:
+			keys := fieldValue.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+			for _, key := range keys {
+				elem := fieldValue.MapIndex(key)
+				if elem.Kind() == reflect.Ptr && !elem.IsNil() {
+					elem = elem.Elem()
+				}
+				if elem.Kind() == reflect.Struct {
+					checkRequired(elem, fmt.Sprintf("%s%s[%v].", prefix, field.Name, key), errs)
+				}
+			}
+		}
+	}
+}
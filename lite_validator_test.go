@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type liteValidatorConfig struct {
+	Name     string `validate:"required"`
+	Port     int    `validate:"required,min=1"`
+	Optional string `validate:"omitempty,email"`
+	Database struct {
+		Host string `validate:"required"`
+	}
+}
+
+type selfValidatingConfig struct {
+	Min int
+	Max int
+}
+
+func (c selfValidatingConfig) Validate() error {
+	if c.Min > c.Max {
+		return errors.New("min must not exceed max")
+	}
+	return nil
+}
+
+func TestLiteValidator_Struct_Required(t *testing.T) {
+	v := &LiteValidator{}
+
+	cfg := liteValidatorConfig{Port: 8080, Optional: "not-an-email"}
+	err := v.Struct(&cfg)
+	if err == nil {
+		t.Fatal("expected error for missing required fields")
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.FieldName != "Name" || validationErr.Rule != "required" {
+		t.Errorf("expected ValidationError for Name, got %v", err)
+	}
+	if !contains(err.Error(), "Database.Host") {
+		t.Errorf("expected nested field to be reported, got %v", err)
+	}
+	if contains(err.Error(), "Optional") {
+		t.Errorf("expected non-required rules to be ignored, got %v", err)
+	}
+
+	cfg.Name = "app"
+	cfg.Database.Host = "db"
+	if err := v.Struct(cfg); err != nil {
+		t.Errorf("expected valid config to pass, got %v", err)
+	}
+}
+
+func TestLiteValidator_Struct_SelfValidator(t *testing.T) {
+	v := &LiteValidator{}
+
+	if err := v.Struct(selfValidatingConfig{Min: 5, Max: 1}); err == nil {
+		t.Error("expected Validate method error to be returned")
+	}
+	if err := v.Struct(selfValidatingConfig{Min: 1, Max: 5}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLiteValidator_Struct_NonStruct(t *testing.T) {
+	v := &LiteValidator{}
+	if err := v.Struct("not a struct"); err == nil {
+		t.Error("expected error for non-struct value")
+	}
+}
+
+func TestLiteValidator_Struct_MapSections(t *testing.T) {
+	type target struct {
+		URL string `validate:"required"`
+	}
+	cfg := struct {
+		Targets  map[string]target
+		Pointers map[string]*target
+	}{
+		Targets:  map[string]target{"us": {URL: "https://us"}, "eu": {}},
+		Pointers: map[string]*target{"apac": {}, "nil": nil},
+	}
+
+	err := (&LiteValidator{}).Struct(&cfg)
+	for _, want := range []string{"Targets[eu].URL", "Pointers[apac].URL"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error for %s, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "Targets[us]") {
+		t.Errorf("unexpected error for valid entry: %v", err)
+	}
+}
+
+func TestLiteValidator_Struct_SliceSections(t *testing.T) {
+	type endpoint struct {
+		URL string `validate:"required"`
+	}
+	cfg := struct {
+		Endpoints []endpoint
+	}{Endpoints: []endpoint{{URL: "https://a"}, {URL: "https://b"}, {}}}
+
+	violations := Violations((&LiteValidator{}).Struct(&cfg))
+	if len(violations) != 1 || violations[0].String() != "Endpoints[2].URL: is required" {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
@@ -1,5 +1,7 @@
 package config
 
+import "context"
+
 // Loader defines the interface for configuration loaders.
 // Each loader is responsible for populating configuration from a specific source.
 type Loader[T any] interface {
@@ -7,3 +9,20 @@ type Loader[T any] interface {
 	// It should not overwrite existing non-zero values unless explicitly designed to do so.
 	Load(c *T) error
 }
+
+// ContextLoader is implemented by loaders whose sources may be slow, such as remote
+// services, so a deadline or cancellation stops them. Handler.LoadContext and the chain
+// loaders call LoadContext instead of Load for loaders implementing it.
+type ContextLoader[T any] interface {
+	Loader[T]
+	// LoadContext is like Load, but stops when ctx is done and returns its error.
+	LoadContext(ctx context.Context, c *T) error
+}
+
+// loadWith runs l, with ctx if it implements ContextLoader.
+func loadWith[T any](ctx context.Context, l Loader[T], c *T) error {
+	if cl, ok := l.(ContextLoader[T]); ok {
+		return cl.LoadContext(ctx, c)
+	}
+	return l.Load(c)
+}
@@ -0,0 +1,113 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// AccessDeniedError explains an AWS AccessDenied failure in terms of who called, what they
+// tried to do and on which resource. It wraps the original SDK error.
+type AccessDeniedError struct {
+	Identity string // ARN of the calling identity, if known
+	Action   string // IAM action that was denied (e.g., "secretsmanager:GetSecretValue")
+	Resource string // ARN or name of the resource that was accessed
+	Err      error  // Original AWS SDK error
+}
+
+// Error returns an actionable message naming the identity, action and resource.
+func (e *AccessDeniedError) Error() string {
+	identity := e.Identity
+	if identity == "" {
+		identity = "the current identity"
+	}
+	resource := e.Resource
+	if resource == "" {
+		resource = "the requested resource"
+	}
+	return fmt.Sprintf("access denied: %s is not allowed to perform %s on %s; grant %s on this resource in an IAM policy: %v",
+		identity, e.Action, resource, e.Action, e.Err)
+}
+
+// Unwrap returns the original AWS SDK error.
+func (e *AccessDeniedError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	deniedIdentityRegex = regexp.MustCompile(`User: (\S+) is not authorized`)
+	deniedActionRegex   = regexp.MustCompile(`perform: (\S+)`)
+	deniedResourceRegex = regexp.MustCompile(`on resource: (\S+)`)
+)
+
+// isAccessDenied reports whether err is an AWS access denied error from either SDK version.
+// Libraries that flatten SDK errors with %v are recognised by the error code in the message.
+func isAccessDenied(err error) bool {
+	var v2Err interface{ ErrorCode() string }
+	if errors.As(err, &v2Err) {
+		return isAccessDeniedCode(v2Err.ErrorCode())
+	}
+	var v1Err interface{ Code() string }
+	if errors.As(err, &v1Err) {
+		return isAccessDeniedCode(v1Err.Code())
+	}
+	return strings.Contains(err.Error(), "AccessDenied")
+}
+
+// isAccessDeniedCode reports whether code is one of the AWS access denied error codes.
+func isAccessDeniedCode(code string) bool {
+	return code == "AccessDeniedException" || code == "AccessDenied" || code == "UnauthorizedOperation"
+}
+
+// diagnoseAccessDenied wraps err in an AccessDeniedError when it is an access denied error.
+// Identity, action and resource are taken from the AWS error message when present, and
+// otherwise from identity, action and resource. Other errors are returned unchanged.
+func diagnoseAccessDenied(ctx context.Context, err error, action, resource string, identity func(context.Context) (string, error)) error {
+	if err == nil || !isAccessDenied(err) {
+		return err
+	}
+
+	diagnosed := &AccessDeniedError{Action: action, Resource: resource, Err: err}
+	msg := err.Error()
+	if m := deniedActionRegex.FindStringSubmatch(msg); m != nil {
+		diagnosed.Action = m[1]
+	}
+	if m := deniedResourceRegex.FindStringSubmatch(msg); m != nil {
+		diagnosed.Resource = strings.TrimSuffix(m[1], ",")
+	}
+	if m := deniedIdentityRegex.FindStringSubmatch(msg); m != nil {
+		diagnosed.Identity = m[1]
+	} else if identity != nil {
+		// A failed lookup leaves Identity empty; the original error is what matters
+		diagnosed.Identity, _ = identity(ctx)
+	}
+	return diagnosed
+}
+
+// callerCache holds the ARN of the calling identity once a loader has looked it up, so it
+// is looked up at most once however many loads run concurrently.
+type callerCache struct {
+	mu  sync.Mutex
+	arn string
+}
+
+// get returns the cached ARN, calling lookup to find it if it is not cached yet. Failed
+// lookups are not cached.
+func (c *callerCache) get(lookup func() (string, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.arn != "" {
+		return c.arn, nil
+	}
+	arn, err := lookup()
+	if err != nil {
+		return "", err
+	}
+	c.arn = arn
+	return arn, nil
+}
@@ -0,0 +1,134 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/smithy-go"
+)
+
+func TestIsAccessDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"sdk v2", &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "denied"}, true},
+		{"sdk v2 wrapped", fmt.Errorf("fetch: %w", &smithy.GenericAPIError{Code: "AccessDenied"}), true},
+		{"sdk v1", awserr.New("AccessDeniedException", "denied", nil), true},
+		{"flattened message", errors.New("error fetching secret s: operation error Secrets Manager: GetSecretValue, api error AccessDeniedException: denied"), true},
+		{"other code", &smithy.GenericAPIError{Code: "ResourceNotFoundException"}, false},
+		{"plain error", errors.New("timeout"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAccessDenied(tt.err); got != tt.want {
+				t.Errorf("isAccessDenied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnoseAccessDenied_ParsesMessage(t *testing.T) {
+	sdkErr := &smithy.GenericAPIError{
+		Code:    "AccessDeniedException",
+		Message: "User: arn:aws:sts::123456789012:assumed-role/app/i-1 is not authorized to perform: secretsmanager:GetSecretValue on resource: arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/db because no identity-based policy allows the action",
+	}
+	identityCalled := false
+	identity := func(context.Context) (string, error) {
+		identityCalled = true
+		return "unused", nil
+	}
+
+	err := diagnoseAccessDenied(context.Background(), sdkErr, "fallback:Action", "fallback-resource", identity)
+
+	var denied *AccessDeniedError
+	if !errors.As(err, &denied) {
+		t.Fatalf("expected AccessDeniedError, got %T", err)
+	}
+	if denied.Identity != "arn:aws:sts::123456789012:assumed-role/app/i-1" ||
+		denied.Action != "secretsmanager:GetSecretValue" ||
+		denied.Resource != "arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/db" {
+		t.Errorf("unexpected diagnosis: %+v", denied)
+	}
+	if identityCalled {
+		t.Error("expected identity lookup to be skipped when the message names the caller")
+	}
+	if !errors.Is(err, sdkErr) {
+		t.Error("expected original SDK error to be wrapped")
+	}
+}
+
+func TestDiagnoseAccessDenied_FallsBackToIdentityLookup(t *testing.T) {
+	err := diagnoseAccessDenied(context.Background(), awsAccessDenied(), "ssm:GetParameters", "/myapp/prod", func(context.Context) (string, error) {
+		return "arn:aws:iam::123456789012:user/ci", nil
+	})
+
+	var denied *AccessDeniedError
+	if !errors.As(err, &denied) {
+		t.Fatalf("expected AccessDeniedError, got %T", err)
+	}
+	if denied.Identity != "arn:aws:iam::123456789012:user/ci" || denied.Action != "ssm:GetParameters" || denied.Resource != "/myapp/prod" {
+		t.Errorf("unexpected diagnosis: %+v", denied)
+	}
+	for _, want := range []string{"arn:aws:iam::123456789012:user/ci", "ssm:GetParameters", "/myapp/prod"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected message to contain %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestDiagnoseAccessDenied_LeavesOtherErrors(t *testing.T) {
+	original := errors.New("timeout")
+	if err := diagnoseAccessDenied(context.Background(), original, "ssm:GetParameters", "/p", nil); err != original {
+		t.Errorf("expected error to be returned unchanged, got %v", err)
+	}
+}
+
+func awsAccessDenied() error {
+	return awserr.New("AccessDeniedException", "access denied", nil)
+}
+
+func TestCallerCache_LooksUpOnceConcurrently(t *testing.T) {
+	var cache callerCache
+	var lookups atomic.Int32
+	lookup := func() (string, error) {
+		lookups.Add(1)
+		return "arn:aws:iam::123456789012:role/app", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if arn, err := cache.get(lookup); err != nil || arn != "arn:aws:iam::123456789012:role/app" {
+				t.Errorf("get() = %q, %v", arn, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("expected one lookup, got %d", n)
+	}
+}
+
+func TestCallerCache_DoesNotCacheFailures(t *testing.T) {
+	var cache callerCache
+	if _, err := cache.get(func() (string, error) { return "", errors.New("throttled") }); err == nil {
+		t.Fatal("expected the lookup error")
+	}
+	arn, err := cache.get(func() (string, error) { return "arn:aws:iam::123456789012:role/app", nil })
+	if err != nil || arn != "arn:aws:iam::123456789012:role/app" {
+		t.Errorf("get() after a failure = %q, %v", arn, err)
+	}
+}
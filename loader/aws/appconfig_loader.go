@@ -0,0 +1,278 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"reflect"
+	"strings"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/aws/aws-sdk-go/service/appconfig/appconfigiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/loader/generic"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// DefaultAppConfigClientID identifies AppConfigLoader to AppConfig when ClientID is unset.
+const DefaultAppConfigClientID = "go-easy-config"
+
+// AppConfigLoader loads configuration deployed with AWS AppConfig, so settings and feature
+// toggles can be rolled out gradually and rolled back without redeploying the application.
+//
+// A freeform configuration profile is loaded as a JSON, YAML, TOML or INI document, chosen
+// by its content type or Format. When the struct has fields tagged
+// `featureflag:"flag-name"`, the profile is read as a feature flags profile instead and only
+// those fields are set: bool fields from whether the flag is enabled, struct fields from the
+// flag's enabled state and attributes as JSON, and other fields from an attribute named
+// after a dot, e.g. `featureflag:"checkout.max-items"`. Fields whose flag or attribute is
+// absent, as attributes of disabled flags are, are left unchanged unless the tag has the
+// "required" option.
+//
+// AppConfig only sends a configuration when it has changed since the version this loader
+// last received, so keep the loader to reload cheaply.
+//
+// With DryRun, Load makes no AWS calls and leaves the config unchanged, and PlanFetches
+// reports the configuration it would get.
+//
+// Example:
+//
+//	type Config struct {
+//	    NewCheckout  bool `featureflag:"new-checkout"`
+//	    MaxItems     int  `featureflag:"new-checkout.max-items"`
+//	    DarkMode     bool `featureflag:"dark-mode,required"`
+//	}
+//	ldr := &aws.AppConfigLoader[Config]{Application: "shop", Environment: "prod", Profile: "flags"}
+type AppConfigLoader[T any] struct {
+	Application string                      // Application name or ID
+	Environment string                      // Environment name or ID
+	Profile     string                      // Configuration profile name or ID
+	ClientID    string                      // Identifies this client to AppConfig (defaults to DefaultAppConfigClientID)
+	Format      string                      // Optional format of a freeform profile: "json", "yaml", "toml" or "ini" (defaults to its content type)
+	Decoders    *utils.Decoders             // Decoders for custom types (defaults to utils.DefaultDecoders)
+	Client      appconfigiface.AppConfigAPI // Optional AppConfig client (defaults to one created from AWS)
+	STSClient   stsiface.STSAPI             // Optional STS client used to identify the caller in access denied errors
+	AWS         ClientConfig                // AWS settings for the clients created when Client or STSClient is nil
+	EndpointURL string                      // Optional AppConfig endpoint, e.g. http://localhost:4566 for LocalStack (overrides AWS.EndpointURL)
+	Clients     *ClientFactory              // Optional factory sharing resolved AWS settings with other loaders, used instead of AWS
+	DryRun      bool                        // Make no AWS calls in Load, leaving the config unchanged
+
+	caller      callerCache // Cached result of GetCallerIdentity
+	version     string      // Configuration version of content
+	content     []byte      // Configuration last received
+	contentType string      // Content type of content
+}
+
+// Load gets the configuration from AppConfig and populates c from it.
+func (a *AppConfigLoader[T]) Load(c *T) error {
+	return a.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the AWS calls, so its deadline and
+// cancellation stop a slow or unreachable AppConfig.
+func (a *AppConfigLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if a.DryRun {
+		return nil
+	}
+	source := a.Application + "/" + a.Environment + "/" + a.Profile
+	content, contentType, err := a.get(ctx)
+	if err != nil {
+		return &loader.LoaderError{
+			LoaderType: "AppConfigLoader",
+			Operation:  "get configuration",
+			Source:     source,
+			Err:        diagnoseAccessDenied(ctx, err, "appconfig:GetConfiguration", source, a.callerIdentity),
+		}
+	}
+
+	if !hasFeatureFlagTags(reflect.TypeOf(c).Elem()) {
+		format := a.Format
+		if format == "" {
+			format = appConfigFormat(contentType)
+		}
+		doc := &generic.DocumentLoader[T]{Name: "appconfig://" + source, Data: content, Format: format, Decoders: a.Decoders}
+		return doc.Load(c)
+	}
+	return a.setFlags(c, content, source)
+}
+
+// PlanFetches returns a fetch for the configuration Load gets, without calling AWS.
+func (a *AppConfigLoader[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	return []loader.Fetch{{
+		LoaderType: "AppConfigLoader",
+		Source:     a.Application + "/" + a.Environment + "/" + a.Profile,
+		Action:     "appconfig:GetConfiguration",
+	}}
+}
+
+// get returns the configuration and its content type, reusing the last one received when
+// AppConfig reports that it has not changed.
+func (a *AppConfigLoader[T]) get(ctx context.Context) ([]byte, string, error) {
+	client, err := a.client()
+	if err != nil {
+		return nil, "", err
+	}
+	clientID := a.ClientID
+	if clientID == "" {
+		clientID = DefaultAppConfigClientID
+	}
+	input := &appconfig.GetConfigurationInput{
+		Application:   awsv1.String(a.Application),
+		Environment:   awsv1.String(a.Environment),
+		Configuration: awsv1.String(a.Profile),
+		ClientId:      awsv1.String(clientID),
+	}
+	if a.version != "" {
+		input.ClientConfigurationVersion = awsv1.String(a.version)
+	}
+	out, err := client.GetConfigurationWithContext(ctx, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	version := awsv1.StringValue(out.ConfigurationVersion)
+	if len(out.Content) > 0 || version != a.version {
+		a.version, a.content, a.contentType = version, out.Content, awsv1.StringValue(out.ContentType)
+	}
+	return a.content, a.contentType, nil
+}
+
+// setFlags sets the `featureflag` fields of c from a feature flags document.
+func (a *AppConfigLoader[T]) setFlags(c *T, content []byte, source string) error {
+	var flags map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(content, &flags); err != nil {
+		return &loader.LoaderError{LoaderType: "AppConfigLoader", Operation: "parse feature flags", Source: source, Err: err}
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, options, _ := strings.Cut(field.Tag.Get("featureflag"), ",")
+		if tag == "" || tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, attribute, _ := strings.Cut(tag, ".")
+
+		flag, ok := flags[name]
+		var value json.RawMessage
+		if ok && attribute != "" {
+			value, ok = flag[attribute]
+		}
+		if !ok {
+			if utils.HasTagOption(options, "required") {
+				return &loader.LoaderError{LoaderType: "AppConfigLoader", Operation: "find flag", Source: source, Err: fmt.Errorf("feature flag %q not found", tag)}
+			}
+			continue
+		}
+
+		var err error
+		switch {
+		case attribute != "":
+			err = a.set(v.Field(i), value)
+		case field.Type.Kind() == reflect.Bool:
+			var enabled bool
+			if raw, ok := flag["enabled"]; ok {
+				err = json.Unmarshal(raw, &enabled)
+			}
+			v.Field(i).SetBool(enabled)
+		case field.Type.Kind() == reflect.Struct:
+			var raw []byte
+			if raw, err = json.Marshal(flag); err == nil {
+				err = json.Unmarshal(raw, v.Field(i).Addr().Interface())
+			}
+		default:
+			err = fmt.Errorf("%s field needs a bool type, a struct type or an attribute, e.g. %q", field.Type, name+".value")
+		}
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "AppConfigLoader", Operation: "parse value", Source: source, Err: fmt.Errorf("feature flag %q: %w", tag, err)}
+		}
+	}
+	return nil
+}
+
+// set sets v from a JSON attribute value: strings, numbers and booleans with decoders, and
+// lists and objects as JSON.
+func (a *AppConfigLoader[T]) set(v reflect.Value, value json.RawMessage) error {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	switch value[0] {
+	case '[', '{':
+		return json.Unmarshal(value, v.Addr().Interface())
+	case '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return err
+		}
+		return a.Decoders.SetFromString(v, s)
+	default:
+		return a.Decoders.SetFromString(v, string(value))
+	}
+}
+
+// hasFeatureFlagTags reports whether t has a field tagged `featureflag`.
+func hasFeatureFlagTags(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("featureflag"); tag != "" && tag != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// appConfigFormat returns the document format of a content type, or "" to detect it.
+func appConfigFormat(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		return "json"
+	case strings.HasSuffix(mediaType, "yaml"):
+		return "yaml"
+	case strings.HasSuffix(mediaType, "toml"):
+		return "toml"
+	}
+	return ""
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (a *AppConfigLoader[T]) SetDecoders(d *utils.Decoders) {
+	a.Decoders = d
+}
+
+// client returns Client, or a client created from AWS when unset.
+func (a *AppConfigLoader[T]) client() (appconfigiface.AppConfigAPI, error) {
+	if a.Client != nil {
+		return a.Client, nil
+	}
+	sess, err := newSession(a.Clients, a.AWS)
+	if err != nil {
+		return nil, err
+	}
+	return appconfig.New(sess, endpointConfigs(a.EndpointURL)...), nil
+}
+
+// callerIdentity returns the ARN of the calling identity, looked up once with STS.
+func (a *AppConfigLoader[T]) callerIdentity(ctx context.Context) (string, error) {
+	return a.caller.get(func() (string, error) {
+		client := a.STSClient
+		if client == nil {
+			sess, err := newSession(a.Clients, a.AWS)
+			if err != nil {
+				return "", err
+			}
+			client = sts.New(sess)
+		}
+		out, err := client.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return "", err
+		}
+		return awsv1.StringValue(out.Arn), nil
+	})
+}
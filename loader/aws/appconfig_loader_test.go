@@ -0,0 +1,179 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/aws/aws-sdk-go/service/appconfig/appconfigiface"
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// fakeAppConfig serves content as the given version, returning no content to clients that
+// already have it, as AppConfig does.
+type fakeAppConfig struct {
+	appconfigiface.AppConfigAPI
+	content     string
+	contentType string
+	version     string
+	err         error
+	inputs      []*appconfig.GetConfigurationInput
+}
+
+func (f *fakeAppConfig) GetConfigurationWithContext(ctx awsv1.Context, input *appconfig.GetConfigurationInput, _ ...request.Option) (*appconfig.GetConfigurationOutput, error) {
+	f.inputs = append(f.inputs, input)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	out := &appconfig.GetConfigurationOutput{ConfigurationVersion: awsv1.String(f.version), ContentType: awsv1.String(f.contentType)}
+	if awsv1.StringValue(input.ClientConfigurationVersion) != f.version {
+		out.Content = []byte(f.content)
+	}
+	return out, nil
+}
+
+const appConfigFlags = `{
+	"new-checkout": {"enabled": true, "max-items": 25, "timeout": "3s", "regions": ["eu", "us"]},
+	"dark-mode": {"enabled": false},
+	"banner": {"enabled": true, "text": "Sale"}
+}`
+
+type bannerFlag struct {
+	Enabled bool   `json:"enabled"`
+	Text    string `json:"text"`
+}
+
+type appConfigFlagConfig struct {
+	Env         string        `config:"availableAs=ENV"`
+	NewCheckout bool          `featureflag:"new-checkout"`
+	MaxItems    int           `featureflag:"new-checkout.max-items"`
+	Timeout     time.Duration `featureflag:"new-checkout.timeout"`
+	Regions     []string      `featureflag:"new-checkout.regions"`
+	DarkMode    bool          `featureflag:"dark-mode,required"`
+	DarkTheme   string        `featureflag:"dark-mode.theme"`
+	Banner      bannerFlag    `featureflag:"banner"`
+	Missing     bool          `featureflag:"missing"`
+}
+
+func TestAppConfigLoader_FeatureFlags(t *testing.T) {
+	client := &fakeAppConfig{content: appConfigFlags, contentType: "application/json", version: "1"}
+	ldr := &AppConfigLoader[appConfigFlagConfig]{Application: "shop", Environment: "prod", Profile: "flags", Client: client}
+
+	cfg := appConfigFlagConfig{DarkMode: true, DarkTheme: "night", Missing: true}
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := appConfigFlagConfig{
+		NewCheckout: true,
+		MaxItems:    25,
+		Timeout:     3 * time.Second,
+		Regions:     []string{"eu", "us"},
+		DarkTheme:   "night",
+		Banner:      bannerFlag{Enabled: true, Text: "Sale"},
+		Missing:     true,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+	if got := awsv1.StringValue(client.inputs[0].ClientId); got != DefaultAppConfigClientID {
+		t.Errorf("ClientId = %q, want %q", got, DefaultAppConfigClientID)
+	}
+
+	// An unchanged configuration is not sent again, so the last one is reused
+	var again appConfigFlagConfig
+	if err := ldr.Load(&again); err != nil || !again.NewCheckout {
+		t.Errorf("Load() of an unchanged configuration = %+v, %v", again, err)
+	}
+	if got := awsv1.StringValue(client.inputs[1].ClientConfigurationVersion); got != "1" {
+		t.Errorf("ClientConfigurationVersion = %q, want %q", got, "1")
+	}
+}
+
+func TestAppConfigLoader_Document(t *testing.T) {
+	type config struct {
+		LogLevel string `json:"logLevel" yaml:"logLevel"`
+		MaxConns int    `json:"maxConns" yaml:"maxConns"`
+	}
+	tests := []struct {
+		name        string
+		content     string
+		contentType string
+	}{
+		{"json", `{"logLevel": "debug", "maxConns": 10}`, "application/json"},
+		{"yaml", "logLevel: debug\nmaxConns: 10\n", "application/x-yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldr := &AppConfigLoader[config]{Application: "shop", Environment: "prod", Profile: "settings",
+				Client: &fakeAppConfig{content: tt.content, contentType: tt.contentType, version: "1"}}
+			var cfg config
+			if err := ldr.Load(&cfg); err != nil || cfg != (config{"debug", 10}) {
+				t.Errorf("Load() = %+v, %v", cfg, err)
+			}
+		})
+	}
+}
+
+func TestAppConfigLoader_Errors(t *testing.T) {
+	denied := awserr.New("AccessDeniedException", "User: arn:aws:sts::123456789012:assumed-role/app/i-1 is not authorized to perform: appconfig:GetConfiguration", nil)
+	tests := []struct {
+		name      string
+		client    *fakeAppConfig
+		operation string
+		check     func(error) bool
+	}{
+		{"access denied", &fakeAppConfig{err: denied}, "get configuration", func(err error) bool {
+			var deniedErr *AccessDeniedError
+			return errors.As(err, &deniedErr) && deniedErr.Action == "appconfig:GetConfiguration"
+		}},
+		{"not flags", &fakeAppConfig{content: `["new-checkout"]`, version: "1"}, "parse feature flags", nil},
+		{"required flag", &fakeAppConfig{content: `{"new-checkout": {"enabled": true}}`, version: "1"}, "find flag", nil},
+		{"invalid attribute", &fakeAppConfig{content: `{"dark-mode": {"enabled": true}, "new-checkout": {"max-items": "many"}}`, version: "1"}, "parse value", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldr := &AppConfigLoader[appConfigFlagConfig]{Application: "shop", Environment: "prod", Profile: "flags", Client: tt.client, STSClient: fakeSTS{}}
+			err := ldr.Load(&appConfigFlagConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "AppConfigLoader" || loaderErr.Operation != tt.operation {
+				t.Fatalf("Load() error = %v, want a LoaderError for %q", err, tt.operation)
+			}
+			if tt.check != nil && !tt.check(err) {
+				t.Errorf("Load() error = %v", err)
+			}
+		})
+	}
+
+	type unmappable struct {
+		Limit int `featureflag:"limit"`
+	}
+	ldr := &AppConfigLoader[unmappable]{Client: &fakeAppConfig{content: `{"limit": {"enabled": true}}`, version: "1"}}
+	if err := ldr.Load(&unmappable{}); err == nil {
+		t.Error("Load() of an int field without an attribute error = nil")
+	}
+}
+
+func TestAppConfigLoader_LoadContext(t *testing.T) {
+	ldr := &AppConfigLoader[appConfigFlagConfig]{Client: &fakeAppConfig{content: appConfigFlags, version: "1"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ldr.LoadContext(ctx, &appConfigFlagConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadContext() error = %v, want context.Canceled", err)
+	}
+	var cfg appConfigFlagConfig
+	if err := ldr.LoadContext(context.Background(), &cfg); err != nil || !cfg.NewCheckout {
+		t.Errorf("LoadContext() = %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,254 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"sync"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	stsv2 "github.com/aws/aws-sdk-go-v2/service/sts"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	credentialsv1 "github.com/aws/aws-sdk-go/aws/credentials"
+	stscredsv1 "github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// ClientConfig is a configuration section describing how to reach AWS: region, shared
+// config profile, a role to assume, retries and an endpoint override. The zero value uses
+// the SDK's default credential chain and settings.
+//
+// Applications can embed it in their own configuration and build clients with LoadConfig
+// (AWS SDK v2) or Session (AWS SDK v1). SecretsManagerLoader and SSMParameterStoreLoader
+// take one in their AWS field, so loaders and application clients resolve credentials the
+// same way:
+//
+//	type AppConfig struct {
+//	    AWS aws.ClientConfig `envPrefix:"APP_AWS_"` // APP_AWS_REGION, APP_AWS_ROLE_ARN, ...
+//	}
+type ClientConfig struct {
+	Region          string `env:"REGION" json:"region" yaml:"region" description:"AWS region"`
+	Profile         string `env:"PROFILE" json:"profile" yaml:"profile" description:"Shared config profile"`
+	RoleARN         string `env:"ROLE_ARN" json:"roleArn" yaml:"roleArn" description:"Role to assume with the resolved credentials"`
+	RoleSessionName string `env:"ROLE_SESSION_NAME" json:"roleSessionName" yaml:"roleSessionName" description:"Session name for RoleARN"`
+	ExternalID      string `env:"EXTERNAL_ID" json:"externalId" yaml:"externalId" config:"sensitive" description:"External ID for RoleARN"`
+	MaxAttempts     int    `env:"MAX_ATTEMPTS" json:"maxAttempts" yaml:"maxAttempts" validate:"gte=0" description:"Maximum attempts per request, including the first"`
+	RetryMode       string `env:"RETRY_MODE" json:"retryMode" yaml:"retryMode" validate:"omitempty,oneof=standard adaptive" description:"SDK v2 retry mode"`
+	EndpointURL     string `env:"ENDPOINT_URL" json:"endpointUrl" yaml:"endpointUrl" validate:"omitempty,url" description:"Endpoint override, e.g. for LocalStack"`
+}
+
+// LoadConfig returns the AWS SDK v2 configuration for c. When RoleARN is set, the
+// configuration's credentials assume the role using the otherwise resolved credentials,
+// and are shared with earlier and later configurations loaded from an equal c.
+func (c ClientConfig) LoadConfig(ctx context.Context) (awsv2.Config, error) {
+	var opts []func(*config.LoadOptions) error
+	if c.Region != "" {
+		opts = append(opts, config.WithRegion(c.Region))
+	}
+	if c.Profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(c.Profile))
+	}
+	if c.MaxAttempts > 0 {
+		opts = append(opts, config.WithRetryMaxAttempts(c.MaxAttempts))
+	}
+	if c.RetryMode != "" {
+		mode, err := awsv2.ParseRetryMode(c.RetryMode)
+		if err != nil {
+			return awsv2.Config{}, err
+		}
+		opts = append(opts, config.WithRetryMode(mode))
+	}
+	if c.EndpointURL != "" {
+		opts = append(opts, config.WithBaseEndpoint(c.EndpointURL))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return awsv2.Config{}, err
+	}
+	if c.RoleARN != "" {
+		cfg.Credentials = assumedRoles.v2Credentials(c, func() *awsv2.CredentialsCache {
+			provider := stscreds.NewAssumeRoleProvider(stsv2.NewFromConfig(cfg), c.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+				if c.RoleSessionName != "" {
+					o.RoleSessionName = c.RoleSessionName
+				}
+				if c.ExternalID != "" {
+					o.ExternalID = awsv2.String(c.ExternalID)
+				}
+			})
+			return awsv2.NewCredentialsCache(provider)
+		})
+	}
+	return cfg, nil
+}
+
+// Session returns an AWS SDK v1 session for c. When RoleARN is set, the session's
+// credentials assume the role using the otherwise resolved credentials, and are shared
+// with other sessions created from an equal c. RetryMode only applies to SDK v2 and is
+// ignored.
+func (c ClientConfig) Session() (*session.Session, error) {
+	if c == (ClientConfig{}) {
+		return session.NewSession()
+	}
+
+	opts := session.Options{Profile: c.Profile}
+	if c.Profile != "" {
+		opts.SharedConfigState = session.SharedConfigEnable
+	}
+	if c.Region != "" {
+		opts.Config.Region = awsv1.String(c.Region)
+	}
+	if c.MaxAttempts > 0 {
+		opts.Config.MaxRetries = awsv1.Int(c.MaxAttempts - 1)
+	}
+	if c.EndpointURL != "" {
+		opts.Config.Endpoint = awsv1.String(c.EndpointURL)
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.RoleARN != "" {
+		sess.Config.Credentials = assumedRoles.v1Credentials(c, func() *credentialsv1.Credentials {
+			return stscredsv1.NewCredentials(sess, c.RoleARN, func(p *stscredsv1.AssumeRoleProvider) {
+				if c.RoleSessionName != "" {
+					p.RoleSessionName = c.RoleSessionName
+				}
+				if c.ExternalID != "" {
+					p.ExternalID = awsv1.String(c.ExternalID)
+				}
+			})
+		})
+	}
+	return sess, nil
+}
+
+// assumedRoles holds the credentials of roles assumed by LoadConfig and Session.
+var assumedRoles roleCredentials
+
+// roleCredentials caches assumed role credentials by ClientConfig, so every load and
+// client built from an equal configuration shares one set of temporary credentials,
+// which the SDK refreshes before they expire, instead of calling AssumeRole each time.
+type roleCredentials struct {
+	mu sync.Mutex
+	v2 map[ClientConfig]*awsv2.CredentialsCache
+	v1 map[ClientConfig]*credentialsv1.Credentials
+}
+
+// v2Credentials returns the SDK v2 credentials for c, creating them with create once.
+func (r *roleCredentials) v2Credentials(c ClientConfig, create func() *awsv2.CredentialsCache) *awsv2.CredentialsCache {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if creds, ok := r.v2[c]; ok {
+		return creds
+	}
+	if r.v2 == nil {
+		r.v2 = make(map[ClientConfig]*awsv2.CredentialsCache)
+	}
+	r.v2[c] = create()
+	return r.v2[c]
+}
+
+// v1Credentials returns the SDK v1 credentials for c, creating them with create once.
+func (r *roleCredentials) v1Credentials(c ClientConfig, create func() *credentialsv1.Credentials) *credentialsv1.Credentials {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if creds, ok := r.v1[c]; ok {
+		return creds
+	}
+	if r.v1 == nil {
+		r.v1 = make(map[ClientConfig]*credentialsv1.Credentials)
+	}
+	r.v1[c] = create()
+	return r.v1[c]
+}
+
+// ClientFactory resolves a ClientConfig once and shares the result between the loaders
+// given it, so every SecretsManagerLoader, SSMParameterStoreLoader, AppConfigLoader,
+// DynamoDBLoader and KMSDecrypter in a chain uses one AWS configuration and one set of
+// credentials, refreshed by the SDK, instead of each loading the default configuration
+// on every load. Set it on the loaders' Clients field, which takes precedence over AWS:
+//
+//	clients := aws.NewClientFactory(aws.ClientConfig{Region: "eu-west-1", RoleARN: role})
+//	secrets := &aws.SecretsManagerLoader[AppConfig]{Clients: clients}
+//	params := &aws.SSMParameterStoreLoader[AppConfig]{Clients: clients}
+//
+// A failed resolution is not kept, so the next load tries again. A ClientFactory is safe
+// for concurrent use.
+type ClientFactory struct {
+	config ClientConfig
+
+	mu      sync.Mutex
+	v2      *awsv2.Config
+	session *session.Session
+}
+
+// NewClientFactory returns a factory resolving c.
+func NewClientFactory(c ClientConfig) *ClientFactory {
+	return &ClientFactory{config: c}
+}
+
+// ClientConfig returns the settings the factory resolves.
+func (f *ClientFactory) ClientConfig() ClientConfig {
+	return f.config
+}
+
+// LoadConfig returns the AWS SDK v2 configuration, loading it with ClientConfig.LoadConfig
+// on the first call.
+func (f *ClientFactory) LoadConfig(ctx context.Context) (awsv2.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.v2 == nil {
+		cfg, err := f.config.LoadConfig(ctx)
+		if err != nil {
+			return awsv2.Config{}, err
+		}
+		f.v2 = &cfg
+	}
+	return f.v2.Copy(), nil
+}
+
+// Session returns the AWS SDK v1 session, creating it with ClientConfig.Session on the
+// first call.
+func (f *ClientFactory) Session() (*session.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.session == nil {
+		sess, err := f.config.Session()
+		if err != nil {
+			return nil, err
+		}
+		f.session = sess
+	}
+	return f.session, nil
+}
+
+// loadConfig returns the AWS SDK v2 configuration from clients, or loaded for c when
+// clients is nil.
+func loadConfig(ctx context.Context, clients *ClientFactory, c ClientConfig) (awsv2.Config, error) {
+	if clients != nil {
+		return clients.LoadConfig(ctx)
+	}
+	return c.LoadConfig(ctx)
+}
+
+// newSession returns the AWS SDK v1 session from clients, or one created for c when
+// clients is nil.
+func newSession(clients *ClientFactory, c ClientConfig) (*session.Session, error) {
+	if clients != nil {
+		return clients.Session()
+	}
+	return c.Session()
+}
+
+// endpointConfigs returns the SDK v1 client configuration overriding the endpoint of a
+// service client with url, or none when url is empty.
+func endpointConfigs(url string) []*awsv1.Config {
+	if url == "" {
+		return nil
+	}
+	return []*awsv1.Config{awsv1.NewConfig().WithEndpoint(url)}
+}
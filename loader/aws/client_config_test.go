@@ -0,0 +1,283 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// isolateAWSEnvironment points the SDKs at empty shared config files and static credentials.
+func isolateAWSEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ENDPOINT_URL", "")
+}
+
+func TestClientConfig_LoadConfig(t *testing.T) {
+	isolateAWSEnvironment(t)
+
+	cfg, err := ClientConfig{
+		Region:      "eu-west-1",
+		MaxAttempts: 5,
+		RetryMode:   "adaptive",
+		EndpointURL: "http://localhost:4566",
+	}.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Region != "eu-west-1" {
+		t.Errorf("Region = %q, want eu-west-1", cfg.Region)
+	}
+	if cfg.RetryMaxAttempts != 5 || cfg.RetryMode != awsv2.RetryModeAdaptive {
+		t.Errorf("retries = %d/%s, want 5/adaptive", cfg.RetryMaxAttempts, cfg.RetryMode)
+	}
+	if awsv2.ToString(cfg.BaseEndpoint) != "http://localhost:4566" {
+		t.Errorf("BaseEndpoint = %q, want http://localhost:4566", awsv2.ToString(cfg.BaseEndpoint))
+	}
+}
+
+func TestClientConfig_LoadConfig_AssumeRole(t *testing.T) {
+	isolateAWSEnvironment(t)
+
+	cfg, err := ClientConfig{RoleARN: "arn:aws:iam::123456789012:role/app"}.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	cache, ok := cfg.Credentials.(*awsv2.CredentialsCache)
+	if !ok || !cache.IsCredentialsProvider(&stscreds.AssumeRoleProvider{}) {
+		t.Errorf("Credentials = %T, want a cached AssumeRoleProvider", cfg.Credentials)
+	}
+}
+
+func TestClientConfig_LoadConfig_InvalidRetryMode(t *testing.T) {
+	isolateAWSEnvironment(t)
+
+	if _, err := (ClientConfig{RetryMode: "eager"}).LoadConfig(context.Background()); err == nil {
+		t.Error("LoadConfig() expected error for an invalid retry mode")
+	}
+}
+
+func TestClientConfig_Session(t *testing.T) {
+	isolateAWSEnvironment(t)
+
+	sess, err := ClientConfig{
+		Region:      "eu-west-1",
+		MaxAttempts: 4,
+		EndpointURL: "http://localhost:4566",
+		RoleARN:     "arn:aws:iam::123456789012:role/app",
+	}.Session()
+	if err != nil {
+		t.Fatalf("Session() error = %v", err)
+	}
+	if got := awsv1.StringValue(sess.Config.Region); got != "eu-west-1" {
+		t.Errorf("Region = %q, want eu-west-1", got)
+	}
+	if got := awsv1.IntValue(sess.Config.MaxRetries); got != 3 {
+		t.Errorf("MaxRetries = %d, want 3", got)
+	}
+	if got := awsv1.StringValue(sess.Config.Endpoint); got != "http://localhost:4566" {
+		t.Errorf("Endpoint = %q, want http://localhost:4566", got)
+	}
+
+	zero, err := ClientConfig{}.Session()
+	if err != nil {
+		t.Fatalf("Session() error = %v", err)
+	}
+	if sess.Config.Credentials == zero.Config.Credentials {
+		t.Error("RoleARN did not replace the session credentials")
+	}
+}
+
+func TestSSMParameterStoreLoader_UsesClientConfig(t *testing.T) {
+	isolateAWSEnvironment(t)
+
+	ldr := &SSMParameterStoreLoader[SSMTestConfig]{AWS: ClientConfig{Region: "ap-southeast-2"}}
+	client, err := ldr.client()
+	if err != nil {
+		t.Fatalf("client() error = %v", err)
+	}
+	if got := awsv1.StringValue(client.(*ssm.SSM).Config.Region); got != "ap-southeast-2" {
+		t.Errorf("client region = %q, want ap-southeast-2", got)
+	}
+}
+
+// assumeRoleServer serves AssumeRole, recording the external ID of each call.
+type assumeRoleServer struct {
+	mu          sync.Mutex
+	externalIDs []string
+}
+
+func (f *assumeRoleServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Form.Get("Action") != "AssumeRole" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.externalIDs = append(f.externalIDs, r.Form.Get("ExternalId"))
+	f.mu.Unlock()
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprint(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult>
+<Credentials><AccessKeyId>ASIAEXAMPLE</AccessKeyId><SecretAccessKey>role-secret</SecretAccessKey><SessionToken>token</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration></Credentials>
+<AssumedRoleUser><Arn>arn:aws:sts::210987654321:assumed-role/reader/app</Arn><AssumedRoleId>AROAEXAMPLE:app</AssumedRoleId></AssumedRoleUser>
+</AssumeRoleResult></AssumeRoleResponse>`)
+}
+
+func (f *assumeRoleServer) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.externalIDs...)
+}
+
+func TestClientConfig_AssumeRole_CachesCredentials(t *testing.T) {
+	isolateAWSEnvironment(t)
+	roles := &assumeRoleServer{}
+	server := httptest.NewServer(roles)
+	defer server.Close()
+
+	c := ClientConfig{
+		RoleARN:     "arn:aws:iam::210987654321:role/reader",
+		ExternalID:  "tenant-42",
+		EndpointURL: server.URL,
+	}
+	for i := 0; i < 3; i++ {
+		cfg, err := c.LoadConfig(context.Background())
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("Retrieve() error = %v", err)
+		}
+		if creds.AccessKeyID != "ASIAEXAMPLE" {
+			t.Errorf("AccessKeyID = %q, want the assumed role's", creds.AccessKeyID)
+		}
+	}
+	if calls := roles.calls(); len(calls) != 1 || calls[0] != "tenant-42" {
+		t.Errorf("AssumeRole calls = %q, want one with the external ID", calls)
+	}
+
+	// A different configuration assumes the role separately
+	other := c
+	other.RoleSessionName = "other"
+	cfg, err := other.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if _, err := cfg.Credentials.Retrieve(context.Background()); err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if calls := roles.calls(); len(calls) != 2 {
+		t.Errorf("AssumeRole calls = %d, want 2", len(calls))
+	}
+}
+
+func TestClientConfig_Session_CachesCredentials(t *testing.T) {
+	isolateAWSEnvironment(t)
+	roles := &assumeRoleServer{}
+	server := httptest.NewServer(roles)
+	defer server.Close()
+
+	c := ClientConfig{
+		RoleARN:     "arn:aws:iam::210987654321:role/reader",
+		ExternalID:  "tenant-7",
+		EndpointURL: server.URL,
+	}
+	for i := 0; i < 3; i++ {
+		sess, err := c.Session()
+		if err != nil {
+			t.Fatalf("Session() error = %v", err)
+		}
+		creds, err := sess.Config.Credentials.Get()
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if creds.AccessKeyID != "ASIAEXAMPLE" {
+			t.Errorf("AccessKeyID = %q, want the assumed role's", creds.AccessKeyID)
+		}
+	}
+	if calls := roles.calls(); len(calls) != 1 || calls[0] != "tenant-7" {
+		t.Errorf("AssumeRole calls = %q, want one with the external ID", calls)
+	}
+}
+
+func TestClientFactory_SharesConfig(t *testing.T) {
+	isolateAWSEnvironment(t)
+
+	clients := NewClientFactory(ClientConfig{Region: "eu-west-2"})
+	ssmLoader := &SSMParameterStoreLoader[SSMTestConfig]{Clients: clients, AWS: ClientConfig{Region: "ignored"}}
+	kmsDecrypter := &KMSDecrypter{Clients: clients}
+
+	ssmClient, err := ssmLoader.client()
+	if err != nil {
+		t.Fatalf("client() error = %v", err)
+	}
+	if got := awsv1.StringValue(ssmClient.(*ssm.SSM).Config.Region); got != "eu-west-2" {
+		t.Errorf("client region = %q, want the factory's eu-west-2", got)
+	}
+	kmsClient, err := kmsDecrypter.kmsClient()
+	if err != nil {
+		t.Fatalf("kmsClient() error = %v", err)
+	}
+	if ssmClient.(*ssm.SSM).Config.Credentials != kmsClient.(*kms.KMS).Config.Credentials {
+		t.Error("loaders sharing a factory use different credentials")
+	}
+
+	first, err := clients.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	second, err := clients.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if first.Region != "eu-west-2" || first.Credentials != second.Credentials {
+		t.Errorf("LoadConfig() = region %q, shared credentials %v; want eu-west-2, true", first.Region, first.Credentials == second.Credentials)
+	}
+}
+
+func TestLoaders_EndpointURL(t *testing.T) {
+	isolateAWSEnvironment(t)
+	aws := ClientConfig{EndpointURL: "http://localhost:4566"}
+
+	ssmLoader := &SSMParameterStoreLoader[SSMTestConfig]{AWS: aws, EndpointURL: "http://localhost:5000"}
+	ssmClient, err := ssmLoader.client()
+	if err != nil {
+		t.Fatalf("client() error = %v", err)
+	}
+	if got := ssmClient.(*ssm.SSM).Endpoint; got != "http://localhost:5000" {
+		t.Errorf("SSM endpoint = %q, want the loader's http://localhost:5000", got)
+	}
+
+	kmsDecrypter := &KMSDecrypter{AWS: aws}
+	kmsClient, err := kmsDecrypter.kmsClient()
+	if err != nil {
+		t.Fatalf("kmsClient() error = %v", err)
+	}
+	if got := kmsClient.(*kms.KMS).Endpoint; got != "http://localhost:4566" {
+		t.Errorf("KMS endpoint = %q, want AWS.EndpointURL http://localhost:4566", got)
+	}
+
+	var opts secretsmanager.Options
+	(&SecretsManagerLoader[SSMTestConfig]{EndpointURL: "http://localhost:5001"}).clientOptions("eu-west-1")(&opts)
+	if awsv2.ToString(opts.BaseEndpoint) != "http://localhost:5001" || opts.Region != "eu-west-1" {
+		t.Errorf("Secrets Manager options = %q/%q, want http://localhost:5001/eu-west-1", awsv2.ToString(opts.BaseEndpoint), opts.Region)
+	}
+}
@@ -0,0 +1,4 @@
+// Package aws provides loaders for AWS-specific configuration sources.
+//
+// The loaders depend on the AWS SDK and are excluded from js/wasm, wasip1 and TinyGo builds.
+package aws
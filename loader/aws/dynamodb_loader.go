@@ -0,0 +1,343 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// DynamoDBLoader loads configuration from a DynamoDB table, either from the attributes of a
+// single item or, with Rows, from one item per setting holding a name and a value.
+//
+// Attributes and setting names are matched against the name in the field's `dynamodb` tag,
+// or the field name when the tag is absent, and values are parsed according to the field
+// type. String, number and boolean attributes are supported, as are string sets, number
+// sets and lists of those, which fill slice fields. Fields tagged `dynamodb:"-"` and fields
+// without a matching attribute are left unchanged.
+//
+// Key and SortValue may reference ${VAR}, resolved from fields declaring
+// `config:"availableAs=VAR"` that are already populated, or from Context, so one table can
+// hold the configuration of every environment. Run by an InterpolatingChainLoader, the
+// values are those of its interpolation engine, including nested and scoped variables and
+// checked by its guard; run on its own, only top-level fields are used, unchecked:
+//
+//	type Config struct {
+//	    Env      string `env:"ENV" config:"availableAs=ENV"`
+//	    LogLevel string `dynamodb:"logLevel"`
+//	    MaxConns int    `dynamodb:"maxConns"`
+//	}
+//	ldr := &aws.DynamoDBLoader[Config]{Table: "app-config", PartitionKey: "pk", Key: "payments#${ENV}"}
+//
+// With DryRun, Load makes no AWS calls and leaves the config unchanged, and PlanFetches
+// reports the item or rows it would read.
+type DynamoDBLoader[T any] struct {
+	Table          string                    // Table name
+	PartitionKey   string                    // Name of the partition key attribute (defaults to "pk")
+	Key            string                    // Partition key value; may reference ${VAR}
+	SortKey        string                    // Optional name of the sort key attribute
+	SortValue      string                    // Sort key value of the item when SortKey is set and Rows is false; may reference ${VAR}
+	Rows           bool                      // Read every item with the partition key as a name/value row
+	NameAttribute  string                    // Attribute holding a row's setting name (defaults to SortKey, then "name")
+	ValueAttribute string                    // Attribute holding a row's value (defaults to "value")
+	Context        map[string]string         // Optional extra values for ${VAR} references
+	Optional       bool                      // Leave the configuration unchanged when no item exists
+	Client         dynamodbiface.DynamoDBAPI // Optional DynamoDB client (defaults to one created from AWS)
+	STSClient      stsiface.STSAPI           // Optional STS client used to identify the caller in access denied errors
+	AWS            ClientConfig              // AWS settings for the clients created when Client or STSClient is nil
+	EndpointURL    string                    // Optional DynamoDB endpoint, e.g. http://localhost:8000 for DynamoDB Local (overrides AWS.EndpointURL)
+	Clients        *ClientFactory            // Optional factory sharing resolved AWS settings with other loaders, used instead of AWS
+	DryRun         bool                      // Make no AWS calls in Load, leaving the config unchanged
+
+	caller callerCache // Cached result of GetCallerIdentity
+}
+
+// errItemNotFound is returned when the key matches no item.
+var errItemNotFound = errors.New("item not found")
+
+// Load reads the item or rows for the resolved key and sets the fields of c from them.
+func (d *DynamoDBLoader[T]) Load(c *T) error {
+	return d.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the AWS calls, so its deadline and
+// cancellation stop a slow or unreachable table.
+func (d *DynamoDBLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if d.DryRun {
+		return nil
+	}
+	v := reflect.ValueOf(c).Elem()
+	key, err := d.resolve(ctx, v, d.Key)
+	var sortValue string
+	if err == nil && d.SortKey != "" && !d.Rows {
+		sortValue, err = d.resolve(ctx, v, d.SortValue)
+	}
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "DynamoDBLoader", Operation: "interpolate key", Source: d.Table, Err: err}
+	}
+	source := d.Table + "/" + key
+	if d.SortKey != "" && !d.Rows {
+		source += "/" + sortValue
+	}
+
+	client, err := d.client()
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "DynamoDBLoader", Operation: "create client", Source: source, Err: err}
+	}
+
+	operation, action := "get item", "dynamodb:GetItem"
+	if d.Rows {
+		operation, action = "query rows", "dynamodb:Query"
+	}
+	values, err := d.fetch(ctx, client, key, sortValue)
+	if err != nil {
+		if errors.Is(err, errItemNotFound) && d.Optional {
+			return nil
+		}
+		return &loader.LoaderError{
+			LoaderType: "DynamoDBLoader",
+			Operation:  operation,
+			Source:     source,
+			Err:        diagnoseAccessDenied(ctx, err, action, d.Table, d.callerIdentity),
+		}
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := dynamoDBFieldName(field)
+		value, ok := values[name]
+		if !field.IsExported() || name == "" || !ok {
+			continue
+		}
+		if err := setAttribute(v.Field(i), value); err != nil {
+			return &loader.LoaderError{LoaderType: "DynamoDBLoader", Operation: "parse " + name, Source: source, Err: err}
+		}
+	}
+	return nil
+}
+
+// PlanFetches returns a fetch for the item, or the rows, Load reads, without calling AWS.
+// References in Key and SortValue are resolved from Context only, as fields carry no
+// values, and are reported as written when Context does not define them.
+func (d *DynamoDBLoader[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	var zero T
+	v := reflect.ValueOf(&zero).Elem()
+	source := d.Table + "/" + d.planValue(v, d.Key)
+	action := "dynamodb:GetItem"
+	if d.Rows {
+		action = "dynamodb:Query"
+	} else if d.SortKey != "" {
+		source += "/" + d.planValue(v, d.SortValue)
+	}
+	return []loader.Fetch{{LoaderType: "DynamoDBLoader", Source: source, Action: action}}
+}
+
+// planValue returns s resolved as in resolve, or s itself when it cannot be resolved.
+func (d *DynamoDBLoader[T]) planValue(v reflect.Value, s string) string {
+	if resolved, err := d.resolve(context.Background(), v, s); err == nil {
+		return resolved
+	}
+	return s
+}
+
+// fetch returns the attributes of the item with the given key, or the values of the rows
+// with the partition key, keyed by name.
+func (d *DynamoDBLoader[T]) fetch(ctx context.Context, client dynamodbiface.DynamoDBAPI, key, sortValue string) (map[string]*dynamodb.AttributeValue, error) {
+	partitionKey := d.PartitionKey
+	if partitionKey == "" {
+		partitionKey = "pk"
+	}
+
+	if !d.Rows {
+		itemKey := map[string]*dynamodb.AttributeValue{partitionKey: {S: awsv1.String(key)}}
+		if d.SortKey != "" {
+			itemKey[d.SortKey] = &dynamodb.AttributeValue{S: awsv1.String(sortValue)}
+		}
+		out, err := client.GetItemWithContext(ctx, &dynamodb.GetItemInput{TableName: awsv1.String(d.Table), Key: itemKey})
+		if err != nil {
+			return nil, err
+		}
+		if out.Item == nil {
+			return nil, errItemNotFound
+		}
+		return out.Item, nil
+	}
+
+	nameAttribute, valueAttribute := d.NameAttribute, d.ValueAttribute
+	if nameAttribute == "" {
+		nameAttribute = d.SortKey
+	}
+	if nameAttribute == "" {
+		nameAttribute = "name"
+	}
+	if valueAttribute == "" {
+		valueAttribute = "value"
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 awsv1.String(d.Table),
+		KeyConditionExpression:    awsv1.String("#pk = :pk"),
+		ExpressionAttributeNames:  map[string]*string{"#pk": awsv1.String(partitionKey)},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":pk": {S: awsv1.String(key)}},
+	}
+	values := make(map[string]*dynamodb.AttributeValue)
+	var rowErr error
+	err := client.QueryPagesWithContext(ctx, input, func(page *dynamodb.QueryOutput, _ bool) bool {
+		for _, item := range page.Items {
+			var name string
+			if attr := item[nameAttribute]; attr != nil {
+				name = awsv1.StringValue(attr.S)
+			}
+			if name == "" {
+				rowErr = fmt.Errorf("row without a string %s attribute", nameAttribute)
+				return false
+			}
+			value, ok := item[valueAttribute]
+			if !ok {
+				rowErr = fmt.Errorf("row %s has no %s attribute", name, valueAttribute)
+				return false
+			}
+			values[name] = value
+		}
+		return true
+	})
+	if err == nil {
+		err = rowErr
+	}
+	if err == nil && len(values) == 0 {
+		err = errItemNotFound
+	}
+	return values, err
+}
+
+// resolve replaces ${VAR} references in s with the values loader.Variables returns for ctx
+// and v, or of Context.
+func (d *DynamoDBLoader[T]) resolve(ctx context.Context, v reflect.Value, s string) (string, error) {
+	if !utils.VariableReference.MatchString(s) {
+		return s, nil
+	}
+	values, err := loader.Variables(ctx, v)
+	if err != nil {
+		return "", err
+	}
+	for name, value := range d.Context {
+		values[name] = value
+	}
+	resolved, missing := utils.ExpandVariables(s, values)
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%q references undefined %s", s, strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+// client returns Client, or a client created from AWS when unset.
+func (d *DynamoDBLoader[T]) client() (dynamodbiface.DynamoDBAPI, error) {
+	if d.Client != nil {
+		return d.Client, nil
+	}
+	sess, err := newSession(d.Clients, d.AWS)
+	if err != nil {
+		return nil, err
+	}
+	return dynamodb.New(sess, endpointConfigs(d.EndpointURL)...), nil
+}
+
+// callerIdentity returns the ARN of the calling identity, looked up once with STS.
+func (d *DynamoDBLoader[T]) callerIdentity(ctx context.Context) (string, error) {
+	return d.caller.get(func() (string, error) {
+		client := d.STSClient
+		if client == nil {
+			sess, err := newSession(d.Clients, d.AWS)
+			if err != nil {
+				return "", err
+			}
+			client = sts.New(sess)
+		}
+		out, err := client.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return "", err
+		}
+		return awsv1.StringValue(out.Arn), nil
+	})
+}
+
+// dynamoDBFieldName returns the attribute or setting name a field is loaded from: the name
+// in its `dynamodb` tag, or the field name when the tag is absent. Fields tagged
+// `dynamodb:"-"` return "".
+func dynamoDBFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("dynamodb"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// setAttribute sets v from a string, number or boolean attribute, or a slice field from a
+// string set, number set or list of those.
+func setAttribute(v reflect.Value, attr *dynamodb.AttributeValue) error {
+	var elems []*dynamodb.AttributeValue
+	switch {
+	case attr.SS != nil:
+		for _, s := range attr.SS {
+			elems = append(elems, &dynamodb.AttributeValue{S: s})
+		}
+	case attr.NS != nil:
+		for _, n := range attr.NS {
+			elems = append(elems, &dynamodb.AttributeValue{N: n})
+		}
+	case attr.L != nil:
+		elems = attr.L
+	default:
+		value, err := attributeString(attr)
+		if err != nil {
+			return err
+		}
+		return utils.SetFromString(v, value)
+	}
+
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot set %s from a set or list", v.Type())
+	}
+	slice := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		value, err := attributeString(elem)
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		if err := utils.SetFromString(slice.Index(i), value); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// attributeString returns a string, number or boolean attribute as a string.
+func attributeString(attr *dynamodb.AttributeValue) (string, error) {
+	switch {
+	case attr.S != nil:
+		return *attr.S, nil
+	case attr.N != nil:
+		return *attr.N, nil
+	case attr.BOOL != nil:
+		if *attr.BOOL {
+			return "true", nil
+		}
+		return "false", nil
+	}
+	return "", errors.New("unsupported attribute type, expected a string, number or boolean")
+}
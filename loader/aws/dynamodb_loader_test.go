@@ -0,0 +1,209 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// fakeDynamoDB serves GetItem and Query from items keyed by partition key value.
+type fakeDynamoDB struct {
+	dynamodbiface.DynamoDBAPI
+	items    map[string][]map[string]*dynamodb.AttributeValue
+	err      error
+	getInput *dynamodb.GetItemInput
+}
+
+func (f *fakeDynamoDB) GetItemWithContext(ctx awsv1.Context, input *dynamodb.GetItemInput, _ ...request.Option) (*dynamodb.GetItemOutput, error) {
+	f.getInput = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, attr := range input.Key {
+		if items := f.items[awsv1.StringValue(attr.S)]; len(items) > 0 {
+			return &dynamodb.GetItemOutput{Item: items[0]}, nil
+		}
+	}
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (f *fakeDynamoDB) QueryPagesWithContext(_ awsv1.Context, input *dynamodb.QueryInput, fn func(*dynamodb.QueryOutput, bool) bool, _ ...request.Option) error {
+	if f.err != nil {
+		return f.err
+	}
+	// Serve each item as its own page
+	items := f.items[awsv1.StringValue(input.ExpressionAttributeValues[":pk"].S)]
+	for i, item := range items {
+		if !fn(&dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{item}}, i == len(items)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+type fakeSTS struct {
+	stsiface.STSAPI
+}
+
+func (fakeSTS) GetCallerIdentityWithContext(awsv1.Context, *sts.GetCallerIdentityInput, ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Arn: awsv1.String("arn:aws:iam::123456789012:role/app")}, nil
+}
+
+type dynamoDBTestConfig struct {
+	Env      string        `config:"availableAs=ENV"`
+	LogLevel string        `dynamodb:"logLevel"`
+	MaxConns int           `dynamodb:"maxConns"`
+	Debug    bool          `dynamodb:"debug"`
+	Timeout  time.Duration `dynamodb:"timeout"`
+	Hosts    []string      `dynamodb:"hosts"`
+	Internal string        `dynamodb:"-"`
+}
+
+func TestDynamoDBLoader_Item(t *testing.T) {
+	client := &fakeDynamoDB{items: map[string][]map[string]*dynamodb.AttributeValue{
+		"payments#prod": {{
+			"pk":       {S: awsv1.String("payments#prod")},
+			"logLevel": {S: awsv1.String("warn")},
+			"maxConns": {N: awsv1.String("20")},
+			"debug":    {BOOL: awsv1.Bool(true)},
+			"timeout":  {S: awsv1.String("3s")},
+			"hosts":    {L: []*dynamodb.AttributeValue{{S: awsv1.String("a")}, {S: awsv1.String("b")}}},
+			"-":        {S: awsv1.String("ignored")},
+		}},
+	}}
+	ldr := &DynamoDBLoader[dynamoDBTestConfig]{Table: "app-config", Key: "payments#${ENV}", SortKey: "sk", SortValue: "${REV}", Context: map[string]string{"REV": "v1"}, Client: client}
+
+	cfg := dynamoDBTestConfig{Env: "prod"}
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := dynamoDBTestConfig{Env: "prod", LogLevel: "warn", MaxConns: 20, Debug: true, Timeout: 3 * time.Second, Hosts: []string{"a", "b"}}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+	if sk := awsv1.StringValue(client.getInput.Key["sk"].S); sk != "v1" {
+		t.Errorf("sort key = %q, want v1", sk)
+	}
+}
+
+func TestDynamoDBLoader_Rows(t *testing.T) {
+	row := func(name string, value *dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{"pk": {S: awsv1.String("payments#dev")}, "sk": {S: awsv1.String(name)}, "value": value}
+	}
+	client := &fakeDynamoDB{items: map[string][]map[string]*dynamodb.AttributeValue{
+		"payments#dev": {
+			row("logLevel", &dynamodb.AttributeValue{S: awsv1.String("debug")}),
+			row("maxConns", &dynamodb.AttributeValue{N: awsv1.String("5")}),
+			row("hosts", &dynamodb.AttributeValue{SS: awsv1.StringSlice([]string{"x", "y"})}),
+		},
+	}}
+	ldr := &DynamoDBLoader[dynamoDBTestConfig]{Table: "app-config", Key: "payments#${ENV}", SortKey: "sk", Rows: true, Client: client}
+
+	cfg := dynamoDBTestConfig{Env: "dev"}
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.LogLevel != "debug" || cfg.MaxConns != 5 || !reflect.DeepEqual(cfg.Hosts, []string{"x", "y"}) {
+		t.Errorf("config = %+v", cfg)
+	}
+}
+
+func TestDynamoDBLoader_Errors(t *testing.T) {
+	denied := awserr.New("AccessDeniedException", "User: arn:aws:sts::123456789012:assumed-role/app/i-1 is not authorized to perform: dynamodb:GetItem", nil)
+	tests := []struct {
+		name      string
+		ldr       *DynamoDBLoader[dynamoDBTestConfig]
+		operation string
+		check     func(error) bool
+	}{
+		{"undefined variable", &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "${REGION}", Client: &fakeDynamoDB{}}, "interpolate key", nil},
+		{"missing item", &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "k", Client: &fakeDynamoDB{}}, "get item", func(err error) bool { return errors.Is(err, errItemNotFound) }},
+		{"missing rows", &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "k", Rows: true, Client: &fakeDynamoDB{}}, "query rows", func(err error) bool { return errors.Is(err, errItemNotFound) }},
+		{"access denied", &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "k", Client: &fakeDynamoDB{err: denied}, STSClient: fakeSTS{}}, "get item", func(err error) bool {
+			var deniedErr *AccessDeniedError
+			return errors.As(err, &deniedErr) && deniedErr.Action == "dynamodb:GetItem"
+		}},
+		{"unsupported attribute", &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "k", Client: &fakeDynamoDB{items: map[string][]map[string]*dynamodb.AttributeValue{
+			"k": {{"logLevel": {M: map[string]*dynamodb.AttributeValue{}}, "unmapped": {M: map[string]*dynamodb.AttributeValue{}}}},
+		}}}, "parse logLevel", nil},
+		{"invalid value", &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "k", Client: &fakeDynamoDB{items: map[string][]map[string]*dynamodb.AttributeValue{
+			"k": {{"maxConns": {S: awsv1.String("many")}}},
+		}}}, "parse maxConns", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg dynamoDBTestConfig
+			err := tt.ldr.Load(&cfg)
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "DynamoDBLoader" || loaderErr.Operation != tt.operation {
+				t.Fatalf("Load() error = %v, want a LoaderError for %q", err, tt.operation)
+			}
+			if tt.check != nil && !tt.check(err) {
+				t.Errorf("Load() error = %v", err)
+			}
+		})
+	}
+
+	ldr := &DynamoDBLoader[dynamoDBTestConfig]{Table: "t", Key: "k", Optional: true, Client: &fakeDynamoDB{}}
+	if err := ldr.Load(&dynamoDBTestConfig{}); err != nil {
+		t.Errorf("Load() of a missing optional item error = %v", err)
+	}
+}
+
+func TestDynamoDBLoader_LoadContext(t *testing.T) {
+	client := &fakeDynamoDB{items: map[string][]map[string]*dynamodb.AttributeValue{
+		"payments": {{"pk": {S: awsv1.String("payments")}, "logLevel": {S: awsv1.String("debug")}}},
+	}}
+	ldr := &DynamoDBLoader[dynamoDBTestConfig]{Table: "app-config", Key: "payments", Client: client}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ldr.LoadContext(ctx, &dynamoDBTestConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadContext() error = %v, want context.Canceled", err)
+	}
+	var cfg dynamoDBTestConfig
+	if err := ldr.LoadContext(context.Background(), &cfg); err != nil || cfg.LogLevel != "debug" {
+		t.Errorf("LoadContext() = %+v, %v", cfg, err)
+	}
+}
+
+func TestDynamoDBLoader_VariableSource(t *testing.T) {
+	client := &fakeDynamoDB{items: map[string][]map[string]*dynamodb.AttributeValue{
+		"payments#eu": {{"pk": {S: awsv1.String("payments#eu")}, "logLevel": {S: awsv1.String("info")}}},
+	}}
+	ldr := &DynamoDBLoader[dynamoDBTestConfig]{Table: "app-config", Key: "payments#${Primary.REGION}", Client: client}
+
+	ctx := loader.WithVariableSource(context.Background(), func() (map[string]string, error) {
+		return map[string]string{"Primary.REGION": "eu"}, nil
+	})
+	var cfg dynamoDBTestConfig
+	if err := ldr.LoadContext(ctx, &cfg); err != nil || cfg.LogLevel != "info" {
+		t.Errorf("LoadContext() with a scoped variable = %+v, %v", cfg, err)
+	}
+
+	rejected := errors.New("rejected by guard")
+	ctx = loader.WithVariableSource(context.Background(), func() (map[string]string, error) {
+		return nil, rejected
+	})
+	err := ldr.LoadContext(ctx, &dynamoDBTestConfig{Env: "prod"})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "interpolate key" || !errors.Is(err, rejected) {
+		t.Errorf("LoadContext() with a rejected variable error = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+// KMSValuePrefix marks inline values encrypted with AWS KMS, e.g.
+// "kms:AQICAHh...", for use with config.WithValueDecryptionContext.
+const KMSValuePrefix = "kms:"
+
+// KMSDecrypter decrypts base64-encoded AWS KMS ciphertext, such as the output of
+// `aws kms encrypt --output text --query CiphertextBlob`. The key is identified by the
+// ciphertext itself, so one decrypter serves values encrypted under different keys.
+//
+// Example:
+//
+//	kms := &aws.KMSDecrypter{AWS: aws.ClientConfig{Region: "eu-west-1"}}
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithValueDecryptionContext[AppConfig](aws.KMSValuePrefix, kms.DecryptContext),
+//	)
+type KMSDecrypter struct {
+	Client            kmsiface.KMSAPI   // Optional KMS client (defaults to one created from AWS)
+	AWS               ClientConfig      // AWS settings for the client created when Client is nil
+	EndpointURL       string            // Optional KMS endpoint, e.g. http://localhost:4566 for LocalStack (overrides AWS.EndpointURL)
+	Clients           *ClientFactory    // Optional factory sharing resolved AWS settings with other loaders, used instead of AWS
+	EncryptionContext map[string]string // Encryption context the values were encrypted with, if any
+
+	mu     sync.Mutex
+	client kmsiface.KMSAPI
+}
+
+// Decrypt returns the plaintext of the base64-encoded ciphertext. Prefer DecryptContext,
+// which stops the KMS call when the load is cancelled.
+func (d *KMSDecrypter) Decrypt(ciphertext string) (string, error) {
+	return d.DecryptContext(context.Background(), ciphertext)
+}
+
+// DecryptContext returns the plaintext of the base64-encoded ciphertext, using ctx for the
+// KMS call.
+func (d *KMSDecrypter) DecryptContext(ctx context.Context, ciphertext string) (string, error) {
+	blob, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("ciphertext is not base64: %w", err)
+	}
+
+	client, err := d.kmsClient()
+	if err != nil {
+		return "", err
+	}
+	input := &kms.DecryptInput{CiphertextBlob: blob}
+	if len(d.EncryptionContext) > 0 {
+		input.EncryptionContext = awsv1.StringMap(d.EncryptionContext)
+	}
+	out, err := client.DecryptWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return string(out.Plaintext), nil
+}
+
+// kmsClient returns Client, or a client created from AWS once when unset.
+func (d *KMSDecrypter) kmsClient() (kmsiface.KMSAPI, error) {
+	if d.Client != nil {
+		return d.Client, nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client == nil {
+		sess, err := newSession(d.Clients, d.AWS)
+		if err != nil {
+			return nil, err
+		}
+		d.client = kms.New(sess, endpointConfigs(d.EndpointURL)...)
+	}
+	return d.client, nil
+}
@@ -0,0 +1,52 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+type mockKMSClient struct {
+	kmsiface.KMSAPI
+	plaintexts map[string]string // Plaintexts by ciphertext blob
+	input      *kms.DecryptInput
+}
+
+func (m *mockKMSClient) DecryptWithContext(_ awsv1.Context, input *kms.DecryptInput, _ ...request.Option) (*kms.DecryptOutput, error) {
+	m.input = input
+	plaintext, ok := m.plaintexts[string(input.CiphertextBlob)]
+	if !ok {
+		return nil, errors.New("InvalidCiphertextException")
+	}
+	return &kms.DecryptOutput{Plaintext: []byte(plaintext)}, nil
+}
+
+func TestKMSDecrypter_Decrypt(t *testing.T) {
+	client := &mockKMSClient{plaintexts: map[string]string{"blob": "s3cret"}}
+	d := &KMSDecrypter{Client: client, EncryptionContext: map[string]string{"app": "orders"}}
+
+	got, err := d.Decrypt(base64.StdEncoding.EncodeToString([]byte("blob")))
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if got != "s3cret" {
+		t.Errorf("Decrypt = %q, want s3cret", got)
+	}
+	if awsv1.StringValue(client.input.EncryptionContext["app"]) != "orders" {
+		t.Errorf("EncryptionContext = %v, want app=orders", awsv1.StringValueMap(client.input.EncryptionContext))
+	}
+
+	if _, err := d.Decrypt("not base64!"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+	if _, err := d.Decrypt(base64.StdEncoding.EncodeToString([]byte("other"))); err == nil {
+		t.Error("expected error from KMS")
+	}
+}
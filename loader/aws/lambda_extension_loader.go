@@ -0,0 +1,222 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// DefaultLambdaExtensionPort is the port the AWS Parameters and Secrets Lambda Extension
+// listens on unless PARAMETERS_SECRETS_EXTENSION_HTTP_PORT is set.
+const DefaultLambdaExtensionPort = "2773"
+
+// errExtensionNotFound is returned by the extension requests when a parameter or secret
+// does not exist.
+var errExtensionNotFound = errors.New("not found")
+
+// LambdaExtensionLoader loads configuration through the AWS Parameters and Secrets Lambda
+// Extension, the local HTTP endpoint that serves and caches Parameter Store and Secrets
+// Manager values inside a Lambda function. Functions avoid creating SDK clients during cold
+// starts, and only need the extension layer and the usual GetParameter or GetSecretValue
+// permissions.
+//
+// It supports the tags of SSMParameterStoreLoader and SecretsManagerLoader:
+//   - `ssm:"name"` reads a parameter below Path, with decryption. A missing parameter is an
+//     error unless the field has a `default` tag, whose value is used instead.
+//   - `secret:"aws=name"` reads the string value of a secret. A missing secret leaves the
+//     field unchanged, or sets it to the tag's fallback=value, unless the tag has the
+//     "required" option. Struct fields are set by unmarshaling the secret as JSON.
+//
+// Values are parsed according to the field type.
+//
+// Example:
+//
+//	type Config struct {
+//	    Port       int    `ssm:"port" default:"8080"`
+//	    DBPassword string `secret:"aws=prod/db/password,required" config:"sensitive"`
+//	}
+//	ldr := &aws.LambdaExtensionLoader[Config]{Path: "/myapp/prod"}
+type LambdaExtensionLoader[T any] struct {
+	Path     string          // Base path for `ssm` tags, as in SSMParameterStoreLoader
+	Endpoint string          // Extension URL (defaults to http://localhost:$PARAMETERS_SECRETS_EXTENSION_HTTP_PORT)
+	Token    string          // Token sent in X-Aws-Parameters-Secrets-Token (defaults to $AWS_SESSION_TOKEN)
+	Client   *http.Client    // Optional HTTP client (defaults to one with a 5 second timeout)
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+	DryRun   bool            // Make no requests to the extension in Load, leaving the config unchanged
+}
+
+// Load reads the parameter or secret of each tagged field of c through the extension.
+func (l *LambdaExtensionLoader[T]) Load(c *T) error {
+	return l.load(context.Background(), c, nil)
+}
+
+// LoadContext is like Load, but makes the requests to the extension with ctx, so its
+// deadline and cancellation apply to them.
+func (l *LambdaExtensionLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	return l.load(ctx, c, nil)
+}
+
+// LoadFields reads the parameters and secrets of the tagged fields named in fields only.
+func (l *LambdaExtensionLoader[T]) LoadFields(c *T, fields []string) error {
+	return l.load(context.Background(), c, fields)
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (l *LambdaExtensionLoader[T]) SetDecoders(d *utils.Decoders) {
+	l.Decoders = d
+}
+
+// PlanFetches returns a fetch for the parameter or secret of each tagged field, with the
+// IAM action the extension calls under the function's role, without making requests.
+func (l *LambdaExtensionLoader[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	var fetches []loader.Fetch
+	for _, field := range fields {
+		if name := field.Tag.Get("ssm"); name != "" {
+			fetches = append(fetches, loader.Fetch{LoaderType: "LambdaExtensionLoader", Field: field.Name, Source: path.Join(l.Path, name), Action: "ssm:GetParameter"})
+		} else if id, ok := utils.TagOptionValue(field.Tag.Get("secret"), "aws"); ok && id != "" {
+			fetches = append(fetches, loader.Fetch{LoaderType: "LambdaExtensionLoader", Field: field.Name, Source: id, Action: "secretsmanager:GetSecretValue"})
+		}
+	}
+	return fetches
+}
+
+// load reads the tagged fields named in fields, or all tagged fields if fields is nil.
+func (l *LambdaExtensionLoader[T]) load(ctx context.Context, c *T, fields []string) error {
+	if l.DryRun {
+		return nil
+	}
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || (fields != nil && !slices.Contains(fields, field.Name)) {
+			continue
+		}
+
+		var source, value, operation string
+		var err error
+		if name := field.Tag.Get("ssm"); name != "" {
+			source, operation = path.Join(l.Path, name), "get parameter"
+			value, err = l.parameter(ctx, source)
+			if errors.Is(err, errExtensionNotFound) {
+				if fallback, ok := field.Tag.Lookup("default"); ok {
+					value, err = fallback, nil
+				}
+			}
+		} else if tag := field.Tag.Get("secret"); tag != "" {
+			id, ok := utils.TagOptionValue(tag, "aws")
+			if !ok || id == "" {
+				continue
+			}
+			source, operation = id, "get secret"
+			value, err = l.secret(ctx, id)
+			if errors.Is(err, errExtensionNotFound) && !utils.HasTagOption(tag, "required") {
+				fallback, ok := utils.TagOptionValue(tag, "fallback")
+				if !ok {
+					continue
+				}
+				value, err = fallback, nil
+			}
+		} else {
+			continue
+		}
+
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "LambdaExtensionLoader", Operation: operation, Source: source, Err: err}
+		}
+		if operation == "get secret" && isNestedStruct(field.Type) {
+			err = setSecretDocument(v.Field(i), value)
+		} else {
+			err = l.Decoders.SetFromString(v.Field(i), value)
+		}
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "LambdaExtensionLoader", Operation: "parse value", Source: source, Err: err}
+		}
+	}
+	return nil
+}
+
+// parameter returns the value of the parameter name, decrypted.
+func (l *LambdaExtensionLoader[T]) parameter(ctx context.Context, name string) (string, error) {
+	var out struct {
+		Parameter struct {
+			Value string
+		}
+	}
+	query := url.Values{"name": {name}, "withDecryption": {"true"}}
+	if err := l.get(ctx, "/systemsmanager/parameters/get", query, &out); err != nil {
+		return "", err
+	}
+	return out.Parameter.Value, nil
+}
+
+// secret returns the string value of the secret id, or its binary value as a string.
+func (l *LambdaExtensionLoader[T]) secret(ctx context.Context, id string) (string, error) {
+	var out struct {
+		SecretString *string
+		SecretBinary []byte
+	}
+	if err := l.get(ctx, "/secretsmanager/get", url.Values{"secretId": {id}}, &out); err != nil {
+		return "", err
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+// get requests route from the extension and decodes the JSON response into out.
+func (l *LambdaExtensionLoader[T]) get(ctx context.Context, route string, query url.Values, out any) error {
+	endpoint := l.Endpoint
+	if endpoint == "" {
+		port := os.Getenv("PARAMETERS_SECRETS_EXTENSION_HTTP_PORT")
+		if port == "" {
+			port = DefaultLambdaExtensionPort
+		}
+		endpoint = "http://localhost:" + port
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+route+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	token := l.Token
+	if token == "" {
+		token = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	req.Header.Set("X-Aws-Parameters-Secrets-Token", token)
+
+	client := l.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		msg := strings.TrimSpace(string(body))
+		if resp.StatusCode == http.StatusNotFound || strings.Contains(msg, "ParameterNotFound") || strings.Contains(msg, "ResourceNotFoundException") {
+			return fmt.Errorf("%w: %s", errExtensionNotFound, msg)
+		}
+		return fmt.Errorf("extension returned %s: %s", resp.Status, msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
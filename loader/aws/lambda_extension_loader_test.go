@@ -0,0 +1,152 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// fakeExtension serves parameters and secrets the way the Lambda extension does.
+func fakeExtension(t *testing.T, token string, parameters, secrets map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Aws-Parameters-Secrets-Token") != token {
+			http.Error(w, "missing token", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/systemsmanager/parameters/get":
+			value, ok := parameters[r.URL.Query().Get("name")]
+			if !ok || r.URL.Query().Get("withDecryption") != "true" {
+				http.Error(w, "ParameterNotFound: parameter not found", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"Parameter": map[string]string{"Value": value}})
+		case "/secretsmanager/get":
+			value, ok := secrets[r.URL.Query().Get("secretId")]
+			if !ok {
+				http.Error(w, "ResourceNotFoundException: secret not found", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"SecretString": value})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type lambdaTestConfig struct {
+	Port       int    `ssm:"port"`
+	LogLevel   string `ssm:"log-level" default:"info"`
+	DBPassword string `secret:"aws=prod/db/password,required"`
+	APIKey     string `secret:"aws=prod/api-key"`
+	Region     string `secret:"aws=prod/region,fallback=eu-west-1"`
+	Name       string
+}
+
+func TestLambdaExtensionLoader_Load(t *testing.T) {
+	server := fakeExtension(t, "session-token",
+		map[string]string{"/myapp/prod/port": "8080"},
+		map[string]string{"prod/db/password": "s3cret"},
+	)
+	t.Setenv("AWS_SESSION_TOKEN", "session-token")
+	cfg := &lambdaTestConfig{APIKey: "unchanged", Name: "unchanged"}
+	ldr := &LambdaExtensionLoader[lambdaTestConfig]{Path: "/myapp/prod", Endpoint: server.URL}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := lambdaTestConfig{Port: 8080, LogLevel: "info", DBPassword: "s3cret", APIKey: "unchanged", Region: "eu-west-1", Name: "unchanged"}
+	if *cfg != want {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestLambdaExtensionLoader_DefaultEndpoint(t *testing.T) {
+	server := fakeExtension(t, "", nil, map[string]string{"prod/db/password": "s3cret"})
+	t.Setenv("PARAMETERS_SECRETS_EXTENSION_HTTP_PORT", strconv.Itoa(server.Listener.Addr().(*net.TCPAddr).Port))
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	cfg := &lambdaTestConfig{}
+	ldr := &LambdaExtensionLoader[lambdaTestConfig]{}
+
+	if err := ldr.LoadFields(cfg, []string{"DBPassword"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DBPassword != "s3cret" {
+		t.Errorf("expected the secret from the extension port, got %+v", cfg)
+	}
+}
+
+func TestLambdaExtensionLoader_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		parameters map[string]string
+		secrets    map[string]string
+		operation  string
+		source     string
+	}{
+		{"missing parameter", "tok", nil, nil, "get parameter", "/myapp/prod/port"},
+		{"required secret missing", "tok", map[string]string{"/myapp/prod/port": "80"}, nil, "get secret", "prod/db/password"},
+		{"invalid value", "tok", map[string]string{"/myapp/prod/port": "eighty"}, nil, "parse value", "/myapp/prod/port"},
+		{"rejected token", "other", nil, nil, "get parameter", "/myapp/prod/port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fakeExtension(t, tt.token, tt.parameters, tt.secrets)
+			ldr := &LambdaExtensionLoader[lambdaTestConfig]{Path: "/myapp/prod", Endpoint: server.URL, Token: "tok"}
+
+			err := ldr.Load(&lambdaTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) {
+				t.Fatalf("expected *loader.LoaderError, got %v", err)
+			}
+			if loaderErr.LoaderType != "LambdaExtensionLoader" || loaderErr.Operation != tt.operation || loaderErr.Source != tt.source {
+				t.Errorf("unexpected error: %v", loaderErr)
+			}
+		})
+	}
+}
+
+func TestLambdaExtensionLoader_LoadContext(t *testing.T) {
+	server := fakeExtension(t, "token", map[string]string{"/myapp/prod/port": "8080"}, nil)
+	ldr := &LambdaExtensionLoader[lambdaTestConfig]{Path: "/myapp/prod", Endpoint: server.URL, Token: "token"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ldr.LoadContext(ctx, &lambdaTestConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLambdaExtensionLoader_StructSecret(t *testing.T) {
+	type dbSecret struct {
+		Host     string `json:"host"`
+		Port     int    `json:"port"`
+		Password string `json:"password"`
+	}
+	type dbConfig struct {
+		DB dbSecret `secret:"aws=myapp/db,required"`
+	}
+	server := fakeExtension(t, "token", nil, map[string]string{"myapp/db": `{"host":"db.internal","port":5432,"password":"s3cret"}`})
+	ldr := &LambdaExtensionLoader[dbConfig]{Endpoint: server.URL, Token: "token"}
+
+	var cfg dbConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (dbSecret{Host: "db.internal", Port: 5432, Password: "s3cret"}); cfg.DB != want {
+		t.Errorf("DB = %+v, want %+v", cfg.DB, want)
+	}
+}
@@ -0,0 +1,192 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/crazywolf132/secretfetch"
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// DefaultRefreshInterval is how often SecretsManagerLoader.Watch checks secrets when
+// RefreshInterval is unset.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// Watch checks the secrets referenced by `secret:"aws=..."` tags every RefreshInterval and
+// calls changed after a check finds that one has been rotated, until ctx is done. With a
+// RefreshStage, a secret has changed when the version holding that stage changes, which
+// costs a DescribeSecret call rather than reading the value; otherwise the values are
+// retrieved again and compared. Changed secrets are dropped from the CacheTTL cache first,
+// so the next Load reads the new values.
+//
+// Secret IDs referencing ${VAR} are resolved with the availableAs values of the last load,
+// as each check runs; secrets whose variables that load did not set are not checked.
+//
+// It returns an error if the secrets cannot be checked when Watch starts. Later failed
+// checks are retried at the next interval.
+func (s *SecretsManagerLoader[T]) Watch(ctx context.Context, changed func()) error {
+	if !hasSecretTags(new(T)) {
+		<-ctx.Done()
+		return nil
+	}
+
+	check, err := s.rotationCheck(ctx)
+	if err != nil {
+		return err
+	}
+	versions, err := check(ctx, secretRefs((*T)(nil), s.variables()))
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "SecretsManagerLoader", Operation: "watch secrets", Err: err}
+	}
+
+	interval := s.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		refs := secretRefs((*T)(nil), s.variables())
+		latest, err := check(ctx, refs)
+		if err != nil {
+			continue
+		}
+		// A secret first checked now, after a load resolved a new ID, has not been rotated
+		var rotated []string
+		for _, ref := range refs {
+			if previous, ok := versions[ref.key()]; ok && latest[ref.key()] != previous {
+				rotated = append(rotated, ref.id)
+			}
+		}
+		versions = latest
+		if len(rotated) > 0 {
+			s.Invalidate(rotated...)
+			changed()
+		}
+	}
+}
+
+// WatchRotation calls onRotate with a copy of current whose secret fields have been loaded
+// again each time Watch reports a rotation, until ctx is done, so long-running services can
+// swap in new credentials without a restart. A copy that loaded without error becomes the
+// base of the next one. It returns Watch's error.
+//
+// Example:
+//
+//	go ldr.WatchRotation(ctx, *cfg, func(updated *AppConfig, err error) {
+//	    if err != nil {
+//	        log.Printf("reloading rotated secrets: %v", err)
+//	        return
+//	    }
+//	    db.Reconnect(updated.DBPassword)
+//	})
+func (s *SecretsManagerLoader[T]) WatchRotation(ctx context.Context, current T, onRotate func(*T, error)) error {
+	return s.Watch(ctx, func() {
+		updated := current
+		err := s.LoadContext(ctx, &updated)
+		if err == nil {
+			current = updated
+		}
+		onRotate(&updated, err)
+	})
+}
+
+// setVariables records the availableAs values of a load, for resolving watched secret IDs.
+func (s *SecretsManagerLoader[T]) setVariables(vars map[string]string) {
+	s.varsMu.Lock()
+	defer s.varsMu.Unlock()
+	s.vars = vars
+}
+
+// variables returns the availableAs values recorded by the last load.
+func (s *SecretsManagerLoader[T]) variables() map[string]string {
+	s.varsMu.Lock()
+	defer s.varsMu.Unlock()
+	return s.vars
+}
+
+// rotationCheck returns a function reporting a fingerprint of each secret in refs, by
+// key: the version holding RefreshStage when it is set, or otherwise a hash of the value.
+func (s *SecretsManagerLoader[T]) rotationCheck(ctx context.Context) (func(context.Context, []secretRef) (map[string]string, error), error) {
+	if s.RefreshStage != "" {
+		describers := make(map[string]SecretDescriber)
+		return func(ctx context.Context, refs []secretRef) (map[string]string, error) {
+			versions := make(map[string]string, len(refs))
+			for _, ref := range refs {
+				client, ok := describers[ref.region]
+				if !ok {
+					var err error
+					if client, err = s.describer(ctx, ref.region); err != nil {
+						return nil, err
+					}
+					describers[ref.region] = client
+				}
+				version, err := s.stageVersion(ctx, client, ref.id)
+				if err != nil {
+					return nil, err
+				}
+				versions[ref.key()] = version
+			}
+			return versions, nil
+		}, nil
+	}
+
+	opts, err := s.options(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clients := make(map[string]secretfetch.SecretsManagerClient)
+	return func(ctx context.Context, refs []secretRef) (map[string]string, error) {
+		hashes := make(map[string]string, len(refs))
+		for _, ref := range refs {
+			client, ok := clients[ref.region]
+			if !ok {
+				var err error
+				if client, err = s.regionClient(opts, ref.region); err != nil {
+					return nil, err
+				}
+				clients[ref.region] = client
+			}
+			out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: awsv2.String(ref.id)})
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ref.id, err)
+			}
+			value := out.SecretBinary
+			if out.SecretString != nil {
+				value = []byte(*out.SecretString)
+			}
+			// Keep a hash rather than the plaintext between checks
+			hashes[ref.key()] = fmt.Sprintf("%x", sha256.Sum256(value))
+		}
+		return hashes, nil
+	}, nil
+}
+
+// stageVersion returns the ID of the version of the secret id that holds RefreshStage.
+func (s *SecretsManagerLoader[T]) stageVersion(ctx context.Context, client SecretDescriber, id string) (string, error) {
+	out, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{SecretId: awsv2.String(id)})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", id, err)
+	}
+	// Sort so a misconfigured secret with the stage on several versions is stable
+	for _, version := range slices.Sorted(maps.Keys(out.VersionIdsToStages)) {
+		if slices.Contains(out.VersionIdsToStages[version], s.RefreshStage) {
+			return version, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,181 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/crazywolf132/secretfetch"
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+var _ loader.Watcher = (*SecretsManagerLoader[SecretsTestConfig])(nil)
+
+// rotatingSecret is a secret whose value and AWSCURRENT version can be changed by a test.
+type rotatingSecret struct {
+	mu      sync.Mutex
+	value   string
+	version string
+}
+
+func (r *rotatingSecret) rotate(value, version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.value, r.version = value, version
+}
+
+func (r *rotatingSecret) client() *mockSecretDescriberClient {
+	return &mockSecretDescriberClient{
+		mockSecretsManagerClient: mockSecretsManagerClient{
+			getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(r.value)}, nil
+			},
+		},
+		describeSecretFn: func(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			return &secretsmanager.DescribeSecretOutput{
+				Name:               params.SecretId,
+				VersionIdsToStages: map[string][]string{r.version: {"AWSCURRENT"}, "old": {"AWSPREVIOUS"}},
+			}, nil
+		},
+	}
+}
+
+func TestSecretsManagerLoader_WatchRotation(t *testing.T) {
+	tests := []struct {
+		name   string
+		stage  string
+		rotate func(*rotatingSecret)
+	}{
+		{"value comparison", "", func(r *rotatingSecret) { r.rotate("rotated", "v1") }},
+		{"version stage", "AWSCURRENT", func(r *rotatingSecret) { r.rotate("rotated", "v2") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &rotatingSecret{value: "initial", version: "v1"}
+			ldr := &SecretsManagerLoader[SecretsTestConfig]{
+				SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: secret.client()},
+				CacheTTL:        time.Hour,
+				RefreshInterval: 5 * time.Millisecond,
+				RefreshStage:    tt.stage,
+			}
+			var cfg SecretsTestConfig
+			if err := ldr.Load(&cfg); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			updates := make(chan string, 10)
+			done := make(chan error, 1)
+			go func() {
+				done <- ldr.WatchRotation(ctx, cfg, func(updated *SecretsTestConfig, err error) {
+					if err != nil {
+						t.Errorf("reload failed: %v", err)
+					}
+					updates <- updated.SecretVar1
+				})
+			}()
+
+			// Unchanged secrets are not reported
+			time.Sleep(30 * time.Millisecond)
+			select {
+			case value := <-updates:
+				t.Fatalf("unexpected update %q before rotation", value)
+			default:
+			}
+
+			tt.rotate(secret)
+			select {
+			case value := <-updates:
+				if value != "rotated" {
+					t.Errorf("expected the rotated value despite CacheTTL, got %q", value)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("rotation was not reported")
+			}
+
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("expected nil after cancel, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSecretsManagerLoader_Watch_NoSecrets(t *testing.T) {
+	type plainConfig struct {
+		Port int `env:"PORT"`
+	}
+	ldr := &SecretsManagerLoader[plainConfig]{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := ldr.Watch(ctx, func() { t.Error("unexpected change") }); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestSecretsManagerLoader_Watch_InterpolatedSecretID(t *testing.T) {
+	type interpolatedConfig struct {
+		Env        string `env:"ENV" config:"availableAs=ENV"`
+		DBPassword string `secret:"aws=/myapp/${ENV}/db/password"`
+	}
+
+	var mu sync.Mutex
+	var requested []string
+	client := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			requested = append(requested, *params.SecretId)
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("password")}, nil
+		},
+	}
+	ldr := &SecretsManagerLoader[interpolatedConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: client},
+		RefreshInterval: 5 * time.Millisecond,
+	}
+
+	// Before a load has set ENV, the secret cannot be resolved and is not checked
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ldr.Watch(ctx, func() {}); err != nil {
+		t.Fatalf("Watch before loading failed: %v", err)
+	}
+	if len(requested) != 0 {
+		t.Fatalf("expected no unresolved secret to be checked, got %v", requested)
+	}
+
+	cfg := interpolatedConfig{Env: "prod"}
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	mu.Lock()
+	requested = nil
+	mu.Unlock()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ldr.Watch(ctx, func() {}); err != nil {
+		t.Fatalf("Watch after loading failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requested) == 0 {
+		t.Fatal("expected the resolved secret to be checked")
+	}
+	for _, id := range requested {
+		if id != "/myapp/prod/db/password" {
+			t.Errorf("expected /myapp/prod/db/password to be checked, got %q", id)
+		}
+	}
+}
@@ -1,10 +1,19 @@
+//go:build !js && !wasip1 && !tinygo
+
 // Package aws provides helper functions for handling mixed tag scenarios
 // where structs contain both secret tags and other types of tags.
 package aws
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // hasSecretTags checks if the struct has any fields with secret tags
@@ -31,7 +40,10 @@ func hasSecretTags(c interface{}) bool {
 }
 
 // createSecretOnlyStruct creates a new struct containing only fields with secret tags
-func createSecretOnlyStruct(c interface{}) (interface{}, map[string]int, error) {
+// whose region option is region, or that have none when region is empty. The region
+// option, which secretfetch does not accept, is removed from the copied tags, and struct
+// fields, which it cannot set, are copied as strings to be unmarshaled as JSON.
+func createSecretOnlyStruct(c interface{}, region string) (interface{}, map[string]int, error) {
 	v := reflect.ValueOf(c)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -49,10 +61,23 @@ func createSecretOnlyStruct(c interface{}) (interface{}, map[string]int, error)
 		if field.PkgPath != "" { // skip unexported fields
 			continue
 		}
-		if field.Tag.Get("secret") != "" {
-			fieldMap[field.Name] = i
-			fields = append(fields, field)
+		tag := field.Tag.Get("secret")
+		if tag == "" {
+			continue
+		}
+		if tagRegion, ok := utils.TagOptionValue(tag, "region"); ok {
+			if tagRegion != region {
+				continue
+			}
+			field.Tag = reflect.StructTag("secret:" + strconv.Quote(removeTagOption(tag, "region")))
+		} else if region != "" {
+			continue
 		}
+		if isNestedStruct(field.Type) {
+			field.Type = reflect.TypeOf("")
+		}
+		fieldMap[field.Name] = i
+		fields = append(fields, field)
 	}
 
 	if len(fields) == 0 {
@@ -66,7 +91,8 @@ func createSecretOnlyStruct(c interface{}) (interface{}, map[string]int, error)
 	return newStruct, fieldMap, nil
 }
 
-// copySecretValues copies values from the temporary struct back to the original struct
+// copySecretValues copies values from the temporary struct back to the original struct,
+// unmarshaling the JSON secrets of struct fields into them.
 func copySecretValues(original, temp interface{}, fieldMap map[string]int) error {
 	origVal := reflect.ValueOf(original)
 	if origVal.Kind() == reflect.Ptr {
@@ -89,10 +115,134 @@ func copySecretValues(original, temp interface{}, fieldMap map[string]int) error
 		}
 
 		origField := origVal.Field(origIndex)
-		if origField.CanSet() && !tempField.IsZero() {
-			origField.Set(tempField)
+		if !origField.CanSet() || tempField.IsZero() {
+			continue
+		}
+		if isNestedStruct(origField.Type()) {
+			if err := setSecretDocument(origField, tempField.String()); err != nil {
+				return fmt.Errorf("field %s: %w", fieldName, err)
+			}
+			continue
 		}
+		origField.Set(tempField)
+	}
+
+	return nil
+}
+
+// isNestedStruct reports whether fields of type t hold several values rather than one
+// parsed from a string: structs, and pointers to structs, that do not implement
+// encoding.TextUnmarshaler. Secret fields of such types are set by unmarshaling a whole
+// JSON secret, such as the host, port, username and password of a database secret.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
 
+// setSecretDocument unmarshals the JSON secret value into v.
+func setSecretDocument(v reflect.Value, value string) error {
+	if err := json.Unmarshal([]byte(value), v.Addr().Interface()); err != nil {
+		return fmt.Errorf("secret is not a JSON object matching %s: %w", v.Type(), err)
+	}
 	return nil
 }
+
+// secretIDs returns the Secrets Manager IDs referenced by `secret:"aws=..."` tags in c.
+func secretIDs(c interface{}) []string {
+	t := reflect.TypeOf(c)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var ids []string
+	for i := 0; i < t.NumField(); i++ {
+		if id, ok := utils.TagOptionValue(t.Field(i).Tag.Get("secret"), "aws"); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// removeTagOption returns tag without its key=value option named option.
+func removeTagOption(tag, option string) string {
+	parts := strings.Split(tag, ",")
+	return strings.Join(slices.DeleteFunc(parts, func(part string) bool {
+		key, _, _ := strings.Cut(strings.TrimSpace(part), "=")
+		return key == option
+	}), ",")
+}
+
+// secretRef is a secret referenced by a `secret:"aws=..."` tag, in the region named by the
+// tag's region option, or in the loader's region when region is empty.
+type secretRef struct {
+	id     string
+	region string
+}
+
+// key returns the key the secret is cached under.
+func (r secretRef) key() string {
+	if r.region == "" {
+		return r.id
+	}
+	return r.region + ":" + r.id
+}
+
+// secretRefs returns the distinct secrets referenced by `secret:"aws=..."` tags in c,
+// sorted by region and ID, with ${VAR} references in the IDs replaced by values. Secrets
+// whose IDs reference a variable missing from values are left out.
+func secretRefs(c interface{}, values map[string]string) []secretRef {
+	t := reflect.TypeOf(c)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var refs []secretRef
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("secret")
+		id, ok := utils.TagOptionValue(tag, "aws")
+		if !ok || id == "" {
+			continue
+		}
+		if id, missing := utils.ExpandVariables(id, values); len(missing) == 0 {
+			region, _ := utils.TagOptionValue(tag, "region")
+			refs = append(refs, secretRef{id: id, region: region})
+		}
+	}
+	slices.SortFunc(refs, func(a, b secretRef) int {
+		if a.region != b.region {
+			return strings.Compare(a.region, b.region)
+		}
+		return strings.Compare(a.id, b.id)
+	})
+	return slices.Compact(refs)
+}
+
+// secretRegions returns the distinct region options of the secret tags in c, sorted, with
+// "" first for tags without one.
+func secretRegions(c interface{}) []string {
+	t := reflect.TypeOf(c)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var regions []string
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("secret"); tag != "" && t.Field(i).IsExported() {
+			region, _ := utils.TagOptionValue(tag, "region")
+			regions = append(regions, region)
+		}
+	}
+	slices.Sort(regions)
+	return slices.Compact(regions)
+}
@@ -1,12 +1,23 @@
-// Package aws provides loaders for AWS-specific configuration sources.
+//go:build !js && !wasip1 && !tinygo
+
 package aws
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/config"
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/crazywolf132/secretfetch"
 	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // SecretsManagerLoader loads configuration values from AWS Secrets Manager.
@@ -14,36 +25,90 @@ import (
 // fields tagged with `secret:"aws=secret-name"`.
 // Unlike secretfetch directly, this loader can handle structs with mixed tag types
 // by only processing fields that have secret tags.
+//
+// A field is fetched from another region than the loader's with the tag's region option,
+// e.g. `secret:"aws=prod/db,region=eu-west-1"`, so a secret replicated for failover can be
+// read from each region it lives in.
+//
+// When AWS denies access, the returned LoaderError wraps an *AccessDeniedError naming the
+// calling identity (looked up once with STS GetCallerIdentity), the denied action and the
+// secret, with Source set to the secret.
+//
+// With a CacheTTL, retrieved secrets are kept in the loader and reused by later loads and
+// by other fields naming the same secret until they expire, so repeated loads do not
+// exhaust the GetSecretValue quota. CacheMaxEntries bounds the cache, CacheStats reports
+// its use and Invalidate drops secrets, e.g. after a rotation.
+//
+// The loader implements loader.Watcher: Watch reports rotated secrets, and WatchRotation
+// passes the updated config to a callback.
+//
+// With DryRun, Load and Prefetch make no AWS calls and leave the config unchanged, and
+// PlanFetches reports the secrets they would read, e.g. to derive an IAM policy in CI.
 type SecretsManagerLoader[T any] struct {
 	SecretFetchOpts *secretfetch.Options
+	STSClient       CallerIdentityAPI // Optional STS client used to identify the caller in access denied errors
+	AWS             ClientConfig      // AWS settings used when SecretFetchOpts is nil
+	EndpointURL     string            // Optional Secrets Manager endpoint, e.g. http://localhost:4566 for LocalStack (overrides AWS.EndpointURL)
+	Clients         *ClientFactory    // Optional factory sharing resolved AWS settings with other loaders, used instead of AWS
+	CacheTTL        time.Duration     // How long retrieved secrets are reused (0 disables caching)
+	CacheMaxEntries int               // Maximum number of cached secrets (0 for no limit)
+	RefreshInterval time.Duration     // How often Watch checks for rotated secrets (defaults to DefaultRefreshInterval)
+	RefreshStage    string            // Optional version stage Watch follows, e.g. "AWSCURRENT", instead of comparing values
+	DryRun          bool              // Make no AWS calls in Load and Prefetch, leaving the config unchanged
+
+	// RegionalClient optionally returns the client for secrets tagged region=..., instead
+	// of one created from the AWS config for that region.
+	RegionalClient func(region string) secretfetch.SecretsManagerClient
+
+	caller  callerCache         // Cached result of GetCallerIdentity
+	cache   *loader.SourceCache // Cache for secret values, set by SetSourceCache
+	secrets ttlCache            // Secrets kept for CacheTTL
+
+	varsMu sync.Mutex        // Guards vars
+	vars   map[string]string // availableAs values of the last load, resolving ${VAR} in the secrets Watch checks
 }
 
 // Load fetches secrets from AWS Secrets Manager for fields with appropriate tags.
 // It handles mixed tag scenarios by only processing fields with secret tags.
 func (s *SecretsManagerLoader[T]) Load(c *T) error {
-	opts := s.SecretFetchOpts
-	if opts == nil {
-		cfg, err := config.LoadDefaultConfig(context.TODO())
-		if err != nil {
-			return &loader.LoaderError{
-				LoaderType: "SecretsManagerLoader",
-				Operation:  "create AWS config",
-				Err:        err,
-			}
-		}
+	return s.LoadContext(context.Background(), c)
+}
 
-		opts = &secretfetch.Options{
-			AWS: &cfg,
-		}
+// LoadContext is like Load, but passes ctx to the AWS calls, so its deadline and
+// cancellation stop a slow or unreachable Secrets Manager.
+func (s *SecretsManagerLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if s.DryRun {
+		return nil
+	}
+	opts, err := s.options(ctx)
+	if err != nil {
+		return err
 	}
 
 	// Check if any fields have secret tags before calling secretfetch
 	if !hasSecretTags(c) {
 		return nil // No secret fields to process
 	}
+	s.setVariables(utils.AvailableAsValues(reflect.ValueOf(c).Elem()))
+
+	// Secrets in other regions are fetched separately with a client for their region
+	for _, region := range secretRegions(c) {
+		if err := s.loadRegion(ctx, c, opts, region); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadRegion fetches the secrets tagged with region, or without a region when it is empty.
+func (s *SecretsManagerLoader[T]) loadRegion(ctx context.Context, c *T, opts *secretfetch.Options, region string) error {
+	opts, err := s.regionOptions(opts, region)
+	if err != nil {
+		return err
+	}
 
 	// Create a temporary struct with only secret-tagged fields
-	tempStruct, fieldMap, err := createSecretOnlyStruct(c)
+	tempStruct, fieldMap, err := createSecretOnlyStruct(c, region)
 	if err != nil {
 		return &loader.LoaderError{
 			LoaderType: "SecretsManagerLoader",
@@ -53,14 +118,391 @@ func (s *SecretsManagerLoader[T]) Load(c *T) error {
 	}
 
 	// Fetch secrets into the temporary struct
-	if err := secretfetch.Fetch(context.Background(), tempStruct, opts); err != nil {
-		return &loader.LoaderError{
+	if err := secretfetch.Fetch(ctx, tempStruct, opts); err != nil {
+		// secretfetch does not wrap client errors, so restore the context's
+		if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+			err = fmt.Errorf("%w (%v)", ctxErr, err)
+		}
+		loaderErr := &loader.LoaderError{
 			LoaderType: "SecretsManagerLoader",
 			Operation:  "fetch secrets",
-			Err:        err,
+			Err:        diagnoseAccessDenied(ctx, err, "secretsmanager:GetSecretValue", strings.Join(secretIDs(tempStruct), ", "), s.callerIdentity),
+		}
+		var denied *AccessDeniedError
+		if errors.As(loaderErr.Err, &denied) {
+			loaderErr.Source = denied.Resource
 		}
+		return loaderErr
 	}
 
 	// Copy values back to the original struct
-	return copySecretValues(c, tempStruct, fieldMap)
+	if err := copySecretValues(c, tempStruct, fieldMap); err != nil {
+		return &loader.LoaderError{
+			LoaderType: "SecretsManagerLoader",
+			Operation:  "parse secret",
+			Err:        err,
+		}
+	}
+	return nil
+}
+
+// options returns SecretFetchOpts, or options using the AWS config from AWS when unset.
+func (s *SecretsManagerLoader[T]) options(ctx context.Context) (*secretfetch.Options, error) {
+	if s.SecretFetchOpts != nil {
+		return s.SecretFetchOpts, nil
+	}
+
+	cfg, err := loadConfig(ctx, s.Clients, s.AWS)
+	if err != nil {
+		return nil, &loader.LoaderError{
+			LoaderType: "SecretsManagerLoader",
+			Operation:  "create AWS config",
+			Err:        err,
+		}
+	}
+	opts := &secretfetch.Options{AWS: &cfg}
+	if s.EndpointURL != "" {
+		opts.SecretsManager = secretsmanager.NewFromConfig(cfg, s.clientOptions(""))
+	}
+	return opts, nil
+}
+
+// regionOptions returns the options secrets in region are fetched with: opts itself for
+// the loader's region without caching, or otherwise a copy whose client is the region's,
+// reading through the CacheTTL cache and the source cache, as configured.
+func (s *SecretsManagerLoader[T]) regionOptions(opts *secretfetch.Options, region string) (*secretfetch.Options, error) {
+	if region == "" && s.cache == nil && s.CacheTTL <= 0 {
+		return opts, nil
+	}
+
+	client, err := s.regionClient(opts, region)
+	if err != nil {
+		return nil, err
+	}
+	if s.CacheTTL > 0 {
+		client = &ttlSecretsClient{client: client, region: region, ttl: s.CacheTTL, max: s.CacheMaxEntries, cache: &s.secrets}
+	}
+	if s.cache != nil {
+		client = &cachedSecretsClient{client: client, region: region, cache: s.cache}
+	}
+	cfg := opts.AWS
+	if region != "" && cfg != nil {
+		regional := cfg.Copy()
+		regional.Region = region
+		cfg = &regional
+	}
+	return &secretfetch.Options{
+		AWS:              cfg,
+		Validators:       opts.Validators,
+		Transformers:     opts.Transformers,
+		CacheDuration:    opts.CacheDuration,
+		PreloadARNs:      opts.PreloadARNs,
+		SecretsManager:   client,
+		OnSecretAccess:   opts.OnSecretAccess,
+		MetricsCollector: opts.MetricsCollector,
+		SecureCache:      opts.SecureCache,
+	}, nil
+}
+
+// regionClient returns the client for secrets in region: secretsClient's for the loader's
+// region, and otherwise RegionalClient's or one created from opts.AWS for the region.
+func (s *SecretsManagerLoader[T]) regionClient(opts *secretfetch.Options, region string) (secretfetch.SecretsManagerClient, error) {
+	if region == "" {
+		return s.secretsClient(opts)
+	}
+	if s.RegionalClient != nil {
+		return s.RegionalClient(region), nil
+	}
+	if opts.AWS == nil {
+		return nil, &loader.LoaderError{
+			LoaderType: "SecretsManagerLoader",
+			Operation:  "create Secrets Manager client",
+			Source:     region,
+			Err:        fmt.Errorf("SecretFetchOpts.AWS is nil"),
+		}
+	}
+	return secretsmanager.NewFromConfig(*opts.AWS, s.clientOptions(region)), nil
+}
+
+// secretsClient returns opts.SecretsManager, or a client created from opts.AWS when unset.
+func (s *SecretsManagerLoader[T]) secretsClient(opts *secretfetch.Options) (secretfetch.SecretsManagerClient, error) {
+	if opts.SecretsManager != nil {
+		return opts.SecretsManager, nil
+	}
+	if opts.AWS == nil {
+		return nil, &loader.LoaderError{
+			LoaderType: "SecretsManagerLoader",
+			Operation:  "create Secrets Manager client",
+			Err:        fmt.Errorf("SecretFetchOpts.AWS is nil"),
+		}
+	}
+	return secretsmanager.NewFromConfig(*opts.AWS, s.clientOptions("")), nil
+}
+
+// clientOptions returns the options of Secrets Manager clients created for region, or the
+// loader's region when empty, applying EndpointURL.
+func (s *SecretsManagerLoader[T]) clientOptions(region string) func(*secretsmanager.Options) {
+	return func(o *secretsmanager.Options) {
+		if region != "" {
+			o.Region = region
+		}
+		if s.EndpointURL != "" {
+			o.BaseEndpoint = awsv2.String(s.EndpointURL)
+		}
+	}
+}
+
+// PlanFetches returns a fetch for the secret referenced by each `secret:"aws=..."` tag,
+// prefixed by the tag's region option when it has one, without calling AWS.
+func (s *SecretsManagerLoader[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	var fetches []loader.Fetch
+	for _, field := range fields {
+		tag := field.Tag.Get("secret")
+		id, ok := utils.TagOptionValue(tag, "aws")
+		if !ok || id == "" {
+			continue
+		}
+		region, _ := utils.TagOptionValue(tag, "region")
+		fetches = append(fetches, loader.Fetch{
+			LoaderType: "SecretsManagerLoader",
+			Field:      field.Name,
+			Source:     secretRef{id: id, region: region}.key(),
+			Action:     "secretsmanager:GetSecretValue",
+		})
+	}
+	return fetches
+}
+
+// SetSourceCache sets the cache secret values are read from and stored in.
+func (s *SecretsManagerLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	s.cache = cache
+}
+
+// Prefetch retrieves the secret referenced by each `secret:"aws=..."` tag into the source
+// cache. Secrets already cached are not retrieved again.
+func (s *SecretsManagerLoader[T]) Prefetch(ctx context.Context, fields []loader.Field) error {
+	if s.cache == nil || s.DryRun {
+		return nil
+	}
+
+	var opts *secretfetch.Options
+	clients := make(map[string]secretfetch.SecretsManagerClient)
+	var errs []error
+	for _, field := range fields {
+		tag := field.Tag.Get("secret")
+		secretID, ok := utils.TagOptionValue(tag, "aws")
+		if !ok || secretID == "" {
+			continue
+		}
+
+		region, _ := utils.TagOptionValue(tag, "region")
+		client, ok := clients[region]
+		if !ok {
+			var err error
+			if opts == nil {
+				if opts, err = s.options(ctx); err != nil {
+					return err
+				}
+			}
+			regional, err := s.regionOptions(opts, region)
+			if err != nil {
+				return err
+			}
+			client = regional.SecretsManager
+			clients[region] = client
+		}
+
+		if _, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &secretID}); err != nil {
+			errs = append(errs, &loader.LoaderError{
+				LoaderType: "SecretsManagerLoader",
+				Operation:  "prefetch secret",
+				Source:     secretID,
+				Err:        diagnoseAccessDenied(ctx, err, "secretsmanager:GetSecretValue", secretID, s.callerIdentity),
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cachedSecretsClient serves GetSecretValue from a source cache, retrieving and storing
+// secrets that are not cached yet. Concurrent requests for the same secret share one call.
+type cachedSecretsClient struct {
+	client secretfetch.SecretsManagerClient
+	region string // Region of the client, or "" for the loader's
+	cache  *loader.SourceCache
+}
+
+// GetSecretValue returns the cached value of the secret, retrieving it on a miss.
+func (c *cachedSecretsClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	ref := secretRef{id: awsv2.ToString(params.SecretId), region: c.region}
+	value, err := c.cache.Fetch("secretsmanager:"+ref.key(), func() (string, error) {
+		out, err := c.client.GetSecretValue(ctx, params, optFns...)
+		if err != nil {
+			return "", err
+		}
+		if out.SecretString == nil && out.SecretBinary == nil {
+			return "", fmt.Errorf("no secret value found for %s", awsv2.ToString(params.SecretId))
+		}
+		if out.SecretString != nil {
+			return *out.SecretString, nil
+		}
+		return string(out.SecretBinary), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &secretsmanager.GetSecretValueOutput{Name: params.SecretId, SecretString: &value}, nil
+}
+
+// CacheStats returns the hits, misses and evictions of the CacheTTL cache and the number
+// of secrets it holds.
+func (s *SecretsManagerLoader[T]) CacheStats() CacheStats {
+	return s.secrets.snapshot()
+}
+
+// Invalidate drops the named secrets from the CacheTTL cache, in every region they are
+// tagged with, or every secret when none are named, so the next load retrieves them again.
+func (s *SecretsManagerLoader[T]) Invalidate(secretIDs ...string) {
+	if len(secretIDs) == 0 {
+		s.secrets.invalidate()
+		return
+	}
+	keys := slices.Clone(secretIDs)
+	for _, ref := range secretRefs((*T)(nil), s.variables()) {
+		if ref.region != "" && slices.Contains(secretIDs, ref.id) {
+			keys = append(keys, ref.key())
+		}
+	}
+	s.secrets.invalidate(keys...)
+}
+
+// ttlSecretsClient serves GetSecretValue from a loader's CacheTTL cache, retrieving and
+// storing secrets that are not cached or have expired. Requests for a specific version or
+// stage are not cached.
+type ttlSecretsClient struct {
+	client secretfetch.SecretsManagerClient
+	region string        // Region of the client, or "" for the loader's
+	ttl    time.Duration // How long secrets are kept
+	max    int           // Maximum number of cached secrets, or 0
+	cache  *ttlCache
+}
+
+// GetSecretValue returns the cached value of the secret, retrieving it on a miss.
+func (c *ttlSecretsClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	if params.VersionId != nil || params.VersionStage != nil {
+		return c.client.GetSecretValue(ctx, params, optFns...)
+	}
+
+	key := secretRef{id: awsv2.ToString(params.SecretId), region: c.region}.key()
+	now := time.Now()
+	if entry, ok := c.cache.get(key, now); ok {
+		return &secretsmanager.GetSecretValueOutput{Name: params.SecretId, SecretString: awsv2.String(entry.value)}, nil
+	}
+
+	out, err := c.client.GetSecretValue(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case out.SecretString != nil:
+		c.cache.set(key, *out.SecretString, true, now, c.ttl, c.max)
+	case out.SecretBinary != nil:
+		c.cache.set(key, string(out.SecretBinary), true, now, c.ttl, c.max)
+	}
+	return out, nil
+}
+
+// SecretDescriber is the subset of the Secrets Manager client used by VerifySources.
+// A SecretFetchOpts.SecretsManager client implementing it is used directly.
+type SecretDescriber interface {
+	DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+}
+
+// VerifySources checks that the secret referenced by each `secret:"aws=..."` tag exists
+// and can be described, without retrieving its value. Secrets scheduled for deletion are
+// reported as failures.
+func (s *SecretsManagerLoader[T]) VerifySources(ctx context.Context, fields []loader.Field) []loader.SourceCheck {
+	var checks []loader.SourceCheck
+	clients := make(map[string]SecretDescriber)
+
+	for _, field := range fields {
+		tag := field.Tag.Get("secret")
+		secretID, ok := utils.TagOptionValue(tag, "aws")
+		if !ok || secretID == "" {
+			continue
+		}
+
+		region, _ := utils.TagOptionValue(tag, "region")
+		check := loader.SourceCheck{LoaderType: "SecretsManagerLoader", Field: field.Name, Source: secretID}
+		client, ok := clients[region]
+		if !ok {
+			var err error
+			if client, err = s.describer(ctx, region); err != nil {
+				check.Err = err
+				checks = append(checks, check)
+				continue
+			}
+			clients[region] = client
+		}
+
+		out, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{SecretId: &secretID})
+		if err != nil {
+			check.Err = diagnoseAccessDenied(ctx, err, "secretsmanager:DescribeSecret", secretID, s.callerIdentity)
+		} else if out.DeletedDate != nil {
+			check.Err = fmt.Errorf("secret is scheduled for deletion on %s", out.DeletedDate.Format(time.RFC3339))
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// describer returns the client used to describe secrets in region, or in the loader's
+// region when it is empty.
+func (s *SecretsManagerLoader[T]) describer(ctx context.Context, region string) (SecretDescriber, error) {
+	opts, err := s.options(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := opts.SecretsManager
+	if region != "" {
+		client = nil
+		if s.RegionalClient != nil {
+			client = s.RegionalClient(region)
+		}
+	}
+	if describer, ok := client.(SecretDescriber); ok {
+		return describer, nil
+	}
+	if opts.AWS == nil {
+		return nil, fmt.Errorf("SecretFetchOpts.AWS is nil")
+	}
+	return secretsmanager.NewFromConfig(*opts.AWS, s.clientOptions(region)), nil
+}
+
+// CallerIdentityAPI is the subset of the STS client used to identify the caller.
+type CallerIdentityAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// callerIdentity returns the ARN of the calling identity, looking it up at most once.
+func (s *SecretsManagerLoader[T]) callerIdentity(ctx context.Context) (string, error) {
+	return s.caller.get(func() (string, error) {
+		client := s.STSClient
+		if client == nil {
+			opts, err := s.options(ctx)
+			if err != nil {
+				return "", err
+			}
+			if opts.AWS == nil {
+				return "", fmt.Errorf("SecretFetchOpts.AWS is nil")
+			}
+			client = sts.NewFromConfig(*opts.AWS)
+		}
+
+		out, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return "", err
+		}
+		return awsv2.ToString(out.Arn), nil
+	})
 }
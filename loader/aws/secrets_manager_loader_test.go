@@ -1,12 +1,18 @@
+//go:build !js && !wasip1 && !tinygo
+
 package aws
 
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
 	"github.com/crazywolf132/secretfetch"
 	"github.com/gymshark/go-easy-config/loader"
 )
@@ -164,3 +170,419 @@ func TestSecretsManagerLoader_ErrorWrapping(t *testing.T) {
 		})
 	}
 }
+
+type mockSecretDescriberClient struct {
+	mockSecretsManagerClient
+	describeSecretFn func(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+}
+
+func (m *mockSecretDescriberClient) DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+	return m.describeSecretFn(ctx, params, optFns...)
+}
+
+func TestSecretsManagerLoader_VerifySources(t *testing.T) {
+	deleted := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	mockClient := &mockSecretDescriberClient{
+		describeSecretFn: func(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+			switch *params.SecretId {
+			case "prod/db":
+				return &secretsmanager.DescribeSecretOutput{Name: params.SecretId}, nil
+			case "prod/old":
+				return &secretsmanager.DescribeSecretOutput{Name: params.SecretId, DeletedDate: &deleted}, nil
+			}
+			return nil, errors.New("ResourceNotFoundException")
+		},
+	}
+
+	ldr := &SecretsManagerLoader[SecretsTestConfig]{
+		SecretFetchOpts: &secretfetch.Options{
+			AWS:            &aws.Config{Region: "us-east-1"},
+			SecretsManager: mockClient,
+		},
+	}
+
+	checks := ldr.VerifySources(context.Background(), []loader.Field{
+		{Name: "DB", Tag: `secret:"aws=prod/db"`},
+		{Name: "Old", Tag: `secret:"aws=prod/old"`},
+		{Name: "Missing", Tag: `secret:"aws=prod/missing,required"`},
+		{Name: "Plain", Tag: `env:"PLAIN"`},
+	})
+
+	if len(checks) != 3 {
+		t.Fatalf("expected 3 checks, got %v", checks)
+	}
+	if checks[0].Err != nil || checks[0].Source != "prod/db" || checks[0].LoaderType != "SecretsManagerLoader" {
+		t.Errorf("expected passing check for prod/db, got %v", checks[0])
+	}
+	if checks[1].Err == nil {
+		t.Error("expected secret scheduled for deletion to fail")
+	}
+	if checks[2].Err == nil || checks[2].Source != "prod/missing" {
+		t.Errorf("expected missing secret to fail, got %v", checks[2])
+	}
+}
+
+type mockSTSClient struct {
+	calls int
+}
+
+func (m *mockSTSClient) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	m.calls++
+	return &sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123456789012:user/ci")}, nil
+}
+
+func TestSecretsManagerLoader_AccessDeniedDiagnosis(t *testing.T) {
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "access denied"}
+		},
+	}
+	stsClient := &mockSTSClient{}
+	ldr := &SecretsManagerLoader[SecretsTestConfig]{
+		SecretFetchOpts: &secretfetch.Options{
+			AWS:            &aws.Config{Region: "us-east-1"},
+			SecretsManager: mockClient,
+		},
+		STSClient: stsClient,
+	}
+
+	for i := 0; i < 2; i++ {
+		err := ldr.Load(&SecretsTestConfig{})
+
+		var loaderErr *loader.LoaderError
+		var denied *AccessDeniedError
+		if !errors.As(err, &loaderErr) || !errors.As(err, &denied) {
+			t.Fatalf("expected LoaderError wrapping AccessDeniedError, got %v", err)
+		}
+		if denied.Identity != "arn:aws:iam::123456789012:user/ci" || denied.Action != "secretsmanager:GetSecretValue" || denied.Resource != "test-secret" {
+			t.Errorf("unexpected diagnosis: %+v", denied)
+		}
+		if loaderErr.Source != "test-secret" {
+			t.Errorf("expected Source 'test-secret', got '%s'", loaderErr.Source)
+		}
+	}
+	if stsClient.calls != 1 {
+		t.Errorf("expected caller identity to be looked up once, got %d calls", stsClient.calls)
+	}
+}
+
+func TestSecretsManagerLoader_Prefetch(t *testing.T) {
+	var calls int
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			calls++
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("value-of-" + *params.SecretId)}, nil
+		},
+	}
+	opts := &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: mockClient}
+	cache := loader.NewSourceCache()
+
+	prefetcher := &SecretsManagerLoader[SecretsTestConfig]{SecretFetchOpts: opts}
+	prefetcher.SetSourceCache(cache)
+	fields := []loader.Field{{Name: "SecretVar1", Tag: `secret:"aws=test-secret"`}, {Name: "Plain", Tag: `env:"PLAIN"`}}
+	if err := prefetcher.Prefetch(context.Background(), fields); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+
+	// A loader for another struct sharing the cache reads the prefetched secret
+	type otherConfig struct {
+		Secret string `secret:"aws=test-secret"`
+	}
+	other := &SecretsManagerLoader[otherConfig]{SecretFetchOpts: opts}
+	other.SetSourceCache(cache)
+	var cfg otherConfig
+	if err := other.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Secret != "value-of-test-secret" {
+		t.Errorf("expected cached secret, got %q", cfg.Secret)
+	}
+	if calls != 1 {
+		t.Errorf("expected the secret to be retrieved once, got %d calls", calls)
+	}
+}
+
+func TestSecretsManagerLoader_Prefetch_Error(t *testing.T) {
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return nil, errors.New("ResourceNotFoundException")
+		},
+	}
+	ldr := &SecretsManagerLoader[SecretsTestConfig]{SecretFetchOpts: &secretfetch.Options{SecretsManager: mockClient}}
+	ldr.SetSourceCache(loader.NewSourceCache())
+
+	err := ldr.Prefetch(context.Background(), []loader.Field{{Name: "SecretVar1", Tag: `secret:"aws=missing"`}})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "prefetch secret" || loaderErr.Source != "missing" {
+		t.Errorf("expected prefetch LoaderError for the secret, got %v", err)
+	}
+}
+
+func TestSecretsManagerLoader_CacheTTL(t *testing.T) {
+	calls := make(map[string]int)
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			calls[*params.SecretId]++
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("value-of-" + *params.SecretId)}, nil
+		},
+	}
+	type sharedConfig struct {
+		Password string `secret:"aws=db"`
+		Copy     string `secret:"aws=db"`
+		APIKey   string `secret:"aws=api"`
+	}
+	ldr := &SecretsManagerLoader[sharedConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: mockClient},
+		CacheTTL:        time.Minute,
+	}
+
+	for i := 0; i < 3; i++ {
+		var cfg sharedConfig
+		if err := ldr.Load(&cfg); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.Password != "value-of-db" || cfg.Copy != "value-of-db" || cfg.APIKey != "value-of-api" {
+			t.Fatalf("unexpected config %+v", cfg)
+		}
+	}
+	if calls["db"] != 1 || calls["api"] != 1 {
+		t.Errorf("expected each secret to be retrieved once, got %v", calls)
+	}
+	if stats := ldr.CacheStats(); stats.Misses != 2 || stats.Hits != 7 || stats.Entries != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	ldr.Invalidate("db")
+	var cfg sharedConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if calls["db"] != 2 || calls["api"] != 1 {
+		t.Errorf("expected only the invalidated secret to be retrieved again, got %v", calls)
+	}
+
+	ldr.Invalidate()
+	if stats := ldr.CacheStats(); stats.Entries != 0 {
+		t.Errorf("expected an empty cache after Invalidate(), got %+v", stats)
+	}
+}
+
+func TestSecretsManagerLoader_CacheMaxEntries(t *testing.T) {
+	var calls int
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			calls++
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("value")}, nil
+		},
+	}
+	type threeSecrets struct {
+		A string `secret:"aws=a"`
+		B string `secret:"aws=b"`
+		C string `secret:"aws=c"`
+	}
+	ldr := &SecretsManagerLoader[threeSecrets]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: mockClient},
+		CacheTTL:        time.Minute,
+		CacheMaxEntries: 2,
+	}
+
+	var cfg threeSecrets
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if stats := ldr.CacheStats(); stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("expected the cache to hold 2 secrets after 1 eviction, got %+v", stats)
+	}
+}
+
+func TestSecretsManagerLoader_RegionOption(t *testing.T) {
+	calls := make(map[string]int)
+	regional := func(region string) *mockSecretsManagerClient {
+		return &mockSecretsManagerClient{
+			getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+				calls[region+"/"+*params.SecretId]++
+				return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(*params.SecretId + "@" + region)}, nil
+			},
+		}
+	}
+	type failoverConfig struct {
+		Primary  string `secret:"aws=prod/db"`
+		Replica  string `secret:"aws=prod/db,region=eu-west-1"`
+		Required string `secret:"aws=prod/api,region=eu-west-1,required"`
+		Plain    string `env:"PLAIN"`
+	}
+	ldr := &SecretsManagerLoader[failoverConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: regional("us-east-1")},
+		RegionalClient:  func(region string) secretfetch.SecretsManagerClient { return regional(region) },
+		CacheTTL:        time.Minute,
+	}
+
+	for i := 0; i < 2; i++ {
+		var cfg failoverConfig
+		if err := ldr.Load(&cfg); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.Primary != "prod/db@us-east-1" || cfg.Replica != "prod/db@eu-west-1" || cfg.Required != "prod/api@eu-west-1" {
+			t.Fatalf("unexpected config %+v", cfg)
+		}
+	}
+	// Each region's copy is cached separately
+	if len(calls) != 3 || calls["us-east-1/prod/db"] != 1 || calls["eu-west-1/prod/db"] != 1 {
+		t.Errorf("unexpected calls %v", calls)
+	}
+
+	ldr.Invalidate("prod/db")
+	var cfg failoverConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if calls["us-east-1/prod/db"] != 2 || calls["eu-west-1/prod/db"] != 2 || calls["eu-west-1/prod/api"] != 1 {
+		t.Errorf("expected both regions of the invalidated secret to be retrieved again, got %v", calls)
+	}
+}
+
+func TestSecretsManagerLoader_RegionOption_VerifySources(t *testing.T) {
+	describer := func(region string) *mockSecretDescriberClient {
+		return &mockSecretDescriberClient{
+			describeSecretFn: func(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+				if region != "eu-west-1" {
+					return nil, errors.New("ResourceNotFoundException")
+				}
+				return &secretsmanager.DescribeSecretOutput{Name: params.SecretId}, nil
+			},
+		}
+	}
+	ldr := &SecretsManagerLoader[SecretsTestConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: describer("us-east-1")},
+		RegionalClient:  func(region string) secretfetch.SecretsManagerClient { return describer(region) },
+	}
+
+	checks := ldr.VerifySources(context.Background(), []loader.Field{
+		{Name: "Replica", Tag: `secret:"aws=prod/db,region=eu-west-1"`},
+		{Name: "Primary", Tag: `secret:"aws=prod/db"`},
+	})
+	if len(checks) != 2 || checks[0].Err != nil || checks[1].Err == nil {
+		t.Errorf("expected only the eu-west-1 secret to be found, got %v", checks)
+	}
+}
+
+func TestCreateSecretOnlyStruct_Region(t *testing.T) {
+	type regionConfig struct {
+		Local  string `secret:"aws=a,required"`
+		Remote string `secret:"aws=b,region=eu-west-1,required"`
+	}
+	temp, fieldMap, err := createSecretOnlyStruct(&regionConfig{}, "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fieldMap["Remote"]; !ok || len(fieldMap) != 1 {
+		t.Fatalf("expected only Remote, got %v", fieldMap)
+	}
+	field, _ := reflect.TypeOf(temp).Elem().FieldByName("Remote")
+	if tag := field.Tag.Get("secret"); tag != "aws=b,required" {
+		t.Errorf("expected the region option to be removed, got %q", tag)
+	}
+	if regions := secretRegions(&regionConfig{}); !reflect.DeepEqual(regions, []string{"", "eu-west-1"}) {
+		t.Errorf("unexpected regions %q", regions)
+	}
+}
+
+func TestSecretsManagerLoader_LoadContext(t *testing.T) {
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("test-secret-value")}, nil
+		},
+	}
+	ldr := &SecretsManagerLoader[SecretsTestConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: mockClient},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ldr.LoadContext(ctx, &SecretsTestConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	var cfg SecretsTestConfig
+	if err := ldr.LoadContext(context.Background(), &cfg); err != nil || cfg.SecretVar1 != "test-secret-value" {
+		t.Errorf("LoadContext() = %+v, %v", cfg, err)
+	}
+}
+
+func TestSecretsManagerLoader_StructField(t *testing.T) {
+	type dbSecret struct {
+		Host     string `json:"host"`
+		Port     int    `json:"port"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	type dbConfig struct {
+		DB      dbSecret  `secret:"aws=myapp/db"`
+		Replica *dbSecret `secret:"aws=myapp/replica"`
+		Broken  dbSecret  `secret:"aws=myapp/broken"`
+	}
+	secrets := map[string]string{
+		"myapp/db":      `{"host":"db.internal","port":5432,"username":"app","password":"s3cret"}`,
+		"myapp/replica": `{"host":"replica.internal","port":5433}`,
+		"myapp/broken":  `not json`,
+	}
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(secrets[*params.SecretId])}, nil
+		},
+	}
+	ldr := &SecretsManagerLoader[dbConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: mockClient},
+	}
+
+	var cfg dbConfig
+	err := ldr.Load(&cfg)
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "parse secret" {
+		t.Fatalf("expected a parse secret error for Broken, got %v", err)
+	}
+
+	secrets["myapp/broken"] = `{"host":"broken.internal"}`
+	cfg = dbConfig{}
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := (dbSecret{Host: "db.internal", Port: 5432, Username: "app", Password: "s3cret"}); cfg.DB != want {
+		t.Errorf("DB = %+v, want %+v", cfg.DB, want)
+	}
+	if cfg.Replica == nil || cfg.Replica.Host != "replica.internal" || cfg.Replica.Port != 5433 {
+		t.Errorf("unexpected Replica %+v", cfg.Replica)
+	}
+}
+
+func TestSecretsManagerLoader_DryRun(t *testing.T) {
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFn: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			t.Error("no secret should be read in dry-run mode")
+			return nil, errors.New("unexpected call")
+		},
+	}
+	ldr := &SecretsManagerLoader[SecretsTestConfig]{
+		SecretFetchOpts: &secretfetch.Options{AWS: &aws.Config{Region: "us-east-1"}, SecretsManager: mockClient},
+		DryRun:          true,
+	}
+
+	cfg := SecretsTestConfig{SecretVar1: "unchanged"}
+	if err := ldr.Load(&cfg); err != nil || cfg.SecretVar1 != "unchanged" {
+		t.Fatalf("Load() = %+v, %v; want the config unchanged", cfg, err)
+	}
+	fetches := ldr.PlanFetches([]loader.Field{
+		{Name: "Primary", Tag: `secret:"aws=prod/db"`},
+		{Name: "Replica", Tag: `secret:"aws=prod/db,region=eu-west-1"`},
+		{Name: "Plain", Tag: `env:"PLAIN"`},
+	})
+	want := []loader.Fetch{
+		{LoaderType: "SecretsManagerLoader", Field: "Primary", Source: "prod/db", Action: "secretsmanager:GetSecretValue"},
+		{LoaderType: "SecretsManagerLoader", Field: "Replica", Source: "eu-west-1:prod/db", Action: "secretsmanager:GetSecretValue"},
+	}
+	if !reflect.DeepEqual(fetches, want) {
+		t.Errorf("PlanFetches() = %v, want %v", fetches, want)
+	}
+}
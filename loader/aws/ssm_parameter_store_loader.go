@@ -1,25 +1,572 @@
+//go:build !js && !wasip1 && !tinygo
+
 package aws
 
 import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/gymshark/go-easy-config/loader"
 	"github.com/ianlopshire/go-ssm-config"
 )
 
 // SSMParameterStoreLoader loads configuration from AWS Systems Manager Parameter Store.
-// It uses the go-ssm-config library to fetch parameters based on struct tags.
+// It uses the go-ssm-config library to map parameters onto struct tags.
+//
+// Parameters are fetched with GetParameters in batches of ten, the most the API accepts per
+// call, or with one paginated GetParametersByPath walk of Path when ByPath is set, which
+// needs fewer calls for large structs. With a CacheTTL, fetched parameters, and names that
+// were not found, are reused by later loads until they expire, so frequent reloads do not
+// cause throttling.
+//
+// A tag may select a parameter version or label after a #, e.g. `ssm:"db/password#3"` or
+// `ssm:"db/password#blue"`, to target a staged rollout. Selected parameters are always
+// fetched by name. SecureString parameters are decrypted unless NoDecryption is set.
+//
+// With ExactNames, `ssm` tags are full parameter names, e.g. `ssm:"/shared/db/host"`, for
+// parameters that do not share a hierarchy. Path then only sets where ByPath walks.
+//
+// A struct field tagged with a name, e.g. `ssm:"db"`, maps the hierarchy below that name
+// onto its fields: each field reads the parameter named by its own `ssm` tag, or by its
+// lower-cased field name, below db, so `/myapp/db/host` sets DB.Host. Nested structs map
+// deeper levels the same way, and fields tagged `ssm:"-"` are skipped.
+//
+// When AWS denies access, the returned LoaderError wraps an *AccessDeniedError naming the
+// calling identity (looked up once with STS GetCallerIdentity), the denied action and the
+// parameter.
+//
+// With DryRun, Load and Prefetch make no AWS calls and leave the config unchanged, and
+// PlanFetches reports the parameters they would read.
 type SSMParameterStoreLoader[T any] struct {
-	Path string // Base path for parameter lookup in Parameter Store
+	Path         string          // Base path for parameter lookup in Parameter Store
+	Client       ssmiface.SSMAPI // Optional SSM client (defaults to one created from AWS)
+	STSClient    stsiface.STSAPI // Optional STS client used to identify the caller in access denied errors
+	AWS          ClientConfig    // AWS settings for the clients created when Client or STSClient is nil
+	EndpointURL  string          // Optional SSM endpoint, e.g. http://localhost:4566 for LocalStack (overrides AWS.EndpointURL)
+	Clients      *ClientFactory  // Optional factory sharing resolved AWS settings with other loaders, used instead of AWS
+	ByPath       bool            // Fetch every parameter below Path with GetParametersByPath (needs ssm:GetParametersByPath)
+	ExactNames   bool            // Treat ssm tags as full parameter names rather than names below Path
+	NoDecryption bool            // Return SecureString parameters encrypted rather than decrypting them
+	CacheTTL     time.Duration   // How long fetched parameters are reused by later loads (0 disables caching)
+	DryRun       bool            // Make no AWS calls in Load and Prefetch, leaving the config unchanged
+
+	caller callerCache         // Cached result of GetCallerIdentity
+	cache  *loader.SourceCache // Cache for parameter values, set by SetSourceCache
+	values ttlCache            // Parameters kept for CacheTTL
 }
 
 // Load fetches parameters from SSM Parameter Store for fields with appropriate tags.
 func (s *SSMParameterStoreLoader[T]) Load(c *T) error {
-	if err := ssmconfig.Process(s.Path, c); err != nil {
+	return s.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the AWS calls, so its deadline and
+// cancellation stop a slow or unreachable Parameter Store.
+func (s *SSMParameterStoreLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if s.DryRun {
+		return nil
+	}
+	client, err := s.client()
+	if err == nil {
+		if s.cache != nil {
+			client = &cachedSSMClient{SSMAPI: client, cache: s.cache}
+		}
+		batching := &batchingSSMClient{SSMAPI: client, ctx: ctx, path: s.Path, byPath: s.ByPath, decrypt: !s.NoDecryption, ttl: s.CacheTTL, cache: &s.values}
+		err = s.process(&ssmconfig.Provider{SSM: batching}, c)
+	}
+	if err != nil {
+		action := "ssm:GetParameters"
+		if s.ByPath {
+			action = "ssm:GetParametersByPath"
+		}
 		return &loader.LoaderError{
 			LoaderType: "SSMParameterStoreLoader",
 			Operation:  "fetch parameters",
-			Source:     s.Path,
-			Err:        err,
+			Source:     s.source(),
+			Err:        diagnoseAccessDenied(ctx, err, action, s.source(), s.callerIdentity),
+		}
+	}
+	return nil
+}
+
+// process sets the fields of c from the provider. go-ssm-config only sets tagged fields of
+// the struct it is given, so the fields of nested structs are flattened into a temporary
+// struct holding every parameter's field, whose values are copied back afterwards.
+func (s *SSMParameterStoreLoader[T]) process(provider *ssmconfig.Provider, c *T) error {
+	fields := ssmFields(reflect.TypeOf(c).Elem())
+	if !slices.ContainsFunc(fields, func(f ssmField) bool { return len(f.index) > 1 }) {
+		return provider.Process(s.namePath(), c)
+	}
+
+	structFields := make([]reflect.StructField, len(fields))
+	for i, f := range fields {
+		tag := "ssm:" + strconv.Quote(f.name)
+		for _, key := range []string{"default", "required"} {
+			if value, ok := f.field.Tag.Lookup(key); ok {
+				tag += " " + key + ":" + strconv.Quote(value)
+			}
+		}
+		structFields[i] = reflect.StructField{Name: fmt.Sprintf("F%d", i), Type: f.field.Type, Tag: reflect.StructTag(tag)}
+	}
+	v := reflect.ValueOf(c).Elem()
+	temp := reflect.New(reflect.StructOf(structFields)).Elem()
+	for i, f := range fields {
+		// Fields of nil pointers start from zero values
+		if field, err := v.FieldByIndexErr(f.index); err == nil {
+			temp.Field(i).Set(field)
+		}
+	}
+
+	if err := provider.Process(s.namePath(), temp.Addr().Interface()); err != nil {
+		return err
+	}
+	for i, f := range fields {
+		if value := temp.Field(i); !value.IsZero() {
+			fieldByIndexAlloc(v, f.index).Set(value)
+		} else if field, err := v.FieldByIndexErr(f.index); err == nil {
+			field.Set(value)
+		}
+	}
+	return nil
+}
+
+// ssmField is a field set from a parameter.
+type ssmField struct {
+	path  string              // Field path, e.g. "DB.Host"
+	name  string              // Parameter name relative to the loader's namePath
+	index []int               // Index sequence of the field, as for reflect.Value.FieldByIndex
+	field reflect.StructField // The field, with the tags of the field of T for top-level fields
+}
+
+// ssmFields returns the fields of t set from parameters: the fields with an `ssm` tag,
+// and the fields of tagged nested structs.
+func ssmFields(t reflect.Type) []ssmField {
+	var fields []ssmField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name := field.Tag.Get("ssm"); name != "" && field.IsExported() {
+			fields = appendSSMFields(fields, field, field.Name, name, []int{i})
+		}
+	}
+	return fields
+}
+
+// appendSSMFields appends field, read from the parameter name, to fields, or when field is
+// a nested struct, its fields, read from the parameters below name.
+func appendSSMFields(fields []ssmField, field reflect.StructField, fieldPath, name string, index []int) []ssmField {
+	if !isNestedStruct(field.Type) {
+		return append(fields, ssmField{path: fieldPath, name: name, index: index, field: field})
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sub := t.Field(i)
+		subName := sub.Tag.Get("ssm")
+		if !sub.IsExported() || subName == "-" {
+			continue
+		}
+		if subName == "" {
+			subName = strings.ToLower(sub.Name)
+		}
+		fields = appendSSMFields(fields, sub, fieldPath+"."+sub.Name, path.Join(name, subName), append(slices.Clip(index), i))
+	}
+	return fields
+}
+
+// fieldByIndexAlloc returns the nested field of v at index, allocating nil struct pointers
+// on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// parameters returns the parameters read for field, a field of T whose `ssm` tag may have
+// its ${VAR} references resolved: the tagged name, or those below it for a nested struct.
+func (s *SSMParameterStoreLoader[T]) parameters(field loader.Field) []ssmField {
+	name := field.Tag.Get("ssm")
+	if name == "" {
+		return nil
+	}
+	structField, ok := reflect.TypeOf((*T)(nil)).Elem().FieldByName(field.Name)
+	if !ok {
+		structField = reflect.StructField{Name: field.Name}
+	}
+	structField.Tag = field.Tag
+	return appendSSMFields(nil, structField, field.Name, name, structField.Index)
+}
+
+// namePath returns the path `ssm` tags are relative to: Path, or none with ExactNames.
+func (s *SSMParameterStoreLoader[T]) namePath() string {
+	if s.ExactNames {
+		return ""
+	}
+	return s.Path
+}
+
+// source describes the parameters in errors: Path, or the tagged names with ExactNames.
+func (s *SSMParameterStoreLoader[T]) source() string {
+	if !s.ExactNames {
+		return s.Path
+	}
+	var names []string
+	for _, field := range ssmFields(reflect.TypeOf((*T)(nil)).Elem()) {
+		names = append(names, path.Clean(field.name))
+	}
+	return strings.Join(names, ", ")
+}
+
+// batchingSSMClient serves the single GetParameters call go-ssm-config makes for a struct,
+// which the API rejects for more than ten names, in batches or from GetParametersByPath,
+// reading through the loader's CacheTTL cache. Other operations go straight to SSMAPI.
+type batchingSSMClient struct {
+	ssmiface.SSMAPI
+	ctx     context.Context // Context of the load, as go-ssm-config calls GetParameters without one
+	path    string          // Base path fetched by GetParametersByPath
+	byPath  bool            // Fetch with GetParametersByPath
+	decrypt bool            // Decrypt SecureString parameters
+	ttl     time.Duration   // How long values are kept in cache
+	cache   *ttlCache
+}
+
+// GetParameters returns the parameters named in input, reporting missing ones as invalid.
+func (c *batchingSSMClient) GetParameters(input *ssm.GetParametersInput) (*ssm.GetParametersOutput, error) {
+	now := time.Now()
+	out := &ssm.GetParametersOutput{}
+	var missing []*string
+	for _, name := range input.Names {
+		entry, ok := ttlEntry{}, false
+		if c.ttl > 0 {
+			entry, ok = c.cache.get(awsv1.StringValue(name), now)
+		}
+		switch {
+		case !ok:
+			missing = append(missing, name)
+		case entry.found:
+			out.Parameters = append(out.Parameters, &ssm.Parameter{Name: name, Value: awsv1.String(entry.value)})
+		default:
+			out.InvalidParameters = append(out.InvalidParameters, name)
+		}
+	}
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	values, err := c.fetch(missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range missing {
+		value, found := values[awsv1.StringValue(name)]
+		if found {
+			out.Parameters = append(out.Parameters, &ssm.Parameter{Name: name, Value: awsv1.String(value)})
+		} else {
+			out.InvalidParameters = append(out.InvalidParameters, name)
+		}
+		if c.ttl > 0 {
+			c.cache.set(awsv1.StringValue(name), value, found, now, c.ttl, 0)
+		}
+	}
+	return out, nil
+}
+
+// fetch returns the values of the parameters in names that exist. When byPath is set, the
+// parameters below path are fetched with GetParametersByPath and only names outside it,
+// or with a version or label selector, are fetched by name.
+func (c *batchingSSMClient) fetch(names []*string) (map[string]string, error) {
+	values := make(map[string]string)
+	ctx := c.ctx
+	if c.byPath && c.path != "" {
+		prefix := strings.TrimSuffix(c.path, "/") + "/"
+		input := &ssm.GetParametersByPathInput{Path: awsv1.String(c.path), Recursive: awsv1.Bool(true), WithDecryption: awsv1.Bool(c.decrypt)}
+		err := c.SSMAPI.GetParametersByPathPagesWithContext(ctx, input, func(page *ssm.GetParametersByPathOutput, _ bool) bool {
+			for _, param := range page.Parameters {
+				values[awsv1.StringValue(param.Name)] = awsv1.StringValue(param.Value)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		var outside []*string
+		for _, name := range names {
+			// The walk returns the latest version only
+			if n := awsv1.StringValue(name); !strings.HasPrefix(n, prefix) || strings.Contains(n, "#") {
+				outside = append(outside, name)
+			}
+		}
+		names = outside
+	}
+
+	requested := make(map[string]string, len(names)) // Tag names by the names requested
+	for start := 0; start < len(names); start += ssmGetBatchSize {
+		var batch []*string
+		for _, name := range names[start:min(start+ssmGetBatchSize, len(names))] {
+			request := ssmRequestName(awsv1.StringValue(name))
+			requested[request] = awsv1.StringValue(name)
+			batch = append(batch, awsv1.String(request))
+		}
+		out, err := c.SSMAPI.GetParametersWithContext(ctx, &ssm.GetParametersInput{Names: batch, WithDecryption: awsv1.Bool(c.decrypt)})
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range out.Parameters {
+			if name, ok := requested[ssmParameterKey(param)]; ok {
+				values[name] = awsv1.StringValue(param.Value)
+			}
+		}
+	}
+	return values, nil
+}
+
+// ssmRequestName returns the name GetParameters is asked for: name, or for a tag name with
+// a "#version" or "#label" suffix the API's "name:version" or "name:label" selector.
+func ssmRequestName(name string) string {
+	if base, selector, ok := strings.Cut(name, "#"); ok {
+		return base + ":" + selector
+	}
+	return name
+}
+
+// ssmParameterKey returns the name a parameter in a GetParameters response was requested
+// as: its name, followed by the selector it was requested with, if any.
+func ssmParameterKey(param *ssm.Parameter) string {
+	return awsv1.StringValue(param.Name) + awsv1.StringValue(param.Selector)
+}
+
+// ssmGetBatchSize is the maximum number of names in a GetParameters request.
+const ssmGetBatchSize = 10
+
+// PlanFetches returns a fetch for each parameter read for fields, without calling AWS. With
+// ByPath, the parameters below Path are covered by a single GetParametersByPath fetch of
+// Path, and only those outside it, or with a version or label selector, are listed.
+func (s *SSMParameterStoreLoader[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	var fetches []loader.Fetch
+	byPath := false
+	prefix := strings.TrimSuffix(s.Path, "/") + "/"
+	for _, field := range fields {
+		for _, param := range s.parameters(field) {
+			name := path.Join(s.namePath(), param.name)
+			if s.ByPath && s.Path != "" && strings.HasPrefix(name, prefix) && !strings.Contains(name, "#") {
+				byPath = true
+				continue
+			}
+			fetches = append(fetches, loader.Fetch{LoaderType: "SSMParameterStoreLoader", Field: param.path, Source: name, Action: "ssm:GetParameters"})
+		}
+	}
+	if byPath {
+		fetches = append(fetches, loader.Fetch{LoaderType: "SSMParameterStoreLoader", Source: s.Path, Action: "ssm:GetParametersByPath"})
+	}
+	return fetches
+}
+
+// SetSourceCache sets the cache parameter values are read from and stored in.
+func (s *SSMParameterStoreLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	s.cache = cache
+}
+
+// Prefetch retrieves the parameter referenced by each `ssm` tag into the source cache,
+// decrypted unless NoDecryption is set. Parameters already cached are not retrieved again, and missing parameters
+// are left for Load to report or default.
+func (s *SSMParameterStoreLoader[T]) Prefetch(ctx context.Context, fields []loader.Field) error {
+	if s.cache == nil || s.DryRun {
+		return nil
+	}
+
+	var names []*string
+	for _, field := range fields {
+		for _, param := range s.parameters(field) {
+			names = append(names, awsv1.String(ssmRequestName(path.Join(s.namePath(), param.name))))
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	client, err := s.cachedClient()
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "SSMParameterStoreLoader", Operation: "prefetch parameters", Source: s.source(), Err: err}
+	}
+	for start := 0; start < len(names); start += ssmGetBatchSize {
+		batch := names[start:min(start+ssmGetBatchSize, len(names))]
+		if _, err := client.GetParametersWithContext(ctx, &ssm.GetParametersInput{Names: batch, WithDecryption: awsv1.Bool(!s.NoDecryption)}); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "SSMParameterStoreLoader",
+				Operation:  "prefetch parameters",
+				Source:     s.source(),
+				Err:        diagnoseAccessDenied(ctx, err, "ssm:GetParameters", s.source(), s.callerIdentity),
+			}
 		}
 	}
 	return nil
 }
+
+// client returns Client, or a client created from AWS when unset.
+func (s *SSMParameterStoreLoader[T]) client() (ssmiface.SSMAPI, error) {
+	if s.Client != nil {
+		return s.Client, nil
+	}
+	sess, err := newSession(s.Clients, s.AWS)
+	if err != nil {
+		return nil, err
+	}
+	return ssm.New(sess, endpointConfigs(s.EndpointURL)...), nil
+}
+
+// cachedClient returns the client from client, reading through the source cache.
+func (s *SSMParameterStoreLoader[T]) cachedClient() (*cachedSSMClient, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	return &cachedSSMClient{SSMAPI: client, cache: s.cache}, nil
+}
+
+// cachedSSMClient serves GetParameters from a source cache, retrieving and storing
+// parameters that are not cached yet. Other operations go straight to SSMAPI.
+type cachedSSMClient struct {
+	ssmiface.SSMAPI
+	cache *loader.SourceCache
+}
+
+// GetParameters returns cached parameters and retrieves the rest.
+func (c *cachedSSMClient) GetParameters(input *ssm.GetParametersInput) (*ssm.GetParametersOutput, error) {
+	return c.GetParametersWithContext(context.Background(), input)
+}
+
+// GetParametersWithContext returns cached parameters and retrieves the rest.
+func (c *cachedSSMClient) GetParametersWithContext(ctx awsv1.Context, input *ssm.GetParametersInput, opts ...request.Option) (*ssm.GetParametersOutput, error) {
+	out := &ssm.GetParametersOutput{}
+	var missing []*string
+	for _, name := range input.Names {
+		if value, ok := c.cache.Get("ssm:" + awsv1.StringValue(name)); ok {
+			out.Parameters = append(out.Parameters, &ssm.Parameter{Name: name, Value: awsv1.String(value)})
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	fetched, err := c.SSMAPI.GetParametersWithContext(ctx, &ssm.GetParametersInput{Names: missing, WithDecryption: input.WithDecryption}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range fetched.Parameters {
+		c.cache.Set("ssm:"+ssmParameterKey(param), awsv1.StringValue(param.Value))
+	}
+	out.Parameters = append(out.Parameters, fetched.Parameters...)
+	out.InvalidParameters = fetched.InvalidParameters
+	return out, nil
+}
+
+// ssmDescribeBatchSize is the maximum number of names in a DescribeParameters filter.
+const ssmDescribeBatchSize = 50
+
+// VerifySources checks that the parameter referenced by each `ssm` tag exists, using
+// DescribeParameters so no values are read. Version and label selectors are not checked.
+// Fields with a `default` tag may be missing.
+func (s *SSMParameterStoreLoader[T]) VerifySources(ctx context.Context, fields []loader.Field) []loader.SourceCheck {
+	var checks []loader.SourceCheck
+	var names []*string
+	var hasDefault []bool
+	for _, field := range fields {
+		for _, param := range s.parameters(field) {
+			name := path.Join(s.namePath(), param.name)
+			checks = append(checks, loader.SourceCheck{LoaderType: "SSMParameterStoreLoader", Field: param.path, Source: name})
+			base, _, _ := strings.Cut(name, "#")
+			names = append(names, awsv1.String(base))
+			_, ok := param.field.Tag.Lookup("default")
+			hasDefault = append(hasDefault, ok)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	client, err := s.client()
+	if err != nil {
+		for i := range checks {
+			checks[i].Err = err
+		}
+		return checks
+	}
+
+	found := make(map[string]bool)
+	describeErrs := make(map[string]error)
+	for start := 0; start < len(names); start += ssmDescribeBatchSize {
+		batch := names[start:min(start+ssmDescribeBatchSize, len(names))]
+		input := &ssm.DescribeParametersInput{
+			ParameterFilters: []*ssm.ParameterStringFilter{{
+				Key:    awsv1.String("Name"),
+				Option: awsv1.String("Equals"),
+				Values: batch,
+			}},
+		}
+		err := client.DescribeParametersPagesWithContext(ctx, input, func(page *ssm.DescribeParametersOutput, _ bool) bool {
+			for _, param := range page.Parameters {
+				found[awsv1.StringValue(param.Name)] = true
+			}
+			return true
+		})
+		if err != nil {
+			err = diagnoseAccessDenied(ctx, err, "ssm:DescribeParameters", s.source(), s.callerIdentity)
+			for _, name := range batch {
+				describeErrs[*name] = err
+			}
+		}
+	}
+
+	for i := range checks {
+		name, _, _ := strings.Cut(checks[i].Source, "#")
+		switch {
+		case describeErrs[name] != nil:
+			checks[i].Err = describeErrs[name]
+		case !found[name] && !hasDefault[i]:
+			checks[i].Err = fmt.Errorf("parameter not found")
+		}
+	}
+	return checks
+}
+
+// callerIdentity returns the ARN of the calling identity, looking it up at most once.
+func (s *SSMParameterStoreLoader[T]) callerIdentity(ctx context.Context) (string, error) {
+	return s.caller.get(func() (string, error) {
+		client := s.STSClient
+		if client == nil {
+			sess, err := newSession(s.Clients, s.AWS)
+			if err != nil {
+				return "", err
+			}
+			client = sts.New(sess)
+		}
+
+		out, err := client.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return "", err
+		}
+		return awsv1.StringValue(out.Arn), nil
+	})
+}
@@ -1,9 +1,19 @@
+//go:build !js && !wasip1 && !tinygo
+
 package aws
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/gymshark/go-easy-config/loader"
 )
 
@@ -91,3 +101,441 @@ func TestSSMParameterStoreLoader_ErrorMessageFormat(t *testing.T) {
 		}
 	}
 }
+
+type mockSSMClient struct {
+	ssmiface.SSMAPI
+	existing   map[string]bool
+	values     map[string]string // Values by name, or by "name:selector" for selected versions
+	gets       int
+	decryption []bool // WithDecryption of each GetParameters call
+	err        error
+}
+
+func (m *mockSSMClient) GetParametersWithContext(ctx awsv1.Context, input *ssm.GetParametersInput, _ ...request.Option) (*ssm.GetParametersOutput, error) {
+	m.gets++
+	m.decryption = append(m.decryption, awsv1.BoolValue(input.WithDecryption))
+	if m.err != nil {
+		return nil, m.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	out := &ssm.GetParametersOutput{}
+	for _, name := range input.Names {
+		if value, ok := m.values[*name]; ok {
+			// Like the API, report the selector separately from the name
+			param := &ssm.Parameter{Name: name, Value: awsv1.String(value)}
+			if base, selector, ok := strings.Cut(*name, ":"); ok {
+				param.Name, param.Selector = awsv1.String(base), awsv1.String(":"+selector)
+			}
+			out.Parameters = append(out.Parameters, param)
+		} else {
+			out.InvalidParameters = append(out.InvalidParameters, name)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSSMClient) DescribeParametersPagesWithContext(_ awsv1.Context, input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool, _ ...request.Option) error {
+	if m.err != nil {
+		return m.err
+	}
+	out := &ssm.DescribeParametersOutput{}
+	for _, name := range input.ParameterFilters[0].Values {
+		if m.existing[*name] {
+			out.Parameters = append(out.Parameters, &ssm.ParameterMetadata{Name: name})
+		}
+	}
+	fn(out, true)
+	return nil
+}
+
+func TestSSMParameterStoreLoader_VerifySources(t *testing.T) {
+	ldr := &SSMParameterStoreLoader[SSMTestConfig]{
+		Path:   "/myapp/prod",
+		Client: &mockSSMClient{existing: map[string]bool{"/myapp/prod/parameter1": true}},
+	}
+
+	checks := ldr.VerifySources(context.Background(), []loader.Field{
+		{Name: "Parameter1", Tag: `ssm:"parameter1"`},
+		{Name: "Parameter2", Tag: `ssm:"parameter2"`},
+		{Name: "Parameter3", Tag: `ssm:"parameter3" default:"x"`},
+		{Name: "Plain", Tag: `env:"PLAIN"`},
+	})
+
+	if len(checks) != 3 {
+		t.Fatalf("expected 3 checks, got %v", checks)
+	}
+	if checks[0].Err != nil || checks[0].Source != "/myapp/prod/parameter1" {
+		t.Errorf("expected passing check for parameter1, got %v", checks[0])
+	}
+	if checks[1].Err == nil {
+		t.Error("expected missing parameter2 to fail")
+	}
+	if checks[2].Err != nil {
+		t.Errorf("expected missing parameter with default to pass, got %v", checks[2])
+	}
+}
+
+func TestSSMParameterStoreLoader_VerifySources_DescribeError(t *testing.T) {
+	describeErr := errors.New("AccessDeniedException")
+	ldr := &SSMParameterStoreLoader[SSMTestConfig]{Client: &mockSSMClient{err: describeErr}}
+
+	checks := ldr.VerifySources(context.Background(), []loader.Field{{Name: "Parameter1", Tag: `ssm:"parameter1"`}})
+	if len(checks) != 1 || !errors.Is(checks[0].Err, describeErr) {
+		t.Errorf("expected describe error to be reported, got %v", checks)
+	}
+}
+
+func TestSSMParameterStoreLoader_Prefetch(t *testing.T) {
+	client := &mockSSMClient{values: map[string]string{"/myapp/prod/parameter1": "one", "/myapp/prod/parameter2": "2"}}
+	ldr := &SSMParameterStoreLoader[SSMTestConfig]{Path: "/myapp/prod", Client: client}
+	ldr.SetSourceCache(loader.NewSourceCache())
+
+	fields := []loader.Field{{Name: "Parameter1", Tag: `ssm:"parameter1"`}, {Name: "Parameter2", Tag: `ssm:"parameter2"`}}
+	if err := ldr.Prefetch(context.Background(), fields); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+
+	var cfg SSMTestConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Parameter1 != "one" || cfg.Parameter2 != 2 {
+		t.Errorf("expected cached parameters, got %+v", cfg)
+	}
+	if client.gets != 1 {
+		t.Errorf("expected parameters to be retrieved once, got %d calls", client.gets)
+	}
+}
+
+func (m *mockSSMClient) GetParametersByPathPagesWithContext(_ awsv1.Context, input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool, _ ...request.Option) error {
+	m.gets++
+	if m.err != nil {
+		return m.err
+	}
+	// One parameter per page, to exercise pagination
+	for name, value := range m.values {
+		if strings.HasPrefix(name, *input.Path+"/") {
+			page := &ssm.GetParametersByPathOutput{Parameters: []*ssm.Parameter{{Name: awsv1.String(name), Value: awsv1.String(value)}}}
+			if !fn(page, false) {
+				return nil
+			}
+		}
+	}
+	fn(&ssm.GetParametersByPathOutput{}, true)
+	return nil
+}
+
+type ssmLargeConfig struct {
+	P1  string `ssm:"p1"`
+	P2  string `ssm:"p2"`
+	P3  string `ssm:"p3"`
+	P4  string `ssm:"p4"`
+	P5  string `ssm:"p5"`
+	P6  string `ssm:"p6"`
+	P7  string `ssm:"p7"`
+	P8  string `ssm:"p8"`
+	P9  string `ssm:"p9"`
+	P10 string `ssm:"p10"`
+	P11 string `ssm:"p11"`
+	P12 string `ssm:"p12" default:"fallback"`
+}
+
+func ssmLargeValues() map[string]string {
+	values := make(map[string]string)
+	for i := 1; i <= 11; i++ {
+		values[fmt.Sprintf("/myapp/prod/p%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	return values
+}
+
+func TestSSMParameterStoreLoader_Load_Batches(t *testing.T) {
+	client := &mockSSMClient{values: ssmLargeValues()}
+	ldr := &SSMParameterStoreLoader[ssmLargeConfig]{Path: "/myapp/prod", Client: client}
+
+	var cfg ssmLargeConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.P1 != "value1" || cfg.P11 != "value11" || cfg.P12 != "fallback" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if client.gets != 2 {
+		t.Errorf("expected 12 names to be fetched in 2 batches, got %d calls", client.gets)
+	}
+}
+
+func TestSSMParameterStoreLoader_Load_ByPath(t *testing.T) {
+	values := ssmLargeValues()
+	values["/myapp/prod/unrelated"] = "x"
+	client := &mockSSMClient{values: values}
+	ldr := &SSMParameterStoreLoader[ssmLargeConfig]{Path: "/myapp/prod", Client: client, ByPath: true}
+
+	var cfg ssmLargeConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.P1 != "value1" || cfg.P11 != "value11" || cfg.P12 != "fallback" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if client.gets != 1 {
+		t.Errorf("expected one GetParametersByPath walk, got %d calls", client.gets)
+	}
+}
+
+func TestSSMParameterStoreLoader_Load_CacheTTL(t *testing.T) {
+	client := &mockSSMClient{values: ssmLargeValues()}
+	ldr := &SSMParameterStoreLoader[ssmLargeConfig]{Path: "/myapp/prod", Client: client, CacheTTL: time.Hour}
+
+	for i := 0; i < 3; i++ {
+		var cfg ssmLargeConfig
+		if err := ldr.Load(&cfg); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.P1 != "value1" || cfg.P12 != "fallback" {
+			t.Errorf("unexpected config: %+v", cfg)
+		}
+	}
+	if client.gets != 2 {
+		t.Errorf("expected cached parameters, and the missing one, to be fetched once, got %d calls", client.gets)
+	}
+
+	expired := &SSMParameterStoreLoader[ssmLargeConfig]{Path: "/myapp/prod", Client: client, CacheTTL: time.Nanosecond}
+	var cfg ssmLargeConfig
+	for i := 0; i < 2; i++ {
+		if err := expired.Load(&cfg); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if client.gets != 6 {
+		t.Errorf("expected expired parameters to be fetched again, got %d calls", client.gets)
+	}
+}
+
+func TestSSMParameterStoreLoader_Load_ByPathError(t *testing.T) {
+	client := &mockSSMClient{err: errors.New("ThrottlingException")}
+	ldr := &SSMParameterStoreLoader[ssmLargeConfig]{Path: "/myapp/prod", Client: client, ByPath: true}
+	var loaderErr *loader.LoaderError
+	if err := ldr.Load(&ssmLargeConfig{}); !errors.As(err, &loaderErr) || loaderErr.Operation != "fetch parameters" {
+		t.Errorf("expected fetch parameters error, got %v", err)
+	}
+}
+
+type ssmExactNamesConfig struct {
+	DBHost   string `ssm:"/shared/db/host"`
+	QueueURL string `ssm:"/teams/orders/queue_url"`
+	Region   string `ssm:"/myapp/prod/region"`
+	Missing  string `ssm:"/shared/missing" default:"fallback"`
+}
+
+func TestSSMParameterStoreLoader_Load_ExactNames(t *testing.T) {
+	client := &mockSSMClient{values: map[string]string{
+		"/shared/db/host":         "db.internal",
+		"/teams/orders/queue_url": "https://sqs.example.com/orders",
+		"/myapp/prod/region":      "eu-west-1",
+	}}
+	ldr := &SSMParameterStoreLoader[ssmExactNamesConfig]{Path: "/myapp/prod", Client: client, ExactNames: true}
+
+	var cfg ssmExactNamesConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := ssmExactNamesConfig{DBHost: "db.internal", QueueURL: "https://sqs.example.com/orders", Region: "eu-west-1", Missing: "fallback"}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+
+	// With ByPath, names below Path come from the walk and the rest are fetched by name
+	client.gets = 0
+	ldr.ByPath = true
+	cfg = ssmExactNamesConfig{}
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg != want || client.gets != 2 {
+		t.Errorf("got %+v in %d calls, want %+v in 2", cfg, client.gets, want)
+	}
+
+	checks := ldr.VerifySources(context.Background(), []loader.Field{{Name: "DBHost", Tag: `ssm:"/shared/db/host"`}})
+	if len(checks) != 1 || checks[0].Source != "/shared/db/host" {
+		t.Errorf("expected the exact name to be verified, got %v", checks)
+	}
+}
+
+func TestSSMParameterStoreLoader_Load_ExactNamesError(t *testing.T) {
+	client := &mockSSMClient{err: errors.New("ThrottlingException")}
+	ldr := &SSMParameterStoreLoader[ssmExactNamesConfig]{Client: client, ExactNames: true}
+	var loaderErr *loader.LoaderError
+	err := ldr.Load(&ssmExactNamesConfig{})
+	if !errors.As(err, &loaderErr) || !strings.Contains(loaderErr.Source, "/teams/orders/queue_url") {
+		t.Errorf("expected the error to name the parameters, got %v", err)
+	}
+}
+
+type ssmSelectorConfig struct {
+	Password string `ssm:"db/password"`
+	Pinned   string `ssm:"db/password#3"`
+	Blue     string `ssm:"db/password#blue"`
+	Missing  string `ssm:"db/password#green" default:"fallback"`
+}
+
+func TestSSMParameterStoreLoader_Load_Selectors(t *testing.T) {
+	newClient := func() *mockSSMClient {
+		return &mockSSMClient{values: map[string]string{
+			"/myapp/prod/db/password":      "latest",
+			"/myapp/prod/db/password:3":    "version3",
+			"/myapp/prod/db/password:blue": "blue",
+		}}
+	}
+	want := ssmSelectorConfig{Password: "latest", Pinned: "version3", Blue: "blue", Missing: "fallback"}
+
+	for _, byPath := range []bool{false, true} {
+		client := newClient()
+		ldr := &SSMParameterStoreLoader[ssmSelectorConfig]{Path: "/myapp/prod", Client: client, ByPath: byPath}
+		var cfg ssmSelectorConfig
+		if err := ldr.Load(&cfg); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg != want {
+			t.Errorf("ByPath=%v: got %+v, want %+v", byPath, cfg, want)
+		}
+	}
+
+	// Selected values are cached separately from the latest version
+	client := newClient()
+	ldr := &SSMParameterStoreLoader[ssmSelectorConfig]{Path: "/myapp/prod", Client: client}
+	ldr.SetSourceCache(loader.NewSourceCache())
+	fields := []loader.Field{{Name: "Password", Tag: `ssm:"db/password"`}, {Name: "Blue", Tag: `ssm:"db/password#blue"`}}
+	if err := ldr.Prefetch(context.Background(), fields); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+	var cfg ssmSelectorConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg != want {
+		t.Errorf("with source cache: got %+v, want %+v", cfg, want)
+	}
+}
+
+func TestSSMParameterStoreLoader_NoDecryption(t *testing.T) {
+	client := &mockSSMClient{values: map[string]string{"/myapp/prod/parameter1": "ciphertext"}}
+	ldr := &SSMParameterStoreLoader[SSMTestConfig]{Path: "/myapp/prod", Client: client, NoDecryption: true}
+	var cfg SSMTestConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(client.decryption) != 1 || client.decryption[0] {
+		t.Errorf("expected GetParameters without decryption, got %v", client.decryption)
+	}
+
+	ldr.NoDecryption = false
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(client.decryption) != 2 || !client.decryption[1] {
+		t.Errorf("expected GetParameters with decryption by default, got %v", client.decryption)
+	}
+}
+
+func TestSSMParameterStoreLoader_LoadContext(t *testing.T) {
+	client := &mockSSMClient{values: map[string]string{"/myapp/prod/parameter1": "one"}}
+	ldr := &SSMParameterStoreLoader[SSMTestConfig]{Path: "/myapp/prod", Client: client}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ldr.LoadContext(ctx, &SSMTestConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	var cfg SSMTestConfig
+	if err := ldr.LoadContext(context.Background(), &cfg); err != nil || cfg.Parameter1 != "one" {
+		t.Errorf("LoadContext() = %+v, %v", cfg, err)
+	}
+}
+
+type ssmPoolConfig struct {
+	MaxConns int `ssm:"max_conns" default:"10"`
+	Idle     int
+}
+
+type ssmDBConfig struct {
+	Host    string
+	Port    int
+	Pool    ssmPoolConfig
+	Replica *ssmPoolConfig `ssm:"replica_pool"`
+	Ignored string         `ssm:"-"`
+	secret  string
+}
+
+type ssmNestedConfig struct {
+	Name string      `ssm:"name"`
+	DB   ssmDBConfig `ssm:"db"`
+	Env  string      `env:"ENV"`
+}
+
+func TestSSMParameterStoreLoader_Load_NestedStructs(t *testing.T) {
+	client := &mockSSMClient{values: map[string]string{
+		"/myapp/prod/name":                      "orders",
+		"/myapp/prod/db/host":                   "db.internal",
+		"/myapp/prod/db/port":                   "5432",
+		"/myapp/prod/db/pool/idle":              "2",
+		"/myapp/prod/db/replica_pool/max_conns": "4",
+		"/myapp/prod/db/replica_pool/idle":      "1",
+	}}
+
+	for _, byPath := range []bool{false, true} {
+		ldr := &SSMParameterStoreLoader[ssmNestedConfig]{Path: "/myapp/prod", Client: client, ByPath: byPath}
+		cfg := ssmNestedConfig{Env: "prod", DB: ssmDBConfig{Ignored: "kept"}}
+		if err := ldr.Load(&cfg); err != nil {
+			t.Fatalf("ByPath=%v: Load failed: %v", byPath, err)
+		}
+		want := ssmNestedConfig{
+			Name: "orders",
+			DB: ssmDBConfig{
+				Host:    "db.internal",
+				Port:    5432,
+				Pool:    ssmPoolConfig{MaxConns: 10, Idle: 2},
+				Replica: &ssmPoolConfig{MaxConns: 4, Idle: 1},
+				Ignored: "kept",
+			},
+			Env: "prod",
+		}
+		if cfg.Name != want.Name || cfg.Env != want.Env || cfg.DB.Host != want.DB.Host || cfg.DB.Port != want.DB.Port ||
+			cfg.DB.Pool != want.DB.Pool || cfg.DB.Replica == nil || *cfg.DB.Replica != *want.DB.Replica || cfg.DB.Ignored != "kept" {
+			t.Errorf("ByPath=%v: got %+v, want %+v", byPath, cfg, want)
+		}
+	}
+
+	ldr := &SSMParameterStoreLoader[ssmNestedConfig]{Path: "/myapp/prod", Client: &mockSSMClient{existing: map[string]bool{"/myapp/prod/db/host": true}}}
+	checks := ldr.VerifySources(context.Background(), []loader.Field{{Name: "DB", Tag: `ssm:"db"`}})
+	if len(checks) != 6 || checks[0].Field != "DB.Host" || checks[0].Source != "/myapp/prod/db/host" || checks[0].Err != nil {
+		t.Fatalf("expected a check per nested parameter, got %v", checks)
+	}
+	if checks[1].Err == nil || checks[2].Err != nil {
+		t.Errorf("expected only missing parameters without defaults to fail, got %v", checks)
+	}
+}
+
+func TestSSMParameterStoreLoader_DryRun(t *testing.T) {
+	client := &mockSSMClient{err: errors.New("no calls expected")}
+	ldr := &SSMParameterStoreLoader[ssmNestedConfig]{Path: "/myapp/prod", Client: client, DryRun: true}
+
+	cfg := ssmNestedConfig{Name: "unchanged"}
+	if err := ldr.Load(&cfg); err != nil || cfg.Name != "unchanged" || client.gets != 0 {
+		t.Fatalf("Load() = %+v, %v in %d calls; want the config unchanged", cfg, err, client.gets)
+	}
+
+	fields := []loader.Field{{Name: "Name", Tag: `ssm:"name"`}, {Name: "DB", Tag: `ssm:"db"`}}
+	fetches := ldr.PlanFetches(fields)
+	if len(fetches) != 7 || fetches[0].Source != "/myapp/prod/name" || fetches[1].Field != "DB.Host" || fetches[1].Action != "ssm:GetParameters" {
+		t.Errorf("unexpected fetches %v", fetches)
+	}
+
+	ldr.ByPath = true
+	fetches = ldr.PlanFetches(fields)
+	if len(fetches) != 1 || fetches[0].Source != "/myapp/prod" || fetches[0].Action != "ssm:GetParametersByPath" {
+		t.Errorf("expected a single GetParametersByPath fetch, got %v", fetches)
+	}
+}
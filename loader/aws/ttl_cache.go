@@ -0,0 +1,102 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheStats reports how a loader's response cache has been used.
+type CacheStats struct {
+	Hits      uint64 // Lookups served from the cache
+	Misses    uint64 // Lookups that were absent or expired and went to AWS
+	Evictions uint64 // Entries dropped to stay within the entry limit
+	Entries   int    // Entries currently held, including expired ones not yet dropped
+}
+
+// ttlCache holds fetched values, and names that were not found, for a fixed time.
+type ttlCache struct {
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+	stats   CacheStats
+}
+
+// ttlEntry is a cached value, or a name that was not found when found is false.
+type ttlEntry struct {
+	value   string
+	found   bool
+	expires time.Time
+}
+
+// get returns the entry for key if it has not expired.
+func (c *ttlCache) get(key string, now time.Time) (ttlEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		c.stats.Misses++
+		return ttlEntry{}, false
+	}
+	c.stats.Hits++
+	return entry, true
+}
+
+// set stores the entry for key until now plus ttl. When max is positive and the cache is
+// full, expired entries are dropped, then the entries closest to expiry.
+func (c *ttlCache) set(key, value string, found bool, now time.Time, ttl time.Duration, max int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]ttlEntry)
+	}
+	if _, ok := c.entries[key]; !ok && max > 0 && len(c.entries) >= max {
+		c.evict(now, len(c.entries)-max+1)
+	}
+	c.entries[key] = ttlEntry{value: value, found: found, expires: now.Add(ttl)}
+}
+
+// evict drops expired entries, then the entries closest to expiry until n have gone.
+// Entries share a TTL, so those closest to expiry are the oldest.
+func (c *ttlCache) evict(now time.Time, n int) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			c.stats.Evictions++
+			n--
+		}
+	}
+	for ; n > 0 && len(c.entries) > 0; n-- {
+		var oldest string
+		var expires time.Time
+		for key, entry := range c.entries {
+			if expires.IsZero() || entry.expires.Before(expires) {
+				oldest, expires = key, entry.expires
+			}
+		}
+		delete(c.entries, oldest)
+		c.stats.Evictions++
+	}
+}
+
+// invalidate drops the entries for keys, or every entry when keys is empty.
+func (c *ttlCache) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(keys) == 0 {
+		c.entries = nil
+		return
+	}
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// snapshot returns the cache's statistics.
+func (c *ttlCache) snapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
@@ -0,0 +1,69 @@
+//go:build !js && !wasip1 && !tinygo
+
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache_Expiry(t *testing.T) {
+	var c ttlCache
+	now := time.Now()
+	c.set("a", "1", true, now, time.Minute, 0)
+
+	if entry, ok := c.get("a", now.Add(30*time.Second)); !ok || entry.value != "1" {
+		t.Errorf("expected a cached value, got %+v, %v", entry, ok)
+	}
+	if _, ok := c.get("a", now.Add(time.Minute)); ok {
+		t.Error("expected the entry to have expired")
+	}
+	if stats := c.snapshot(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestTTLCache_Eviction(t *testing.T) {
+	var c ttlCache
+	now := time.Now()
+	c.set("old", "1", true, now, time.Minute, 2)
+	c.set("new", "2", true, now.Add(time.Second), time.Minute, 2)
+	c.set("new", "3", true, now.Add(2*time.Second), time.Minute, 2) // replacing does not evict
+	c.set("newest", "4", true, now.Add(3*time.Second), time.Minute, 2)
+
+	at := now.Add(4 * time.Second)
+	if _, ok := c.get("old", at); ok {
+		t.Error("expected the oldest entry to be evicted")
+	}
+	if _, ok := c.get("new", at); !ok {
+		t.Error("expected the newer entry to be kept")
+	}
+	if stats := c.snapshot(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	// Expired entries are dropped before live ones
+	c.set("later", "5", true, now.Add(2*time.Minute), time.Minute, 2)
+	if stats := c.snapshot(); stats.Entries != 1 || stats.Evictions != 3 {
+		t.Errorf("expected both expired entries to be dropped, got %+v", stats)
+	}
+}
+
+func TestTTLCache_Invalidate(t *testing.T) {
+	var c ttlCache
+	now := time.Now()
+	c.set("a", "1", true, now, time.Minute, 0)
+	c.set("b", "2", true, now, time.Minute, 0)
+
+	c.invalidate("a")
+	if _, ok := c.get("a", now); ok {
+		t.Error("expected a to be invalidated")
+	}
+	if _, ok := c.get("b", now); !ok {
+		t.Error("expected b to be kept")
+	}
+	c.invalidate()
+	if stats := c.snapshot(); stats.Entries != 0 {
+		t.Errorf("expected no entries, got %+v", stats)
+	}
+}
@@ -0,0 +1,26 @@
+package loader
+
+import "fmt"
+
+// Fetch describes a remote read a loader would make, as reported in dry-run mode.
+type Fetch struct {
+	LoaderType string // Type of loader making the read (e.g., "SecretsManagerLoader")
+	Field      string // Field the value populates, or empty for whole-struct sources such as documents
+	Source     string // Source identifier (e.g., secret name, parameter name, URL without password)
+	Action     string // IAM action needed for the read (e.g., "secretsmanager:GetSecretValue"), or the HTTP method
+}
+
+// String returns a one-line summary of the fetch.
+func (f Fetch) String() string {
+	if f.Field != "" {
+		return fmt.Sprintf("%s %s %s (field %s)", f.LoaderType, f.Action, f.Source, f.Field)
+	}
+	return fmt.Sprintf("%s %s %s", f.LoaderType, f.Action, f.Source)
+}
+
+// FetchPlanner is implemented by loaders that can report the remote reads they would make
+// for fields without making any network calls. Handler.DryRun calls it for every loader
+// that implements it.
+type FetchPlanner interface {
+	PlanFetches(fields []Field) []Fetch
+}
@@ -15,11 +15,36 @@ import "fmt"
 // Loaders that return LoaderError:
 //   - EnvironmentLoader - When parsing environment variables fails
 //   - CommandLineLoader - When parsing command-line arguments fails
-//   - JSONLoader - When reading or unmarshaling JSON files fails
-//   - YAMLLoader - When reading or unmarshaling YAML files fails
-//   - INILoader - When reading or parsing INI files fails
+//   - JSONLoader - When reading, prefetching or unmarshaling JSON files fails
+//   - YAMLLoader - When reading, prefetching or unmarshaling YAML files fails
+//   - TOMLLoader - When reading, prefetching, parsing or decoding TOML files fails
+//   - INILoader - When reading, prefetching or parsing INI files fails
+//   - FileLoader - When a file cannot be read or prefetched, or its format is not supported
+//   - DocumentLoader - When a document's format is not supported
+//   - TerraformLoader - When the state or outputs document cannot be read or parsed, a required output is missing, or a value cannot be parsed
+//   - MapLoader - When a map value cannot be parsed into its field
+//   - DirectoryLoader - When the directory cannot be read, a required one is missing, or a file fails to load
+//   - DiscoveryLoader - When a search path cannot be checked or no file is found (if required)
+//   - DockerSecretsLoader - When a secret file cannot be read, a required one is missing, or a value cannot be parsed
+//   - PromptLoader - When reading or parsing interactive input fails
+//   - CachingLoader - When the disk cache cannot be read, written or decoded
+//   - FaultInjector - When a fault is injected on purpose
+//   - FixtureLoader - When a fixture file cannot be read or written, or has no entry to replay
+//   - HTTPLoader - When a document cannot be fetched over HTTP, fails its schema or cannot be decoded
+//   - GraphQLLoader - When a GraphQL query fails or its data cannot be decoded
+//   - JSONRPCLoader - When a JSON-RPC/MCP call fails or its result cannot be decoded
+//   - LayeredLoader - When a required layer cannot be expanded or any layer fails to load
+//   - KeyringLoader - When a tag names no service, an item cannot be read, a required one is missing, or a value cannot be parsed
+//   - KVLoader - When no bucket is set, a key cannot be read or watched, a required one is missing, or a value cannot be parsed
+//   - PluginLoader - When a plugin fails to run, returns an error or an undecodable value
+//   - ConfigMapLoader - When the Kubernetes API cannot be reached, the ConfigMap cannot be fetched or a value cannot be parsed
+//   - VolumeLoader - When the mounted directory or one of its files cannot be read, or a value cannot be parsed
 //   - SecretsManagerLoader - When AWS Secrets Manager operations fail
 //   - SSMParameterStoreLoader - When AWS SSM Parameter Store operations fail
+//   - LambdaExtensionLoader - When the Lambda extension cannot be reached, rejects a request, lacks a required value, or a value cannot be parsed
+//   - GCSLoader - When the URI is invalid, no token can be obtained, or the object cannot be downloaded or decoded
+//   - DynamoDBLoader - When the key cannot be interpolated, the item or rows cannot be read, or a value cannot be parsed
+//   - AppConfigLoader - When the configuration cannot be fetched or parsed, a required feature flag is missing, or a value cannot be parsed
 //
 // Example - Creating a LoaderError:
 //
@@ -0,0 +1,7 @@
+// Package gcp provides loaders for Google Cloud configuration sources.
+//
+// The loaders do not depend on the Google Cloud client libraries: they call the JSON APIs
+// over HTTP, authenticating with a token from the metadata server of the instance, Cloud Run
+// service or GKE workload they run on, or from a TokenSource the caller provides. They are
+// excluded from js/wasm, wasip1 and TinyGo builds.
+package gcp
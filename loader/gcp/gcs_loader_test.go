@@ -0,0 +1,151 @@
+//go:build !js && !wasip1 && !tinygo
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type gcsTestConfig struct {
+	Name string `json:"name" yaml:"name"`
+	Port int    `json:"port" yaml:"port"`
+}
+
+// fakeStorage serves objects the way the Cloud Storage JSON API does.
+func fakeStorage(t *testing.T, token string, objects map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, `{"error": {"code": 401}}`, http.StatusUnauthorized)
+			return
+		}
+		contents, ok := objects[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"error": {"code": 404, "message": "No such object"}}`, http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("alt") == "media" {
+			w.Write([]byte(contents))
+			return
+		}
+		w.Write([]byte(`{"kind": "storage#object"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func staticToken(token string) TokenSource {
+	return func(context.Context) (string, error) { return token, nil }
+}
+
+func TestGCSLoader_Load(t *testing.T) {
+	server := fakeStorage(t, "ya29.token", map[string]string{
+		"/storage/v1/b/myapp-config/o/prod/config.yaml": "name: prod\nport: 8080\n",
+	})
+	cfg := &gcsTestConfig{}
+	ldr := &GCSLoader[gcsTestConfig]{URI: "gs://myapp-config/prod/config.yaml", Endpoint: server.URL, TokenSource: staticToken("ya29.token")}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "prod" || cfg.Port != 8080 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestGCSLoader_Load_DetectsFormatFromContents(t *testing.T) {
+	server := fakeStorage(t, "", map[string]string{
+		"/storage/v1/b/bucket/o/settings": `{"name": "json"}`,
+	})
+	t.Setenv("STORAGE_EMULATOR_HOST", server.Listener.Addr().String())
+	cfg := &gcsTestConfig{}
+	if err := (&GCSLoader[gcsTestConfig]{URI: "gs://bucket/settings"}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "json" {
+		t.Errorf("expected name from JSON object, got %q", cfg.Name)
+	}
+}
+
+func TestGCSLoader_Load_Errors(t *testing.T) {
+	server := fakeStorage(t, "ya29.token", map[string]string{
+		"/storage/v1/b/bucket/o/bad.json": "{",
+	})
+	tests := []struct {
+		name, uri, operation string
+		token                TokenSource
+		notExist             bool
+	}{
+		{name: "invalid URI", uri: "s3://bucket/key", operation: "parse URI"},
+		{name: "missing object", uri: "gs://bucket/missing.yaml", operation: "download object", notExist: true},
+		{name: "invalid document", uri: "gs://bucket/bad.json", operation: "decode object"},
+		{name: "token failure", uri: "gs://bucket/bad.json", operation: "get token", token: func(context.Context) (string, error) {
+			return "", errors.New("no credentials")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.token
+			if token == nil {
+				token = staticToken("ya29.token")
+			}
+			err := (&GCSLoader[gcsTestConfig]{URI: tt.uri, Endpoint: server.URL, TokenSource: token}).Load(&gcsTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "GCSLoader" || loaderErr.Operation != tt.operation || loaderErr.Source != tt.uri {
+				t.Fatalf("expected GCSLoader %q error for %s, got %v", tt.operation, tt.uri, err)
+			}
+			if errors.Is(err, fs.ErrNotExist) != tt.notExist {
+				t.Errorf("expected fs.ErrNotExist to be %v, got %v", tt.notExist, err)
+			}
+		})
+	}
+}
+
+func TestGCSLoader_VerifySources(t *testing.T) {
+	server := fakeStorage(t, "ya29.token", map[string]string{
+		"/storage/v1/b/bucket/o/config.yaml": "name: x\n",
+	})
+	ldr := &GCSLoader[gcsTestConfig]{URI: "gs://bucket/config.yaml", Endpoint: server.URL, TokenSource: staticToken("ya29.token")}
+	if checks := ldr.VerifySources(context.Background(), nil); len(checks) != 1 || checks[0].Err != nil {
+		t.Errorf("expected one passing check, got %v", checks)
+	}
+	ldr.URI = "gs://bucket/missing.yaml"
+	if checks := ldr.VerifySources(context.Background(), nil); len(checks) != 1 || !errors.Is(checks[0].Err, fs.ErrNotExist) {
+		t.Errorf("expected failing check, got %v", checks)
+	}
+}
+
+func TestMetadataToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" || r.URL.Path != "/computeMetadata/v1/instance/service-accounts/default/token" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"access_token": "ya29.metadata", "expires_in": 3599, "token_type": "Bearer"}`))
+	}))
+	defer server.Close()
+	t.Setenv("GCE_METADATA_HOST", server.Listener.Addr().String())
+
+	token, err := MetadataToken(context.Background())
+	if err != nil || token != "ya29.metadata" {
+		t.Errorf("expected metadata token, got %q, %v", token, err)
+	}
+}
+
+func TestParseGCSURI(t *testing.T) {
+	bucket, object, err := ParseGCSURI("gs://bucket/path/to/config.yaml")
+	if err != nil || bucket != "bucket" || object != "path/to/config.yaml" {
+		t.Errorf("unexpected result: %q, %q, %v", bucket, object, err)
+	}
+	for _, uri := range []string{"gs://bucket", "gs:///object", "bucket/object"} {
+		if _, _, err := ParseGCSURI(uri); err == nil {
+			t.Errorf("expected error for %q", uri)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !js && !wasip1 && !tinygo
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TokenSource returns an OAuth2 access token for Google Cloud APIs, e.g. from
+// golang.org/x/oauth2/google's default token source or `gcloud auth print-access-token`.
+type TokenSource func(ctx context.Context) (string, error)
+
+// MetadataToken returns an access token for the default service account from the metadata
+// server, at metadata.google.internal or $GCE_METADATA_HOST. It works on Compute Engine,
+// Cloud Run, Cloud Functions and GKE with Workload Identity.
+func MetadataToken(ctx context.Context) (string, error) {
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = "metadata.google.internal"
+	}
+	url := "http://" + host + "/computeMetadata/v1/instance/service-accounts/default/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("metadata server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("metadata server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("metadata server: %w", err)
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,467 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// VersionedSource is implemented by loaders that can cheaply report the current version of
+// their source (for example an HTTP ETag or a parameter version) without loading it.
+// CachingLoader uses it to revalidate an expired cache entry instead of reloading.
+type VersionedSource interface {
+	SourceVersion() (string, error)
+}
+
+// VersionedContextSource is implemented by a VersionedSource whose version lookup can be
+// stopped, so CachingLoader.LoadContext passes its ctx to the lookup.
+type VersionedContextSource interface {
+	VersionedSource
+	SourceVersionContext(ctx context.Context) (string, error)
+}
+
+// CachingLoader wraps another loader and persists its last result to disk, so that a
+// remote source does not have to be reachable at every boot.
+//
+// A cache entry younger than TTL is served without calling the wrapped loader. Once it
+// expires, a loader implementing VersionedSource is asked for its current version and the
+// entry is reused if the version is unchanged; otherwise the wrapped loader runs and the
+// entry is replaced. With StaleIfError set, an expired entry is served when the wrapped
+// loader fails.
+//
+// The wrapped loader runs against a copy of c, so it sees the values loaded before it, such
+// as availableAs variables, and the top-level fields it changes are applied to c, including
+// fields it sets to a zero value. Entries are kept per set of availableAs values, so a
+// loader resolving ${VAR} references does not serve one environment's values to another.
+// Entries are stored as JSON using the struct's json tags. Fields that cannot round-trip
+// through encoding/json, such as config.Secret fields, which encode as "***", are stored
+// gob-encoded instead, and fields neither can round-trip are not cached. Cache files are
+// created with mode 0600 but are not encrypted; avoid caching secrets on shared hosts.
+//
+// Example:
+//
+//	ldr := &generic.CachingLoader[Config]{
+//	    Loader:       &aws.SSMParameterStoreLoader[Config]{Path: "/myapp/prod"},
+//	    Key:          "ssm:/myapp/prod",
+//	    TTL:          10 * time.Minute,
+//	    StaleIfError: true,
+//	}
+type CachingLoader[T any] struct {
+	Loader       interface{ Load(c *T) error } // Loader whose results are cached
+	Key          string                        // Identity of the source (defaults to the wrapped loader's type and settings)
+	Dir          string                        // Cache directory (defaults to <user cache dir>/go-easy-config)
+	TTL          time.Duration                 // How long an entry is served without revalidation
+	StaleIfError bool                          // Serve an expired entry when the wrapped loader fails
+
+	onDegraded func(source string, err error) // Reports stale entries served, set by SetDegradationHandler
+}
+
+// cacheEntry is the on-disk representation of a cached result.
+type cacheEntry struct {
+	Key      string    `json:"key"`
+	Version  string    `json:"version,omitempty"`
+	StoredAt time.Time `json:"storedAt"`
+	encodedFields
+}
+
+// Load serves c from the cache when possible and otherwise runs the wrapped loader.
+func (l *CachingLoader[T]) Load(c *T) error {
+	return l.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the wrapped loader if it has a LoadContext
+// method.
+func (l *CachingLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if l.Loader == nil {
+		return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "validate loader", Source: l.Key, Err: errors.New("Loader is nil")}
+	}
+
+	key := l.cacheKey(c)
+	path, err := l.cachePath(key)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "resolve cache path", Source: l.Key, Err: err}
+	}
+
+	entry, err := readCacheEntry(path, key)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "read cache", Source: path, Err: err}
+	}
+
+	if entry != nil && time.Since(entry.StoredAt) < l.TTL {
+		return l.apply(c, entry, path)
+	}
+
+	versioned, isVersioned := l.Loader.(VersionedSource)
+	var version string
+	if isVersioned {
+		// A failed version lookup is treated like a change and falls through to a full load
+		if version, err = sourceVersion(ctx, versioned); err == nil && entry != nil && version != "" && version == entry.Version {
+			entry.StoredAt = time.Now()
+			if err := writeCacheEntry(path, entry); err != nil {
+				return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "write cache", Source: path, Err: err}
+			}
+			return l.apply(c, entry, path)
+		}
+	}
+
+	loaded := *c
+	if err := loadWrapped(ctx, l.Loader, &loaded); err != nil {
+		if entry != nil && l.StaleIfError {
+			if l.onDegraded != nil {
+				l.onDegraded(sourceKey(l.Key, l.Loader), err)
+			}
+			return l.apply(c, entry, path)
+		}
+		return err
+	}
+
+	changed := changedFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&loaded).Elem())
+	encoded, err := encodeFields(&loaded, changed)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "encode payload", Source: path, Err: err}
+	}
+	entry = &cacheEntry{Key: key, Version: version, StoredAt: time.Now(), encodedFields: encoded}
+	if err := writeCacheEntry(path, entry); err != nil {
+		return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "write cache", Source: path, Err: err}
+	}
+
+	copyFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&loaded).Elem(), changed)
+	return nil
+}
+
+// SetDegradationHandler sets fn to be called with the source key and the wrapped loader's
+// error whenever an expired entry is served because of StaleIfError.
+func (l *CachingLoader[T]) SetDegradationHandler(fn func(source string, err error)) {
+	l.onDegraded = fn
+}
+
+// VerifySources delegates to the wrapped loader when it implements loader.SourceVerifier.
+func (l *CachingLoader[T]) VerifySources(ctx context.Context, fields []loader.Field) []loader.SourceCheck {
+	if verifier, ok := l.Loader.(loader.SourceVerifier); ok {
+		return verifier.VerifySources(ctx, fields)
+	}
+	return nil
+}
+
+// SourceFiles returns the files read by the wrapped loader, so the configuration can be
+// reloaded when one changes.
+func (l *CachingLoader[T]) SourceFiles() []string {
+	return wrappedSourceFiles(l.Loader)
+}
+
+// Watch reports the changes reported by the wrapped loader.
+func (l *CachingLoader[T]) Watch(ctx context.Context, changed func()) error {
+	return watchWrapped(ctx, l.Loader, changed)
+}
+
+// SetSchema passes s on to the wrapped loader if it supports schema validation.
+func (l *CachingLoader[T]) SetSchema(s *schema.Schema) {
+	setWrappedSchema(l.Loader, s)
+}
+
+// setWrappedSchema sets s on l if it supports schema validation.
+func setWrappedSchema(l any, s *schema.Schema) {
+	if setter, ok := l.(interface{ SetSchema(*schema.Schema) }); ok {
+		setter.SetSchema(s)
+	}
+}
+
+// sourceVersion returns the version of source, passing ctx if it is a VersionedContextSource.
+func sourceVersion(ctx context.Context, source VersionedSource) (string, error) {
+	if cs, ok := source.(VersionedContextSource); ok {
+		return cs.SourceVersionContext(ctx)
+	}
+	return source.SourceVersion()
+}
+
+// loadWrapped runs l with ctx if it has a LoadContext method, or with Load otherwise.
+func loadWrapped[T any](ctx context.Context, l interface{ Load(c *T) error }, c *T) error {
+	if cl, ok := l.(interface {
+		LoadContext(ctx context.Context, c *T) error
+	}); ok {
+		return cl.LoadContext(ctx, c)
+	}
+	return l.Load(c)
+}
+
+// wrappedSourceFiles returns the files read by l if it implements loader.FileSource.
+func wrappedSourceFiles(l any) []string {
+	if source, ok := l.(loader.FileSource); ok {
+		return source.SourceFiles()
+	}
+	return nil
+}
+
+// watchWrapped watches l if it implements loader.Watcher, or waits for ctx to be done.
+func watchWrapped(ctx context.Context, l any, changed func()) error {
+	if watcher, ok := l.(loader.Watcher); ok {
+		return watcher.Watch(ctx, changed)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// apply decodes the cached payload and sets the fields it holds in c.
+func (l *CachingLoader[T]) apply(c *T, entry *cacheEntry, path string) error {
+	if err := decodeFields(c, entry.encodedFields); err != nil {
+		return &loader.LoaderError{LoaderType: "CachingLoader", Operation: "decode payload", Source: path, Err: err}
+	}
+	return nil
+}
+
+// cacheKey returns the key of the entry for c: the source key followed by the availableAs
+// values of c, which the wrapped loader may resolve ${VAR} references with.
+func (l *CachingLoader[T]) cacheKey(c *T) string {
+	key := sourceKey(l.Key, l.Loader)
+	vars := utils.AvailableAsValues(reflect.ValueOf(c).Elem())
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		key += fmt.Sprintf(" %s=%q", name, vars[name])
+	}
+	return key
+}
+
+// cachePath returns the file holding the entry stored under key.
+func (l *CachingLoader[T]) cachePath(key string) (string, error) {
+	dir := l.Dir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(base, "go-easy-config")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// readCacheEntry returns the entry stored at path under key, or nil if there is none.
+func readCacheEntry(path, key string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key || entry.Fields == nil {
+		// A corrupt entry, or one stored under another key or by an older version, is
+		// discarded rather than failing the load
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// writeCacheEntry atomically replaces the entry stored at path.
+func writeCacheEntry(path string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// sourceKey returns key, or an identity derived from the loader's type and settings when empty.
+func sourceKey(key string, l interface{}) string {
+	if key != "" {
+		return key
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%T ", l)
+	writeSettings(&b, reflect.ValueOf(l), settingsDepth)
+	return b.String()
+}
+
+// settingsDepth is how many levels of nested structs sourceKey describes, enough for a
+// wrapped loader's settings but not the internals of the clients it holds.
+const settingsDepth = 3
+
+// writeSettings writes a description of v to b that is stable across runs: values of basic
+// types as formatted by fmt, and the exported fields of structs, following pointers and
+// interfaces rather than printing addresses. Functions, channels and structs nested deeper
+// than depth are written as their type.
+func writeSettings(b *strings.Builder, v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		b.WriteString("nil")
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		writeSettings(b, v.Elem(), depth)
+	case reflect.Struct:
+		if depth == 0 {
+			b.WriteString(v.Type().String())
+			return
+		}
+		b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.IsExported() {
+				fmt.Fprintf(b, "%s:", field.Name)
+				writeSettings(b, v.Field(i), depth-1)
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			writeSettings(b, v.Index(i), depth)
+			b.WriteString(" ")
+		}
+		b.WriteString("]")
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+		b.WriteString("map[")
+		for _, key := range keys {
+			fmt.Fprintf(b, "%v:", key)
+			writeSettings(b, v.MapIndex(key), depth)
+			b.WriteString(" ")
+		}
+		b.WriteString("]")
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		b.WriteString(v.Type().String())
+	default:
+		fmt.Fprint(b, v)
+	}
+}
+
+// changedFields returns the names of the exported top-level fields whose values differ
+// between before and after, the config before and after a wrapped loader ran on it.
+func changedFields(before, after reflect.Value) []string {
+	var names []string
+	t := before.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && !reflect.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// copyFields sets the named fields of dst to their values in src.
+func copyFields(dst, src reflect.Value, names []string) {
+	for _, name := range names {
+		if field := dst.FieldByName(name); field.CanSet() {
+			field.Set(src.FieldByName(name))
+		}
+	}
+}
+
+// encodedFields holds the values of the fields a wrapped loader changed: in Payload as
+// JSON, so fixture files stay readable, or in Binary gob-encoded by field name when JSON
+// cannot round-trip them.
+type encodedFields struct {
+	Fields  []string          `json:"fields"`
+	Payload json.RawMessage   `json:"payload"`
+	Binary  map[string][]byte `json:"binary,omitempty"`
+}
+
+// encodeFields encodes the named fields of c. Fields that neither encoding can round-trip
+// are left out, so decoding cannot replace a value with a lossy copy.
+func encodeFields[T any](c *T, names []string) (encodedFields, error) {
+	var fields T
+	src := reflect.ValueOf(&fields).Elem()
+	copyFields(src, reflect.ValueOf(c).Elem(), names)
+	payload, err := json.Marshal(&fields)
+	if err != nil {
+		return encodedFields{}, err
+	}
+
+	var decoded T
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return encodedFields{}, err
+	}
+	encoded := encodedFields{Fields: make([]string, 0, len(names)), Payload: payload}
+	for _, name := range names {
+		value := src.FieldByName(name)
+		if reflect.DeepEqual(value.Interface(), reflect.ValueOf(&decoded).Elem().FieldByName(name).Interface()) {
+			encoded.Fields = append(encoded.Fields, name)
+			continue
+		}
+		if data, ok := gobEncode(value); ok {
+			if encoded.Binary == nil {
+				encoded.Binary = make(map[string][]byte)
+			}
+			encoded.Binary[name] = data
+			encoded.Fields = append(encoded.Fields, name)
+		}
+	}
+	return encoded, nil
+}
+
+// gobEncode returns v gob-encoded, if it survives the round trip.
+func gobEncode(v reflect.Value) ([]byte, bool) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).EncodeValue(v); err != nil {
+		return nil, false
+	}
+	decoded := reflect.New(v.Type())
+	if err := gob.NewDecoder(bytes.NewReader(buf.Bytes())).DecodeValue(decoded); err != nil {
+		return nil, false
+	}
+	return buf.Bytes(), reflect.DeepEqual(v.Interface(), decoded.Elem().Interface())
+}
+
+// decodeFields decodes fields written by encodeFields and sets them in c.
+func decodeFields[T any](c *T, encoded encodedFields) error {
+	var decoded T
+	if err := json.Unmarshal(encoded.Payload, &decoded); err != nil {
+		return err
+	}
+	dst := reflect.ValueOf(&decoded).Elem()
+	for name, data := range encoded.Binary {
+		field := dst.FieldByName(name)
+		if !field.CanSet() {
+			continue
+		}
+		if err := gob.NewDecoder(bytes.NewReader(data)).DecodeValue(field.Addr()); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	copyFields(reflect.ValueOf(c).Elem(), dst, encoded.Fields)
+	return nil
+}
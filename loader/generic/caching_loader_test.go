@@ -0,0 +1,196 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type cachingTestConfig struct {
+	Host  string `json:"host"`
+	Port  int    `json:"port"`
+	Local string `json:"local"`
+}
+
+type countingLoader struct {
+	calls   int
+	host    string
+	err     error
+	version string
+}
+
+func (l *countingLoader) Load(c *cachingTestConfig) error {
+	l.calls++
+	if l.err != nil {
+		return l.err
+	}
+	c.Host = l.host
+	c.Port = 5432
+	return nil
+}
+
+type versionedCountingLoader struct {
+	countingLoader
+}
+
+func (l *versionedCountingLoader) SourceVersion() (string, error) {
+	return l.version, nil
+}
+
+func TestCachingLoader_Load_ServesFreshEntryWithinTTL(t *testing.T) {
+	remote := &countingLoader{host: "db-1"}
+	ldr := &CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: t.TempDir(), TTL: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		cfg := &cachingTestConfig{Local: "kept"}
+		if err := ldr.Load(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Host != "db-1" || cfg.Port != 5432 || cfg.Local != "kept" {
+			t.Errorf("unexpected config values: %+v", cfg)
+		}
+	}
+	if remote.calls != 1 {
+		t.Errorf("expected wrapped loader to run once, ran %d times", remote.calls)
+	}
+}
+
+func TestCachingLoader_Load_ReloadsExpiredEntry(t *testing.T) {
+	remote := &countingLoader{host: "db-1"}
+	ldr := &CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: t.TempDir()}
+
+	cfg := &cachingTestConfig{}
+	_ = ldr.Load(cfg)
+	remote.host = "db-2"
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "db-2" || remote.calls != 2 {
+		t.Errorf("expected reload, got Host %q after %d calls", cfg.Host, remote.calls)
+	}
+}
+
+func TestCachingLoader_Load_RevalidatesByVersion(t *testing.T) {
+	remote := &versionedCountingLoader{countingLoader{host: "db-1", version: "v1"}}
+	ldr := &CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: t.TempDir()}
+
+	_ = ldr.Load(&cachingTestConfig{})
+	remote.host = "db-2"
+
+	cfg := &cachingTestConfig{}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "db-1" || remote.calls != 1 {
+		t.Errorf("expected cached value for unchanged version, got Host %q after %d calls", cfg.Host, remote.calls)
+	}
+
+	remote.version = "v2"
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "db-2" || remote.calls != 2 {
+		t.Errorf("expected reload for new version, got Host %q after %d calls", cfg.Host, remote.calls)
+	}
+}
+
+func TestCachingLoader_Load_StaleIfError(t *testing.T) {
+	remote := &countingLoader{host: "db-1"}
+	dir := t.TempDir()
+	_ = (&CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: dir}).Load(&cachingTestConfig{})
+
+	remote.err = errors.New("unreachable")
+
+	cfg := &cachingTestConfig{}
+	stale := &CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: dir, StaleIfError: true}
+	var degradedSource string
+	var degradedErr error
+	stale.SetDegradationHandler(func(source string, err error) { degradedSource, degradedErr = source, err })
+	if err := stale.Load(cfg); err != nil {
+		t.Fatalf("expected stale entry to be served, got %v", err)
+	}
+	if cfg.Host != "db-1" {
+		t.Errorf("expected stale Host 'db-1', got %q", cfg.Host)
+	}
+	if degradedSource != "test" || !errors.Is(degradedErr, remote.err) {
+		t.Errorf("expected degradation of 'test' to be reported, got %q, %v", degradedSource, degradedErr)
+	}
+
+	if err := (&CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: dir}).Load(cfg); !errors.Is(err, remote.err) {
+		t.Errorf("expected wrapped loader error without StaleIfError, got %v", err)
+	}
+}
+
+func TestCachingLoader_Load_IgnoresCorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	remote := &countingLoader{host: "db-1"}
+	ldr := &CachingLoader[cachingTestConfig]{Loader: remote, Key: "test", Dir: dir, TTL: time.Hour}
+
+	path, _ := ldr.cachePath(ldr.cacheKey(&cachingTestConfig{}))
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &cachingTestConfig{}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "db-1" || filepath.Dir(path) != dir {
+		t.Errorf("expected reload after corrupt entry, got %+v", cfg)
+	}
+}
+
+type envCachingTestConfig struct {
+	Env     string `json:"env" config:"availableAs=ENV"`
+	Host    string `json:"host"`
+	Enabled bool   `json:"enabled"`
+}
+
+// envLoader reads the host of the environment already loaded into the config, and
+// disables the feature Enabled guards.
+type envLoader struct {
+	calls int
+	hosts map[string]string
+}
+
+func (l *envLoader) Load(c *envCachingTestConfig) error {
+	l.calls++
+	c.Host = l.hosts[c.Env]
+	c.Enabled = false
+	return nil
+}
+
+func TestCachingLoader_Load_RunsOnCopyOfConfig(t *testing.T) {
+	remote := &envLoader{hosts: map[string]string{"prod": "db.prod", "staging": "db.staging"}}
+	ldr := &CachingLoader[envCachingTestConfig]{Loader: remote, Dir: t.TempDir(), TTL: time.Hour}
+
+	for _, env := range []string{"prod", "staging", "prod"} {
+		cfg := &envCachingTestConfig{Env: env, Enabled: true}
+		if err := ldr.Load(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Host != "db."+env || cfg.Enabled || cfg.Env != env {
+			t.Errorf("unexpected config values for %s: %+v", env, cfg)
+		}
+	}
+	if remote.calls != 2 {
+		t.Errorf("expected one load per environment, got %d", remote.calls)
+	}
+}
+
+func TestSourceKey_IgnoresPointerAddresses(t *testing.T) {
+	first := &FaultInjector[cachingTestConfig]{Loader: &JSONLoader[cachingTestConfig]{Source: "prod.json"}}
+	second := &FaultInjector[cachingTestConfig]{Loader: &JSONLoader[cachingTestConfig]{Source: "prod.json"}}
+	other := &FaultInjector[cachingTestConfig]{Loader: &JSONLoader[cachingTestConfig]{Source: "staging.json"}}
+
+	if sourceKey("", first) != sourceKey("", second) {
+		t.Errorf("expected equal settings to give equal keys, got %q and %q", sourceKey("", first), sourceKey("", second))
+	}
+	if sourceKey("", first) == sourceKey("", other) {
+		t.Errorf("expected different settings to give different keys, got %q", sourceKey("", first))
+	}
+}
@@ -1,19 +1,33 @@
+//go:build !tinygo
+
 package generic
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/fred1268/go-clap/clap"
 	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // CommandLineLoader loads configuration from command-line arguments.
 // It supports fields tagged with `clap:"flag-name"`.
+//
+// Flags for fields of types with a decoder in Decoders take one value, given as the next
+// argument or after "=", which is parsed with that decoder.
 type CommandLineLoader[T any] struct {
-	Args []string // Command-line arguments to parse (typically os.Args[1:])
+	Args     []string        // Command-line arguments to parse (typically os.Args[1:])
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
 }
 
 // Load populates configuration fields from command-line arguments.
 func (cmd *CommandLineLoader[T]) Load(c *T) error {
-	_, err := clap.Parse(cmd.Args, c)
+	args, decoded, err := cmd.decodeFlags(reflect.ValueOf(c).Elem())
+	if err == nil {
+		_, err = clap.Parse(args, c)
+	}
 	if err != nil {
 		return &loader.LoaderError{
 			LoaderType: "CommandLineLoader",
@@ -21,5 +35,102 @@ func (cmd *CommandLineLoader[T]) Load(c *T) error {
 			Err:        err,
 		}
 	}
+	for index, value := range decoded {
+		reflect.ValueOf(c).Elem().Field(index).Set(value)
+	}
 	return nil
 }
+
+// SetDecoders sets the decoders used for custom types.
+func (cmd *CommandLineLoader[T]) SetDecoders(d *utils.Decoders) {
+	cmd.Decoders = d
+}
+
+// decodeFlags decodes the flags of the fields of v that have a registered decoder. It
+// returns the arguments for clap, in which those flags are replaced with placeholders so
+// clap still sees mandatory flags, and the decoded values by field index.
+func (cmd *CommandLineLoader[T]) decodeFlags(v reflect.Value) ([]string, map[int]reflect.Value, error) {
+	decoders := cmd.Decoders
+	if decoders == nil {
+		decoders = utils.DefaultDecoders
+	}
+
+	flags := make(map[string]int) // Flag, e.g. "--account" or "-a", to field index
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := field.Tag.Get("clap")
+		if tag == "" || !field.IsExported() || !hasDecoder(decoders, field.Type) {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if long := strings.Trim(parts[0], " -"); long != "" {
+			flags["--"+long] = i
+		}
+		for _, part := range parts[1:] {
+			if part = strings.Trim(part, " -"); part != "" && part != "mandatory" {
+				flags["-"+part] = i
+			}
+		}
+	}
+	if len(flags) == 0 {
+		return cmd.Args, nil, nil
+	}
+
+	args := make([]string, 0, len(cmd.Args))
+	decoded := make(map[int]reflect.Value)
+	for i := 0; i < len(cmd.Args); i++ {
+		name, value, inline := strings.Cut(cmd.Args[i], "=")
+		index, ok := flags[name]
+		if !ok {
+			args = append(args, cmd.Args[i])
+			continue
+		}
+		if !inline {
+			if i+1 >= len(cmd.Args) || strings.HasPrefix(cmd.Args[i+1], "-") {
+				return nil, nil, fmt.Errorf("argument '%s': missing value", name)
+			}
+			i++
+			value = cmd.Args[i]
+		}
+		if _, seen := decoded[index]; seen {
+			return nil, nil, fmt.Errorf("argument '%s': duplicated argument", name)
+		}
+		field := reflect.New(v.Type().Field(index).Type).Elem()
+		if err := decoders.SetFromString(field, value); err != nil {
+			return nil, nil, fmt.Errorf("argument '%s': %w", name, err)
+		}
+		decoded[index] = field
+		args = append(args, placeholder(name, field.Type())...)
+	}
+	return args, decoded, nil
+}
+
+// placeholder returns arguments clap accepts for flag on a field of type t. The value clap
+// sets from them is replaced with the decoded one.
+func placeholder(flag string, t reflect.Type) []string {
+	switch t.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Slice:
+		return []string{flag, "0"}
+	case reflect.Array:
+		args := []string{flag}
+		for i := 0; i < t.Len(); i++ {
+			args = append(args, "0")
+		}
+		return args
+	}
+	return []string{flag}
+}
+
+// hasDecoder reports whether t, or its element type if t is a slice, has a decoder in d.
+func hasDecoder(d *utils.Decoders, t reflect.Type) bool {
+	if _, ok := d.Lookup(t); ok {
+		return true
+	}
+	if t.Kind() == reflect.Slice {
+		_, ok := d.Lookup(t.Elem())
+		return ok
+	}
+	return false
+}
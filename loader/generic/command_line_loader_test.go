@@ -1,7 +1,12 @@
+//go:build !tinygo
+
 package generic
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 type CmdTestConfig struct {
@@ -19,3 +24,40 @@ func TestCommandLineLoader_Load(t *testing.T) {
 		t.Errorf("CmdVar1 not loaded, got: %s", cfg.CmdVar1)
 	}
 }
+
+type decoderCmdConfig struct {
+	Account  testAccount   `clap:"--account,a,mandatory"`
+	Accounts []testAccount `clap:"--accounts"`
+	Name     string        `clap:"--name"`
+	SKU      testSKU       `clap:"--sku"`
+}
+
+// testSKU is a string type that clap would otherwise set without decoding.
+type testSKU string
+
+func TestCommandLineLoader_Load_Decoders(t *testing.T) {
+	ldr := &CommandLineLoader[decoderCmdConfig]{
+		Args:     []string{"-a", "payments/42", "--name", "api", "--accounts=a/1,b/2", "--sku", "abc"},
+		Decoders: testDecoders(),
+	}
+	utils.RegisterDecoder(ldr.Decoders, func(s string) (testSKU, error) { return testSKU(strings.ToUpper(s)), nil })
+	var cfg decoderCmdConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Account != (testAccount{"payments", "42"}) || cfg.Name != "api" || len(cfg.Accounts) != 2 || cfg.Accounts[1].ID != "2" || cfg.SKU != "ABC" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	for args, want := range map[string]string{
+		"--name api":           "mandatory",
+		"--account":            "missing value",
+		"--account bad":        "invalid account",
+		"-a x/1 --account y/2": "duplicated argument",
+	} {
+		ldr.Args = strings.Fields(args)
+		if err := ldr.Load(&decoderCmdConfig{}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Load(%q) error = %v, want %q", args, err, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// DirectoryLoader loads every configuration file in a directory, such as
+// /etc/myapp/conf.d, in lexical order of file name. Each file is loaded into the same
+// struct with FileLoader, so later files override the values earlier ones set and leave
+// the rest unchanged; naming files 10-base.yaml, 50-site.yaml and so on orders them.
+//
+// By default files with the extensions .json, .yaml, .yml, .toml, .ini, .cfg and .conf are
+// loaded; set Pattern to choose others. Hidden files and subdirectories are skipped. Each
+// file's format is detected as by FileLoader unless Format is set.
+//
+// Files are fragments rather than complete documents, so they are not validated against a
+// JSON Schema; validate the merged struct with the handler's validator instead.
+//
+// After Load, Files holds the files that were loaded in order.
+//
+// Example:
+//
+//	ldr := &generic.DirectoryLoader[Config]{Dir: "/etc/myapp/conf.d"}
+type DirectoryLoader[T any] struct {
+	Dir      string          // Directory to load; ~ and $VAR are expanded
+	Pattern  string          // Optional filepath.Match pattern for file names, e.g. "*.conf"
+	Format   string          // Optional format of every file: "json", "yaml", "toml" or "ini"
+	Required bool            // Return an error when the directory does not exist
+	Decoders *utils.Decoders // Decoders for custom types in TOML files (defaults to utils.DefaultDecoders)
+	Files    []string        // Files that were loaded, in order (populated after Load)
+}
+
+// Load loads each matching file in the directory into c in lexical order. It is a no-op
+// when the directory does not exist, unless Required is set.
+func (d *DirectoryLoader[T]) Load(c *T) error {
+	d.Files = nil
+
+	dir, err := utils.ExpandHome(utils.ExpandEnv(d.Dir))
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "DirectoryLoader", Operation: "expand directory", Source: d.Dir, Err: err}
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) && !d.Required {
+		return nil
+	}
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "DirectoryLoader", Operation: "read directory", Source: dir, Err: err}
+	}
+
+	// os.ReadDir sorts entries by file name
+	for _, entry := range entries {
+		match, err := d.matches(dir, entry)
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "DirectoryLoader", Operation: "match file", Source: d.Pattern, Err: err}
+		}
+		if !match {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		file := &FileLoader[T]{Path: path, Format: d.Format, Decoders: d.Decoders}
+		if err := file.Load(c); err != nil {
+			return err
+		}
+		d.Files = append(d.Files, path)
+	}
+	return nil
+}
+
+// matches reports whether entry, in dir, is a file to load.
+func (d *DirectoryLoader[T]) matches(dir string, entry fs.DirEntry) (bool, error) {
+	name := entry.Name()
+	if strings.HasPrefix(name, ".") || entry.IsDir() {
+		return false, nil
+	}
+	if entry.Type()&fs.ModeSymlink != 0 {
+		// Follow links, as deployment tools often link files into conf.d
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || info.IsDir() {
+			return false, nil
+		}
+	}
+	if d.Pattern != "" {
+		return filepath.Match(d.Pattern, name)
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml", ".toml", ".ini", ".cfg", ".conf":
+		return true, nil
+	}
+	return false, nil
+}
+
+// SetDecoders sets the decoders used for custom types in TOML files.
+func (d *DirectoryLoader[T]) SetDecoders(decoders *utils.Decoders) {
+	d.Decoders = decoders
+}
+
+// VerifySources checks that the directory can be read when Required is set.
+func (d *DirectoryLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	if !d.Required {
+		return nil
+	}
+	check := loader.SourceCheck{LoaderType: "DirectoryLoader", Source: d.Dir}
+	dir, err := utils.ExpandHome(utils.ExpandEnv(d.Dir))
+	if err == nil {
+		check.Source = dir
+		var info fs.FileInfo
+		if info, err = os.Stat(dir); err == nil && !info.IsDir() {
+			err = fmt.Errorf("is not a directory")
+		}
+	}
+	check.Err = err
+	return []loader.SourceCheck{check}
+}
+
+// SourceFiles returns the expanded Dir, so the configuration can be reloaded when a file
+// in it is added, removed or modified.
+func (d *DirectoryLoader[T]) SourceFiles() []string {
+	dir, err := utils.ExpandHome(utils.ExpandEnv(d.Dir))
+	if err != nil {
+		return nil
+	}
+	return []string{dir}
+}
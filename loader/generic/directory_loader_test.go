@@ -0,0 +1,122 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type directoryTestConfig struct {
+	Name  string            `json:"name" yaml:"name" toml:"name"`
+	Port  int               `json:"port" yaml:"port" toml:"port"`
+	Debug bool              `json:"debug" yaml:"debug" toml:"debug"`
+	Tags  map[string]string `json:"tags" yaml:"tags" toml:"tags"`
+}
+
+func writeDirectoryFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+}
+
+func TestDirectoryLoader_Load_LexicalMerge(t *testing.T) {
+	dir := t.TempDir()
+	writeDirectoryFiles(t, dir, map[string]string{
+		"10-base.yaml":    "name: base\nport: 80\ntags:\n  team: core\n",
+		"20-site.json":    `{"port": 8080, "tags": {"region": "eu"}}`,
+		"30-debug.toml":   "debug = true\n",
+		".99-hidden.yaml": "name: hidden\n",
+		"README.md":       "# not configuration\n",
+	})
+	if err := os.Mkdir(filepath.Join(dir, "40-sub.yaml"), 0o700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	cfg := &directoryTestConfig{}
+	ldr := &DirectoryLoader[directoryTestConfig]{Dir: dir}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := directoryTestConfig{Name: "base", Port: 8080, Debug: true, Tags: map[string]string{"team": "core", "region": "eu"}}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("expected %+v, got %+v", want, *cfg)
+	}
+	wantFiles := []string{
+		filepath.Join(dir, "10-base.yaml"),
+		filepath.Join(dir, "20-site.json"),
+		filepath.Join(dir, "30-debug.toml"),
+	}
+	if !reflect.DeepEqual(ldr.Files, wantFiles) {
+		t.Errorf("expected files %v, got %v", wantFiles, ldr.Files)
+	}
+}
+
+func TestDirectoryLoader_Load_Pattern(t *testing.T) {
+	dir := t.TempDir()
+	writeDirectoryFiles(t, dir, map[string]string{
+		"a.conf":  "name: a\n",
+		"b.yaml":  "name: b\n",
+		"c.local": "name: c\n",
+	})
+	cfg := &directoryTestConfig{}
+	if err := (&DirectoryLoader[directoryTestConfig]{Dir: dir, Pattern: "*.local"}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "c" {
+		t.Errorf("expected only the matching file to load, got %q", cfg.Name)
+	}
+
+	err := (&DirectoryLoader[directoryTestConfig]{Dir: dir, Pattern: "["}).Load(cfg)
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "match file" {
+		t.Errorf("expected match file error, got %v", err)
+	}
+}
+
+func TestDirectoryLoader_Load_Missing(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "conf.d")
+	cfg := &directoryTestConfig{}
+	if err := (&DirectoryLoader[directoryTestConfig]{Dir: dir}).Load(cfg); err != nil {
+		t.Fatalf("expected missing optional directory to be skipped, got %v", err)
+	}
+
+	err := (&DirectoryLoader[directoryTestConfig]{Dir: dir, Required: true}).Load(cfg)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
+
+func TestDirectoryLoader_Load_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	writeDirectoryFiles(t, dir, map[string]string{"10-bad.json": "{"})
+	err := (&DirectoryLoader[directoryTestConfig]{Dir: dir}).Load(&directoryTestConfig{})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Source != filepath.Join(dir, "10-bad.json") {
+		t.Errorf("expected error naming the file, got %v", err)
+	}
+}
+
+func TestDirectoryLoader_VerifySources(t *testing.T) {
+	dir := t.TempDir()
+	if checks := (&DirectoryLoader[directoryTestConfig]{Dir: dir}).VerifySources(t.Context(), nil); checks != nil {
+		t.Errorf("expected no checks for an optional directory, got %v", checks)
+	}
+	checks := (&DirectoryLoader[directoryTestConfig]{Dir: dir, Required: true}).VerifySources(t.Context(), nil)
+	if len(checks) != 1 || checks[0].Err != nil {
+		t.Errorf("expected one passing check, got %v", checks)
+	}
+	checks = (&DirectoryLoader[directoryTestConfig]{Dir: filepath.Join(dir, "missing"), Required: true}).VerifySources(t.Context(), nil)
+	if len(checks) != 1 || !errors.Is(checks[0].Err, fs.ErrNotExist) {
+		t.Errorf("expected failing check, got %v", checks)
+	}
+}
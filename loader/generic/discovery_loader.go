@@ -0,0 +1,166 @@
+//go:build !js && !wasip1 && !tinygo
+
+package generic
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// DiscoveryLoader searches a list of standard locations for a configuration file
+// and loads the first one that exists. The file format is taken from Format, or
+// inferred from the file extension (.json, .ini, otherwise YAML).
+//
+// When Paths is empty the defaults from DefaultSearchPaths(AppName) are used:
+//
+//	$XDG_CONFIG_HOME/<app>/config.yaml (falling back to ~/.config/<app>/config.yaml)
+//	~/.<app>rc
+//	/etc/<app>/config.yaml
+//
+// See DefaultSearchPaths for the equivalent locations on Windows.
+//
+// After Load, SourceFiles returns the file that was chosen (none if no file was found),
+// so callers can report where their configuration came from and the file is watched for
+// changes.
+type DiscoveryLoader[T any] struct {
+	AppName  string         // Application name used to build the default search paths
+	Paths    []string       // Optional search paths in priority order; ~, $VAR and (on Windows) %VAR% are expanded
+	Format   string         // Optional format override: "yaml", "json" or "ini"
+	Required bool           // Return an error when no file is found
+	Schema   *schema.Schema // Optional JSON Schema that JSON and YAML files must satisfy
+
+	mu   sync.Mutex
+	path string // Path of the file chosen by the last Load
+}
+
+// DefaultSearchPaths returns the standard configuration file locations for an application,
+// in the order they are searched. On Windows, when $XDG_CONFIG_HOME is unset, the user config
+// directory is %AppData% and the system-wide location is %ProgramData%\<app>\config.yaml.
+func DefaultSearchPaths(appName string) []string {
+	var paths []string
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		if runtime.GOOS == "windows" {
+			configDir = os.Getenv("AppData")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			configDir = filepath.Join(home, ".config")
+		}
+	}
+	if configDir != "" {
+		paths = append(paths, filepath.Join(configDir, appName, "config.yaml"))
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, "."+appName+"rc"))
+	}
+
+	systemDir := filepath.Join(string(filepath.Separator), "etc")
+	if runtime.GOOS == "windows" {
+		systemDir = os.Getenv("ProgramData")
+	}
+	if systemDir != "" {
+		paths = append(paths, filepath.Join(systemDir, appName, "config.yaml"))
+	}
+	return paths
+}
+
+// Load finds the first existing file in the search paths and loads it into c.
+// It is a no-op when no file is found, unless Required is set.
+func (d *DiscoveryLoader[T]) Load(c *T) error {
+	d.setPath("")
+
+	paths := d.Paths
+	if len(paths) == 0 {
+		paths = DefaultSearchPaths(d.AppName)
+	}
+
+	for _, candidate := range paths {
+		expanded, err := utils.ExpandHome(utils.ExpandEnv(candidate))
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "DiscoveryLoader",
+				Operation:  "expand search path",
+				Source:     candidate,
+				Err:        err,
+			}
+		}
+
+		info, err := os.Stat(expanded)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "DiscoveryLoader",
+				Operation:  "stat file",
+				Source:     expanded,
+				Err:        err,
+			}
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		d.setPath(expanded)
+		return d.loaderFor(expanded).Load(c)
+	}
+
+	if d.Required {
+		return &loader.LoaderError{
+			LoaderType: "DiscoveryLoader",
+			Operation:  "discover config file",
+			Source:     strings.Join(paths, ", "),
+			Err:        fs.ErrNotExist,
+		}
+	}
+	return nil
+}
+
+// SourceFiles returns the file chosen by the last Load, if any.
+func (d *DiscoveryLoader[T]) SourceFiles() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.path == "" {
+		return nil
+	}
+	return []string{d.path}
+}
+
+// setPath records the file chosen by a Load.
+func (d *DiscoveryLoader[T]) setPath(path string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.path = path
+}
+
+// loaderFor returns the file loader matching the configured or inferred format.
+func (d *DiscoveryLoader[T]) loaderFor(path string) interface{ Load(*T) error } {
+	format := d.Format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	switch format {
+	case "json":
+		return &JSONLoader[T]{Source: path, Schema: d.Schema}
+	case "ini":
+		return &IniLoader[T]{Source: path}
+	default:
+		return &YAMLLoader[T]{Source: path, Schema: d.Schema}
+	}
+}
+
+// SetSchema sets the JSON Schema that discovered JSON and YAML files are validated against.
+func (d *DiscoveryLoader[T]) SetSchema(s *schema.Schema) {
+	d.Schema = s
+}
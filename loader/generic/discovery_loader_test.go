@@ -0,0 +1,128 @@
+//go:build !js && !wasip1 && !tinygo
+
+package generic
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+var _ loader.FileSource = (*DiscoveryLoader[discoveryTestConfig])(nil)
+
+type discoveryTestConfig struct {
+	Name string `yaml:"name" json:"name"`
+}
+
+func TestDiscoveryLoader_Load_FirstMatchWins(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.yaml")
+	first := filepath.Join(dir, "first.yaml")
+	second := filepath.Join(dir, "second.yaml")
+	if err := os.WriteFile(first, []byte("name: first\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(second, []byte("name: second\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	cfg := &discoveryTestConfig{}
+	ldr := &DiscoveryLoader[discoveryTestConfig]{Paths: []string{missing, dir, first, second}}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "first" {
+		t.Errorf("expected first match to be loaded, got %q", cfg.Name)
+	}
+	if files := ldr.SourceFiles(); !slices.Equal(files, []string{first}) {
+		t.Errorf("expected SourceFiles [%s], got %v", first, files)
+	}
+}
+
+func TestDiscoveryLoader_Load_DefaultPathsUseXDGConfigHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := filepath.Join(dir, "discoverytest", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("name: xdg\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	cfg := &discoveryTestConfig{}
+	ldr := &DiscoveryLoader[discoveryTestConfig]{AppName: "discoverytest"}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files := ldr.SourceFiles(); cfg.Name != "xdg" || !slices.Equal(files, []string{path}) {
+		t.Errorf("expected XDG config to be loaded, got name %q from %v", cfg.Name, files)
+	}
+}
+
+func TestDiscoveryLoader_Load_InfersFormatFromExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"name":"json"}`), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	cfg := &discoveryTestConfig{}
+	ldr := &DiscoveryLoader[discoveryTestConfig]{Paths: []string{path}}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "json" {
+		t.Errorf("expected JSON config to be loaded, got %q", cfg.Name)
+	}
+}
+
+func TestDiscoveryLoader_Load_NotFound(t *testing.T) {
+	paths := []string{filepath.Join(t.TempDir(), "missing.yaml")}
+
+	ldr := &DiscoveryLoader[discoveryTestConfig]{Paths: paths}
+	if err := ldr.Load(&discoveryTestConfig{}); err != nil {
+		t.Fatalf("expected no error for optional discovery, got: %v", err)
+	}
+	if files := ldr.SourceFiles(); files != nil {
+		t.Errorf("expected no SourceFiles, got %v", files)
+	}
+
+	ldr = &DiscoveryLoader[discoveryTestConfig]{Paths: paths, Required: true}
+	err := ldr.Load(&discoveryTestConfig{})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Fatalf("expected LoaderError, got %T: %v", err, err)
+	}
+	if loaderErr.LoaderType != "DiscoveryLoader" || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDefaultSearchPaths(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(string(filepath.Separator), "xdg"))
+
+	paths := DefaultSearchPaths("myapp")
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 search paths, got %v", paths)
+	}
+	if paths[0] != filepath.Join(string(filepath.Separator), "xdg", "myapp", "config.yaml") {
+		t.Errorf("unexpected XDG path: %s", paths[0])
+	}
+	if filepath.Base(paths[1]) != ".myapprc" {
+		t.Errorf("unexpected rc path: %s", paths[1])
+	}
+	systemPath := filepath.Join(string(filepath.Separator), "etc", "myapp", "config.yaml")
+	if runtime.GOOS == "windows" {
+		systemPath = filepath.Join(os.Getenv("ProgramData"), "myapp", "config.yaml")
+	}
+	if paths[2] != systemPath {
+		t.Errorf("unexpected system path: %s", paths[2])
+	}
+}
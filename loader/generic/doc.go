@@ -0,0 +1,5 @@
+// Package generic provides loaders for common configuration sources.
+//
+// In TinyGo builds only the reflection-light loaders (JSONLoader and MapLoader)
+// are available.
+package generic
@@ -0,0 +1,133 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// DefaultDockerSecretsDir returns the directory Docker mounts secrets in: /run/secrets, or
+// C:\ProgramData\Docker\secrets for Windows containers.
+func DefaultDockerSecretsDir() string {
+	if runtime.GOOS == "windows" {
+		return `C:\ProgramData\Docker\secrets`
+	}
+	return "/run/secrets"
+}
+
+// DockerSecretsLoader loads configuration from the secret files Docker Swarm and Compose
+// mount into containers. It supports fields tagged with `secretfile:"name"`, which are set
+// from the file of that name in Dir.
+//
+// One trailing newline is removed from each value and values are parsed according to the
+// field type. Fields whose file does not exist are left unchanged unless the tag has the
+// "required" option, e.g. `secretfile:"db_password,required"`, which makes a missing file
+// an error. Fields tagged `secretfile:"-"` are ignored.
+//
+// Example:
+//
+//	type Config struct {
+//	    DBPassword string `secretfile:"db_password,required" config:"sensitive"`
+//	    APIKey     string `secretfile:"api_key" config:"sensitive"`
+//	}
+//	ldr := &generic.DockerSecretsLoader[Config]{}
+type DockerSecretsLoader[T any] struct {
+	Dir      string          // Directory holding the secret files (defaults to DefaultDockerSecretsDir())
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+}
+
+// Load reads the secret file of each tagged field of c and sets the field from it.
+func (d *DockerSecretsLoader[T]) Load(c *T) error {
+	return d.load(c, nil)
+}
+
+// LoadFields reads the secret files of the tagged fields named in fields only.
+func (d *DockerSecretsLoader[T]) LoadFields(c *T, fields []string) error {
+	return d.load(c, fields)
+}
+
+// load reads the secret files of the tagged fields named in fields, or of all tagged
+// fields if fields is nil.
+func (d *DockerSecretsLoader[T]) load(c *T, fields []string) error {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, required := secretFileName(field)
+		if name == "" || !field.IsExported() || (fields != nil && !slices.Contains(fields, field.Name)) {
+			continue
+		}
+
+		path := filepath.Join(d.dir(), name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && !required {
+				continue
+			}
+			return &loader.LoaderError{LoaderType: "DockerSecretsLoader", Operation: "read secret", Source: path, Err: err}
+		}
+		value := strings.TrimSuffix(string(data), "\n")
+		value = strings.TrimSuffix(value, "\r")
+		if err := d.Decoders.SetFromString(v.Field(i), value); err != nil {
+			return &loader.LoaderError{LoaderType: "DockerSecretsLoader", Operation: "parse value", Source: path, Err: err}
+		}
+	}
+	return nil
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (d *DockerSecretsLoader[T]) SetDecoders(decoders *utils.Decoders) {
+	d.Decoders = decoders
+}
+
+// VerifySources checks that the secret file of each field with the "required" option
+// exists and can be opened.
+func (d *DockerSecretsLoader[T]) VerifySources(_ context.Context, fields []loader.Field) []loader.SourceCheck {
+	var checks []loader.SourceCheck
+	for _, field := range fields {
+		name, required := secretFileName(reflect.StructField{Tag: field.Tag})
+		if name == "" || !required {
+			continue
+		}
+		for _, check := range verifyFileSource("DockerSecretsLoader", filepath.Join(d.dir(), name)) {
+			check.Field = field.Name
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// SourceFiles returns the secrets directory, so the configuration can be reloaded when a
+// secret file changes.
+func (d *DockerSecretsLoader[T]) SourceFiles() []string {
+	return []string{d.dir()}
+}
+
+// dir returns Dir, or the default secrets directory when it is empty.
+func (d *DockerSecretsLoader[T]) dir() string {
+	if d.Dir == "" {
+		return DefaultDockerSecretsDir()
+	}
+	return d.Dir
+}
+
+// secretFileName returns the secret file name in the field's `secretfile` tag and whether
+// the tag has the "required" option. Untagged fields and fields tagged "-" return "".
+func secretFileName(field reflect.StructField) (string, bool) {
+	name, options, _ := strings.Cut(field.Tag.Get("secretfile"), ",")
+	if name == "-" {
+		return "", false
+	}
+	return name, utils.HasTagOption(options, "required")
+}
@@ -0,0 +1,135 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type dockerSecretsTestConfig struct {
+	Password string      `secretfile:"db_password"`
+	Port     int         `secretfile:"port"`
+	Token    string      `secretfile:"token,required"`
+	Account  testAccount `secretfile:"account"`
+	Missing  string      `secretfile:"missing"`
+	Ignored  string      `secretfile:"-"`
+	Name     string
+}
+
+func writeSecrets(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDockerSecretsLoader_Load(t *testing.T) {
+	dir := writeSecrets(t, map[string]string{
+		"db_password": "s3cret\n\n",
+		"port":        "5432\r\n",
+		"token":       "abc",
+		"account":     "payments/42\n",
+		"-":           "ignored",
+	})
+	cfg := &dockerSecretsTestConfig{Missing: "unchanged", Ignored: "unchanged", Name: "unchanged"}
+	ldr := &DockerSecretsLoader[dockerSecretsTestConfig]{Dir: dir}
+	ldr.SetDecoders(testDecoders())
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := dockerSecretsTestConfig{
+		Password: "s3cret\n",
+		Port:     5432,
+		Token:    "abc",
+		Account:  testAccount{"payments", "42"},
+		Missing:  "unchanged",
+		Ignored:  "unchanged",
+		Name:     "unchanged",
+	}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestDockerSecretsLoader_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		operation string
+		source    string
+	}{
+		{"required missing", map[string]string{}, "read secret", "token"},
+		{"invalid value", map[string]string{"token": "abc", "port": "not-a-number"}, "parse value", "port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeSecrets(t, tt.files)
+			ldr := &DockerSecretsLoader[dockerSecretsTestConfig]{Dir: dir}
+
+			err := ldr.Load(&dockerSecretsTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) {
+				t.Fatalf("expected *loader.LoaderError, got %v", err)
+			}
+			if loaderErr.LoaderType != "DockerSecretsLoader" || loaderErr.Operation != tt.operation {
+				t.Errorf("unexpected error: %v", loaderErr)
+			}
+			if want := filepath.Join(dir, tt.source); loaderErr.Source != want {
+				t.Errorf("expected source %q, got %q", want, loaderErr.Source)
+			}
+		})
+	}
+}
+
+func TestDockerSecretsLoader_DefaultDir(t *testing.T) {
+	ldr := &DockerSecretsLoader[dockerSecretsTestConfig]{}
+	if got := ldr.dir(); got != DefaultDockerSecretsDir() {
+		t.Errorf("expected %q, got %q", DefaultDockerSecretsDir(), got)
+	}
+}
+
+func TestDockerSecretsLoader_VerifySources(t *testing.T) {
+	dir := writeSecrets(t, map[string]string{"token": "abc"})
+	ldr := &DockerSecretsLoader[dockerSecretsTestConfig]{Dir: dir}
+
+	checks := ldr.VerifySources(context.Background(), []loader.Field{
+		{Name: "Password", Tag: `secretfile:"db_password"`},
+		{Name: "Token", Tag: `secretfile:"token,required"`},
+		{Name: "Key", Tag: `secretfile:"key,required"`},
+	})
+	if len(checks) != 2 {
+		t.Fatalf("expected checks for the required secrets only, got %v", checks)
+	}
+	if checks[0].Field != "Token" || checks[0].Err != nil {
+		t.Errorf("unexpected check: %v", checks[0])
+	}
+	if checks[1].Field != "Key" || checks[1].Source != filepath.Join(dir, "key") || !errors.Is(checks[1].Err, os.ErrNotExist) {
+		t.Errorf("unexpected check: %v", checks[1])
+	}
+}
+
+func TestDockerSecretsLoader_LoadFields(t *testing.T) {
+	// Token is required but not selected, so its missing file is not an error
+	dir := writeSecrets(t, map[string]string{"db_password": "s3cret", "port": "5432"})
+	cfg := &dockerSecretsTestConfig{}
+	ldr := &DockerSecretsLoader[dockerSecretsTestConfig]{Dir: dir}
+
+	if err := ldr.LoadFields(cfg, []string{"Password"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Password != "s3cret" || cfg.Port != 0 {
+		t.Errorf("expected only Password to be loaded, got %+v", cfg)
+	}
+}
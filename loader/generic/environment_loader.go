@@ -1,23 +1,255 @@
-// Package generic provides loaders for common configuration sources.
+//go:build !tinygo
+
 package generic
 
 import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
 	"github.com/caarlos0/env/v11"
 	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 // EnvironmentLoader loads configuration from environment variables.
 // It supports fields tagged with `env:"VARIABLE_NAME"`.
-type EnvironmentLoader[T any] struct{}
+//
+// Keyed sections of type map[string]S or map[string]*S, where S is a struct, are loaded
+// from variables named <NAME>_<KEY>_<FIELD>: NAME is the map field's env tag and FIELD the
+// env tag of a field of S. For example, TARGETS_EU_WEST_URL sets Targets["eu_west"].URL
+// for a field tagged `env:"TARGETS"`. Keys are lower-cased, and entries already in the map
+// (e.g. from a file loader) are updated in place.
+//
+// Variable names are matched case-insensitively when CaseInsensitive is set, and
+// always on Windows, where the operating system treats them that way.
+//
+// Fields, slice elements and envDefault values of types with a decoder in Decoders are
+// parsed with that decoder.
+type EnvironmentLoader[T any] struct {
+	CaseInsensitive bool            // Match variable names regardless of case
+	Decoders        *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+}
 
 // Load populates configuration fields from environment variables.
 func (e *EnvironmentLoader[T]) Load(c *T) error {
-	if err := env.Parse(c); err != nil {
+	opts := env.Options{FuncMap: e.funcMap()}
+	fold := e.CaseInsensitive || runtime.GOOS == "windows"
+	if fold {
+		opts.Environment = caseInsensitiveEnvironment(reflect.TypeOf(c).Elem(), os.Environ())
+	}
+
+	if err := env.ParseWithOptions(c, opts); err != nil {
 		return &loader.LoaderError{
 			LoaderType: "EnvironmentLoader",
 			Operation:  "parse environment variables",
 			Err:        err,
 		}
 	}
+
+	if err := loadMapSections(reflect.ValueOf(c).Elem(), "", env.ToMap(os.Environ()), fold, opts.FuncMap); err != nil {
+		return &loader.LoaderError{
+			LoaderType: "EnvironmentLoader",
+			Operation:  "parse map section",
+			Err:        err,
+		}
+	}
+	return nil
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (e *EnvironmentLoader[T]) SetDecoders(d *utils.Decoders) {
+	e.Decoders = d
+}
+
+// funcMap returns the registered decoders as env parsers, or nil if there are none.
+func (e *EnvironmentLoader[T]) funcMap() map[reflect.Type]env.ParserFunc {
+	decoders := e.Decoders
+	if decoders == nil {
+		decoders = utils.DefaultDecoders
+	}
+	funcs := decoders.Funcs()
+	if funcs == nil {
+		return nil
+	}
+	funcMap := make(map[reflect.Type]env.ParserFunc, len(funcs))
+	for t, fn := range funcs {
+		funcMap[t] = env.ParserFunc(fn)
+	}
+	return funcMap
+}
+
+// loadMapSections populates every map-of-struct field tagged with `env` in v, including
+// those in nested structs (honouring envPrefix), from <NAME>_<KEY>_<FIELD> variables.
+func loadMapSections(v reflect.Value, prefix string, environment map[string]string, fold bool, funcMap map[reflect.Type]env.ParserFunc) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldValue := v.Field(i)
+
+		if name, _, _ := strings.Cut(field.Tag.Get("env"), ","); name != "" && isMapSection(field.Type) {
+			if err := loadMapSection(fieldValue, prefix+name+"_", environment, fold, funcMap); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.Struct {
+			if err := loadMapSections(fieldValue, prefix+field.Tag.Get("envPrefix"), environment, fold, funcMap); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isMapSection reports whether t is a map with string keys and struct or *struct values.
+func isMapSection(t reflect.Type) bool {
+	if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+		return false
+	}
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct
+}
+
+// loadMapSection loads the entries of map m from variables starting with prefix.
+func loadMapSection(m reflect.Value, prefix string, environment map[string]string, fold bool, funcMap map[reflect.Type]env.ParserFunc) error {
+	elemType := m.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	// Element field names, longest first so FIELD_NAME wins over NAME
+	var fieldNames []string
+	for i := 0; i < structType.NumField(); i++ {
+		if name, _, _ := strings.Cut(structType.Field(i).Tag.Get("env"), ","); name != "" && structType.Field(i).IsExported() {
+			fieldNames = append(fieldNames, name)
+		}
+	}
+	sort.Slice(fieldNames, func(i, j int) bool { return len(fieldNames[i]) > len(fieldNames[j]) })
+
+	// Split each matching variable into key and field, grouping values by key
+	entries := make(map[string]map[string]string)
+	for name, value := range environment {
+		if len(name) <= len(prefix) || !matchesFold(name[:len(prefix)], prefix, fold) {
+			continue
+		}
+		rest := name[len(prefix):]
+		for _, fieldName := range fieldNames {
+			suffix := "_" + fieldName
+			if len(rest) > len(suffix) && matchesFold(rest[len(rest)-len(suffix):], suffix, fold) {
+				key := strings.ToLower(rest[:len(rest)-len(suffix)])
+				if entries[key] == nil {
+					entries[key] = make(map[string]string)
+				}
+				entries[key][fieldName] = value
+				break
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	for key, values := range entries {
+		mapKey := reflect.ValueOf(key).Convert(m.Type().Key())
+		elem := reflect.New(structType)
+		if existing := m.MapIndex(mapKey); existing.IsValid() {
+			if existing.Kind() != reflect.Ptr {
+				elem.Elem().Set(existing)
+			} else if !existing.IsNil() {
+				elem.Elem().Set(existing.Elem())
+			}
+		}
+
+		if err := env.ParseWithOptions(elem.Interface(), env.Options{Environment: values, FuncMap: funcMap}); err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+
+		if elemType.Kind() == reflect.Ptr {
+			m.SetMapIndex(mapKey, elem)
+		} else {
+			m.SetMapIndex(mapKey, elem.Elem())
+		}
+	}
 	return nil
 }
+
+// matchesFold compares a and b exactly, or ignoring case if fold is set.
+func matchesFold(a, b string, fold bool) bool {
+	if fold {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// caseInsensitiveEnvironment builds an environment map in which every variable named by an
+// `env` tag in t (including nested structs and their envPrefix) is also present under the
+// exact spelling used in the tag, if a variable differing only in case is set.
+func caseInsensitiveEnvironment(t reflect.Type, environ []string) map[string]string {
+	environment := make(map[string]string, len(environ))
+	folded := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		// Windows keeps per-drive working directories in variables starting with '='
+		key, value, ok := strings.Cut(strings.TrimPrefix(kv, "="), "=")
+		if !ok || key == "" {
+			continue
+		}
+		if strings.HasPrefix(kv, "=") {
+			key = "=" + key
+		}
+		environment[key] = value
+		if _, exists := folded[strings.ToLower(key)]; !exists {
+			folded[strings.ToLower(key)] = value
+		}
+	}
+
+	visiting := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		// Guard against self-referencing types
+		if t.Kind() != reflect.Struct || visiting[t] {
+			return
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if name, _, _ := strings.Cut(field.Tag.Get("env"), ","); name != "" {
+				name = prefix + name
+				if _, exists := environment[name]; !exists {
+					if value, ok := folded[strings.ToLower(name)]; ok {
+						environment[name] = value
+					}
+				}
+			}
+			walk(field.Type, prefix+field.Tag.Get("envPrefix"))
+		}
+	}
+	walk(t, "")
+
+	return environment
+}
@@ -1,8 +1,15 @@
+//go:build !tinygo
+
 package generic
 
 import (
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/gymshark/go-easy-config/utils"
 )
 
 type EnvTestConfig struct {
@@ -20,3 +27,169 @@ func TestEnvironmentLoader_Load(t *testing.T) {
 		t.Errorf("EnvVar1 not loaded, got: %s", cfg.EnvVar1)
 	}
 }
+
+// testAccount is a custom type parsed from "org/id" by the decoders of testDecoders.
+type testAccount struct {
+	Org, ID string
+}
+
+func testDecoders() *utils.Decoders {
+	d := utils.NewDecoders(nil)
+	utils.RegisterDecoder(d, func(s string) (testAccount, error) {
+		org, id, ok := strings.Cut(s, "/")
+		if !ok {
+			return testAccount{}, fmt.Errorf("invalid account %q", s)
+		}
+		return testAccount{Org: org, ID: id}, nil
+	})
+	return d
+}
+
+type decoderEnvConfig struct {
+	Account  testAccount   `env:"TEST_DECODER_ACCOUNT"`
+	Default  testAccount   `env:"TEST_DECODER_DEFAULT" envDefault:"ops/7"`
+	Accounts []testAccount `env:"TEST_DECODER_ACCOUNTS"`
+	Regions  map[string]struct {
+		Account testAccount `env:"ACCOUNT"`
+	} `env:"TEST_DECODER_REGIONS"`
+}
+
+func TestEnvironmentLoader_Load_Decoders(t *testing.T) {
+	t.Setenv("TEST_DECODER_ACCOUNT", "payments/42")
+	t.Setenv("TEST_DECODER_ACCOUNTS", "a/1,b/2")
+	t.Setenv("TEST_DECODER_REGIONS_EU_ACCOUNT", "eu/3")
+	ldr := &EnvironmentLoader[decoderEnvConfig]{}
+	ldr.SetDecoders(testDecoders())
+
+	var cfg decoderEnvConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Account != (testAccount{"payments", "42"}) || cfg.Default != (testAccount{"ops", "7"}) {
+		t.Errorf("unexpected accounts %+v and %+v", cfg.Account, cfg.Default)
+	}
+	if !reflect.DeepEqual(cfg.Accounts, []testAccount{{"a", "1"}, {"b", "2"}}) {
+		t.Errorf("unexpected account list %+v", cfg.Accounts)
+	}
+	if cfg.Regions["eu"].Account != (testAccount{"eu", "3"}) {
+		t.Errorf("unexpected map section %+v", cfg.Regions)
+	}
+
+	t.Setenv("TEST_DECODER_ACCOUNT", "invalid")
+	if err := ldr.Load(&decoderEnvConfig{}); err == nil || !strings.Contains(err.Error(), "invalid account") {
+		t.Errorf("expected the decoder's error, got %v", err)
+	}
+}
+
+type caseInsensitiveEnvConfig struct {
+	Name     string `env:"test_ci_name"`
+	Database struct {
+		Host string `env:"host"`
+	} `envPrefix:"TEST_CI_DB_"`
+}
+
+func TestEnvironmentLoader_Load_CaseInsensitive(t *testing.T) {
+	t.Setenv("TEST_CI_NAME", "upper")
+	t.Setenv("TEST_CI_DB_HOST", "db.internal")
+
+	cfg := &caseInsensitiveEnvConfig{}
+	loader := &EnvironmentLoader[caseInsensitiveEnvConfig]{CaseInsensitive: true}
+	if err := loader.Load(cfg); err != nil {
+		t.Fatalf("EnvironmentLoader failed: %v", err)
+	}
+	if cfg.Name != "upper" {
+		t.Errorf("Name not loaded case-insensitively, got: %s", cfg.Name)
+	}
+	if cfg.Database.Host != "db.internal" {
+		t.Errorf("Database.Host not loaded case-insensitively, got: %s", cfg.Database.Host)
+	}
+}
+
+func TestCaseInsensitiveEnvironment_PrefersExactMatch(t *testing.T) {
+	environ := []string{"=C:=C:\\work", "TEST_CI_NAME=upper", "test_ci_name=exact"}
+	environment := caseInsensitiveEnvironment(reflect.TypeOf(caseInsensitiveEnvConfig{}), environ)
+
+	if environment["test_ci_name"] != "exact" {
+		t.Errorf("expected exact match to win, got %q", environment["test_ci_name"])
+	}
+	if environment["=C:"] != "C:\\work" {
+		t.Errorf("expected drive variable to be preserved, got %v", environment)
+	}
+}
+
+type mapSectionTarget struct {
+	URL       string `env:"URL"`
+	BaseURL   string `env:"BASE_URL"`
+	TimeoutMS int    `env:"TIMEOUT_MS" envDefault:"500"`
+}
+
+type mapSectionConfig struct {
+	Targets map[string]mapSectionTarget `env:"TEST_TARGETS"`
+	Nested  struct {
+		Routes map[string]*mapSectionTarget `env:"ROUTES"`
+	} `envPrefix:"TEST_NESTED_"`
+}
+
+func TestEnvironmentLoader_Load_MapSections(t *testing.T) {
+	t.Setenv("TEST_TARGETS_EU_WEST_URL", "https://eu")
+	t.Setenv("TEST_TARGETS_EU_WEST_BASE_URL", "/v1")
+	t.Setenv("TEST_TARGETS_US_TIMEOUT_MS", "750")
+	t.Setenv("TEST_NESTED_ROUTES_API_URL", "https://api")
+	t.Setenv("TEST_TARGETS_EU_WEST", "ignored: no field suffix")
+
+	cfg := &mapSectionConfig{Targets: map[string]mapSectionTarget{"us": {URL: "https://us-from-file"}}}
+	if err := (&EnvironmentLoader[mapSectionConfig]{}).Load(cfg); err != nil {
+		t.Fatalf("EnvironmentLoader failed: %v", err)
+	}
+
+	want := map[string]mapSectionTarget{
+		"eu_west": {URL: "https://eu", BaseURL: "/v1", TimeoutMS: 500},
+		"us":      {URL: "https://us-from-file", TimeoutMS: 750},
+	}
+	if !reflect.DeepEqual(cfg.Targets, want) {
+		t.Errorf("unexpected targets: %+v", cfg.Targets)
+	}
+	if route := cfg.Nested.Routes["api"]; route == nil || route.URL != "https://api" {
+		t.Errorf("unexpected nested routes: %+v", cfg.Nested.Routes)
+	}
+}
+
+func TestEnvironmentLoader_Load_MapSectionsCaseInsensitive(t *testing.T) {
+	t.Setenv("test_targets_Asia_url", "https://asia")
+
+	cfg := &mapSectionConfig{}
+	if err := (&EnvironmentLoader[mapSectionConfig]{CaseInsensitive: true}).Load(cfg); err != nil {
+		t.Fatalf("EnvironmentLoader failed: %v", err)
+	}
+	if cfg.Targets["asia"].URL != "https://asia" {
+		t.Errorf("unexpected targets: %+v", cfg.Targets)
+	}
+}
+
+func TestEnvironmentLoader_Load_MapSectionParseError(t *testing.T) {
+	t.Setenv("TEST_TARGETS_BAD_TIMEOUT_MS", "soon")
+
+	err := (&EnvironmentLoader[mapSectionConfig]{}).Load(&mapSectionConfig{})
+	if err == nil || !strings.Contains(err.Error(), `key "bad"`) {
+		t.Errorf("expected parse error naming the key, got %v", err)
+	}
+}
+
+type sliceSectionConfig struct {
+	Endpoints []mapSectionTarget `envPrefix:"TEST_ENDPOINTS_"`
+}
+
+func TestEnvironmentLoader_Load_SliceSections(t *testing.T) {
+	t.Setenv("TEST_ENDPOINTS_0_URL", "https://a")
+	t.Setenv("TEST_ENDPOINTS_1_URL", "https://b")
+	t.Setenv("TEST_ENDPOINTS_1_TIMEOUT_MS", "900")
+
+	cfg := &sliceSectionConfig{}
+	if err := (&EnvironmentLoader[sliceSectionConfig]{}).Load(cfg); err != nil {
+		t.Fatalf("EnvironmentLoader failed: %v", err)
+	}
+	want := []mapSectionTarget{{URL: "https://a", TimeoutMS: 500}, {URL: "https://b", TimeoutMS: 900}}
+	if !reflect.DeepEqual(cfg.Endpoints, want) {
+		t.Errorf("unexpected endpoints: %+v", cfg.Endpoints)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build windows && !tinygo
+
+package generic
+
+import "testing"
+
+type windowsEnvConfig struct {
+	Value string `env:"Test_Win_Env_Var"`
+}
+
+func TestEnvironmentLoader_Load_CaseInsensitiveByDefaultOnWindows(t *testing.T) {
+	t.Setenv("TEST_WIN_ENV_VAR", "windows_value")
+
+	cfg := &windowsEnvConfig{}
+	loader := &EnvironmentLoader[windowsEnvConfig]{}
+	if err := loader.Load(cfg); err != nil {
+		t.Fatalf("EnvironmentLoader failed: %v", err)
+	}
+	if cfg.Value != "windows_value" {
+		t.Errorf("expected case-insensitive match on Windows, got: %s", cfg.Value)
+	}
+}
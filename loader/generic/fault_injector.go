@@ -0,0 +1,117 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+)
+
+// ErrInjectedFault is the default error returned by FaultInjector when it fails a load.
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultInjector wraps a loader and degrades it on purpose, so tests and staging can check
+// that an application copes with a slow, failing or incomplete configuration backend
+// (retries, fallback caches, circuit breakers).
+//
+// Each Load first waits Latency plus a random duration up to Jitter. It then fails with
+// probability ErrorRate, returning a LoaderError wrapping Err (ErrInjectedFault by default)
+// without calling the wrapped loader. Otherwise the wrapped loader runs against a copy of
+// c, and each top-level field it changes is dropped with probability DropRate before the
+// rest are applied to c. Without faults, it passes the wrapped loader's changes through
+// unchanged.
+//
+// Example:
+//
+//	ldr := &generic.FaultInjector[Config]{
+//	    Loader:    &aws.SSMParameterStoreLoader[Config]{Path: "/myapp/staging"},
+//	    ErrorRate: 0.2,
+//	    Latency:   500 * time.Millisecond,
+//	    DropRate:  0.1,
+//	}
+type FaultInjector[T any] struct {
+	Loader    interface{ Load(c *T) error } // Loader to degrade
+	ErrorRate float64                       // Probability (0-1) that a load fails outright
+	Err       error                         // Error returned for injected failures (defaults to ErrInjectedFault)
+	Latency   time.Duration                 // Delay added to every load
+	Jitter    time.Duration                 // Maximum random delay added on top of Latency
+	DropRate  float64                       // Probability (0-1) that each loaded field is discarded
+	Rand      *rand.Rand                    // Optional source of randomness, for reproducible runs
+}
+
+// Load applies the configured faults around the wrapped loader.
+func (f *FaultInjector[T]) Load(c *T) error {
+	return f.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the wrapped loader if it has a LoadContext
+// method, and stops the injected delay when ctx is done.
+func (f *FaultInjector[T]) LoadContext(ctx context.Context, c *T) error {
+	if f.Loader == nil {
+		return &loader.LoaderError{LoaderType: "FaultInjector", Operation: "validate loader", Err: errors.New("Loader is nil")}
+	}
+
+	delay := f.Latency
+	if f.Jitter > 0 {
+		delay += time.Duration(f.float64() * float64(f.Jitter))
+	}
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	if f.ErrorRate > 0 && f.float64() < f.ErrorRate {
+		err := f.Err
+		if err == nil {
+			err = ErrInjectedFault
+		}
+		return &loader.LoaderError{LoaderType: "FaultInjector", Operation: "inject fault", Err: err}
+	}
+
+	loaded := *c
+	if err := loadWrapped(ctx, f.Loader, &loaded); err != nil {
+		return err
+	}
+
+	changed := changedFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&loaded).Elem())
+	if f.DropRate > 0 {
+		changed = slices.DeleteFunc(changed, func(string) bool { return f.float64() < f.DropRate })
+	}
+	copyFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&loaded).Elem(), changed)
+	return nil
+}
+
+// SetSchema passes s on to the wrapped loader if it supports schema validation.
+func (f *FaultInjector[T]) SetSchema(s *schema.Schema) {
+	setWrappedSchema(f.Loader, s)
+}
+
+// SourceFiles returns the files read by the wrapped loader.
+func (f *FaultInjector[T]) SourceFiles() []string {
+	return wrappedSourceFiles(f.Loader)
+}
+
+// Watch reports the changes reported by the wrapped loader.
+func (f *FaultInjector[T]) Watch(ctx context.Context, changed func()) error {
+	return watchWrapped(ctx, f.Loader, changed)
+}
+
+// float64 returns a random number in [0, 1) from Rand or the global source.
+func (f *FaultInjector[T]) float64() float64 {
+	if f.Rand != nil {
+		return f.Rand.Float64()
+	}
+	return rand.Float64()
+}
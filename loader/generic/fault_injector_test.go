@@ -0,0 +1,95 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+func TestFaultInjector_Load_NoFaultsPassesThrough(t *testing.T) {
+	remote := &countingLoader{host: "db-1"}
+	cfg := &cachingTestConfig{Local: "kept"}
+
+	if err := (&FaultInjector[cachingTestConfig]{Loader: remote}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "db-1" || cfg.Port != 5432 || cfg.Local != "kept" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestFaultInjector_Load_AlwaysFails(t *testing.T) {
+	remote := &countingLoader{host: "db-1"}
+	custom := errors.New("backend unavailable")
+
+	err := (&FaultInjector[cachingTestConfig]{Loader: remote, ErrorRate: 1, Err: custom}).Load(&cachingTestConfig{})
+
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "FaultInjector" || !errors.Is(err, custom) {
+		t.Fatalf("expected injected LoaderError wrapping custom error, got %v", err)
+	}
+	if remote.calls != 0 {
+		t.Errorf("expected wrapped loader not to run on injected failure, ran %d times", remote.calls)
+	}
+
+	if err := (&FaultInjector[cachingTestConfig]{Loader: remote, ErrorRate: 1}).Load(&cachingTestConfig{}); !errors.Is(err, ErrInjectedFault) {
+		t.Errorf("expected ErrInjectedFault by default, got %v", err)
+	}
+}
+
+func TestFaultInjector_Load_ErrorRateIsReproducible(t *testing.T) {
+	run := func() int {
+		injector := &FaultInjector[cachingTestConfig]{Loader: &countingLoader{host: "db-1"}, ErrorRate: 0.5, Rand: rand.New(rand.NewSource(42))}
+		failures := 0
+		for i := 0; i < 100; i++ {
+			if injector.Load(&cachingTestConfig{}) != nil {
+				failures++
+			}
+		}
+		return failures
+	}
+
+	first := run()
+	if first == 0 || first == 100 {
+		t.Errorf("expected some but not all loads to fail, got %d failures", first)
+	}
+	if second := run(); second != first {
+		t.Errorf("expected seeded runs to match, got %d and %d failures", first, second)
+	}
+}
+
+func TestFaultInjector_Load_NoFaultsAppliesZeroValues(t *testing.T) {
+	cfg := &envCachingTestConfig{Env: "prod", Enabled: true}
+	remote := &envLoader{hosts: map[string]string{"prod": "db.prod"}}
+	if err := (&FaultInjector[envCachingTestConfig]{Loader: remote}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "db.prod" || cfg.Enabled {
+		t.Errorf("expected the wrapped loader's changes, including false, got %+v", cfg)
+	}
+}
+
+func TestFaultInjector_Load_DropsFields(t *testing.T) {
+	cfg := &cachingTestConfig{Host: "preset"}
+	if err := (&FaultInjector[cachingTestConfig]{Loader: &countingLoader{host: "db-1"}, DropRate: 1}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "preset" || cfg.Port != 0 {
+		t.Errorf("expected every loaded field to be dropped, got %+v", cfg)
+	}
+}
+
+func TestFaultInjector_Load_AddsLatency(t *testing.T) {
+	start := time.Now()
+	if err := (&FaultInjector[cachingTestConfig]{Loader: &countingLoader{}, Latency: 20 * time.Millisecond}).Load(&cachingTestConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected at least 20ms latency, got %s", elapsed)
+	}
+}
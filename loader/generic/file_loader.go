@@ -0,0 +1,174 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// FileLoader loads configuration from a JSON, YAML, TOML or INI file, choosing the format at
+// run time so callers can accept any of them without picking the loader type. The format is
+// taken from Format, or detected with DetectFormat.
+//
+// Example:
+//
+//	// --config may name config.json, config.yaml, config.toml or config.ini
+//	ldr := &generic.FileLoader[Config]{Path: *configPath}
+type FileLoader[T any] struct {
+	Path     string          // Path of the file to load
+	Format   string          // Optional format override: "json", "yaml", "toml" or "ini"
+	Schema   *schema.Schema  // Optional JSON Schema that JSON, YAML and TOML files must satisfy
+	Decoders *utils.Decoders // Decoders for custom types in TOML files (defaults to utils.DefaultDecoders)
+
+	cache *loader.SourceCache // Cache the file is read from and stored in, set by SetSourceCache
+}
+
+// Load reads the file, detects its format and populates c with the matching loader.
+func (f *FileLoader[T]) Load(c *T) error {
+	data, err := readFile(f.cache, f.Path)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "FileLoader", Operation: "read file", Source: f.Path, Err: err}
+	}
+	return loadDocument(c, "FileLoader", f.Path, data, f.Format, f.Schema, f.Decoders)
+}
+
+// SetSchema sets the JSON Schema that JSON, YAML and TOML files are validated against.
+func (f *FileLoader[T]) SetSchema(s *schema.Schema) {
+	f.Schema = s
+}
+
+// SetDecoders sets the decoders used for custom types in TOML files.
+func (f *FileLoader[T]) SetDecoders(d *utils.Decoders) {
+	f.Decoders = d
+}
+
+// VerifySources checks that the file exists and can be opened.
+func (f *FileLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	return verifyFileSource("FileLoader", f.Path)
+}
+
+// SetSourceCache sets the cache the file is read from and stored in.
+func (f *FileLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	f.cache = cache
+}
+
+// Prefetch reads the file into the source cache.
+func (f *FileLoader[T]) Prefetch(_ context.Context, _ []loader.Field) error {
+	return prefetchFile("FileLoader", f.cache, f.Path)
+}
+
+// DocumentLoader loads configuration from a JSON, YAML, TOML or INI document held in memory,
+// such as one downloaded from object storage, choosing the loader by its format. The format
+// is taken from Format, or detected with DetectFormat from Name and Data.
+type DocumentLoader[T any] struct {
+	Name     string          // Name of the document, e.g. a path or URI; its extension is used for detection and it is reported in errors
+	Data     []byte          // Document contents
+	Format   string          // Optional format override: "json", "yaml", "toml" or "ini"
+	Schema   *schema.Schema  // Optional JSON Schema that JSON, YAML and TOML documents must satisfy
+	Decoders *utils.Decoders // Decoders for custom types in TOML documents (defaults to utils.DefaultDecoders)
+}
+
+// Load detects the document's format and populates c with the matching loader.
+func (d *DocumentLoader[T]) Load(c *T) error {
+	return loadDocument(c, "DocumentLoader", d.Name, d.Data, d.Format, d.Schema, d.Decoders)
+}
+
+// SetSchema sets the JSON Schema that JSON, YAML and TOML documents are validated against.
+func (d *DocumentLoader[T]) SetSchema(s *schema.Schema) {
+	d.Schema = s
+}
+
+// SetDecoders sets the decoders used for custom types in TOML documents.
+func (d *DocumentLoader[T]) SetDecoders(decoders *utils.Decoders) {
+	d.Decoders = decoders
+}
+
+// loadDocument loads data named name into c with the loader for format, or for the detected
+// format when it is empty. Errors name name rather than the bytes.
+func loadDocument[T any](c *T, loaderType, name string, data []byte, format string, s *schema.Schema, d *utils.Decoders) error {
+	format = strings.ToLower(format)
+	if format == "" {
+		format = DetectFormat(name, data)
+	}
+	var ldr interface{ Load(*T) error }
+	switch format {
+	case "json":
+		ldr = &JSONLoader[T]{Source: data, Schema: s}
+	case "yaml", "yml":
+		ldr = &YAMLLoader[T]{Source: data, Schema: s}
+	case "toml":
+		ldr = &TOMLLoader[T]{Source: data, Schema: s, Decoders: d}
+	case "ini":
+		ldr = &IniLoader[T]{Source: data}
+	default:
+		return &loader.LoaderError{
+			LoaderType: loaderType,
+			Operation:  "detect format",
+			Source:     name,
+			Err:        fmt.Errorf("unsupported format %q", format),
+		}
+	}
+
+	err := ldr.Load(c)
+	var loaderErr *loader.LoaderError
+	if errors.As(err, &loaderErr) && loaderErr.Source == "<bytes>" {
+		loaderErr.Source = name
+	}
+	return err
+}
+
+// DetectFormat returns the format of a configuration file: "json", "yaml", "toml" or "ini".
+// The extensions .json, .yaml, .yml, .toml, .ini and .cfg decide the format. Otherwise the
+// contents are sniffed: a JSON object or array is JSON, a valid TOML document is TOML, a
+// document with [section] headers or key = value lines is INI, and anything else is YAML.
+func DetectFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	case ".ini", ".cfg":
+		return "ini"
+	}
+
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return "json"
+	}
+	if len(trimmed) > 0 {
+		if _, err := parseTOML(trimmed); err == nil {
+			return "toml"
+		}
+	}
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' && line[len(line)-1] == ']' {
+			return "ini"
+		}
+		eq, colon := strings.Index(line, "="), strings.Index(line, ":")
+		if eq > 0 && (colon < 0 || eq < colon) {
+			return "ini"
+		}
+	}
+	return "yaml"
+}
+
+// SourceFiles returns Path, so the configuration can be reloaded when the file changes.
+func (f *FileLoader[T]) SourceFiles() []string {
+	return []string{f.Path}
+}
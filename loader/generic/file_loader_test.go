@@ -0,0 +1,111 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type fileLoaderTestConfig struct {
+	Name string `json:"name" yaml:"name" toml:"name" ini:"name"`
+}
+
+func TestFileLoader_Load_Formats(t *testing.T) {
+	tests := []struct {
+		file, contents string
+	}{
+		{"config.json", `{"name": "test"}`},
+		{"config.yaml", "name: test\n"},
+		{"config.yml", "name: test\n"},
+		{"config.toml", "name = \"test\"\n"},
+		{"config.ini", "name = test\n"},
+		{"json.conf", `{"name": "test"}`},
+		{"yaml.conf", "name: test\n"},
+		{"toml.conf", "name = 'test'\n"},
+		{"ini.conf", "; comment\nname = test\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.contents), 0o600); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			cfg := &fileLoaderTestConfig{}
+			if err := (&FileLoader[fileLoaderTestConfig]{Path: path}).Load(cfg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Name != "test" {
+				t.Errorf("expected name %q, got %q", "test", cfg.Name)
+			}
+		})
+	}
+}
+
+func TestFileLoader_Load_FormatOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.txt")
+	if err := os.WriteFile(path, []byte("name = test\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	cfg := &fileLoaderTestConfig{}
+	err := (&FileLoader[fileLoaderTestConfig]{Path: path, Format: "yaml"}).Load(cfg)
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "YAMLLoader" || loaderErr.Source != path {
+		t.Fatalf("expected YAMLLoader error naming %s, got %v", path, err)
+	}
+
+	err = (&FileLoader[fileLoaderTestConfig]{Path: path, Format: "xml"}).Load(cfg)
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "detect format" {
+		t.Errorf("expected detect format error, got %v", err)
+	}
+}
+
+func TestFileLoader_Load_Missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+	err := (&FileLoader[fileLoaderTestConfig]{Path: path}).Load(&fileLoaderTestConfig{})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "read file" || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected read file error, got %v", err)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		path, contents, want string
+	}{
+		{"app.JSON", "", "json"},
+		{"app.cfg", "", "ini"},
+		{"apprc", "[1, 2]", "json"},
+		{"apprc", "[server]\nport = 8080\n", "toml"},
+		{"apprc", "[server]\nhost = localhost\n", "ini"},
+		{"apprc", "server:\n  port: 8080\n", "yaml"},
+		{"apprc", "url: http://example.com/?a=b\n", "yaml"},
+		{"apprc", "", "yaml"},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.path, []byte(tt.contents)); got != tt.want {
+			t.Errorf("DetectFormat(%q, %q) = %q, want %q", tt.path, tt.contents, got, tt.want)
+		}
+	}
+}
+
+func TestDocumentLoader_Load(t *testing.T) {
+	cfg := &fileLoaderTestConfig{}
+	ldr := &DocumentLoader[fileLoaderTestConfig]{Name: "gs://bucket/config", Data: []byte("name = 'test'\n")}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "test" {
+		t.Errorf("expected name %q, got %q", "test", cfg.Name)
+	}
+
+	ldr = &DocumentLoader[fileLoaderTestConfig]{Name: "gs://bucket/config.json", Data: []byte("{")}
+	var loaderErr *loader.LoaderError
+	if err := ldr.Load(cfg); !errors.As(err, &loaderErr) || loaderErr.Source != "gs://bucket/config.json" {
+		t.Errorf("expected error naming the document, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+)
+
+// FixtureMode selects how a FixtureLoader treats its wrapped loader.
+type FixtureMode string
+
+const (
+	// FixturePassthrough runs the wrapped loader without touching the fixture file.
+	FixturePassthrough FixtureMode = ""
+	// FixtureRecord runs the wrapped loader and saves its result to the fixture file.
+	FixtureRecord FixtureMode = "record"
+	// FixtureReplay serves the saved result without running the wrapped loader.
+	FixtureReplay FixtureMode = "replay"
+)
+
+// FixtureLoader wraps a remote loader to record its results to a fixture file and replay
+// them later, so CI runs are hermetic and production load behaviour can be reproduced
+// locally without credentials.
+//
+// A fixture file holds one entry per Key, so several loaders can share a file. As with
+// CachingLoader, the wrapped loader runs against a copy of c, the top-level fields it
+// changes are recorded and applied to c, and entries are stored as JSON using the struct's
+// json tags, with fields JSON cannot round-trip, such as config.Secret fields, gob-encoded.
+// Fixtures contain real values, including the plaintext of Secret fields; review them
+// before committing and never record production secrets.
+//
+// Example:
+//
+//	ldr := &generic.FixtureLoader[Config]{
+//	    Loader: &aws.SSMParameterStoreLoader[Config]{Path: "/myapp/staging"},
+//	    Key:    "ssm:/myapp/staging",
+//	    Path:   "testdata/config-fixtures.json",
+//	    Mode:   generic.FixtureMode(os.Getenv("CONFIG_FIXTURE_MODE")),
+//	}
+type FixtureLoader[T any] struct {
+	Loader interface{ Load(c *T) error } // Loader whose results are recorded or replayed
+	Key    string                        // Identity of the source within the fixture file (defaults to the wrapped loader's type and settings)
+	Path   string                        // Fixture file path
+	Mode   FixtureMode                   // Passthrough (default), record or replay
+}
+
+// Load runs, records or replays the wrapped loader according to Mode.
+func (f *FixtureLoader[T]) Load(c *T) error {
+	return f.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to the wrapped loader if it has a LoadContext
+// method.
+func (f *FixtureLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	switch f.Mode {
+	case FixturePassthrough:
+		if f.Loader == nil {
+			return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "validate loader", Source: f.Path, Err: errors.New("Loader is nil")}
+		}
+		return loadWrapped(ctx, f.Loader, c)
+	case FixtureRecord:
+		return f.record(ctx, c)
+	case FixtureReplay:
+		return f.replay(c)
+	default:
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "validate mode", Source: f.Path, Err: fmt.Errorf("unknown fixture mode %q", f.Mode)}
+	}
+}
+
+// record runs the wrapped loader with ctx and stores its result under Key.
+func (f *FixtureLoader[T]) record(ctx context.Context, c *T) error {
+	if f.Loader == nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "validate loader", Source: f.Path, Err: errors.New("Loader is nil")}
+	}
+
+	loaded := *c
+	if err := loadWrapped(ctx, f.Loader, &loaded); err != nil {
+		return err
+	}
+
+	changed := changedFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&loaded).Elem())
+	encoded, err := encodeFields(&loaded, changed)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "encode payload", Source: f.Path, Err: err}
+	}
+
+	fixtures, err := readFixtures(f.Path)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "read fixtures", Source: f.Path, Err: err}
+	}
+	if fixtures == nil {
+		fixtures = make(map[string]encodedFields)
+	}
+	fixtures[sourceKey(f.Key, f.Loader)] = encoded
+
+	data, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "encode fixtures", Source: f.Path, Err: err}
+	}
+	if dir := filepath.Dir(f.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "write fixtures", Source: f.Path, Err: err}
+		}
+	}
+	if err := os.WriteFile(f.Path, append(data, '\n'), 0o600); err != nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "write fixtures", Source: f.Path, Err: err}
+	}
+
+	copyFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(&loaded).Elem(), changed)
+	return nil
+}
+
+// replay applies the result stored under Key.
+func (f *FixtureLoader[T]) replay(c *T) error {
+	fixtures, err := readFixtures(f.Path)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "read fixtures", Source: f.Path, Err: err}
+	}
+
+	key := sourceKey(f.Key, f.Loader)
+	entry, ok := fixtures[key]
+	if !ok {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "replay", Source: f.Path, Err: fmt.Errorf("no fixture recorded for %q: %w", key, fs.ErrNotExist)}
+	}
+	if entry.Fields == nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "replay", Source: f.Path, Err: fmt.Errorf("fixture for %q was recorded by an older version; record it again", key)}
+	}
+
+	if err := decodeFields(c, entry); err != nil {
+		return &loader.LoaderError{LoaderType: "FixtureLoader", Operation: "decode payload", Source: f.Path, Err: err}
+	}
+	return nil
+}
+
+// VerifySources delegates to the wrapped loader unless replaying, when no remote source is used.
+func (f *FixtureLoader[T]) VerifySources(ctx context.Context, fields []loader.Field) []loader.SourceCheck {
+	if f.Mode == FixtureReplay {
+		return verifyFileSource("FixtureLoader", f.Path)
+	}
+	if verifier, ok := f.Loader.(loader.SourceVerifier); ok {
+		return verifier.VerifySources(ctx, fields)
+	}
+	return nil
+}
+
+// SetSchema passes s on to the wrapped loader if it supports schema validation.
+func (f *FixtureLoader[T]) SetSchema(s *schema.Schema) {
+	setWrappedSchema(f.Loader, s)
+}
+
+// SourceFiles returns the fixture file when replaying, or the files read by the wrapped
+// loader otherwise.
+func (f *FixtureLoader[T]) SourceFiles() []string {
+	if f.Mode == FixtureReplay {
+		return []string{f.Path}
+	}
+	return wrappedSourceFiles(f.Loader)
+}
+
+// Watch reports the changes reported by the wrapped loader unless replaying, when its
+// sources are not read.
+func (f *FixtureLoader[T]) Watch(ctx context.Context, changed func()) error {
+	if f.Mode == FixtureReplay {
+		<-ctx.Done()
+		return nil
+	}
+	return watchWrapped(ctx, f.Loader, changed)
+}
+
+// readFixtures returns the entries in the fixture file at path, or nil if it does not exist.
+func readFixtures(path string) (map[string]encodedFields, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures map[string]encodedFields
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
@@ -0,0 +1,102 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+func TestFixtureLoader_RecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "config.json")
+	remote := &countingLoader{host: "db-1"}
+	other := &countingLoader{host: "db-other"}
+
+	recordCfg := &cachingTestConfig{Local: "kept"}
+	if err := (&FixtureLoader[cachingTestConfig]{Loader: remote, Key: "primary", Path: path, Mode: FixtureRecord}).Load(recordCfg); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+	if err := (&FixtureLoader[cachingTestConfig]{Loader: other, Key: "secondary", Path: path, Mode: FixtureRecord}).Load(&cachingTestConfig{}); err != nil {
+		t.Fatalf("record of second key failed: %v", err)
+	}
+	if recordCfg.Host != "db-1" || recordCfg.Local != "kept" {
+		t.Errorf("unexpected recorded config: %+v", recordCfg)
+	}
+
+	remote.err = errors.New("no credentials")
+	replayCfg := &cachingTestConfig{}
+	if err := (&FixtureLoader[cachingTestConfig]{Loader: remote, Key: "primary", Path: path, Mode: FixtureReplay}).Load(replayCfg); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if replayCfg.Host != "db-1" || replayCfg.Port != 5432 {
+		t.Errorf("unexpected replayed config: %+v", replayCfg)
+	}
+	if remote.calls != 1 {
+		t.Errorf("expected wrapped loader to run only while recording, ran %d times", remote.calls)
+	}
+}
+
+func TestFixtureLoader_ReplayMissingKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	_ = (&FixtureLoader[cachingTestConfig]{Loader: &countingLoader{host: "db-1"}, Key: "primary", Path: path, Mode: FixtureRecord}).Load(&cachingTestConfig{})
+
+	err := (&FixtureLoader[cachingTestConfig]{Key: "unknown", Path: path, Mode: FixtureReplay}).Load(&cachingTestConfig{})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "replay" || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected replay not-exist error, got %v", err)
+	}
+}
+
+func TestFixtureLoader_PassthroughAndUnknownMode(t *testing.T) {
+	remote := &countingLoader{host: "db-1"}
+	cfg := &cachingTestConfig{}
+	if err := (&FixtureLoader[cachingTestConfig]{Loader: remote, Path: "unused.json"}).Load(cfg); err != nil {
+		t.Fatalf("passthrough failed: %v", err)
+	}
+	if cfg.Host != "db-1" {
+		t.Errorf("expected passthrough to load directly, got %+v", cfg)
+	}
+
+	var loaderErr *loader.LoaderError
+	if err := (&FixtureLoader[cachingTestConfig]{Loader: remote, Mode: "rewind"}).Load(cfg); !errors.As(err, &loaderErr) || loaderErr.Operation != "validate mode" {
+		t.Errorf("expected validate mode error, got %v", err)
+	}
+}
+
+// maskedString encodes to JSON as "***", as config.Secret does.
+type maskedString string
+
+func (maskedString) MarshalJSON() ([]byte, error) {
+	return []byte(`"***"`), nil
+}
+
+type maskedFixtureConfig struct {
+	Host     string       `json:"host"`
+	Password maskedString `json:"password"`
+}
+
+type maskedLoader struct{}
+
+func (maskedLoader) Load(c *maskedFixtureConfig) error {
+	c.Host, c.Password = "db-1", "hunter2"
+	return nil
+}
+
+func TestFixtureLoader_ReplaysFieldsJSONMasks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := (&FixtureLoader[maskedFixtureConfig]{Loader: maskedLoader{}, Key: "primary", Path: path, Mode: FixtureRecord}).Load(&maskedFixtureConfig{}); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+
+	cfg := &maskedFixtureConfig{}
+	if err := (&FixtureLoader[maskedFixtureConfig]{Key: "primary", Path: path, Mode: FixtureReplay}).Load(cfg); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if cfg.Host != "db-1" || cfg.Password != "hunter2" {
+		t.Errorf("expected the plaintext to be replayed, got %+v", cfg)
+	}
+}
@@ -0,0 +1,174 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// GraphQLLoader loads configuration from a GraphQL endpoint. It sends Query with Variables
+// and decodes the selected part of the response data into the struct using its json tags.
+//
+// String values in Variables may reference ${VAR}, resolved from fields declaring
+// `config:"availableAs=VAR"` that are already populated, or from Context. References use
+// the interpolation engine's syntax. Used inside an InterpolatingChainLoader this lets the
+// query depend on values loaded in earlier stages, taken from its interpolation engine, so
+// the scoped names of nested sections, such as ${Database.CLUSTER}, resolve and values are
+// checked by its guard. Run on its own, only top-level fields are used, unchecked:
+//
+//	type Config struct {
+//	    Env      string `env:"ENV" config:"availableAs=ENV"`
+//	    LogLevel string `json:"logLevel"`
+//	    MaxConns int    `json:"maxConns"`
+//	}
+//	ldr := &generic.GraphQLLoader[Config]{
+//	    Endpoint:   "https://platform.internal/graphql",
+//	    Query:      `query($env: String!) { appConfig(env: $env) { logLevel maxConns } }`,
+//	    Variables:  map[string]any{"env": "${ENV}"},
+//	    ResultPath: "appConfig",
+//	}
+type GraphQLLoader[T any] struct {
+	Endpoint   string            // HTTP endpoint of the GraphQL API
+	Query      string            // GraphQL query document
+	Variables  map[string]any    // Optional query variables; string values may reference ${VAR}
+	Context    map[string]string // Optional extra values for ${VAR} references
+	ResultPath string            // Optional dot-separated path within "data" selecting the object to decode
+	Client     *http.Client      // Optional HTTP client (defaults to http.DefaultClient)
+	Headers    map[string]string // Optional headers added to the request (e.g. Authorization)
+}
+
+// GraphQLError is an entry of the "errors" array in a GraphQL response.
+type GraphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// GraphQLErrors is returned when the response contains one or more errors.
+type GraphQLErrors []GraphQLError
+
+// Error joins the messages of all reported errors.
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// Load runs the query and decodes the response data into c.
+func (g *GraphQLLoader[T]) Load(c *T) error {
+	return g.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but sends the request with ctx, so its deadline and
+// cancellation stop a slow or unreachable endpoint.
+func (g *GraphQLLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	variables, err := g.resolveVariables(ctx, reflect.ValueOf(c).Elem())
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "interpolate variables", Source: g.Endpoint, Err: err}
+	}
+
+	data, err := g.execute(ctx, variables)
+	if err != nil {
+		return err
+	}
+
+	if g.ResultPath != "" {
+		if data, err = selectJSONPath(data, g.ResultPath); err != nil {
+			return &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "select result", Source: g.Endpoint, Err: err}
+		}
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "decode data", Source: g.Endpoint, Err: err}
+	}
+	return nil
+}
+
+// resolveVariables copies Variables, replacing ${VAR} references in string values with the
+// values loader.Variables returns for ctx and v, or of Context.
+func (g *GraphQLLoader[T]) resolveVariables(ctx context.Context, v reflect.Value) (map[string]any, error) {
+	if len(g.Variables) == 0 {
+		return g.Variables, nil
+	}
+
+	var context map[string]string
+	resolved := make(map[string]any, len(g.Variables))
+	for name, value := range g.Variables {
+		s, ok := value.(string)
+		if !ok || !utils.VariableReference.MatchString(s) {
+			resolved[name] = value
+			continue
+		}
+
+		if context == nil {
+			var err error
+			if context, err = loader.Variables(ctx, v); err != nil {
+				return nil, err
+			}
+			for k, val := range g.Context {
+				context[k] = val
+			}
+		}
+		expanded, missing := utils.ExpandVariables(s, context)
+		resolved[name] = expanded
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("variable %q references undefined %s", name, strings.Join(missing, ", "))
+		}
+	}
+	return resolved, nil
+}
+
+// execute posts the query and returns the raw "data" member of the response.
+func (g *GraphQLLoader[T]) execute(ctx context.Context, variables map[string]any) (json.RawMessage, error) {
+	body, err := json.Marshal(map[string]any{"query": g.Query, "variables": variables})
+	if err != nil {
+		return nil, &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "encode request", Source: g.Endpoint, Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "create request", Source: g.Endpoint, Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range g.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "send request", Source: g.Endpoint, Err: err}
+	}
+	defer resp.Body.Close()
+
+	var gqlResp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected HTTP status %s", resp.Status)
+		}
+		return nil, &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "decode response", Source: g.Endpoint, Err: err}
+	}
+	if len(gqlResp.Errors) > 0 {
+		return nil, &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "execute query", Source: g.Endpoint, Err: gqlResp.Errors}
+	}
+	if len(gqlResp.Data) == 0 || string(gqlResp.Data) == "null" {
+		return nil, &loader.LoaderError{LoaderType: "GraphQLLoader", Operation: "execute query", Source: g.Endpoint, Err: fmt.Errorf("response contains no data")}
+	}
+	return gqlResp.Data, nil
+}
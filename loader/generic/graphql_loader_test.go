@@ -0,0 +1,130 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type graphQLTestConfig struct {
+	Env      string `config:"availableAs=ENV"`
+	LogLevel string `json:"logLevel"`
+	MaxConns int    `json:"maxConns"`
+}
+
+func newGraphQLTestServer(t *testing.T, response string, gotVariables *map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if gotVariables != nil {
+			*gotVariables = req.Variables
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+}
+
+func TestGraphQLLoader_Load(t *testing.T) {
+	var variables map[string]any
+	server := newGraphQLTestServer(t, `{"data":{"appConfig":{"logLevel":"debug","maxConns":20}}}`, &variables)
+	defer server.Close()
+
+	cfg := &graphQLTestConfig{Env: "prod"}
+	ldr := &GraphQLLoader[graphQLTestConfig]{
+		Endpoint:   server.URL,
+		Query:      `query($env: String!, $region: String!) { appConfig(env: $env, region: $region) { logLevel maxConns } }`,
+		Variables:  map[string]any{"env": "${ENV}", "region": "${REGION}", "cluster": "${Database.CLUSTER}", "limit": 5},
+		Context:    map[string]string{"REGION": "eu-west-1", "Database.CLUSTER": "main"},
+		ResultPath: "appConfig",
+	}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.LogLevel != "debug" || cfg.MaxConns != 20 || cfg.Env != "prod" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if variables["env"] != "prod" || variables["region"] != "eu-west-1" || variables["cluster"] != "main" || variables["limit"] != float64(5) {
+		t.Errorf("unexpected variables sent: %v", variables)
+	}
+}
+
+func TestGraphQLLoader_Load_UndefinedVariable(t *testing.T) {
+	ldr := &GraphQLLoader[graphQLTestConfig]{
+		Endpoint:  "http://unused.invalid",
+		Variables: map[string]any{"env": "${ENV}"},
+	}
+
+	var loaderErr *loader.LoaderError
+	err := ldr.Load(&graphQLTestConfig{})
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "interpolate variables" {
+		t.Fatalf("expected interpolate variables error, got %v", err)
+	}
+}
+
+func TestGraphQLLoader_LoadContext_VariableSource(t *testing.T) {
+	var variables map[string]any
+	server := newGraphQLTestServer(t, `{"data":{"logLevel":"info"}}`, &variables)
+	defer server.Close()
+
+	ldr := &GraphQLLoader[graphQLTestConfig]{
+		Endpoint:  server.URL,
+		Variables: map[string]any{"cluster": "${Database.CLUSTER}", "env": "prod"},
+	}
+	ctx := loader.WithVariableSource(context.Background(), func() (map[string]string, error) {
+		return map[string]string{"Database.CLUSTER": "main"}, nil
+	})
+	cfg := &graphQLTestConfig{}
+	if err := ldr.LoadContext(ctx, cfg); err != nil || cfg.LogLevel != "info" {
+		t.Fatalf("LoadContext() = %+v, %v", cfg, err)
+	}
+	if variables["cluster"] != "main" || variables["env"] != "prod" {
+		t.Errorf("unexpected variables sent: %v", variables)
+	}
+
+	rejected := errors.New("rejected by guard")
+	ctx = loader.WithVariableSource(context.Background(), func() (map[string]string, error) {
+		return nil, rejected
+	})
+	var loaderErr *loader.LoaderError
+	err := ldr.LoadContext(ctx, &graphQLTestConfig{Env: "prod"})
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "interpolate variables" || !errors.Is(err, rejected) {
+		t.Errorf("expected the rejected variable to fail interpolation, got %v", err)
+	}
+}
+
+func TestGraphQLLoader_Load_QueryErrors(t *testing.T) {
+	server := newGraphQLTestServer(t, `{"data":null,"errors":[{"message":"not authorised","path":["appConfig"]}]}`, nil)
+	defer server.Close()
+
+	ldr := &GraphQLLoader[graphQLTestConfig]{Endpoint: server.URL, Query: `{ appConfig { logLevel } }`}
+	err := ldr.Load(&graphQLTestConfig{})
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) || len(gqlErrs) != 1 || gqlErrs[0].Message != "not authorised" {
+		t.Fatalf("expected GraphQLErrors, got %T: %v", err, err)
+	}
+}
+
+func TestGraphQLLoader_Load_MissingResultPath(t *testing.T) {
+	server := newGraphQLTestServer(t, `{"data":{"other":{}}}`, nil)
+	defer server.Close()
+
+	ldr := &GraphQLLoader[graphQLTestConfig]{Endpoint: server.URL, Query: `{ other { id } }`, ResultPath: "appConfig"}
+
+	var loaderErr *loader.LoaderError
+	if err := ldr.Load(&graphQLTestConfig{}); !errors.As(err, &loaderErr) || loaderErr.Operation != "select result" {
+		t.Fatalf("expected select result error, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+)
+
+// DefaultHTTPTimeout is the request timeout of HTTPLoader when Timeout is zero.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// HTTPLoader loads configuration from a JSON or YAML document served over HTTP, such as
+// a central configuration service.
+//
+// The loader remembers the last document with its ETag and Last-Modified headers and sends
+// them back as If-None-Match and If-Modified-Since, so a reload of an unchanged document
+// costs a 304 response. It implements VersionedSource with a HEAD request, so a
+// CachingLoader around it can revalidate its disk cache cheaply.
+//
+// The format is taken from Format, then from the response Content-Type, then from the
+// extension of the URL path, defaulting to JSON. Errors are LoaderErrors with the URL,
+// without any password, as the Source.
+//
+// With DryRun, Load makes no request and leaves the config unchanged, and PlanFetches
+// reports the URL it would get.
+//
+// Example:
+//
+//	ldr := &generic.HTTPLoader[Config]{
+//	    URL:     "https://config.internal/payments/prod.yaml",
+//	    Headers: map[string]string{"Authorization": "Bearer " + token},
+//	    Timeout: 5 * time.Second,
+//	}
+type HTTPLoader[T any] struct {
+	URL     string            // Document URL
+	Format  string            // Optional "json" or "yaml", overriding the Content-Type
+	Headers map[string]string // Optional headers added to each request (e.g. Authorization)
+	Timeout time.Duration     // Request timeout (defaults to DefaultHTTPTimeout); ignored when Client is set
+	TLS     *tls.Config       // Optional TLS settings, e.g. a private CA or client certificate; ignored when Client is set
+	Client  *http.Client      // Optional HTTP client, replacing the one built from Timeout and TLS
+	Schema  *schema.Schema    // Optional JSON Schema the document must satisfy before it is decoded
+	DryRun  bool              // Make no request in Load, leaving the config unchanged
+
+	mu           sync.Mutex
+	body         []byte // Last document received
+	format       string // Format of body
+	etag         string // ETag of body
+	lastModified string // Last-Modified of body
+}
+
+// Load fetches the document, or reuses the previous one if the server reports it unchanged,
+// and decodes it into c.
+func (h *HTTPLoader[T]) Load(c *T) error {
+	return h.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but sends the request with ctx, so its deadline and
+// cancellation stop a slow or unreachable server.
+func (h *HTTPLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	if h.DryRun {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	req, err := h.newRequest(ctx, http.MethodGet)
+	if err != nil {
+		return h.loaderError("create request", err)
+	}
+	if h.body != nil {
+		if h.etag != "" {
+			req.Header.Set("If-None-Match", h.etag)
+		}
+		if h.lastModified != "" {
+			req.Header.Set("If-Modified-Since", h.lastModified)
+		}
+	}
+
+	resp, err := h.client().Do(req)
+	if err != nil {
+		return h.loaderError("send request", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && h.body != nil:
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return h.loaderError("read response", err)
+		}
+		h.body = body
+		h.format = h.responseFormat(resp)
+		h.etag = resp.Header.Get("ETag")
+		h.lastModified = resp.Header.Get("Last-Modified")
+	default:
+		return h.loaderError("fetch document", fmt.Errorf("unexpected HTTP status %s", resp.Status))
+	}
+
+	var decoder interface{ Load(c *T) error } = &JSONLoader[T]{Source: h.body, Schema: h.Schema}
+	if h.format == "yaml" {
+		decoder = &YAMLLoader[T]{Source: h.body, Schema: h.Schema}
+	}
+	if err := decoder.Load(c); err != nil {
+		var loaderErr *loader.LoaderError
+		if errors.As(err, &loaderErr) {
+			return h.loaderError(loaderErr.Operation, loaderErr.Err)
+		}
+		return h.loaderError("decode document", err)
+	}
+	return nil
+}
+
+// PlanFetches returns a fetch for the document, with the URL without any password as the
+// Source, without making a request.
+func (h *HTTPLoader[T]) PlanFetches(fields []loader.Field) []loader.Fetch {
+	return []loader.Fetch{{LoaderType: "HTTPLoader", Source: h.redactedURL(), Action: http.MethodGet}}
+}
+
+// SourceVersion returns the ETag, or failing that the Last-Modified date, reported by a
+// HEAD request for the document.
+func (h *HTTPLoader[T]) SourceVersion() (string, error) {
+	return h.SourceVersionContext(context.Background())
+}
+
+// SourceVersionContext is like SourceVersion, but sends the request with ctx.
+func (h *HTTPLoader[T]) SourceVersionContext(ctx context.Context) (string, error) {
+	req, err := h.newRequest(ctx, http.MethodHead)
+	if err != nil {
+		return "", h.loaderError("create request", err)
+	}
+	resp, err := h.client().Do(req)
+	if err != nil {
+		return "", h.loaderError("send request", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", h.loaderError("check version", fmt.Errorf("unexpected HTTP status %s", resp.Status))
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		return etag, nil
+	}
+	return resp.Header.Get("Last-Modified"), nil
+}
+
+// String describes the loader by its URL, without any password. It keeps the cache key a
+// CachingLoader derives from the loader stable while the remembered document changes.
+func (h *HTTPLoader[T]) String() string {
+	return "HTTPLoader " + h.redactedURL()
+}
+
+// SetSchema sets the JSON Schema the document is validated against.
+func (h *HTTPLoader[T]) SetSchema(s *schema.Schema) {
+	h.Schema = s
+}
+
+// newRequest returns a request for the document with ctx and Headers set.
+func (h *HTTPLoader[T]) newRequest(ctx context.Context, method string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, h.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml;q=0.9, */*;q=0.1")
+	for k, v := range h.Headers {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// client returns Client, or a client with Timeout and TLS.
+func (h *HTTPLoader[T]) client() *http.Client {
+	if h.Client != nil {
+		return h.Client
+	}
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+	if h.TLS != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = h.TLS
+		client.Transport = transport
+	}
+	return client
+}
+
+// responseFormat returns "json" or "yaml" for a response.
+func (h *HTTPLoader[T]) responseFormat(resp *http.Response) string {
+	if h.Format != "" {
+		return strings.ToLower(h.Format)
+	}
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		switch {
+		case strings.HasSuffix(mediaType, "yaml"):
+			return "yaml"
+		case strings.HasSuffix(mediaType, "json"):
+			return "json"
+		}
+	}
+	if u, err := url.Parse(h.URL); err == nil {
+		if ext := path.Ext(u.Path); ext == ".yaml" || ext == ".yml" {
+			return "yaml"
+		}
+	}
+	return "json"
+}
+
+// loaderError returns a LoaderError for operation with the URL, without any password, as Source.
+func (h *HTTPLoader[T]) loaderError(operation string, err error) error {
+	return &loader.LoaderError{LoaderType: "HTTPLoader", Operation: operation, Source: h.redactedURL(), Err: err}
+}
+
+// redactedURL returns URL with any password replaced.
+func (h *HTTPLoader[T]) redactedURL() string {
+	if u, err := url.Parse(h.URL); err == nil {
+		return u.Redacted()
+	}
+	return h.URL
+}
@@ -1,7 +1,11 @@
+//go:build !tinygo
+
 package generic
 
 import (
+	"context"
 	"fmt"
+	"io"
 
 	"github.com/gymshark/go-easy-config/loader"
 	"gopkg.in/ini.v1"
@@ -9,24 +13,41 @@ import (
 
 // IniLoader loads configuration from INI files or byte arrays.
 type IniLoader[T any] struct {
-	Source      interface{}     // Either a file path (string) or raw INI data ([]byte)
+	Source      interface{}     // A file path (string), raw INI data ([]byte) or an io.Reader
 	LoadOptions ini.LoadOptions // Options for INI parsing
 	INI         *ini.File       // Parsed INI file data structure (populated after Load)
+
+	cache *loader.SourceCache // Cache for a file path Source, set by SetSourceCache
 }
 
 // Load populates configuration from INI source using struct tags.
 func (i *IniLoader[T]) Load(c *T) error {
 	var source string
+	iniSource := i.Source
 	switch src := i.Source.(type) {
 	case string:
 		source = src
+		if i.cache != nil {
+			contents, err := readFile(i.cache, src)
+			if err != nil {
+				return &loader.LoaderError{
+					LoaderType: "INILoader",
+					Operation:  "load INI file",
+					Source:     source,
+					Err:        err,
+				}
+			}
+			iniSource = contents
+		}
 	case []byte:
 		source = "<bytes>"
+	case io.Reader:
+		source = "<reader>"
 	default:
 		source = fmt.Sprintf("%T", src)
 	}
 
-	data, err := ini.LoadSources(i.LoadOptions, i.Source)
+	data, err := ini.LoadSources(i.LoadOptions, iniSource)
 	if err != nil {
 		return &loader.LoaderError{
 			LoaderType: "INILoader",
@@ -49,3 +70,24 @@ func (i *IniLoader[T]) Load(c *T) error {
 
 	return nil
 }
+
+// VerifySources checks that a file path Source exists and can be opened.
+func (i *IniLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	return verifyFileSource("INILoader", i.Source)
+}
+
+// SetSourceCache sets the cache a file path Source is read from and stored in.
+func (i *IniLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	i.cache = cache
+}
+
+// Prefetch reads a file path Source into the source cache.
+func (i *IniLoader[T]) Prefetch(_ context.Context, _ []loader.Field) error {
+	return prefetchFile("INILoader", i.cache, i.Source)
+}
+
+// SourceFiles returns the file path Source, if it is one, so the configuration can be
+// reloaded when the file changes.
+func (i *IniLoader[T]) SourceFiles() []string {
+	return sourceFiles(i.Source)
+}
@@ -1,7 +1,10 @@
+//go:build !tinygo
+
 package generic
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -73,3 +76,14 @@ func TestIniLoader_Load_BytesSource(t *testing.T) {
 		t.Errorf("unexpected config values: %+v", cfg)
 	}
 }
+
+func TestIniLoader_Load_ReaderSource(t *testing.T) {
+	cfg := &testIniConfig{}
+	loader := IniLoader[testIniConfig]{Source: strings.NewReader("[DEFAULT]\nField1 = value1\nField2 = value2\nField3 = value3\n")}
+	if err := loader.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Field1 != "value1" || cfg.Field2 != "value2" || cfg.Field3 != "value3" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
@@ -1,16 +1,21 @@
 package generic
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
 )
 
 // JSONLoader loads configuration from JSON files or byte arrays.
 type JSONLoader[T any] struct {
-	Source interface{} // Either a file path (string) or raw JSON data ([]byte)
+	Source interface{}    // A file path (string), raw JSON data ([]byte) or an io.Reader
+	Schema *schema.Schema // Optional JSON Schema the document must satisfy before it is decoded
+
+	cache *loader.SourceCache // Cache for a file path Source, set by SetSourceCache
 }
 
 // Load populates configuration from JSON source.
@@ -22,7 +27,7 @@ func (j *JSONLoader[T]) Load(c *T) error {
 	switch src := j.Source.(type) {
 	case string:
 		source = src
-		data, err = os.ReadFile(src)
+		data, err = readFile(j.cache, src)
 		if err != nil {
 			return &loader.LoaderError{
 				LoaderType: "JSONLoader",
@@ -34,6 +39,17 @@ func (j *JSONLoader[T]) Load(c *T) error {
 	case []byte:
 		data = src
 		source = "<bytes>"
+	case io.Reader:
+		source = "<reader>"
+		data, err = io.ReadAll(src)
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "JSONLoader",
+				Operation:  "read source",
+				Source:     source,
+				Err:        err,
+			}
+		}
 	default:
 		return &loader.LoaderError{
 			LoaderType: "JSONLoader",
@@ -43,6 +59,17 @@ func (j *JSONLoader[T]) Load(c *T) error {
 		}
 	}
 
+	if j.Schema != nil {
+		if err := j.Schema.ValidateJSON(data); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "JSONLoader",
+				Operation:  "validate schema",
+				Source:     source,
+				Err:        err,
+			}
+		}
+	}
+
 	if err := json.Unmarshal(data, c); err != nil {
 		return &loader.LoaderError{
 			LoaderType: "JSONLoader",
@@ -53,3 +80,29 @@ func (j *JSONLoader[T]) Load(c *T) error {
 	}
 	return nil
 }
+
+// SetSchema sets the JSON Schema the document is validated against.
+func (j *JSONLoader[T]) SetSchema(s *schema.Schema) {
+	j.Schema = s
+}
+
+// VerifySources checks that a file path Source exists and can be opened.
+func (j *JSONLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	return verifyFileSource("JSONLoader", j.Source)
+}
+
+// SetSourceCache sets the cache a file path Source is read from and stored in.
+func (j *JSONLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	j.cache = cache
+}
+
+// Prefetch reads a file path Source into the source cache.
+func (j *JSONLoader[T]) Prefetch(_ context.Context, _ []loader.Field) error {
+	return prefetchFile("JSONLoader", j.cache, j.Source)
+}
+
+// SourceFiles returns the file path Source, if it is one, so the configuration can be
+// reloaded when the file changes.
+func (j *JSONLoader[T]) SourceFiles() []string {
+	return sourceFiles(j.Source)
+}
@@ -1,8 +1,13 @@
 package generic
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
 )
 
 type testJSONConfig struct {
@@ -73,3 +78,42 @@ func TestJSONLoader_Load_BytesSource(t *testing.T) {
 		t.Errorf("unexpected config values: %+v", cfg)
 	}
 }
+
+func TestJSONLoader_Load_ReaderSource(t *testing.T) {
+	cfg := &testJSONConfig{}
+	loader := JSONLoader[testJSONConfig]{Source: strings.NewReader(`{"Field1":"value1","Field2":"value2","Field3":"value3"}`)}
+	if err := loader.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Field1 != "value1" || cfg.Field2 != "value2" || cfg.Field3 != "value3" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestJSONLoader_Load_SchemaViolation(t *testing.T) {
+	s, err := schema.Parse([]byte(`{"type":"object","required":["Field1"],"properties":{"Field2":{"type":"string","maxLength":3}}}`))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	cfg := &testJSONConfig{}
+	ldr := &JSONLoader[testJSONConfig]{Source: []byte(`{"Field2":"too long"}`), Schema: s}
+	err = ldr.Load(cfg)
+
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "validate schema" {
+		t.Fatalf("expected validate schema error, got %v", err)
+	}
+	var schemaErrs schema.ValidationErrors
+	if !errors.As(err, &schemaErrs) || len(schemaErrs) != 2 {
+		t.Errorf("expected two schema violations, got %v", err)
+	}
+	if cfg.Field2 != "" {
+		t.Errorf("expected document not to be decoded, got Field2 %q", cfg.Field2)
+	}
+
+	ldr.Source = []byte(`{"Field1":"ok","Field2":"abc"}`)
+	if err := ldr.Load(cfg); err != nil || cfg.Field1 != "ok" {
+		t.Errorf("expected valid document to load, got %v (%+v)", err, cfg)
+	}
+}
@@ -0,0 +1,244 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// RPCCaller performs a single JSON-RPC style call and returns the raw result.
+// Implement it to reach services over transports other than HTTP, such as an MCP
+// server on stdio.
+type RPCCaller interface {
+	Call(ctx context.Context, method string, params map[string]any) (json.RawMessage, error)
+}
+
+// JSONRPCLoader loads configuration from a JSON-RPC 2.0 or MCP-style configuration service.
+// Each field tagged with `jsonrpc` results in one call. The tag holds the method name followed
+// by comma-separated params, and an optional result path selecting a value inside the result:
+//
+//	type Config struct {
+//	    DBHost string `jsonrpc:"config.get,key=db.host,env=prod"`
+//	    // MCP tool call returning {"content":[{"type":"text","text":"..."}]}
+//	    APIKey string `jsonrpc:"tools/call,name=get_config,arguments.key=api_key,result=content.0.text"`
+//	}
+//
+// A param name containing a dot builds a nested object (arguments.key=api_key sends
+// {"arguments":{"key":"api_key"}}). Results are decoded into the field with encoding/json;
+// string results are also accepted for numeric, boolean and duration fields.
+type JSONRPCLoader[T any] struct {
+	Endpoint string            // HTTP endpoint of the JSON-RPC service
+	Client   *http.Client      // Optional HTTP client (defaults to http.DefaultClient)
+	Headers  map[string]string // Optional headers added to every request (e.g. Authorization)
+	Caller   RPCCaller         // Optional transport override; Endpoint, Client and Headers are ignored when set
+}
+
+// Load calls the service for every tagged field and stores the results.
+func (j *JSONRPCLoader[T]) Load(c *T) error {
+	return j.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but passes ctx to each call, so its deadline and cancellation
+// stop a slow or unreachable service.
+func (j *JSONRPCLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	caller := j.Caller
+	if caller == nil {
+		caller = &HTTPRPCCaller{Endpoint: j.Endpoint, Client: j.Client, Headers: j.Headers}
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("jsonrpc")
+		if !field.IsExported() || tag == "" {
+			continue
+		}
+
+		method, params, resultPath := parseJSONRPCTag(tag)
+		raw, err := caller.Call(ctx, method, params)
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "JSONRPCLoader",
+				Operation:  fmt.Sprintf("call %s for field %s", method, field.Name),
+				Source:     j.Endpoint,
+				Err:        err,
+			}
+		}
+
+		if resultPath != "" {
+			if raw, err = selectJSONPath(raw, resultPath); err != nil {
+				return &loader.LoaderError{
+					LoaderType: "JSONRPCLoader",
+					Operation:  fmt.Sprintf("select result for field %s", field.Name),
+					Source:     j.Endpoint,
+					Err:        err,
+				}
+			}
+		}
+
+		if err := decodeJSONInto(raw, v.Field(i)); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "JSONRPCLoader",
+				Operation:  fmt.Sprintf("decode result for field %s", field.Name),
+				Source:     j.Endpoint,
+				Err:        err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseJSONRPCTag splits a jsonrpc tag into method, params and result path.
+func parseJSONRPCTag(tag string) (string, map[string]any, string) {
+	parts := strings.Split(tag, ",")
+	method := strings.TrimSpace(parts[0])
+	params := make(map[string]any)
+	var resultPath string
+
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if key == "result" {
+			resultPath = value
+			continue
+		}
+
+		// Dotted keys build nested objects
+		target := params
+		segments := strings.Split(key, ".")
+		for _, segment := range segments[:len(segments)-1] {
+			nested, ok := target[segment].(map[string]any)
+			if !ok {
+				nested = make(map[string]any)
+				target[segment] = nested
+			}
+			target = nested
+		}
+		target[segments[len(segments)-1]] = value
+	}
+
+	return method, params, resultPath
+}
+
+// selectJSONPath walks a dot-separated path of object keys and array indices through raw.
+func selectJSONPath(raw json.RawMessage, path string) (json.RawMessage, error) {
+	for _, segment := range strings.Split(path, ".") {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err == nil {
+			next, ok := obj[segment]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found in result", segment)
+			}
+			raw = next
+			continue
+		}
+
+		var arr []json.RawMessage
+		if err := json.Unmarshal(raw, &arr); err != nil {
+			return nil, fmt.Errorf("cannot select %q from non-container value", segment)
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(arr) {
+			return nil, fmt.Errorf("index %q out of range for array of length %d", segment, len(arr))
+		}
+		raw = arr[index]
+	}
+	return raw, nil
+}
+
+// decodeJSONInto decodes raw into the field, falling back to parsing string
+// results for fields whose JSON representation is not a string.
+func decodeJSONInto(raw json.RawMessage, field reflect.Value) error {
+	err := json.Unmarshal(raw, field.Addr().Interface())
+	if err == nil {
+		return nil
+	}
+
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		return err
+	}
+	return utils.SetFromString(field, s)
+}
+
+// HTTPRPCCaller is the default RPCCaller, sending JSON-RPC 2.0 requests over HTTP POST.
+type HTTPRPCCaller struct {
+	Endpoint string            // HTTP endpoint of the JSON-RPC service
+	Client   *http.Client      // Optional HTTP client (defaults to http.DefaultClient)
+	Headers  map[string]string // Optional headers added to every request
+
+	nextID atomic.Int64
+}
+
+// JSONRPCError is a JSON-RPC error object returned by the service.
+type JSONRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error returns the JSON-RPC error code and message.
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+// Call sends a JSON-RPC 2.0 request and returns its result.
+func (h *HTTPRPCCaller) Call(ctx context.Context, method string, params map[string]any) (json.RawMessage, error) {
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      h.nextID.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *JSONRPCError   `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	return rpcResp.Result, nil
+}
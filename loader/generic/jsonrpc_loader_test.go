@@ -0,0 +1,137 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type jsonRPCTestConfig struct {
+	DBHost  string        `jsonrpc:"config.get,key=db.host,env=prod"`
+	DBPort  int           `jsonrpc:"config.get,key=db.port"`
+	APIKey  string        `jsonrpc:"tools/call,name=get_config,arguments.key=api_key,result=content.0.text"`
+	Timeout time.Duration `jsonrpc:"tools/call,name=get_config,arguments.key=timeout,result=content.0.text"`
+	Local   string        `env:"LOCAL"`
+}
+
+func newJSONRPCTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("expected Authorization header, got %q", r.Header.Get("Authorization"))
+		}
+
+		var req struct {
+			JSONRPC string         `json:"jsonrpc"`
+			ID      int64          `json:"id"`
+			Method  string         `json:"method"`
+			Params  map[string]any `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		var result any
+		switch req.Method {
+		case "config.get":
+			switch req.Params["key"] {
+			case "db.host":
+				result = "db.internal"
+			case "db.port":
+				result = 5432
+			}
+		case "tools/call":
+			args := req.Params["arguments"].(map[string]any)
+			text := map[string]string{"api_key": "abc123", "timeout": "3s"}[args["key"].(string)]
+			result = map[string]any{"content": []any{map[string]any{"type": "text", "text": text}}}
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]any{"code": -32601, "message": "method not found"},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestJSONRPCLoader_Load(t *testing.T) {
+	server := newJSONRPCTestServer(t)
+	defer server.Close()
+
+	cfg := &jsonRPCTestConfig{Local: "unchanged"}
+	ldr := &JSONRPCLoader[jsonRPCTestConfig]{
+		Endpoint: server.URL,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.DBHost != "db.internal" || cfg.DBPort != 5432 || cfg.APIKey != "abc123" || cfg.Timeout != 3*time.Second {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if cfg.Local != "unchanged" {
+		t.Errorf("expected untagged field to be left alone, got %q", cfg.Local)
+	}
+}
+
+func TestJSONRPCLoader_Load_RPCError(t *testing.T) {
+	server := newJSONRPCTestServer(t)
+	defer server.Close()
+
+	type config struct {
+		Value string `jsonrpc:"missing.method"`
+	}
+	ldr := &JSONRPCLoader[config]{Endpoint: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}}
+	err := ldr.Load(&config{})
+
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "JSONRPCLoader" {
+		t.Fatalf("expected LoaderError, got %T: %v", err, err)
+	}
+	var rpcErr *JSONRPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("expected JSONRPCError with code -32601, got %v", err)
+	}
+}
+
+type stubRPCCaller struct {
+	results map[string]json.RawMessage
+}
+
+func (s *stubRPCCaller) Call(_ context.Context, method string, params map[string]any) (json.RawMessage, error) {
+	return s.results[method+":"+params["key"].(string)], nil
+}
+
+func TestJSONRPCLoader_Load_CustomCallerAndBadPath(t *testing.T) {
+	type config struct {
+		Value string `jsonrpc:"get,key=a,result=data.missing"`
+	}
+	ldr := &JSONRPCLoader[config]{Caller: &stubRPCCaller{results: map[string]json.RawMessage{
+		"get:a": json.RawMessage(`{"data":{"value":"x"}}`),
+	}}}
+
+	var loaderErr *loader.LoaderError
+	if err := ldr.Load(&config{}); !errors.As(err, &loaderErr) || loaderErr.Operation != "select result for field Value" {
+		t.Fatalf("expected select result error, got %v", err)
+	}
+}
+
+func TestParseJSONRPCTag(t *testing.T) {
+	method, params, result := parseJSONRPCTag("tools/call, name=get, arguments.key=a, arguments.env=prod, result=content.0.text")
+	if method != "tools/call" || result != "content.0.text" {
+		t.Errorf("unexpected method %q or result path %q", method, result)
+	}
+	args, ok := params["arguments"].(map[string]any)
+	if params["name"] != "get" || !ok || args["key"] != "a" || args["env"] != "prod" {
+		t.Errorf("unexpected params: %v", params)
+	}
+}
@@ -0,0 +1,84 @@
+package generic
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// MapLoader loads configuration from an in-memory map of string values.
+// Keys are matched against the name in the field's Tag (defaulting to `env`), or the
+// field name when the tag is absent, and values are parsed according to the field type.
+//
+// It has no OS dependencies, which makes it the natural way to feed configuration
+// supplied by a host runtime (for example in js/wasm or wasip1 builds) or by tests.
+//
+// Example:
+//
+//	ldr := &MapLoader[Config]{Values: map[string]string{"PORT": "8080"}}
+type MapLoader[T any] struct {
+	Values   map[string]string // Configuration values keyed by tag or field name
+	Tag      string            // Struct tag used to name fields (defaults to "env")
+	Decoders *utils.Decoders   // Decoders for custom types (defaults to utils.DefaultDecoders)
+}
+
+// Load populates fields that have a matching key in Values.
+// Fields without a matching key are left unchanged.
+func (m *MapLoader[T]) Load(c *T) error {
+	return m.load(c, nil)
+}
+
+// LoadFields populates the fields named in fields that have a matching key in Values.
+func (m *MapLoader[T]) LoadFields(c *T, fields []string) error {
+	return m.load(c, fields)
+}
+
+// load populates the fields named in fields, or all fields if fields is nil.
+func (m *MapLoader[T]) load(c *T, fields []string) error {
+	tagKey := m.Tag
+	if tagKey == "" {
+		tagKey = "env"
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || (fields != nil && !slices.Contains(fields, field.Name)) {
+			continue
+		}
+
+		key, _, _ := strings.Cut(field.Tag.Get(tagKey), ",")
+		if key == "" {
+			key = field.Name
+		}
+
+		value, ok := m.Values[key]
+		if !ok {
+			continue
+		}
+
+		if err := m.Decoders.SetFromString(v.Field(i), value); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "MapLoader",
+				Operation:  "parse value",
+				Source:     key,
+				Err:        err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (m *MapLoader[T]) SetDecoders(d *utils.Decoders) {
+	m.Decoders = d
+}
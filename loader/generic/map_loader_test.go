@@ -0,0 +1,95 @@
+package generic
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type mapTestConfig struct {
+	Host    string        `env:"HOST" yaml:"host"`
+	Port    int           `env:"PORT,required"`
+	Debug   bool          `env:"DEBUG"`
+	Ratio   float64       `env:"RATIO"`
+	Timeout time.Duration `env:"TIMEOUT"`
+	Name    string
+}
+
+func TestMapLoader_Load(t *testing.T) {
+	cfg := &mapTestConfig{Name: "unchanged"}
+	ldr := &MapLoader[mapTestConfig]{Values: map[string]string{
+		"HOST":    "localhost",
+		"PORT":    "8080",
+		"DEBUG":   "true",
+		"RATIO":   "0.5",
+		"TIMEOUT": "2s",
+	}}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "localhost" || cfg.Port != 8080 || !cfg.Debug || cfg.Ratio != 0.5 || cfg.Timeout != 2*time.Second {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if cfg.Name != "unchanged" {
+		t.Errorf("expected fields without a value to be left untouched, got %q", cfg.Name)
+	}
+}
+
+func TestMapLoader_Load_CustomTagAndFieldName(t *testing.T) {
+	cfg := &mapTestConfig{}
+	ldr := &MapLoader[mapTestConfig]{
+		Tag:    "yaml",
+		Values: map[string]string{"host": "example.com", "Name": "by-field-name", "HOST": "ignored"},
+	}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "example.com" || cfg.Name != "by-field-name" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestMapLoader_Load_InvalidValue(t *testing.T) {
+	ldr := &MapLoader[mapTestConfig]{Values: map[string]string{"PORT": "eighty"}}
+
+	err := ldr.Load(&mapTestConfig{})
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Fatalf("expected LoaderError, got %T: %v", err, err)
+	}
+	if loaderErr.LoaderType != "MapLoader" || loaderErr.Source != "PORT" {
+		t.Errorf("unexpected error context: %+v", loaderErr)
+	}
+}
+
+func TestMapLoader_Load_Decoders(t *testing.T) {
+	type accountConfig struct {
+		Account testAccount `env:"ACCOUNT"`
+	}
+	ldr := &MapLoader[accountConfig]{Values: map[string]string{"ACCOUNT": "payments/42"}}
+	ldr.SetDecoders(testDecoders())
+
+	var cfg accountConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Account != (testAccount{"payments", "42"}) {
+		t.Errorf("unexpected account %+v", cfg.Account)
+	}
+}
+
+func TestMapLoader_LoadFields(t *testing.T) {
+	cfg := &mapTestConfig{}
+	ldr := &MapLoader[mapTestConfig]{Values: map[string]string{"HOST": "localhost", "PORT": "8080"}}
+
+	if err := ldr.LoadFields(cfg, []string{"Port"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 8080 || cfg.Host != "" {
+		t.Errorf("expected only Port to be loaded, got %+v", cfg)
+	}
+}
@@ -0,0 +1,32 @@
+package generic
+
+import (
+	"os"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// readFile returns the contents of path, using and filling cache when it is not nil.
+func readFile(cache *loader.SourceCache, path string) ([]byte, error) {
+	if cache == nil {
+		return os.ReadFile(path)
+	}
+	data, err := cache.Fetch("file:"+path, func() (string, error) {
+		data, err := os.ReadFile(path)
+		return string(data), err
+	})
+	return []byte(data), err
+}
+
+// prefetchFile reads a file path source into cache. Byte slice and reader sources have
+// nothing to prefetch.
+func prefetchFile(loaderType string, cache *loader.SourceCache, source interface{}) error {
+	path, ok := source.(string)
+	if !ok || cache == nil {
+		return nil
+	}
+	if _, err := readFile(cache, path); err != nil {
+		return &loader.LoaderError{LoaderType: loaderType, Operation: "prefetch file", Source: path, Err: err}
+	}
+	return nil
+}
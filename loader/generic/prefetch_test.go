@@ -0,0 +1,72 @@
+//go:build !tinygo
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+func TestFileLoaders_Prefetch(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "config.json")
+	yamlPath := filepath.Join(dir, "config.yaml")
+	iniPath := filepath.Join(dir, "config.ini")
+	for path, content := range map[string]string{
+		jsonPath: `{"Field1":"json"}`,
+		yamlPath: "Field1: yaml\n",
+		iniPath:  "Field1 = ini\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache := loader.NewSourceCache()
+	jsonLoader := &JSONLoader[testJSONConfig]{Source: jsonPath}
+	yamlLoader := &YAMLLoader[testYAMLConfig]{Source: yamlPath}
+	iniLoader := &IniLoader[testIniConfig]{Source: iniPath}
+	for _, prefetcher := range []loader.Prefetcher{jsonLoader, yamlLoader, iniLoader} {
+		prefetcher.SetSourceCache(cache)
+		if err := prefetcher.Prefetch(context.Background(), nil); err != nil {
+			t.Fatalf("Prefetch failed: %v", err)
+		}
+	}
+	if cache.Len() != 3 {
+		t.Fatalf("expected 3 cached files, got %d", cache.Len())
+	}
+
+	// Loads are served from the cache once the files are gone
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	var jsonCfg testJSONConfig
+	if err := jsonLoader.Load(&jsonCfg); err != nil || jsonCfg.Field1 != "json" {
+		t.Errorf("expected cached JSON, got %+v, %v", jsonCfg, err)
+	}
+	var yamlCfg testYAMLConfig
+	if err := yamlLoader.Load(&yamlCfg); err != nil || yamlCfg.Field1 != "yaml" {
+		t.Errorf("expected cached YAML, got %+v, %v", yamlCfg, err)
+	}
+	var iniCfg testIniConfig
+	if err := iniLoader.Load(&iniCfg); err != nil || iniCfg.Field1 != "ini" {
+		t.Errorf("expected cached INI, got %+v, %v", iniCfg, err)
+	}
+}
+
+func TestFileLoaders_Prefetch_MissingFile(t *testing.T) {
+	ldr := &JSONLoader[testJSONConfig]{Source: filepath.Join(t.TempDir(), "missing.json")}
+	ldr.SetSourceCache(loader.NewSourceCache())
+
+	err := ldr.Prefetch(context.Background(), nil)
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "prefetch file" || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected prefetch LoaderError wrapping fs.ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+//go:build !js && !wasip1 && !tinygo
+
+package generic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+	"golang.org/x/term"
+)
+
+// PromptLoader interactively prompts for required fields that are still unset.
+// It is intended to be placed last in the loader chain so that it only asks for
+// values no other source provided, which makes it useful for developer onboarding
+// and one-off operational tools.
+//
+// A field is prompted for when its `validate` tag contains the "required" rule and
+// its current value is zero. Fields tagged with `secret` or marked `config:"sensitive"`
+// are read without echoing the input back to the terminal.
+//
+// The loader is a no-op unless Input is a terminal, so it is safe to leave in the
+// chain for CI pipelines, containers and other non-interactive environments.
+type PromptLoader[T any] struct {
+	Input       io.Reader   // Source of answers (defaults to os.Stdin)
+	Output      io.Writer   // Destination for prompts (defaults to os.Stderr)
+	Interactive func() bool // Optional override for terminal detection
+}
+
+// Load prompts for each unset required field and stores the parsed answer.
+func (p *PromptLoader[T]) Load(c *T) error {
+	input := p.Input
+	if input == nil {
+		input = os.Stdin
+	}
+	output := p.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	if !p.isInteractive(input) {
+		return nil
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+
+	// A terminal is read unbuffered, so answers read without echo straight from its file
+	// descriptor stay in order with the lines read before them.
+	terminal, isTerminal := terminalOf(input)
+	var reader io.ByteReader = bufio.NewReader(input)
+	if isTerminal {
+		reader = byteReader{input}
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+		if !field.IsExported() || !isRequiredField(field) || !utils.IsZero(fieldValue) {
+			continue
+		}
+
+		fmt.Fprintf(output, "%s: ", field.Name)
+
+		var answer string
+		var err error
+		if isSensitiveField(field) && isTerminal {
+			var b []byte
+			b, err = term.ReadPassword(int(terminal.Fd()))
+			answer = string(b)
+			fmt.Fprintln(output)
+		} else {
+			answer, err = readLine(reader)
+		}
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "PromptLoader",
+				Operation:  "read input",
+				Source:     field.Name,
+				Err:        err,
+			}
+		}
+
+		answer = strings.TrimRight(answer, "\r\n")
+		if answer == "" {
+			continue
+		}
+
+		if err := utils.SetFromString(fieldValue, answer); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "PromptLoader",
+				Operation:  "parse input",
+				Source:     field.Name,
+				Err:        err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// isInteractive reports whether prompting is possible for the given input.
+func (p *PromptLoader[T]) isInteractive(input io.Reader) bool {
+	if p.Interactive != nil {
+		return p.Interactive()
+	}
+	_, ok := terminalOf(input)
+	return ok
+}
+
+// terminalOf returns input as a file if it is a terminal.
+func terminalOf(input io.Reader) (*os.File, bool) {
+	f, ok := input.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil, false
+	}
+	return f, true
+}
+
+// byteReader reads one byte at a time from an unbuffered reader.
+type byteReader struct {
+	io.Reader
+}
+
+func (r byteReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r.Reader, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// readLine reads up to and including the next newline. A final line without one is
+// returned without error.
+func readLine(r io.ByteReader) (string, error) {
+	var line strings.Builder
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && line.Len() > 0 {
+				err = nil
+			}
+			return line.String(), err
+		}
+		line.WriteByte(b)
+		if b == '\n' {
+			return line.String(), nil
+		}
+	}
+}
+
+// isRequiredField reports whether the field's validate tag contains the "required" rule.
+func isRequiredField(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if strings.TrimSpace(rule) == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitiveField reports whether the field holds a secret that should not be echoed.
+func isSensitiveField(field reflect.StructField) bool {
+	return field.Tag.Get("secret") != "" || field.Tag.Get("sensitive") == "true" ||
+		utils.HasTagOption(field.Tag.Get("config"), "sensitive")
+}
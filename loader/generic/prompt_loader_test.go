@@ -0,0 +1,128 @@
+//go:build !js && !wasip1 && !tinygo
+
+package generic
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type promptTestConfig struct {
+	Host     string        `env:"HOST" validate:"required"`
+	Port     int           `validate:"required,min=1"`
+	Timeout  time.Duration `validate:"required"`
+	Password string        `config:"sensitive" validate:"required"`
+	Optional string
+	Preset   string `validate:"required"`
+}
+
+func TestPromptLoader_Load_PromptsForUnsetRequiredFields(t *testing.T) {
+	out := &bytes.Buffer{}
+	cfg := &promptTestConfig{Preset: "already-set"}
+	ldr := &PromptLoader[promptTestConfig]{
+		Input:       strings.NewReader("localhost\n8080\n5s\nhunter2\n"),
+		Output:      out,
+		Interactive: func() bool { return true },
+	}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Host != "localhost" || cfg.Port != 8080 || cfg.Timeout != 5*time.Second || cfg.Password != "hunter2" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if cfg.Preset != "already-set" {
+		t.Errorf("expected Preset to be left untouched, got '%s'", cfg.Preset)
+	}
+	if strings.Contains(out.String(), "Optional") || strings.Contains(out.String(), "Preset") {
+		t.Errorf("unexpected prompt for optional or populated field: %q", out.String())
+	}
+	if strings.Contains(out.String(), "hunter2") {
+		t.Errorf("sensitive input should not be written to output: %q", out.String())
+	}
+}
+
+func TestPromptLoader_Load_NonInteractiveIsNoop(t *testing.T) {
+	cfg := &promptTestConfig{}
+	ldr := &PromptLoader[promptTestConfig]{
+		Input:  strings.NewReader("localhost\n"),
+		Output: &bytes.Buffer{},
+	}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "" {
+		t.Errorf("expected no prompting for non-terminal input, got Host '%s'", cfg.Host)
+	}
+}
+
+func TestPromptLoader_Load_EmptyAnswerLeavesFieldUnset(t *testing.T) {
+	cfg := &promptTestConfig{}
+	ldr := &PromptLoader[promptTestConfig]{
+		Input:       strings.NewReader("\n8080\n\n\n\n"),
+		Output:      &bytes.Buffer{},
+		Interactive: func() bool { return true },
+	}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "" || cfg.Port != 8080 {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestPromptLoader_Load_InvalidInputReturnsLoaderError(t *testing.T) {
+	cfg := &promptTestConfig{Host: "localhost"}
+	ldr := &PromptLoader[promptTestConfig]{
+		Input:       strings.NewReader("not-a-number\n"),
+		Output:      &bytes.Buffer{},
+		Interactive: func() bool { return true },
+	}
+
+	err := ldr.Load(cfg)
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Fatalf("expected LoaderError, got %T: %v", err, err)
+	}
+	if loaderErr.LoaderType != "PromptLoader" || loaderErr.Operation != "parse input" || loaderErr.Source != "Port" {
+		t.Errorf("unexpected error context: %+v", loaderErr)
+	}
+}
+
+func TestPromptLoader_Load_InputExhaustedReturnsLoaderError(t *testing.T) {
+	cfg := &promptTestConfig{}
+	ldr := &PromptLoader[promptTestConfig]{
+		Input:       strings.NewReader(""),
+		Output:      &bytes.Buffer{},
+		Interactive: func() bool { return true },
+	}
+
+	err := ldr.Load(cfg)
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Fatalf("expected LoaderError, got %T: %v", err, err)
+	}
+	if loaderErr.Operation != "read input" || loaderErr.Source != "Host" {
+		t.Errorf("unexpected error context: %+v", loaderErr)
+	}
+}
+
+func TestReadLine_UnbufferedLeavesRestOfInput(t *testing.T) {
+	input := strings.NewReader("localhost\nhunter2\n")
+
+	line, err := readLine(byteReader{input})
+	if err != nil || line != "localhost\n" {
+		t.Fatalf("readLine() = %q, %v, want %q", line, err, "localhost\n")
+	}
+	if input.Len() != len("hunter2\n") {
+		t.Errorf("readLine() consumed past the first line, %d bytes left", input.Len())
+	}
+}
@@ -0,0 +1,231 @@
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// TerraformLoader loads configuration from the outputs of a Terraform state file or a
+// `terraform output -json` document, so identifiers such as ARNs and endpoints created by
+// infrastructure code reach the application without being copied by hand. Both documents
+// are detected automatically.
+//
+// It supports fields tagged with `tfoutput:"name"`, which are set from the output of that
+// name. Object outputs can be indexed with dots, e.g. `tfoutput:"database.endpoint"`.
+// Strings, numbers and booleans are parsed according to the field type, with decoders;
+// lists and objects are decoded into slice, map and struct fields as JSON.
+//
+// Fields whose output does not exist are left unchanged unless the tag has the "required"
+// option, e.g. `tfoutput:"queue_url,required"`, which makes a missing output an error.
+//
+// Example:
+//
+//	type Config struct {
+//	    QueueURL   string `tfoutput:"queue_url,required"`
+//	    DBEndpoint string `tfoutput:"database.endpoint"`
+//	}
+//	ldr := &generic.TerraformLoader[Config]{Source: "infra/terraform.tfstate"}
+type TerraformLoader[T any] struct {
+	Source   interface{}     // A file path (string), raw JSON data ([]byte) or an io.Reader
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+
+	cache *loader.SourceCache // Cache for a file path Source, set by SetSourceCache
+}
+
+// terraformOutput is an output in a state file or `terraform output -json` document.
+type terraformOutput struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// Load reads the document and sets each tagged field from its output.
+func (l *TerraformLoader[T]) Load(c *T) error {
+	return l.load(c, nil)
+}
+
+// LoadFields sets the tagged fields named in fields only.
+func (l *TerraformLoader[T]) LoadFields(c *T, fields []string) error {
+	return l.load(c, fields)
+}
+
+// load sets the tagged fields named in fields, or all tagged fields if fields is nil.
+func (l *TerraformLoader[T]) load(c *T, fields []string) error {
+	outputs, source, err := l.outputs()
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("tfoutput"), ",")
+		if name == "" || name == "-" || !field.IsExported() || (fields != nil && !slices.Contains(fields, field.Name)) {
+			continue
+		}
+
+		value, ok := terraformValue(outputs, name)
+		if !ok {
+			if utils.HasTagOption(options, "required") {
+				return &loader.LoaderError{
+					LoaderType: "TerraformLoader",
+					Operation:  "find output",
+					Source:     source,
+					Err:        fmt.Errorf("output %q not found", name),
+				}
+			}
+			continue
+		}
+		if err := l.set(v.Field(i), value); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "TerraformLoader",
+				Operation:  "parse value",
+				Source:     source,
+				Err:        fmt.Errorf("output %q: %w", name, err),
+			}
+		}
+	}
+	return nil
+}
+
+// outputs reads the Source and returns its outputs and a description of the source.
+func (l *TerraformLoader[T]) outputs() (map[string]terraformOutput, string, error) {
+	var data []byte
+	var err error
+	var source string
+
+	switch src := l.Source.(type) {
+	case string:
+		source = src
+		data, err = readFile(l.cache, src)
+		if err != nil {
+			return nil, source, &loader.LoaderError{LoaderType: "TerraformLoader", Operation: "read file", Source: source, Err: err}
+		}
+	case []byte:
+		data = src
+		source = "<bytes>"
+	case io.Reader:
+		source = "<reader>"
+		data, err = io.ReadAll(src)
+		if err != nil {
+			return nil, source, &loader.LoaderError{LoaderType: "TerraformLoader", Operation: "read source", Source: source, Err: err}
+		}
+	default:
+		return nil, source, &loader.LoaderError{
+			LoaderType: "TerraformLoader",
+			Operation:  "validate source type",
+			Source:     fmt.Sprintf("%T", src),
+			Err:        fmt.Errorf("unsupported source type"),
+		}
+	}
+
+	outputs, err := parseTerraformOutputs(data)
+	if err != nil {
+		return nil, source, &loader.LoaderError{LoaderType: "TerraformLoader", Operation: "parse outputs", Source: source, Err: err}
+	}
+	return outputs, source, nil
+}
+
+// parseTerraformOutputs returns the outputs of a state file, which has a "terraform_version"
+// and an "outputs" object, or of a `terraform output -json` document, which is the outputs
+// object itself.
+func parseTerraformOutputs(data []byte) (map[string]terraformOutput, error) {
+	var state struct {
+		TerraformVersion string                     `json:"terraform_version"`
+		Outputs          map[string]terraformOutput `json:"outputs"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	if state.TerraformVersion != "" {
+		return state.Outputs, nil
+	}
+
+	var outputs map[string]terraformOutput
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, err
+	}
+	for name, output := range outputs {
+		if output.Value == nil {
+			return nil, fmt.Errorf("not a Terraform state file or `terraform output -json` document: output %q has no value", name)
+		}
+	}
+	return outputs, nil
+}
+
+// terraformValue returns the value at the dotted path name, whose first element names an
+// output and whose others index into object values.
+func terraformValue(outputs map[string]terraformOutput, name string) (json.RawMessage, bool) {
+	first, rest, _ := strings.Cut(name, ".")
+	output, ok := outputs[first]
+	if !ok {
+		return nil, false
+	}
+	value := output.Value
+	for rest != "" {
+		var key string
+		key, rest, _ = strings.Cut(rest, ".")
+		var object map[string]json.RawMessage
+		if json.Unmarshal(value, &object) != nil {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	if bytes.Equal(value, []byte("null")) {
+		return nil, false
+	}
+	return value, true
+}
+
+// set sets v from a JSON value: strings, numbers and booleans with decoders, and lists and
+// objects as JSON.
+func (l *TerraformLoader[T]) set(v reflect.Value, value json.RawMessage) error {
+	switch value[0] {
+	case '[', '{':
+		return json.Unmarshal(value, v.Addr().Interface())
+	case '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return err
+		}
+		return l.Decoders.SetFromString(v, s)
+	default:
+		return l.Decoders.SetFromString(v, string(value))
+	}
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (l *TerraformLoader[T]) SetDecoders(d *utils.Decoders) {
+	l.Decoders = d
+}
+
+// VerifySources checks that a file path Source exists and can be opened.
+func (l *TerraformLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	return verifyFileSource("TerraformLoader", l.Source)
+}
+
+// SetSourceCache sets the cache a file path Source is read from and stored in.
+func (l *TerraformLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	l.cache = cache
+}
+
+// Prefetch reads a file path Source into the source cache.
+func (l *TerraformLoader[T]) Prefetch(_ context.Context, _ []loader.Field) error {
+	return prefetchFile("TerraformLoader", l.cache, l.Source)
+}
+
+// SourceFiles returns the file path Source, if it is one, so the configuration can be
+// reloaded when the file changes.
+func (l *TerraformLoader[T]) SourceFiles() []string {
+	return sourceFiles(l.Source)
+}
@@ -0,0 +1,113 @@
+package generic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type terraformTestConfig struct {
+	QueueURL   string            `tfoutput:"queue_url,required"`
+	Port       int               `tfoutput:"port"`
+	Public     bool              `tfoutput:"public"`
+	Timeout    time.Duration     `tfoutput:"timeout"`
+	Subnets    []string          `tfoutput:"subnet_ids"`
+	Tags       map[string]string `tfoutput:"tags"`
+	DBEndpoint string            `tfoutput:"database.endpoint"`
+	DBPort     int               `tfoutput:"database.port"`
+	Missing    string            `tfoutput:"missing"`
+	Nothing    string            `tfoutput:"nothing"`
+	Untagged   string
+}
+
+const terraformOutputsDocument = `{
+  "queue_url": {"sensitive": false, "type": "string", "value": "https://sqs.eu-west-1.amazonaws.com/123/jobs"},
+  "port": {"sensitive": false, "type": "number", "value": 5432},
+  "public": {"sensitive": false, "type": "bool", "value": true},
+  "timeout": {"sensitive": false, "type": "string", "value": "30s"},
+  "subnet_ids": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-a", "subnet-b"]},
+  "tags": {"sensitive": false, "type": ["map", "string"], "value": {"team": "core"}},
+  "database": {"sensitive": true, "type": ["object", {"endpoint": "string", "port": "number"}], "value": {"endpoint": "db.internal", "port": 3306}},
+  "nothing": {"sensitive": false, "type": "string", "value": null}
+}`
+
+func TestTerraformLoader_Load_OutputDocument(t *testing.T) {
+	cfg := &terraformTestConfig{Missing: "default", Untagged: "kept"}
+	if err := (&TerraformLoader[terraformTestConfig]{Source: []byte(terraformOutputsDocument)}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := terraformTestConfig{
+		QueueURL:   "https://sqs.eu-west-1.amazonaws.com/123/jobs",
+		Port:       5432,
+		Public:     true,
+		Timeout:    30 * time.Second,
+		Subnets:    []string{"subnet-a", "subnet-b"},
+		Tags:       map[string]string{"team": "core"},
+		DBEndpoint: "db.internal",
+		DBPort:     3306,
+		Missing:    "default",
+		Untagged:   "kept",
+	}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("expected %+v, got %+v", want, *cfg)
+	}
+}
+
+func TestTerraformLoader_Load_StateFile(t *testing.T) {
+	state := `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "serial": 12,
+  "outputs": {
+    "queue_url": {"value": "https://sqs/jobs", "type": "string"}
+  },
+  "resources": []
+}`
+	path := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(path, []byte(state), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	cfg := &terraformTestConfig{}
+	if err := (&TerraformLoader[terraformTestConfig]{Source: path}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.QueueURL != "https://sqs/jobs" {
+		t.Errorf("expected queue URL from state, got %q", cfg.QueueURL)
+	}
+}
+
+func TestTerraformLoader_LoadFields(t *testing.T) {
+	cfg := &terraformTestConfig{}
+	if err := (&TerraformLoader[terraformTestConfig]{Source: []byte(terraformOutputsDocument)}).LoadFields(cfg, []string{"Port"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 5432 || cfg.QueueURL != "" {
+		t.Errorf("expected only Port to be loaded, got %+v", cfg)
+	}
+}
+
+func TestTerraformLoader_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name, document, operation string
+	}{
+		{"not JSON", "{", "parse outputs"},
+		{"not outputs", `{"name": {"other": 1}}`, "parse outputs"},
+		{"required output missing", `{"port": {"value": 1}}`, "find output"},
+		{"type mismatch", `{"queue_url": {"value": "x"}, "port": {"value": "many"}}`, "parse value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&TerraformLoader[terraformTestConfig]{Source: strings.NewReader(tt.document)}).Load(&terraformTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.Operation != tt.operation {
+				t.Fatalf("expected %q LoaderError, got %v", tt.operation, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,645 @@
+package generic
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// parseTOML parses a TOML document into nested maps. Tables become map[string]any, arrays
+// []any, integers int64, floats float64 and offset date-times time.Time. Local dates and
+// times are kept as strings.
+func parseTOML(data []byte) (map[string]any, error) {
+	p := &tomlParser{src: strings.TrimPrefix(string(data), "\uFEFF"), line: 1}
+	root := map[string]any{}
+	current := root
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return root, nil
+		}
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], "[["):
+			p.pos += 2
+			keys, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			if !p.consume("]]") {
+				return nil, p.errorf("expected ]] after table name")
+			}
+			if current, err = p.arrayTable(root, keys); err != nil {
+				return nil, err
+			}
+		case p.src[p.pos] == '[':
+			p.pos++
+			keys, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			if !p.consume("]") {
+				return nil, p.errorf("expected ] after table name")
+			}
+			if current, err = p.table(root, keys); err != nil {
+				return nil, err
+			}
+		default:
+			if err := p.keyValue(current); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.endLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// tomlParser holds the position of parseTOML in a document.
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("toml: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+// consume advances past s if the input continues with it.
+func (p *tomlParser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// skipBlank skips spaces and tabs.
+func (p *tomlParser) skipBlank() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipSpace skips whitespace, newlines and comments.
+func (p *tomlParser) skipSpace() {
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case '\n':
+			p.line++
+			p.pos++
+		case ' ', '\t', '\r':
+			p.pos++
+		case '#':
+			for !p.eof() && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endLine skips the rest of a line holding only blanks and a comment.
+func (p *tomlParser) endLine() error {
+	p.skipBlank()
+	if !p.eof() && p.src[p.pos] == '#' {
+		for !p.eof() && p.src[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+	if p.eof() || p.consume("\n") || p.consume("\r\n") {
+		p.line++
+		return nil
+	}
+	return p.errorf("unexpected %q after value", p.src[p.pos])
+}
+
+// key parses a bare, quoted or dotted key.
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("expected key")
+		}
+		var part string
+		var err error
+		switch p.src[p.pos] {
+		case '"':
+			p.pos++
+			part, err = p.basicString(false)
+		case '\'':
+			p.pos++
+			part, err = p.literalString(false)
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.src[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("invalid key character %q", p.src[p.pos])
+			}
+			part = p.src[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, part)
+		p.skipBlank()
+		if !p.consume(".") {
+			return keys, nil
+		}
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// keyValue parses a key = value pair into t.
+func (p *tomlParser) keyValue(t map[string]any) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	if !p.consume("=") {
+		return p.errorf("expected = after key %q", strings.Join(keys, "."))
+	}
+	p.skipBlank()
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys[:len(keys)-1] {
+		switch next := t[key].(type) {
+		case nil:
+			table := map[string]any{}
+			t[key] = table
+			t = table
+		case map[string]any:
+			t = next
+		default:
+			return p.errorf("key %q is not a table", key)
+		}
+	}
+	last := keys[len(keys)-1]
+	if _, ok := t[last]; ok {
+		return p.errorf("duplicate key %q", strings.Join(keys, "."))
+	}
+	t[last] = value
+	return nil
+}
+
+// table returns the table named by keys below root, creating missing tables. Names of
+// arrays of tables refer to their last element.
+func (p *tomlParser) table(root map[string]any, keys []string) (map[string]any, error) {
+	t := root
+	for _, key := range keys {
+		switch next := t[key].(type) {
+		case nil:
+			table := map[string]any{}
+			t[key] = table
+			t = table
+		case map[string]any:
+			t = next
+		case []any:
+			last, ok := any(nil), false
+			if len(next) > 0 {
+				last = next[len(next)-1]
+			}
+			if t, ok = last.(map[string]any); !ok {
+				return nil, p.errorf("key %q is not a table", key)
+			}
+		default:
+			return nil, p.errorf("key %q is not a table", key)
+		}
+	}
+	return t, nil
+}
+
+// arrayTable appends a table to the array of tables named by keys and returns it.
+func (p *tomlParser) arrayTable(root map[string]any, keys []string) (map[string]any, error) {
+	parent, err := p.table(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := keys[len(keys)-1]
+	array, ok := parent[last].([]any)
+	if !ok && parent[last] != nil {
+		return nil, p.errorf("key %q is not an array of tables", strings.Join(keys, "."))
+	}
+	table := map[string]any{}
+	parent[last] = append(array, table)
+	return table, nil
+}
+
+// value parses a string, number, boolean, date-time, array or inline table.
+func (p *tomlParser) value() (any, error) {
+	if p.eof() {
+		return nil, p.errorf("expected value")
+	}
+	switch {
+	case p.consume(`"""`):
+		return p.basicString(true)
+	case p.consume(`"`):
+		return p.basicString(false)
+	case p.consume(`'''`):
+		return p.literalString(true)
+	case p.consume(`'`):
+		return p.literalString(false)
+	case p.consume("["):
+		return p.array()
+	case p.consume("{"):
+		return p.inlineTable()
+	}
+
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	token := p.src[start:p.pos]
+	// A date and time may be separated by a space rather than a "T"
+	if isTOMLDate(token) && p.pos+1 < len(p.src) && p.src[p.pos] == ' ' && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9' {
+		p.pos++
+		for !p.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		token = token + "T" + p.src[start+len(token)+1:p.pos]
+	}
+	return p.scalar(token)
+}
+
+func isTOMLDate(s string) bool {
+	return len(s) == 10 && s[4] == '-' && s[7] == '-'
+}
+
+// scalar parses a boolean, number or date-time token.
+func (p *tomlParser) scalar(token string) (any, error) {
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	case "":
+		return nil, p.errorf("expected value")
+	}
+
+	if len(token) >= 10 && token[4] == '-' && token[7] == '-' || len(token) >= 8 && token[2] == ':' {
+		if t, err := time.Parse(time.RFC3339Nano, strings.Replace(token, "t", "T", 1)); err == nil {
+			return t, nil
+		}
+		return token, nil // Local date-time, date or time
+	}
+
+	digits := strings.ReplaceAll(token, "_", "")
+	if len(digits) > 2 && digits[0] == '0' {
+		base := map[byte]int{'x': 16, 'o': 8, 'b': 2}[digits[1]]
+		if base != 0 {
+			if n, err := strconv.ParseInt(digits[2:], base, 64); err == nil {
+				return n, nil
+			}
+			return nil, p.errorf("invalid integer %q", token)
+		}
+	}
+	if strings.ContainsAny(digits, ".eE") {
+		if f, err := strconv.ParseFloat(digits, 64); err == nil {
+			return f, nil
+		}
+	} else if n, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return n, nil
+	}
+	return nil, p.errorf("invalid value %q", token)
+}
+
+// basicString parses a double-quoted string after its opening quotes.
+func (p *tomlParser) basicString(multiline bool) (string, error) {
+	if multiline && !p.consume("\n") {
+		p.consume("\r\n")
+	}
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.src[p.pos]
+		switch {
+		case c == '"' && !multiline:
+			p.pos++
+			return b.String(), nil
+		case c == '"' && strings.HasPrefix(p.src[p.pos:], `"""`):
+			p.pos += closingQuotes(p.src[p.pos:], '"', &b)
+			return b.String(), nil
+		case c == '\n' && !multiline:
+			return "", p.errorf("newline in string")
+		case c == '\\':
+			p.pos++
+			if err := p.escape(&b, multiline); err != nil {
+				return "", err
+			}
+			continue
+		case c == '\n':
+			p.line++
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+// escape writes the character of the escape sequence after a backslash to b.
+func (p *tomlParser) escape(b *strings.Builder, multiline bool) error {
+	if p.eof() {
+		return p.errorf("unterminated string")
+	}
+	c := p.src[p.pos]
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case 'e':
+		b.WriteByte(0x1b)
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.src) {
+			return p.errorf("invalid unicode escape")
+		}
+		r, err := strconv.ParseUint(p.src[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return p.errorf("invalid unicode escape %q", p.src[p.pos:p.pos+n])
+		}
+		b.WriteRune(rune(r))
+		p.pos += n
+	case ' ', '\t', '\r', '\n':
+		if !multiline {
+			return p.errorf("invalid escape %q", c)
+		}
+		// A line ending backslash trims the following whitespace and newlines
+		p.pos--
+		p.skipWhitespace()
+	default:
+		return p.errorf("invalid escape %q", c)
+	}
+	return nil
+}
+
+// skipWhitespace skips whitespace and newlines, but not comments.
+func (p *tomlParser) skipWhitespace() {
+	for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		if p.src[p.pos] == '\n' {
+			p.line++
+		}
+		p.pos++
+	}
+}
+
+// literalString parses a single-quoted string after its opening quotes.
+func (p *tomlParser) literalString(multiline bool) (string, error) {
+	if !multiline {
+		end := strings.IndexAny(p.src[p.pos:], "'\n")
+		if end < 0 || p.src[p.pos+end] == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		s := p.src[p.pos : p.pos+end]
+		p.pos += end + 1
+		return s, nil
+	}
+	if !p.consume("\n") {
+		p.consume("\r\n")
+	}
+	end := strings.Index(p.src[p.pos:], "'''")
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+	var b strings.Builder
+	b.WriteString(p.src[p.pos : p.pos+end])
+	p.line += strings.Count(p.src[p.pos:p.pos+end], "\n")
+	p.pos += end
+	p.pos += closingQuotes(p.src[p.pos:], '\'', &b)
+	return b.String(), nil
+}
+
+// closingQuotes returns the length of the closing delimiter of a multi-line string at the
+// start of s, writing up to two quotes preceding the final three to b.
+func closingQuotes(s string, quote byte, b *strings.Builder) int {
+	n := 0
+	for n < len(s) && n < 5 && s[n] == quote {
+		n++
+	}
+	for i := 3; i < n; i++ {
+		b.WriteByte(quote)
+	}
+	return n
+}
+
+// array parses an array after its opening bracket.
+func (p *tomlParser) array() ([]any, error) {
+	values := []any{}
+	for {
+		p.skipSpace()
+		if p.consume("]") {
+			return values, nil
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		p.skipSpace()
+		if p.consume("]") {
+			return values, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ] in array")
+		}
+	}
+}
+
+// inlineTable parses an inline table after its opening brace.
+func (p *tomlParser) inlineTable() (map[string]any, error) {
+	t := map[string]any{}
+	p.skipSpace()
+	if p.consume("}") {
+		return t, nil
+	}
+	for {
+		if err := p.keyValue(t); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.consume("}") {
+			return t, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or } in inline table")
+		}
+		p.skipSpace()
+	}
+}
+
+// decodeTOML sets v from a value produced by parseTOML. Struct fields are matched by their
+// `toml` tag, or case-insensitively by name; keys without a field are ignored. Strings are
+// set with decoders, so they may hold durations and custom types.
+func decodeTOML(v reflect.Value, value any, key string, decoders *utils.Decoders) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeTOML(v.Elem(), value, key, decoders)
+	}
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		v.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("toml: cannot set %s from %T at %q", v.Type(), value, key)
+	}
+	switch val := value.(type) {
+	case string:
+		if err := decoders.SetFromString(v, val); err != nil {
+			return fmt.Errorf("toml: %q: %w", key, err)
+		}
+	case bool:
+		if v.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		v.SetBool(val)
+	case int64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(val) {
+				return fmt.Errorf("toml: %d overflows %s at %q", val, v.Type(), key)
+			}
+			v.SetInt(val)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if val < 0 || v.OverflowUint(uint64(val)) {
+				return fmt.Errorf("toml: %d overflows %s at %q", val, v.Type(), key)
+			}
+			v.SetUint(uint64(val))
+		case reflect.Float32, reflect.Float64:
+			v.SetFloat(float64(val))
+		default:
+			return mismatch()
+		}
+	case float64:
+		if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+			return mismatch()
+		}
+		v.SetFloat(val)
+	case time.Time:
+		if !reflect.TypeOf(val).AssignableTo(v.Type()) {
+			return mismatch()
+		}
+		v.Set(reflect.ValueOf(val))
+	case []any:
+		switch v.Kind() {
+		case reflect.Slice:
+			v.Set(reflect.MakeSlice(v.Type(), len(val), len(val)))
+		case reflect.Array:
+			if len(val) > v.Len() {
+				return fmt.Errorf("toml: %d values do not fit %s at %q", len(val), v.Type(), key)
+			}
+		default:
+			return mismatch()
+		}
+		for i, elem := range val {
+			if err := decodeTOML(v.Index(i), elem, fmt.Sprintf("%s[%d]", key, i), decoders); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		switch v.Kind() {
+		case reflect.Struct:
+			return decodeTOMLStruct(v, val, key, decoders)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return mismatch()
+			}
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+			for k, elem := range val {
+				e := reflect.New(v.Type().Elem()).Elem()
+				if err := decodeTOML(e, elem, joinTOMLKey(key, k), decoders); err != nil {
+					return err
+				}
+				v.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), e)
+			}
+		default:
+			return mismatch()
+		}
+	default:
+		return mismatch()
+	}
+	return nil
+}
+
+// decodeTOMLStruct sets the fields of the struct v from the keys of table.
+func decodeTOMLStruct(v reflect.Value, table map[string]any, key string, decoders *utils.Decoders) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := decodeTOMLStruct(v.Field(i), table, key, decoders); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := table[name]
+		if name == "" {
+			for k, kv := range table {
+				if strings.EqualFold(k, field.Name) {
+					name, value, ok = k, kv, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := decodeTOML(v.Field(i), value, joinTOMLKey(key, name), decoders); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func joinTOMLKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -0,0 +1,136 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// TOMLLoader loads configuration from TOML files or byte arrays. Keys are matched to fields
+// by their `toml` tag, or case-insensitively by field name, and keys without a field are
+// ignored. String values are parsed according to the field type, so durations and types
+// with a registered decoder can be written as strings.
+//
+// Example:
+//
+//	type Config struct {
+//	    Port    int           `toml:"port"`
+//	    Timeout time.Duration `toml:"timeout"` // timeout = "5s"
+//	}
+//	ldr := &generic.TOMLLoader[Config]{Source: "config.toml"}
+type TOMLLoader[T any] struct {
+	Source   interface{}     // A file path (string), raw TOML data ([]byte) or an io.Reader
+	Schema   *schema.Schema  // Optional JSON Schema the document must satisfy before it is decoded
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+
+	cache *loader.SourceCache // Cache for a file path Source, set by SetSourceCache
+}
+
+// Load populates configuration from TOML source.
+func (l *TOMLLoader[T]) Load(c *T) error {
+	var data []byte
+	var err error
+	var source string
+
+	switch src := l.Source.(type) {
+	case string:
+		source = src
+		data, err = readFile(l.cache, src)
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "TOMLLoader",
+				Operation:  "read file",
+				Source:     source,
+				Err:        err,
+			}
+		}
+	case []byte:
+		data = src
+		source = "<bytes>"
+	case io.Reader:
+		source = "<reader>"
+		data, err = io.ReadAll(src)
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "TOMLLoader",
+				Operation:  "read source",
+				Source:     source,
+				Err:        err,
+			}
+		}
+	default:
+		return &loader.LoaderError{
+			LoaderType: "TOMLLoader",
+			Operation:  "validate source type",
+			Source:     fmt.Sprintf("%T", src),
+			Err:        fmt.Errorf("unsupported source type"),
+		}
+	}
+
+	doc, err := parseTOML(data)
+	if err != nil {
+		return &loader.LoaderError{
+			LoaderType: "TOMLLoader",
+			Operation:  "parse TOML",
+			Source:     source,
+			Err:        err,
+		}
+	}
+
+	if l.Schema != nil {
+		if err := l.Schema.Validate(doc); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "TOMLLoader",
+				Operation:  "validate schema",
+				Source:     source,
+				Err:        err,
+			}
+		}
+	}
+
+	if err := decodeTOML(reflect.ValueOf(c).Elem(), doc, "", l.Decoders); err != nil {
+		return &loader.LoaderError{
+			LoaderType: "TOMLLoader",
+			Operation:  "unmarshal TOML",
+			Source:     source,
+			Err:        err,
+		}
+	}
+	return nil
+}
+
+// SetSchema sets the JSON Schema the document is validated against.
+func (l *TOMLLoader[T]) SetSchema(s *schema.Schema) {
+	l.Schema = s
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (l *TOMLLoader[T]) SetDecoders(d *utils.Decoders) {
+	l.Decoders = d
+}
+
+// VerifySources checks that a file path Source exists and can be opened.
+func (l *TOMLLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	return verifyFileSource("TOMLLoader", l.Source)
+}
+
+// SetSourceCache sets the cache a file path Source is read from and stored in.
+func (l *TOMLLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	l.cache = cache
+}
+
+// Prefetch reads a file path Source into the source cache.
+func (l *TOMLLoader[T]) Prefetch(_ context.Context, _ []loader.Field) error {
+	return prefetchFile("TOMLLoader", l.cache, l.Source)
+}
+
+// SourceFiles returns the file path Source, if it is one, so the configuration can be
+// reloaded when the file changes.
+func (l *TOMLLoader[T]) SourceFiles() []string {
+	return sourceFiles(l.Source)
+}
@@ -0,0 +1,181 @@
+package generic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
+)
+
+type tomlTestConfig struct {
+	Title    string        `toml:"title"`
+	Port     int           `toml:"port"`
+	Ratio    float64       `toml:"ratio"`
+	Debug    bool          `toml:"debug"`
+	Timeout  time.Duration `toml:"timeout"`
+	Released time.Time     `toml:"released"`
+	Tags     []string      `toml:"tags"`
+	Limits   map[string]int
+	Database struct {
+		Host string `toml:"host"`
+		Port uint16 `toml:"port"`
+	} `toml:"database"`
+	Servers []struct {
+		Name string `toml:"name"`
+	} `toml:"servers"`
+	Ignored string `toml:"-"`
+}
+
+const tomlTestDocument = `
+# Application settings
+title = "Example \"app\""
+port = 8_080
+ratio = 0.5
+debug = true
+timeout = "5s"
+released = 1979-05-27 07:32:00Z
+tags = [
+  "a",
+  'b', # literal
+]
+Ignored = "x"
+
+[limits]
+requests = 100
+
+[database]
+host = "db.internal"
+port = 0x1538
+
+[[servers]]
+name = "alpha"
+
+[[servers]]
+name = """
+beta"""
+`
+
+func TestTOMLLoader_Load(t *testing.T) {
+	cfg := &tomlTestConfig{}
+	ldr := &TOMLLoader[tomlTestConfig]{Source: []byte(tomlTestDocument)}
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Title != `Example "app"` || cfg.Port != 8080 || cfg.Ratio != 0.5 || !cfg.Debug {
+		t.Errorf("unexpected scalar values: %+v", cfg)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("expected timeout 5s, got %v", cfg.Timeout)
+	}
+	if want := time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC); !cfg.Released.Equal(want) {
+		t.Errorf("expected released %v, got %v", want, cfg.Released)
+	}
+	if !reflect.DeepEqual(cfg.Tags, []string{"a", "b"}) {
+		t.Errorf("unexpected tags: %v", cfg.Tags)
+	}
+	if cfg.Limits["requests"] != 100 {
+		t.Errorf("expected limits to be matched by field name, got %v", cfg.Limits)
+	}
+	if cfg.Database.Host != "db.internal" || cfg.Database.Port != 5432 {
+		t.Errorf("unexpected database: %+v", cfg.Database)
+	}
+	if len(cfg.Servers) != 2 || cfg.Servers[0].Name != "alpha" || cfg.Servers[1].Name != "beta" {
+		t.Errorf("unexpected servers: %+v", cfg.Servers)
+	}
+	if cfg.Ignored != "" {
+		t.Errorf("expected field tagged - to be ignored, got %q", cfg.Ignored)
+	}
+}
+
+func TestTOMLLoader_Load_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("title = 'from file'\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	cfg := &tomlTestConfig{}
+	if err := (&TOMLLoader[tomlTestConfig]{Source: path}).Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Title != "from file" {
+		t.Errorf("expected title from file, got %q", cfg.Title)
+	}
+}
+
+func TestTOMLLoader_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name, document, operation string
+	}{
+		{"syntax", "title = \n", "parse TOML"},
+		{"duplicate key", "port = 1\nport = 2\n", "parse TOML"},
+		{"unterminated string", "title = \"open\n", "parse TOML"},
+		{"trailing text", "port = 1 2\n", "parse TOML"},
+		{"type mismatch", "port = \"many\"\n", "unmarshal TOML"},
+		{"overflow", "[database]\nport = 70000\n", "unmarshal TOML"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&TOMLLoader[tomlTestConfig]{Source: []byte(tt.document)}).Load(&tomlTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.Operation != tt.operation {
+				t.Fatalf("expected %q LoaderError, got %v", tt.operation, err)
+			}
+		})
+	}
+}
+
+func TestTOMLLoader_Load_Schema(t *testing.T) {
+	s, err := schema.Parse([]byte(`{"type": "object", "required": ["title"]}`))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	err = (&TOMLLoader[tomlTestConfig]{Source: []byte("port = 1\n"), Schema: s}).Load(&tomlTestConfig{})
+	if err == nil || !strings.Contains(err.Error(), "validate schema") {
+		t.Errorf("expected schema validation error, got %v", err)
+	}
+}
+
+func TestParseTOML_Values(t *testing.T) {
+	doc, err := parseTOML([]byte(`
+a.b = 1
+"quoted key" = 'c:\path'
+inline = { x = 1, y.z = "two" }
+nested = [[1, 2], ["a"]]
+floats = [1e3, -0.5, inf]
+bin = 0b101
+oct = 0o17
+date = 2024-01-02
+escaped = "tab\there \u00e9"
+multi = """\
+  joined \
+  line"""
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"a":          map[string]any{"b": int64(1)},
+		"quoted key": `c:\path`,
+		"inline":     map[string]any{"x": int64(1), "y": map[string]any{"z": "two"}},
+		"nested":     []any{[]any{int64(1), int64(2)}, []any{"a"}},
+		"bin":        int64(5),
+		"oct":        int64(15),
+		"date":       "2024-01-02",
+		"escaped":    "tab\there é",
+		"multi":      "joined line",
+	}
+	floats := doc["floats"].([]any)
+	delete(doc, "floats")
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("unexpected document:\n got %#v\nwant %#v", doc, want)
+	}
+	if len(floats) != 3 || floats[0] != 1000.0 || floats[1] != -0.5 {
+		t.Errorf("unexpected floats: %v", floats)
+	}
+}
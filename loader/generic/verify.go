@@ -0,0 +1,40 @@
+package generic
+
+import (
+	"errors"
+	"os"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// verifyFileSource checks that a file path source exists and can be opened.
+// Byte slice and reader sources have nothing to verify.
+func verifyFileSource(loaderType string, source interface{}) []loader.SourceCheck {
+	path, ok := source.(string)
+	if !ok {
+		return nil
+	}
+
+	check := loader.SourceCheck{LoaderType: loaderType, Source: path}
+	f, err := os.Open(path)
+	if err != nil {
+		check.Err = err
+		return []loader.SourceCheck{check}
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err != nil {
+		check.Err = err
+	} else if info.IsDir() {
+		check.Err = errors.New("is a directory")
+	}
+	return []loader.SourceCheck{check}
+}
+
+// sourceFiles returns source in a slice if it is a file path, and nil for data and readers.
+func sourceFiles(source interface{}) []string {
+	if path, ok := source.(string); ok {
+		return []string{path}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONLoader_VerifySources(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(existing, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := (&JSONLoader[struct{}]{Source: existing}).VerifySources(context.Background(), nil)
+	if len(checks) != 1 || checks[0].Err != nil || checks[0].Source != existing {
+		t.Errorf("expected passing check for existing file, got %v", checks)
+	}
+
+	checks = (&JSONLoader[struct{}]{Source: filepath.Join(dir, "missing.json")}).VerifySources(context.Background(), nil)
+	if len(checks) != 1 || !errors.Is(checks[0].Err, fs.ErrNotExist) {
+		t.Errorf("expected not-exist failure, got %v", checks)
+	}
+
+	checks = (&JSONLoader[struct{}]{Source: dir}).VerifySources(context.Background(), nil)
+	if len(checks) != 1 || checks[0].Err == nil {
+		t.Errorf("expected failure for directory source, got %v", checks)
+	}
+
+	if checks := (&JSONLoader[struct{}]{Source: []byte("{}")}).VerifySources(context.Background(), nil); len(checks) != 0 {
+		t.Errorf("expected no checks for byte source, got %v", checks)
+	}
+}
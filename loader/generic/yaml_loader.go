@@ -1,16 +1,23 @@
+//go:build !tinygo
+
 package generic
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/schema"
 	"gopkg.in/yaml.v3"
 )
 
 // YAMLLoader loads configuration from YAML files or byte arrays.
 type YAMLLoader[T any] struct {
-	Source interface{} // Either a file path (string) or raw YAML data ([]byte)
+	Source interface{}    // A file path (string), raw YAML data ([]byte) or an io.Reader
+	Schema *schema.Schema // Optional JSON Schema the document must satisfy before it is decoded
+
+	cache *loader.SourceCache // Cache for a file path Source, set by SetSourceCache
 }
 
 // Load populates configuration from YAML source.
@@ -22,7 +29,7 @@ func (y *YAMLLoader[T]) Load(c *T) error {
 	switch src := y.Source.(type) {
 	case string:
 		source = src
-		data, err = os.ReadFile(src)
+		data, err = readFile(y.cache, src)
 		if err != nil {
 			return &loader.LoaderError{
 				LoaderType: "YAMLLoader",
@@ -34,6 +41,17 @@ func (y *YAMLLoader[T]) Load(c *T) error {
 	case []byte:
 		data = src
 		source = "<bytes>"
+	case io.Reader:
+		source = "<reader>"
+		data, err = io.ReadAll(src)
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "YAMLLoader",
+				Operation:  "read source",
+				Source:     source,
+				Err:        err,
+			}
+		}
 	default:
 		return &loader.LoaderError{
 			LoaderType: "YAMLLoader",
@@ -43,6 +61,22 @@ func (y *YAMLLoader[T]) Load(c *T) error {
 		}
 	}
 
+	if y.Schema != nil {
+		var doc interface{}
+		err := yaml.Unmarshal(data, &doc)
+		if err == nil {
+			err = y.Schema.Validate(doc)
+		}
+		if err != nil {
+			return &loader.LoaderError{
+				LoaderType: "YAMLLoader",
+				Operation:  "validate schema",
+				Source:     source,
+				Err:        err,
+			}
+		}
+	}
+
 	if err := yaml.Unmarshal(data, c); err != nil {
 		return &loader.LoaderError{
 			LoaderType: "YAMLLoader",
@@ -53,3 +87,29 @@ func (y *YAMLLoader[T]) Load(c *T) error {
 	}
 	return nil
 }
+
+// SetSchema sets the JSON Schema the document is validated against.
+func (y *YAMLLoader[T]) SetSchema(s *schema.Schema) {
+	y.Schema = s
+}
+
+// VerifySources checks that a file path Source exists and can be opened.
+func (y *YAMLLoader[T]) VerifySources(_ context.Context, _ []loader.Field) []loader.SourceCheck {
+	return verifyFileSource("YAMLLoader", y.Source)
+}
+
+// SetSourceCache sets the cache a file path Source is read from and stored in.
+func (y *YAMLLoader[T]) SetSourceCache(cache *loader.SourceCache) {
+	y.cache = cache
+}
+
+// Prefetch reads a file path Source into the source cache.
+func (y *YAMLLoader[T]) Prefetch(_ context.Context, _ []loader.Field) error {
+	return prefetchFile("YAMLLoader", y.cache, y.Source)
+}
+
+// SourceFiles returns the file path Source, if it is one, so the configuration can be
+// reloaded when the file changes.
+func (y *YAMLLoader[T]) SourceFiles() []string {
+	return sourceFiles(y.Source)
+}
@@ -1,8 +1,14 @@
+//go:build !tinygo
+
 package generic
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/gymshark/go-easy-config/schema"
 )
 
 type testYAMLConfig struct {
@@ -73,3 +79,29 @@ func TestYAMLLoader_Load_BytesSource(t *testing.T) {
 		t.Errorf("unexpected config values: %+v", cfg)
 	}
 }
+
+func TestYAMLLoader_Load_ReaderSource(t *testing.T) {
+	cfg := &testYAMLConfig{}
+	loader := YAMLLoader[testYAMLConfig]{Source: strings.NewReader("Field1: value1\nField2: value2\nField3: value3\n")}
+	if err := loader.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Field1 != "value1" || cfg.Field2 != "value2" || cfg.Field3 != "value3" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestYAMLLoader_Load_SchemaViolation(t *testing.T) {
+	s, err := schema.Parse([]byte(`{"type":"object","properties":{"Field1":{"type":"string"},"Port":{"type":"integer","maximum":65535}}}`))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	ldr := &YAMLLoader[testYAMLConfig]{Source: []byte("Field1: [a, b]\nPort: 70000\n"), Schema: s}
+	err = ldr.Load(&testYAMLConfig{})
+
+	var schemaErrs schema.ValidationErrors
+	if !errors.As(err, &schemaErrs) || len(schemaErrs) != 2 || schemaErrs[0].Path != "/Field1" || schemaErrs[1].Path != "/Port" {
+		t.Fatalf("expected violations at /Field1 and /Port, got %v", err)
+	}
+}
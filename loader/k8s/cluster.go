@@ -0,0 +1,287 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// serviceAccountDir is where Kubernetes mounts the pod's service account credentials.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// errNotFound is returned by cluster.get when the API server responds 404.
+var errNotFound = errors.New("not found")
+
+// cluster is a connection to a Kubernetes API server.
+type cluster struct {
+	server    string // Base URL, e.g. "https://10.0.0.1:443"
+	namespace string // Namespace of the pod or kubeconfig context, "" if unknown
+	token     string // Bearer token, "" if not used
+	username  string // Basic auth user, "" if not used
+	password  string
+	client    *http.Client
+}
+
+// connect returns a connection using the in-cluster service account, or the kubeconfig file
+// at path when path is set or the process does not run in a cluster. An empty path defaults
+// to the first file in $KUBECONFIG, then ~/.kube/config. context selects a kubeconfig
+// context and defaults to its current-context.
+func connect(path, context string) (*cluster, error) {
+	if path == "" && context == "" && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return inCluster()
+	}
+	if path == "" {
+		path, _, _ = strings.Cut(os.Getenv("KUBECONFIG"), string(os.PathListSeparator))
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("not running in a cluster and no kubeconfig: %w", err)
+		}
+		path = filepath.Join(home, ".kube", "config")
+	}
+	return fromKubeconfig(path, context)
+}
+
+// inCluster returns a connection using the pod's service account. The token is read on every
+// call, since projected service account tokens are rotated.
+func inCluster() (*cluster, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if port == "" {
+		port = "443"
+	}
+	token, err := os.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("read service account token: %w", err)
+	}
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("read service account CA: %w", err)
+	}
+	tlsConfig, err := tlsConfigFor(ca, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	namespace, _ := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	return &cluster{
+		server:    "https://" + net.JoinHostPort(host, port),
+		namespace: strings.TrimSpace(string(namespace)),
+		token:     strings.TrimSpace(string(token)),
+		client:    newHTTPClient(tlsConfig),
+	}, nil
+}
+
+// kubeconfig is the subset of the kubeconfig file format used to reach a cluster.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string    `yaml:"token"`
+			TokenFile             string    `yaml:"tokenFile"`
+			ClientCertificate     string    `yaml:"client-certificate"`
+			ClientCertificateData string    `yaml:"client-certificate-data"`
+			ClientKey             string    `yaml:"client-key"`
+			ClientKeyData         string    `yaml:"client-key-data"`
+			Username              string    `yaml:"username"`
+			Password              string    `yaml:"password"`
+			Exec                  yaml.Node `yaml:"exec"`
+			AuthProvider          yaml.Node `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// fromKubeconfig returns a connection for a context of the kubeconfig file at path.
+// Credential plugins (exec and auth-provider) are not supported.
+func fromKubeconfig(path, contextName string) (*cluster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read kubeconfig: %w", err)
+	}
+	var kc kubeconfig
+	if err := yaml.Unmarshal(data, &kc); err != nil {
+		return nil, fmt.Errorf("parse kubeconfig %s: %w", path, err)
+	}
+	if contextName == "" {
+		contextName = kc.CurrentContext
+	}
+	if contextName == "" {
+		return nil, fmt.Errorf("kubeconfig %s has no current-context", path)
+	}
+
+	c := &cluster{}
+	var clusterName, userName string
+	found := false
+	for _, ctx := range kc.Contexts {
+		if ctx.Name == contextName {
+			clusterName, userName, c.namespace = ctx.Context.Cluster, ctx.Context.User, ctx.Context.Namespace
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("kubeconfig %s has no context %q", path, contextName)
+	}
+
+	// Relative file references are resolved against the kubeconfig's directory.
+	dir := filepath.Dir(path)
+	readRef := func(file, data string) ([]byte, error) {
+		if data != "" {
+			return base64.StdEncoding.DecodeString(data)
+		}
+		if file == "" {
+			return nil, nil
+		}
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		return os.ReadFile(file)
+	}
+
+	var ca, cert, key []byte
+	insecure := false
+	found = false
+	for _, cl := range kc.Clusters {
+		if cl.Name != clusterName {
+			continue
+		}
+		found = true
+		c.server = strings.TrimSuffix(cl.Cluster.Server, "/")
+		insecure = cl.Cluster.InsecureSkipTLSVerify
+		if ca, err = readRef(cl.Cluster.CertificateAuthority, cl.Cluster.CertificateAuthorityData); err != nil {
+			return nil, fmt.Errorf("cluster %q certificate authority: %w", clusterName, err)
+		}
+	}
+	if !found || c.server == "" {
+		return nil, fmt.Errorf("kubeconfig %s has no server for cluster %q", path, clusterName)
+	}
+
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+		if !u.User.Exec.IsZero() || !u.User.AuthProvider.IsZero() {
+			return nil, fmt.Errorf("user %q uses a credential plugin, which is not supported; use a token or client certificate", userName)
+		}
+		c.token, c.username, c.password = u.User.Token, u.User.Username, u.User.Password
+		if c.token == "" && u.User.TokenFile != "" {
+			token, err := readRef(u.User.TokenFile, "")
+			if err != nil {
+				return nil, fmt.Errorf("user %q token file: %w", userName, err)
+			}
+			c.token = strings.TrimSpace(string(token))
+		}
+		if cert, err = readRef(u.User.ClientCertificate, u.User.ClientCertificateData); err != nil {
+			return nil, fmt.Errorf("user %q client certificate: %w", userName, err)
+		}
+		if key, err = readRef(u.User.ClientKey, u.User.ClientKeyData); err != nil {
+			return nil, fmt.Errorf("user %q client key: %w", userName, err)
+		}
+	}
+
+	tlsConfig, err := tlsConfigFor(ca, cert, key, insecure)
+	if err != nil {
+		return nil, err
+	}
+	c.client = newHTTPClient(tlsConfig)
+	return c, nil
+}
+
+// tlsConfigFor returns a TLS configuration trusting ca, or the system roots when ca is
+// empty, and presenting the client certificate cert and key when set.
+func tlsConfigFor(ca, cert, key []byte, insecure bool) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: insecure}
+	if len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("certificate authority contains no PEM certificates")
+		}
+		config.RootCAs = pool
+	}
+	if len(cert) > 0 || len(key) > 0 {
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+	return config, nil
+}
+
+// newHTTPClient returns an HTTP client using tlsConfig.
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}
+}
+
+// get fetches the API path, e.g. "/api/v1/namespaces/default/configmaps/app", into out
+// with ctx. client overrides the connection's HTTP client when set.
+func (c *cluster) get(ctx context.Context, client *http.Client, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	if client == nil {
+		client = c.client
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Failures are described by a Status object; fall back to the HTTP status.
+		var status struct {
+			Message string `json:"message"`
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		if json.Unmarshal(body, &status) == nil && status.Message != "" {
+			return fmt.Errorf("%s: %s", resp.Status, status.Message)
+		}
+		return fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,90 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFromKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	kubeconfig := `current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443/
+    insecure-skip-tls-verify: true
+- name: prod
+  cluster:
+    server: https://prod.example.com
+contexts:
+- name: dev
+  context: {cluster: dev, user: file, namespace: team-a}
+- name: prod
+  context: {cluster: prod, user: basic}
+- name: eks
+  context: {cluster: prod, user: plugin}
+users:
+- name: file
+  user: {tokenFile: token}
+- name: basic
+  user: {username: admin, password: hunter2}
+- name: plugin
+  user:
+    exec: {command: aws, args: [eks, get-token]}
+`
+	path := filepath.Join(dir, "config")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := fromKubeconfig(path, "")
+	if err != nil {
+		t.Fatalf("fromKubeconfig(current) error = %v", err)
+	}
+	if dev.server != "https://dev.example.com:6443" || dev.namespace != "team-a" || dev.token != "from-file" {
+		t.Errorf("current context = %+v", dev)
+	}
+
+	prod, err := fromKubeconfig(path, "prod")
+	if err != nil {
+		t.Fatalf("fromKubeconfig(prod) error = %v", err)
+	}
+	if prod.server != "https://prod.example.com" || prod.username != "admin" || prod.password != "hunter2" || prod.namespace != "" {
+		t.Errorf("prod context = %+v", prod)
+	}
+
+	for context, want := range map[string]string{"eks": "credential plugin", "missing": `no context "missing"`} {
+		if _, err := fromKubeconfig(path, context); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("fromKubeconfig(%s) error = %v, want it to mention %q", context, err, want)
+		}
+	}
+}
+
+func TestConnect_KubeconfigEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `current-context: c
+clusters: [{name: c, cluster: {server: "https://example.com"}}]
+contexts: [{name: c, context: {cluster: c, user: u}}]
+users: [{name: u, user: {token: t}}]
+`
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBECONFIG", path+string(os.PathListSeparator)+"/elsewhere")
+
+	c, err := connect("", "")
+	if err != nil {
+		t.Fatalf("connect() error = %v", err)
+	}
+	if c.server != "https://example.com" || c.token != "t" {
+		t.Errorf("connect() = %+v", c)
+	}
+}
@@ -0,0 +1,91 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// ConfigMapLoader loads configuration from a ConfigMap fetched from the Kubernetes API, so
+// operators can change configuration with kubectl instead of rebuilding images.
+//
+// Data keys are matched against the name in the field's `k8s` tag, or the field name when
+// the tag is absent, and values are parsed according to the field type. Keys of binaryData
+// are matched the same way. Fields tagged `k8s:"-"` and fields without a matching key are
+// left unchanged.
+//
+// Inside a pod the loader uses the service account, which needs the "get" verb on
+// configmaps. Elsewhere it uses a kubeconfig file with a token, basic auth or client
+// certificate; exec and auth-provider credential plugins are not supported.
+//
+// Example:
+//
+//	type Config struct {
+//	    LogLevel string        `k8s:"log-level"`
+//	    Timeout  time.Duration `k8s:"timeout"`
+//	}
+//	ldr := &k8s.ConfigMapLoader[Config]{Name: "payments-api"}
+type ConfigMapLoader[T any] struct {
+	Name       string       // Name of the ConfigMap
+	Namespace  string       // Optional namespace (defaults to the pod's or the kubeconfig context's, then "default")
+	Kubeconfig string       // Optional kubeconfig path; set to use it even inside a cluster
+	Context    string       // Optional kubeconfig context (defaults to current-context)
+	Optional   bool         // Leave the configuration unchanged when the ConfigMap does not exist
+	Client     *http.Client // Optional HTTP client, replacing the one built from the cluster's TLS settings
+}
+
+// configMap is the part of a ConfigMap resource the loader reads.
+type configMap struct {
+	Data       map[string]string `json:"data"`
+	BinaryData map[string][]byte `json:"binaryData"`
+}
+
+// Load fetches the ConfigMap and sets the fields of c from its data.
+func (l *ConfigMapLoader[T]) Load(c *T) error {
+	return l.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but fetches the ConfigMap with ctx, so its deadline and
+// cancellation stop a slow or unreachable API server.
+func (l *ConfigMapLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	cl, err := connect(l.Kubeconfig, l.Context)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "ConfigMapLoader", Operation: "connect", Source: l.Name, Err: err}
+	}
+
+	namespace := l.Namespace
+	if namespace == "" {
+		namespace = cl.namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	source := namespace + "/" + l.Name
+
+	var cm configMap
+	path := "/api/v1/namespaces/" + url.PathEscape(namespace) + "/configmaps/" + url.PathEscape(l.Name)
+	if err := cl.get(ctx, l.Client, path, &cm); err != nil {
+		if errors.Is(err, errNotFound) && l.Optional {
+			return nil
+		}
+		return &loader.LoaderError{LoaderType: "ConfigMapLoader", Operation: "get configmap", Source: source, Err: err}
+	}
+
+	values := make(map[string]string, len(cm.Data)+len(cm.BinaryData))
+	for key, value := range cm.BinaryData {
+		values[key] = string(value)
+	}
+	for key, value := range cm.Data {
+		values[key] = value
+	}
+	if key, err := setFields(reflect.ValueOf(c).Elem(), values); err != nil {
+		return &loader.LoaderError{LoaderType: "ConfigMapLoader", Operation: "parse value", Source: source + ":" + key, Err: err}
+	}
+	return nil
+}
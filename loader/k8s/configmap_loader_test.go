@@ -0,0 +1,167 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type configMapTestConfig struct {
+	LogLevel string        `k8s:"log-level"`
+	Timeout  time.Duration `k8s:"timeout"`
+	Replicas int
+	Cert     string `k8s:"cert.pem"`
+	Ignored  string `k8s:"-"`
+}
+
+// newAPIServer starts a TLS server answering ConfigMap requests from configMaps, keyed by
+// "namespace/name", and checks requests carry the bearer token.
+func newAPIServer(t *testing.T, token string, configMaps map[string]any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","message":"Unauthorized"}`))
+			return
+		}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/"), "/configmaps/")
+		cm, ok := configMaps[strings.Join(parts, "/")]
+		if len(parts) != 2 || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","message":"configmaps not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(cm)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// writeKubeconfig writes a kubeconfig for server with the given user entry and returns its path.
+func writeKubeconfig(t *testing.T, server *httptest.Server, namespace, user string) string {
+	t.Helper()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: test
+clusters:
+- name: test
+  cluster:
+    server: ` + server.URL + `
+    certificate-authority-data: ` + base64.StdEncoding.EncodeToString(ca) + `
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+    namespace: ` + namespace + `
+users:
+- name: test
+  user:
+` + user
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConfigMapLoader_Kubeconfig(t *testing.T) {
+	server := newAPIServer(t, "s3cr3t", map[string]any{
+		"payments/app": map[string]any{
+			"data":       map[string]string{"log-level": "debug", "timeout": "5s", "Replicas": "3", "Ignored": "x"},
+			"binaryData": map[string][]byte{"cert.pem": []byte("-----BEGIN CERTIFICATE-----")},
+		},
+	})
+	path := writeKubeconfig(t, server, "payments", "    token: s3cr3t\n")
+
+	cfg := configMapTestConfig{Ignored: "kept"}
+	if err := (&ConfigMapLoader[configMapTestConfig]{Name: "app", Kubeconfig: path}).Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := configMapTestConfig{LogLevel: "debug", Timeout: 5 * time.Second, Replicas: 3, Cert: "-----BEGIN CERTIFICATE-----", Ignored: "kept"}
+	if cfg != want {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestConfigMapLoader_InCluster(t *testing.T) {
+	server := newAPIServer(t, "pod-token", map[string]any{
+		"shop/app": map[string]any{"data": map[string]string{"log-level": "warn"}},
+	})
+	dir := t.TempDir()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	for name, content := range map[string]string{"token": "pod-token\n", "ca.crt": string(ca), "namespace": "shop"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	original := serviceAccountDir
+	serviceAccountDir = dir
+	t.Cleanup(func() { serviceAccountDir = original })
+	host, port, _ := strings.Cut(strings.TrimPrefix(server.URL, "https://"), ":")
+	t.Setenv("KUBERNETES_SERVICE_HOST", host)
+	t.Setenv("KUBERNETES_SERVICE_PORT", port)
+
+	var cfg configMapTestConfig
+	if err := (&ConfigMapLoader[configMapTestConfig]{Name: "app"}).Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Errorf("LogLevel = %q, want warn", cfg.LogLevel)
+	}
+}
+
+func TestConfigMapLoader_Errors(t *testing.T) {
+	server := newAPIServer(t, "s3cr3t", map[string]any{
+		"default/bad": map[string]any{"data": map[string]string{"timeout": "soon"}},
+	})
+	path := writeKubeconfig(t, server, "", "    token: s3cr3t\n")
+	wrongToken := writeKubeconfig(t, server, "", "    token: wrong\n")
+
+	tests := []struct {
+		name   string
+		loader *ConfigMapLoader[configMapTestConfig]
+		want   string
+	}{
+		{"missing", &ConfigMapLoader[configMapTestConfig]{Name: "missing", Kubeconfig: path}, "get configmap"},
+		{"bad value", &ConfigMapLoader[configMapTestConfig]{Name: "bad", Kubeconfig: path}, "default/bad:timeout"},
+		{"unauthorized", &ConfigMapLoader[configMapTestConfig]{Name: "bad", Kubeconfig: wrongToken}, "Unauthorized"},
+		{"no kubeconfig", &ConfigMapLoader[configMapTestConfig]{Name: "app", Kubeconfig: filepath.Join(t.TempDir(), "none")}, "connect"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg configMapTestConfig
+			err := tt.loader.Load(&cfg)
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "ConfigMapLoader" {
+				t.Fatalf("Load() error = %v, want a ConfigMapLoader error", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("optional", func(t *testing.T) {
+		cfg := configMapTestConfig{LogLevel: "info"}
+		if err := (&ConfigMapLoader[configMapTestConfig]{Name: "missing", Kubeconfig: path, Optional: true}).Load(&cfg); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.LogLevel != "info" {
+			t.Errorf("LogLevel = %q, want it unchanged", cfg.LogLevel)
+		}
+	})
+}
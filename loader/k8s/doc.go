@@ -0,0 +1,7 @@
+// Package k8s provides loaders for Kubernetes configuration sources: ConfigMapLoader reads a
+// ConfigMap from the API server and VolumeLoader reads a mounted Secret or ConfigMap volume.
+//
+// ConfigMapLoader talks to the API server over HTTPS without client-go, using the pod's
+// service account when running in a cluster and a kubeconfig file otherwise. The loaders are
+// excluded from js/wasm, wasip1 and TinyGo builds.
+package k8s
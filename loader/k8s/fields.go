@@ -0,0 +1,44 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// fieldKey returns the key a field is loaded from: the name in its `k8s` tag, or the field
+// name when the tag is absent. Fields tagged `k8s:"-"` return "".
+func fieldKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("k8s"), ",")
+	switch key {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return key
+}
+
+// setFields sets the exported fields of the struct v that have a key in values. Fields
+// without a key are left unchanged. On failure it returns the key that could not be parsed.
+func setFields(v reflect.Value, values map[string]string) (string, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := fieldKey(field)
+		value, ok := values[key]
+		if key == "" || !ok {
+			continue
+		}
+		if err := utils.SetFromString(v.Field(i), value); err != nil {
+			return key, err
+		}
+	}
+	return "", nil
+}
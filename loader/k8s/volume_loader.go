@@ -0,0 +1,76 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// VolumeLoader loads configuration from a directory where each file name is a key and its
+// contents the value, the layout Kubernetes uses for Secret, ConfigMap and projected volumes.
+//
+// File names are matched against the name in the field's `k8s` tag, or the field name when
+// the tag is absent, and values are parsed according to the field type. One trailing newline
+// is removed from each value. Kubernetes' own entries, whose names start with "..", and
+// subdirectories are ignored. Fields tagged `k8s:"-"` and fields without a matching file are
+// left unchanged.
+//
+// Files are read on every Load, so reloading picks up the atomic updates Kubernetes makes
+// when the Secret or ConfigMap changes.
+//
+// Example:
+//
+//	type Config struct {
+//	    DBPassword string `k8s:"db-password" config:"sensitive"`
+//	    APIKey     string `k8s:"api-key" config:"sensitive"`
+//	}
+//	ldr := &k8s.VolumeLoader[Config]{Dir: "/etc/secrets/payments"}
+type VolumeLoader[T any] struct {
+	Dir      string // Mount path of the volume
+	Optional bool   // Leave the configuration unchanged when Dir does not exist
+}
+
+// Load reads the files in Dir and sets the matching fields of c.
+func (l *VolumeLoader[T]) Load(c *T) error {
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && l.Optional {
+			return nil
+		}
+		return &loader.LoaderError{LoaderType: "VolumeLoader", Operation: "read directory", Source: l.Dir, Err: err}
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "..") {
+			continue
+		}
+		path := filepath.Join(l.Dir, entry.Name())
+		// Entries are usually symlinks into the current ..data directory, so stat the target.
+		info, err := os.Stat(path)
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "VolumeLoader", Operation: "read file", Source: path, Err: err}
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "VolumeLoader", Operation: "read file", Source: path, Err: err}
+		}
+		value := strings.TrimSuffix(string(data), "\n")
+		values[entry.Name()] = strings.TrimSuffix(value, "\r")
+	}
+
+	if key, err := setFields(reflect.ValueOf(c).Elem(), values); err != nil {
+		return &loader.LoaderError{LoaderType: "VolumeLoader", Operation: "parse value", Source: filepath.Join(l.Dir, key), Err: err}
+	}
+	return nil
+}
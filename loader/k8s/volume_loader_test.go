@@ -0,0 +1,101 @@
+//go:build !js && !wasip1 && !tinygo
+
+package k8s
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type volumeTestConfig struct {
+	DBPassword string        `k8s:"db-password"`
+	Timeout    time.Duration `k8s:"timeout"`
+	Port       int
+	Unset      string `k8s:"unset"`
+}
+
+// writeVolume lays out files the way the kubelet does: the data lives in a timestamped
+// directory, ..data links to it and each key links through ..data.
+func writeVolume(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	data := filepath.Join(dir, "..2026_10_16_12_00_00.000000001")
+	if err := os.Mkdir(data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Base(data), filepath.Join(dir, "..data")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(data, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestVolumeLoader(t *testing.T) {
+	dir := writeVolume(t, map[string]string{
+		"db-password": "s3cr3t\n",
+		"timeout":     "5s\r\n",
+		"Port":        "8080",
+		"extra":       "ignored",
+	})
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := volumeTestConfig{Unset: "kept"}
+	if err := (&VolumeLoader[volumeTestConfig]{Dir: dir}).Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := volumeTestConfig{DBPassword: "s3cr3t", Timeout: 5 * time.Second, Port: 8080, Unset: "kept"}
+	if cfg != want {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestVolumeLoader_Errors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	bad := writeVolume(t, map[string]string{"timeout": "soon"})
+
+	tests := map[string]struct {
+		dir  string
+		want string
+	}{
+		"missing directory": {missing, "read directory"},
+		"bad value":         {bad, filepath.Join(bad, "timeout")},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg volumeTestConfig
+			err := (&VolumeLoader[volumeTestConfig]{Dir: tt.dir}).Load(&cfg)
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "VolumeLoader" {
+				t.Fatalf("Load() error = %v, want a VolumeLoader error", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("optional", func(t *testing.T) {
+		cfg := volumeTestConfig{Port: 1}
+		if err := (&VolumeLoader[volumeTestConfig]{Dir: missing, Optional: true}).Load(&cfg); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.Port != 1 {
+			t.Errorf("Port = %d, want it unchanged", cfg.Port)
+		}
+	})
+}
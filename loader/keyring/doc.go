@@ -0,0 +1,10 @@
+// Package keyring provides KeyringLoader, which loads configuration from the operating
+// system's credential store: the macOS Keychain, the Windows Credential Manager, or a
+// Secret Service provider such as GNOME Keyring or KWallet on Linux and other Unix systems.
+//
+// It is meant for developer tools and CLIs that must not keep tokens in plaintext files.
+// Items are read with the system's own tools where a C library would otherwise be needed:
+// security(1) on macOS and secret-tool(1) from libsecret elsewhere; on Windows the
+// credential API is called directly. The loader is excluded from js/wasm, wasip1 and TinyGo
+// builds.
+package keyring
@@ -0,0 +1,113 @@
+//go:build !js && !wasip1 && !tinygo
+
+package keyring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// KeyringLoader loads configuration from the OS credential store. It supports fields tagged
+// with `keyring:"service/account"`, which are set from the item stored for that service and
+// account, and parsed according to the field type. The service is everything before the
+// last "/", so it may itself contain slashes; a tag without one, e.g. `keyring:"token"`,
+// names an account of Service.
+//
+// Fields without an item are left unchanged unless the tag has the "required" option, e.g.
+// `keyring:"mycli/token,required"`, which makes a missing item an error. Fields tagged
+// `keyring:"-"` are ignored.
+//
+// Example:
+//
+//	type Config struct {
+//	    APIToken string `keyring:"token,required" config:"sensitive"`
+//	    GitHub   string `keyring:"github.com/octocat" config:"sensitive"`
+//	}
+//	ldr := &keyring.KeyringLoader[Config]{Service: "mycli"}
+type KeyringLoader[T any] struct {
+	Service  string          // Service for tags naming only an account
+	Store    Store           // Optional credential store (defaults to DefaultStore())
+	Timeout  time.Duration   // Optional limit on each lookup, including unlock prompts (defaults to 30s)
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+}
+
+// Load reads the item of each tagged field of c and sets the field from it.
+func (k *KeyringLoader[T]) Load(c *T) error {
+	return k.load(c, nil)
+}
+
+// LoadFields reads the items of the tagged fields named in fields only, so other items are
+// not read and do not trigger unlock prompts.
+func (k *KeyringLoader[T]) LoadFields(c *T, fields []string) error {
+	return k.load(c, fields)
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (k *KeyringLoader[T]) SetDecoders(d *utils.Decoders) {
+	k.Decoders = d
+}
+
+// load reads the items of the tagged fields named in fields, or of all tagged fields if
+// fields is nil.
+func (k *KeyringLoader[T]) load(c *T, fields []string) error {
+	store := k.Store
+	if store == nil {
+		store = DefaultStore()
+	}
+	timeout := k.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, options, _ := strings.Cut(field.Tag.Get("keyring"), ",")
+		if tag == "" || tag == "-" || !field.IsExported() || (fields != nil && !slices.Contains(fields, field.Name)) {
+			continue
+		}
+		service, account, err := k.item(tag)
+		if err != nil {
+			return &loader.LoaderError{LoaderType: "KeyringLoader", Operation: "parse tag", Source: tag, Err: err}
+		}
+
+		source := service + "/" + account
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		value, err := store.Get(ctx, service, account)
+		cancel()
+		if err != nil {
+			if errors.Is(err, ErrNotFound) && !utils.HasTagOption(options, "required") {
+				continue
+			}
+			return &loader.LoaderError{LoaderType: "KeyringLoader", Operation: "read item", Source: source, Err: err}
+		}
+		if err := k.Decoders.SetFromString(v.Field(i), value); err != nil {
+			return &loader.LoaderError{LoaderType: "KeyringLoader", Operation: "parse value", Source: source, Err: err}
+		}
+	}
+	return nil
+}
+
+// item splits tag into the service and account it names.
+func (k *KeyringLoader[T]) item(tag string) (service, account string, err error) {
+	i := strings.LastIndex(tag, "/")
+	if i < 0 {
+		if k.Service == "" {
+			return "", "", fmt.Errorf("tag %q names no service and KeyringLoader.Service is empty", tag)
+		}
+		return k.Service, tag, nil
+	}
+	if i == 0 || i == len(tag)-1 {
+		return "", "", fmt.Errorf("tag %q must be \"service/account\"", tag)
+	}
+	return tag[:i], tag[i+1:], nil
+}
@@ -0,0 +1,116 @@
+//go:build !js && !wasip1 && !tinygo
+
+package keyring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// mapStore is a Store backed by a map keyed by "service/account".
+type mapStore struct {
+	items map[string]string
+	reads []string
+}
+
+func (s *mapStore) Get(_ context.Context, service, account string) (string, error) {
+	key := service + "/" + account
+	s.reads = append(s.reads, key)
+	value, ok := s.items[key]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", key, ErrNotFound)
+	}
+	return value, nil
+}
+
+type keyringTestConfig struct {
+	Token   string        `keyring:"token,required"`
+	GitHub  string        `keyring:"github.com/cli/octocat"`
+	Timeout time.Duration `keyring:"mycli/timeout"`
+	Missing string        `keyring:"mycli/missing"`
+	Ignored string        `keyring:"-"`
+}
+
+func TestKeyringLoader_Load(t *testing.T) {
+	store := &mapStore{items: map[string]string{
+		"mycli/token":            "t0ken",
+		"github.com/cli/octocat": "gh-token",
+		"mycli/timeout":          "5s",
+	}}
+	cfg := &keyringTestConfig{Missing: "unchanged", Ignored: "unchanged"}
+	ldr := &KeyringLoader[keyringTestConfig]{Service: "mycli", Store: store}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := keyringTestConfig{Token: "t0ken", GitHub: "gh-token", Timeout: 5 * time.Second, Missing: "unchanged", Ignored: "unchanged"}
+	if *cfg != want {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestKeyringLoader_LoadFields(t *testing.T) {
+	store := &mapStore{items: map[string]string{"mycli/timeout": "5s"}}
+	ldr := &KeyringLoader[keyringTestConfig]{Service: "mycli", Store: store}
+
+	cfg := &keyringTestConfig{}
+	if err := ldr.LoadFields(cfg, []string{"Timeout"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Timeout != 5*time.Second || len(store.reads) != 1 {
+		t.Errorf("expected only the Timeout item to be read, got %+v after reading %v", cfg, store.reads)
+	}
+}
+
+func TestKeyringLoader_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		service   string
+		items     map[string]string
+		operation string
+		source    string
+	}{
+		{"required missing", "mycli", map[string]string{}, "read item", "mycli/token"},
+		{"invalid value", "mycli", map[string]string{"mycli/token": "t", "mycli/timeout": "soon"}, "parse value", "mycli/timeout"},
+		{"no service", "", map[string]string{}, "parse tag", "token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldr := &KeyringLoader[keyringTestConfig]{Service: tt.service, Store: &mapStore{items: tt.items}}
+
+			err := ldr.Load(&keyringTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) {
+				t.Fatalf("expected *loader.LoaderError, got %v", err)
+			}
+			if loaderErr.LoaderType != "KeyringLoader" || loaderErr.Operation != tt.operation || loaderErr.Source != tt.source {
+				t.Errorf("unexpected error: %v", loaderErr)
+			}
+		})
+	}
+}
+
+func TestKeyringLoader_Item(t *testing.T) {
+	ldr := &KeyringLoader[keyringTestConfig]{Service: "default"}
+	tests := []struct {
+		tag, service, account string
+		wantErr               bool
+	}{
+		{tag: "token", service: "default", account: "token"},
+		{tag: "mycli/token", service: "mycli", account: "token"},
+		{tag: "github.com/cli/octocat", service: "github.com/cli", account: "octocat"},
+		{tag: "/token", wantErr: true},
+		{tag: "mycli/", wantErr: true},
+	}
+	for _, tt := range tests {
+		service, account, err := ldr.item(tt.tag)
+		if (err != nil) != tt.wantErr || service != tt.service || account != tt.account {
+			t.Errorf("item(%q) = %q, %q, %v", tt.tag, service, account, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build !js && !wasip1 && !tinygo
+
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by a Store when the credential store has no item for a service
+// and account.
+var ErrNotFound = errors.New("keyring item not found")
+
+// Store reads secrets from a credential store.
+type Store interface {
+	// Get returns the secret stored for service and account, or an error wrapping
+	// ErrNotFound if there is none.
+	Get(ctx context.Context, service, account string) (string, error)
+}
+
+// DefaultStore returns the store for the operating system: the macOS Keychain, the Windows
+// Credential Manager, or the Secret Service through secret-tool elsewhere.
+func DefaultStore() Store {
+	return defaultStore()
+}
+
+// defaultTimeout limits how long a lookup may take, including any unlock prompt.
+const defaultTimeout = 30 * time.Second
+
+// commandStore reads secrets by running a command line tool.
+type commandStore struct {
+	name     string                                         // Command to run
+	args     func(service, account string) []string         // Arguments looking up an item
+	notFound func(exitCode int, stdout, stderr []byte) bool // Whether a failure means there is no item
+}
+
+// Get runs the command and returns its output without the trailing newline.
+func (s *commandStore) Get(ctx context.Context, service, account string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.name, s.args(service, account)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && s.notFound(exitErr.ExitCode(), stdout.Bytes(), stderr.Bytes()) {
+			return "", fmt.Errorf("%s/%s: %w", service, account, ErrNotFound)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", s.name, err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", s.name, err)
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
@@ -0,0 +1,19 @@
+//go:build !tinygo
+
+package keyring
+
+// securityItemNotFound is the exit status of security(1) when no item matches.
+const securityItemNotFound = 44
+
+// defaultStore reads generic passwords from the user's default keychain.
+func defaultStore() Store {
+	return &commandStore{
+		name: "/usr/bin/security",
+		args: func(service, account string) []string {
+			return []string{"find-generic-password", "-s", service, "-a", account, "-w"}
+		},
+		notFound: func(exitCode int, _, _ []byte) bool {
+			return exitCode == securityItemNotFound
+		},
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !darwin && !windows && !js && !wasip1 && !tinygo
+
+package keyring
+
+// defaultStore reads items from the Secret Service with secret-tool, looking them up by
+// their "service" and "username" attributes, the attributes other Go keyring libraries use.
+func defaultStore() Store {
+	return &commandStore{
+		name: "secret-tool",
+		args: func(service, account string) []string {
+			return []string{"lookup", "service", service, "username", account}
+		},
+		// secret-tool exits with status 1 and prints nothing when no item matches
+		notFound: func(exitCode int, stdout, stderr []byte) bool {
+			return exitCode == 1 && len(stdout) == 0 && len(stderr) == 0
+		},
+	}
+}
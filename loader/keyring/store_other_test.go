@@ -0,0 +1,47 @@
+//go:build linux && !tinygo
+
+package keyring
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSecretTool puts a secret-tool script running body first on PATH.
+func fakeSecretTool(t *testing.T, body string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "secret-tool"), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestDefaultStore_SecretTool(t *testing.T) {
+	fakeSecretTool(t, `[ "$*" = "lookup service mycli username token" ] && printf 's3cret\n' && exit 0
+exit 1`)
+
+	value, err := DefaultStore().Get(context.Background(), "mycli", "token")
+	if err != nil || value != "s3cret" {
+		t.Errorf("Get() = %q, %v, want s3cret", value, err)
+	}
+	if _, err := DefaultStore().Get(context.Background(), "mycli", "other"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing item, got %v", err)
+	}
+}
+
+func TestDefaultStore_SecretToolFailure(t *testing.T) {
+	fakeSecretTool(t, `echo "Cannot autolaunch D-Bus without X11 \$DISPLAY" >&2; exit 1`)
+
+	_, err := DefaultStore().Get(context.Background(), "mycli", "token")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a failure other than ErrNotFound, got %v", err)
+	}
+	if want := "Cannot autolaunch D-Bus"; !strings.Contains(err.Error(), want) {
+		t.Errorf("expected the error to include stderr %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build !tinygo
+
+package keyring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var (
+	advapi32      = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW = advapi32.NewProc("CredReadW")
+	procCredFree  = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric = 1    // CRED_TYPE_GENERIC
+	errorNotFound   = 1168 // ERROR_NOT_FOUND
+)
+
+// credential mirrors the Windows CREDENTIALW structure.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// windowsStore reads generic credentials from the Windows Credential Manager.
+type windowsStore struct{}
+
+// defaultStore returns the Credential Manager store.
+func defaultStore() Store {
+	return windowsStore{}
+}
+
+// Get reads the generic credential with the target name "service:account". Blobs are read
+// as UTF-8, as written by Go keyring libraries, unless they look like the UTF-16 text
+// written by the Credential Manager UI and cmdkey.
+func (windowsStore) Get(_ context.Context, service, account string) (string, error) {
+	target, err := syscall.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	ok, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ok == 0 {
+		if errors.Is(err, syscall.Errno(errorNotFound)) {
+			return "", fmt.Errorf("%s/%s: %w", service, account, ErrNotFound)
+		}
+		return "", fmt.Errorf("CredRead: %w", err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	return decodeBlob(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+// decodeBlob returns blob as a string, decoding it as UTF-16LE if every second byte is zero.
+func decodeBlob(blob []byte) string {
+	if len(blob) == 0 || len(blob)%2 != 0 {
+		return string(blob)
+	}
+	units := make([]uint16, len(blob)/2)
+	for i := range units {
+		if blob[2*i+1] != 0 {
+			return string(blob)
+		}
+		units[i] = uint16(blob[2*i])
+	}
+	return string(utf16.Decode(units))
+}
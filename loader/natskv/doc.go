@@ -0,0 +1,6 @@
+// Package natskv provides KVLoader, which loads configuration from a NATS JetStream
+// key-value bucket and can watch its keys for changes.
+//
+// The loader does not depend on a NATS client: it reads through the Bucket interface,
+// which services wrap around the jetstream.KeyValue of the connection they already have.
+package natskv
@@ -0,0 +1,176 @@
+package natskv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// ErrKeyNotFound is returned by a Bucket when a key has no value, including keys that were
+// deleted or purged.
+var ErrKeyNotFound = errors.New("key not found")
+
+// Bucket reads values from a JetStream key-value bucket. The method set of nats.go's
+// jetstream.KeyValue returns its own entry type, so wrap it:
+//
+//	type bucket struct{ kv jetstream.KeyValue }
+//
+//	func (b bucket) Get(ctx context.Context, key string) ([]byte, error) {
+//	    entry, err := b.kv.Get(ctx, key)
+//	    if errors.Is(err, jetstream.ErrKeyNotFound) {
+//	        return nil, natskv.ErrKeyNotFound
+//	    }
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return entry.Value(), nil
+//	}
+type Bucket interface {
+	// Get returns the current value of key, or an error wrapping ErrKeyNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// KeyWatcher is implemented by buckets that can watch keys, enabling KVLoader.Watch. With
+// jetstream.KeyValue it is a Watch on each key with jetstream.UpdatesOnly(), forwarding the
+// key of every entry received.
+type KeyWatcher interface {
+	// WatchKeys sends the key of each later update, deletion or purge of keys on the
+	// returned channel until ctx is done, then closes it.
+	WatchKeys(ctx context.Context, keys []string) (<-chan string, error)
+}
+
+// KVLoader loads configuration from a NATS JetStream key-value bucket. It supports fields
+// tagged with `nats:"key"`, which are set from the value of Prefix followed by key, and
+// parsed according to the field type.
+//
+// Fields whose key has no value are left unchanged unless the tag has the "required"
+// option, e.g. `nats:"db.password,required"`, which makes a missing key an error. Fields
+// tagged `nats:"-"` are ignored.
+//
+// KVLoader implements loader.Watcher when Bucket implements KeyWatcher.
+//
+// Example:
+//
+//	type Config struct {
+//	    RateLimit int    `nats:"rate-limit"`
+//	    Upstream  string `nats:"upstream,required"`
+//	}
+//	kv, err := js.KeyValue(ctx, "config")
+//	ldr := &natskv.KVLoader[Config]{Bucket: bucket{kv}, Prefix: "payments."}
+type KVLoader[T any] struct {
+	Bucket   Bucket          // Bucket to read from
+	Prefix   string          // Optional prefix for every key, e.g. "payments."
+	Timeout  time.Duration   // Optional limit on each read
+	Decoders *utils.Decoders // Decoders for custom types (defaults to utils.DefaultDecoders)
+}
+
+// Load reads the key of each tagged field of c and sets the field from its value.
+func (k *KVLoader[T]) Load(c *T) error {
+	return k.load(context.Background(), c, nil)
+}
+
+// LoadContext is like Load, but passes ctx to each read, so its deadline and cancellation
+// stop a slow or unreachable server.
+func (k *KVLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	return k.load(ctx, c, nil)
+}
+
+// LoadFields reads the keys of the tagged fields named in fields only.
+func (k *KVLoader[T]) LoadFields(c *T, fields []string) error {
+	return k.load(context.Background(), c, fields)
+}
+
+// SetDecoders sets the decoders used for custom types.
+func (k *KVLoader[T]) SetDecoders(d *utils.Decoders) {
+	k.Decoders = d
+}
+
+// Watch calls changed after each update to the key of a tagged field until ctx is done.
+// It returns an error if Bucket does not implement KeyWatcher or the keys cannot be watched.
+func (k *KVLoader[T]) Watch(ctx context.Context, changed func()) error {
+	watcher, ok := k.Bucket.(KeyWatcher)
+	if !ok {
+		return &loader.LoaderError{LoaderType: "KVLoader", Operation: "watch keys", Source: k.Prefix, Err: fmt.Errorf("bucket %T does not implement KeyWatcher: %w", k.Bucket, errors.ErrUnsupported)}
+	}
+	var keys []string
+	for _, field := range keyFields(reflect.TypeOf((*T)(nil)).Elem()) {
+		keys = append(keys, k.Prefix+field.key)
+	}
+	if len(keys) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	updates, err := watcher.WatchKeys(ctx, keys)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "KVLoader", Operation: "watch keys", Source: k.Prefix, Err: err}
+	}
+	for range updates {
+		changed()
+	}
+	return nil
+}
+
+// load reads with ctx the keys of the tagged fields named in fields, or of all tagged
+// fields if fields is nil.
+func (k *KVLoader[T]) load(ctx context.Context, c *T, fields []string) error {
+	if k.Bucket == nil {
+		return &loader.LoaderError{LoaderType: "KVLoader", Operation: "read key", Source: k.Prefix, Err: errors.New("no bucket configured")}
+	}
+	v := reflect.ValueOf(c).Elem()
+	for _, field := range keyFields(v.Type()) {
+		if fields != nil && !slices.Contains(fields, v.Type().Field(field.index).Name) {
+			continue
+		}
+		key := k.Prefix + field.key
+		value, err := k.get(ctx, key)
+		if err != nil {
+			if errors.Is(err, ErrKeyNotFound) && !field.required {
+				continue
+			}
+			return &loader.LoaderError{LoaderType: "KVLoader", Operation: "read key", Source: key, Err: err}
+		}
+		if err := k.Decoders.SetFromString(v.Field(field.index), string(value)); err != nil {
+			return &loader.LoaderError{LoaderType: "KVLoader", Operation: "parse value", Source: key, Err: err}
+		}
+	}
+	return nil
+}
+
+// get reads key from the bucket with ctx, within Timeout if set.
+func (k *KVLoader[T]) get(ctx context.Context, key string) ([]byte, error) {
+	if k.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, k.Timeout)
+		defer cancel()
+	}
+	return k.Bucket.Get(ctx, key)
+}
+
+// keyField is an exported field of a configuration tagged with a key.
+type keyField struct {
+	index    int
+	key      string
+	required bool
+}
+
+// keyFields returns the fields of the struct type t tagged with a key.
+func keyFields(t reflect.Type) []keyField {
+	var fields []keyField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, options, _ := strings.Cut(field.Tag.Get("nats"), ",")
+		if key == "" || key == "-" || !field.IsExported() {
+			continue
+		}
+		fields = append(fields, keyField{index: i, key: key, required: utils.HasTagOption(options, "required")})
+	}
+	return fields
+}
@@ -0,0 +1,144 @@
+package natskv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// fakeBucket is a Bucket backed by a map, sending keys put on updates to watchers.
+type fakeBucket struct {
+	values  map[string]string
+	updates chan string
+	watched []string
+}
+
+func (b *fakeBucket) Get(_ context.Context, key string) ([]byte, error) {
+	value, ok := b.values[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", key, ErrKeyNotFound)
+	}
+	return []byte(value), nil
+}
+
+func (b *fakeBucket) WatchKeys(ctx context.Context, keys []string) (<-chan string, error) {
+	b.watched = keys
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case key := <-b.updates:
+				out <- key
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// getOnlyBucket hides the WatchKeys method of a fakeBucket.
+type getOnlyBucket struct{ Bucket }
+
+type kvTestConfig struct {
+	RateLimit int           `nats:"rate-limit"`
+	Upstream  string        `nats:"upstream,required"`
+	Timeout   time.Duration `nats:"timeout"`
+	Ignored   string        `nats:"-"`
+	Name      string
+}
+
+func TestKVLoader_Load(t *testing.T) {
+	bucket := &fakeBucket{values: map[string]string{
+		"payments.rate-limit": "100",
+		"payments.upstream":   "https://upstream",
+		"payments.-":          "ignored",
+	}}
+	cfg := &kvTestConfig{Timeout: time.Second, Ignored: "unchanged"}
+	ldr := &KVLoader[kvTestConfig]{Bucket: bucket, Prefix: "payments."}
+
+	if err := ldr.Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := kvTestConfig{RateLimit: 100, Upstream: "https://upstream", Timeout: time.Second, Ignored: "unchanged"}
+	if *cfg != want {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestKVLoader_LoadFields(t *testing.T) {
+	bucket := &fakeBucket{values: map[string]string{"rate-limit": "100"}}
+	cfg := &kvTestConfig{}
+	ldr := &KVLoader[kvTestConfig]{Bucket: bucket}
+
+	// Upstream is required but not selected, so its missing key is not an error
+	if err := ldr.LoadFields(cfg, []string{"RateLimit"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RateLimit != 100 {
+		t.Errorf("expected RateLimit to be loaded, got %+v", cfg)
+	}
+}
+
+func TestKVLoader_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]string
+		operation string
+		source    string
+	}{
+		{"required missing", map[string]string{}, "read key", "upstream"},
+		{"invalid value", map[string]string{"upstream": "u", "timeout": "soon"}, "parse value", "timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldr := &KVLoader[kvTestConfig]{Bucket: &fakeBucket{values: tt.values}}
+
+			err := ldr.Load(&kvTestConfig{})
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) {
+				t.Fatalf("expected *loader.LoaderError, got %v", err)
+			}
+			if loaderErr.LoaderType != "KVLoader" || loaderErr.Operation != tt.operation || loaderErr.Source != tt.source {
+				t.Errorf("unexpected error: %v", loaderErr)
+			}
+		})
+	}
+}
+
+func TestKVLoader_Watch(t *testing.T) {
+	bucket := &fakeBucket{updates: make(chan string)}
+	ldr := &KVLoader[kvTestConfig]{Bucket: bucket, Prefix: "payments."}
+	var _ loader.Watcher = ldr
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan struct{}, 1)
+	done := make(chan error)
+	go func() {
+		done <- ldr.Watch(ctx, func() { changes <- struct{}{} })
+	}()
+
+	bucket.updates <- "payments.rate-limit"
+	<-changes
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch returned %v after cancellation, want nil", err)
+	}
+	if want := []string{"payments.rate-limit", "payments.upstream", "payments.timeout"}; !slices.Equal(bucket.watched, want) {
+		t.Errorf("expected keys %v to be watched, got %v", want, bucket.watched)
+	}
+}
+
+func TestKVLoader_Watch_Unsupported(t *testing.T) {
+	ldr := &KVLoader[kvTestConfig]{Bucket: getOnlyBucket{&fakeBucket{}}}
+
+	if err := ldr.Watch(context.Background(), func() {}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected errors.ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+//go:build !js && !wasip1 && !tinygo
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// PluginLoader loads configuration from an external plugin executable speaking the
+// JSON protocol defined in this package. Fields tagged with the loader's Tag (default
+// `plugin:"key"`) are sent to the plugin, and the values it returns are decoded into them.
+//
+// Example:
+//
+//	type Config struct {
+//	    DBPassword string `plugin:"vault:/prod/db/password"`
+//	}
+//	ldr := &plugin.PluginLoader[Config]{Command: "/usr/local/bin/vault-config-plugin"}
+type PluginLoader[T any] struct {
+	Command string        // Path to the plugin executable
+	Args    []string      // Optional arguments passed to the plugin
+	Env     []string      // Optional extra environment variables (KEY=VALUE) for the plugin
+	Tag     string        // Struct tag naming the key for each field (defaults to "plugin")
+	Timeout time.Duration // Optional limit on how long the plugin may run
+}
+
+// Load runs the plugin once with every tagged field and applies the returned values.
+// Values for fields that were not requested are ignored. The plugin is not started when
+// no field carries the tag.
+func (p *PluginLoader[T]) Load(c *T) error {
+	return p.LoadContext(context.Background(), c)
+}
+
+// LoadContext is like Load, but kills the plugin when ctx is done.
+func (p *PluginLoader[T]) LoadContext(ctx context.Context, c *T) error {
+	tagKey := p.Tag
+	if tagKey == "" {
+		tagKey = "plugin"
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	req := Request{Version: ProtocolVersion}
+	requested := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get(tagKey)
+		if !field.IsExported() || key == "" {
+			continue
+		}
+		req.Fields = append(req.Fields, FieldSpec{Name: field.Name, Key: key, Type: field.Type.String()})
+		requested[field.Name] = i
+	}
+	if len(req.Fields) == 0 {
+		return nil
+	}
+
+	resp, err := p.run(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for name, raw := range resp.Values {
+		index, ok := requested[name]
+		if !ok {
+			continue
+		}
+		field := v.Field(index)
+		if err := json.Unmarshal(raw, field.Addr().Interface()); err != nil {
+			return &loader.LoaderError{
+				LoaderType: "PluginLoader",
+				Operation:  fmt.Sprintf("decode value for field %s", name),
+				Source:     p.Command,
+				Err:        err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// run executes the plugin with ctx and req on stdin and decodes its response.
+func (p *PluginLoader[T]) run(ctx context.Context, req Request) (*Response, error) {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, &loader.LoaderError{LoaderType: "PluginLoader", Operation: "encode request", Source: p.Command, Err: err}
+	}
+
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), p.Env...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &loader.LoaderError{LoaderType: "PluginLoader", Operation: "run plugin", Source: p.Command, Err: err}
+	}
+
+	var resp Response
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, &loader.LoaderError{LoaderType: "PluginLoader", Operation: "decode response", Source: p.Command, Err: err}
+	}
+	if resp.Version != ProtocolVersion {
+		return nil, &loader.LoaderError{LoaderType: "PluginLoader", Operation: "check protocol version", Source: p.Command, Err: fmt.Errorf("unsupported protocol version %d (host speaks %d)", resp.Version, ProtocolVersion)}
+	}
+	if resp.Error != "" {
+		return nil, &loader.LoaderError{LoaderType: "PluginLoader", Operation: "plugin reported error", Source: p.Command, Err: errors.New(resp.Error)}
+	}
+	return &resp, nil
+}
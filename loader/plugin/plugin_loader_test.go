@@ -0,0 +1,130 @@
+//go:build !js && !wasip1 && !tinygo
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type pluginTestConfig struct {
+	Password string   `plugin:"vault:/db/password"`
+	Port     int      `plugin:"port"`
+	Hosts    []string `plugin:"hosts"`
+	Local    string   `env:"LOCAL"`
+}
+
+// TestHelperPlugin is not a real test: it is run as the plugin process by the tests below.
+func TestHelperPlugin(t *testing.T) {
+	mode := os.Getenv("GO_EASY_CONFIG_HELPER_PLUGIN")
+	if mode == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	if mode == "version" {
+		fmt.Println(`{"version": 2, "values": {"Password": "\"s3cret\""}}`)
+		return
+	}
+	Serve(func(req Request) (map[string]any, error) {
+		if mode == "fail" {
+			return nil, errors.New("vault unavailable")
+		}
+		values := make(map[string]any)
+		if mode == "unrequested" {
+			values["Local"] = "overwritten"
+		}
+		for _, f := range req.Fields {
+			switch f.Key {
+			case "vault:/db/password":
+				values[f.Name] = "s3cret"
+			case "port":
+				values[f.Name] = 5432
+			case "hosts":
+				values[f.Name] = []string{"a", "b"}
+			}
+		}
+		return values, nil
+	})
+}
+
+func helperPluginLoader(mode string) *PluginLoader[pluginTestConfig] {
+	return &PluginLoader[pluginTestConfig]{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestHelperPlugin"},
+		Env:     []string{"GO_EASY_CONFIG_HELPER_PLUGIN=" + mode},
+	}
+}
+
+func TestPluginLoader_Load(t *testing.T) {
+	cfg := &pluginTestConfig{Local: "unchanged"}
+	if err := helperPluginLoader("ok").Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Password != "s3cret" || cfg.Port != 5432 || fmt.Sprint(cfg.Hosts) != "[a b]" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if cfg.Local != "unchanged" {
+		t.Errorf("expected untagged field to be left alone, got %q", cfg.Local)
+	}
+}
+
+func TestPluginLoader_Load_IgnoresUnrequestedFields(t *testing.T) {
+	cfg := &pluginTestConfig{Local: "unchanged"}
+	if err := helperPluginLoader("unrequested").Load(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Local != "unchanged" || cfg.Password != "s3cret" {
+		t.Errorf("expected only requested fields to be set, got %+v", cfg)
+	}
+}
+
+func TestPluginLoader_Load_VersionMismatch(t *testing.T) {
+	cfg := &pluginTestConfig{}
+	err := helperPluginLoader("version").Load(cfg)
+
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) || loaderErr.Operation != "check protocol version" {
+		t.Fatalf("expected check protocol version LoaderError, got %v", err)
+	}
+	if cfg.Password != "" {
+		t.Errorf("expected no values to be applied, got %+v", cfg)
+	}
+}
+
+func TestPluginLoader_Load_PluginError(t *testing.T) {
+	err := helperPluginLoader("fail").Load(&pluginTestConfig{})
+
+	var loaderErr *loader.LoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Fatalf("expected LoaderError, got %T: %v", err, err)
+	}
+	if loaderErr.Operation != "plugin reported error" || !strings.Contains(err.Error(), "vault unavailable") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPluginLoader_Load_MissingExecutable(t *testing.T) {
+	ldr := &PluginLoader[pluginTestConfig]{Command: "/nonexistent/plugin"}
+
+	var loaderErr *loader.LoaderError
+	if err := ldr.Load(&pluginTestConfig{}); !errors.As(err, &loaderErr) || loaderErr.Operation != "run plugin" {
+		t.Fatalf("expected run plugin LoaderError, got %v", err)
+	}
+}
+
+func TestPluginLoader_Load_NoTaggedFields(t *testing.T) {
+	type untagged struct {
+		Name string `env:"NAME"`
+	}
+	ldr := &PluginLoader[untagged]{Command: "/nonexistent/plugin"}
+	if err := ldr.Load(&untagged{}); err != nil {
+		t.Errorf("expected plugin not to be started, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package plugin provides an out-of-process loader protocol so configuration sources
+// can be shipped as separate binaries without forking the library.
+//
+// The host (PluginLoader) starts the plugin executable, writes a single JSON Request
+// to its stdin and reads a single JSON Response from its stdout. Anything the plugin
+// writes to stderr is passed through to the host's stderr.
+//
+// A minimal plugin:
+//
+//	func main() {
+//	    plugin.Serve(func(req plugin.Request) (map[string]any, error) {
+//	        values := make(map[string]any)
+//	        for _, f := range req.Fields {
+//	            values[f.Name] = lookup(f.Key)
+//	        }
+//	        return values, nil
+//	    })
+//	}
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ProtocolVersion is the version of the request/response format spoken by this package.
+const ProtocolVersion = 1
+
+// Request is sent by the host to the plugin on stdin.
+type Request struct {
+	Version int         `json:"version"`
+	Fields  []FieldSpec `json:"fields"`
+}
+
+// FieldSpec describes a configuration field the plugin is asked to provide.
+type FieldSpec struct {
+	Name string `json:"name"` // Go field name, used as the key in Response.Values
+	Key  string `json:"key"`  // Value of the field's plugin tag (e.g. a secret path)
+	Type string `json:"type"` // Go type of the field (e.g. "string", "int", "[]string")
+}
+
+// Response is written by the plugin to stdout. Its Version must be ProtocolVersion.
+// Values are keyed by field name and decoded into the field with encoding/json.
+// Fields without an entry are left unchanged, and entries for fields that were not
+// requested are ignored. A non-empty Error fails the load.
+type Response struct {
+	Version int                        `json:"version"`
+	Values  map[string]json.RawMessage `json:"values,omitempty"`
+	Error   string                     `json:"error,omitempty"`
+}
+
+// Serve implements the plugin side of the protocol using os.Stdin and os.Stdout.
+// It exits the process with status 1 if the request cannot be read or the response written.
+func Serve(handler func(Request) (map[string]any, error)) {
+	if err := ServeIO(os.Stdin, os.Stdout, handler); err != nil {
+		fmt.Fprintf(os.Stderr, "plugin: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// ServeIO implements the plugin side of the protocol on the given reader and writer.
+// Errors returned by handler are reported to the host in Response.Error.
+func ServeIO(r io.Reader, w io.Writer, handler func(Request) (map[string]any, error)) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("decode request: %w", err)
+	}
+
+	resp := Response{Version: ProtocolVersion}
+	if req.Version != ProtocolVersion {
+		resp.Error = fmt.Sprintf("unsupported protocol version %d (plugin speaks %d)", req.Version, ProtocolVersion)
+	} else if values, err := handler(req); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Values = make(map[string]json.RawMessage, len(values))
+		for name, value := range values {
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("encode value for field %s: %w", name, err)
+			}
+			resp.Values[name] = raw
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+	return nil
+}
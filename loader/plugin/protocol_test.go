@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestServeIO(t *testing.T) {
+	in := strings.NewReader(`{"version":1,"fields":[{"name":"Port","key":"port","type":"int"}]}`)
+	out := &bytes.Buffer{}
+
+	err := ServeIO(in, out, func(req Request) (map[string]any, error) {
+		if len(req.Fields) != 1 || req.Fields[0].Key != "port" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		return map[string]any{"Port": 8080}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Version != ProtocolVersion || string(resp.Values["Port"]) != "8080" || resp.Error != "" {
+		t.Errorf("unexpected response: %s", out.String())
+	}
+}
+
+func TestServeIO_HandlerError(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := ServeIO(strings.NewReader(`{"version":1}`), out, func(Request) (map[string]any, error) {
+		return nil, errors.New("backend down")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"error":"backend down"`) {
+		t.Errorf("expected error in response, got %s", out.String())
+	}
+}
+
+func TestServeIO_VersionMismatch(t *testing.T) {
+	out := &bytes.Buffer{}
+	called := false
+	err := ServeIO(strings.NewReader(`{"version":99}`), out, func(Request) (map[string]any, error) {
+		called = true
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called || !strings.Contains(out.String(), "unsupported protocol version") {
+		t.Errorf("expected version mismatch to be reported, got %s", out.String())
+	}
+}
+
+func TestServeIO_InvalidRequest(t *testing.T) {
+	if err := ServeIO(strings.NewReader("not json"), &bytes.Buffer{}, nil); err == nil {
+		t.Error("expected error for invalid request")
+	}
+}
@@ -0,0 +1,152 @@
+package loader
+
+import (
+	"context"
+	"sync"
+)
+
+// SourceCache holds raw values fetched from sources, such as secret values, parameter
+// values and file contents, keyed by source. Loaders given the same cache share what any
+// of them has fetched, so applications with several configuration structs reading the
+// same sources fetch each one once. A SourceCache is safe for concurrent use.
+//
+// Keys are prefixed by the kind of source, e.g. "secretsmanager:prod/db", "ssm:/app/port"
+// or "file:config.json". Values are kept until the cache is cleared.
+type SourceCache struct {
+	mu       sync.RWMutex
+	values   map[string]string
+	inflight map[string]*sourceFetch
+}
+
+// sourceFetch is a fetch in progress, shared by concurrent callers of Fetch.
+type sourceFetch struct {
+	done  chan struct{}
+	value string
+	err   error
+}
+
+// NewSourceCache returns an empty SourceCache.
+func NewSourceCache() *SourceCache {
+	return &SourceCache{values: make(map[string]string), inflight: make(map[string]*sourceFetch)}
+}
+
+// Fetch returns the value cached for key, calling fetch to obtain and store it on a miss.
+// Concurrent calls for the same key share a single call of fetch. Errors are not cached.
+func (c *SourceCache) Fetch(key string, fetch func() (string, error)) (string, error) {
+	c.mu.Lock()
+	if value, ok := c.values[key]; ok {
+		c.mu.Unlock()
+		return value, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &sourceFetch{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = fetch()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.values[key] = call.value
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// Get returns the value stored for key and whether there was one.
+func (c *SourceCache) Get(key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Set stores value for key.
+func (c *SourceCache) Set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+}
+
+// Len returns the number of cached values.
+func (c *SourceCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.values)
+}
+
+// Clear removes every cached value.
+func (c *SourceCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.values)
+}
+
+// SourcePool shares one SourceCache between every handler holding a reference to it, so
+// handlers for different configuration structs deduplicate fetches of the same secrets,
+// parameters and files. The cache is created by the first Acquire and cleared once the last
+// reference is released, so fetched secrets do not stay in memory once no handler needs
+// them. The zero value is ready to use and a SourcePool is safe for concurrent use.
+type SourcePool struct {
+	mu    sync.Mutex
+	cache *SourceCache
+	refs  int
+}
+
+// DefaultSourcePool is the process-wide pool.
+var DefaultSourcePool = &SourcePool{}
+
+// Acquire adds a reference to the pool and returns its cache.
+func (p *SourcePool) Acquire() *SourceCache {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cache == nil {
+		p.cache = NewSourceCache()
+	}
+	p.refs++
+	return p.cache
+}
+
+// Release drops a reference taken by Acquire. Releasing the last reference clears the cache
+// and the next Acquire starts a new one.
+func (p *SourcePool) Release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.refs == 0 {
+		return
+	}
+	p.refs--
+	if p.refs == 0 {
+		p.cache.Clear()
+		p.cache = nil
+	}
+}
+
+// Refs returns the number of references held.
+func (p *SourcePool) Refs() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refs
+}
+
+// Prefetcher is implemented by loaders that can fetch their sources into a SourceCache
+// ahead of Load. Handler.Prefetch sets the cache with SetSourceCache and then calls
+// Prefetch with every field whose tag could be resolved; Load then reads cached values
+// instead of calling the source again.
+type Prefetcher interface {
+	SetSourceCache(cache *SourceCache)
+	Prefetch(ctx context.Context, fields []Field) error
+}
+
+// DegradationReporter is implemented by loaders that can fall back, for example to a stale
+// cache entry, when their source fails. Handler.Load succeeds in that case, so the handler
+// sets fn with SetDegradationHandler to report the failure as an event instead.
+type DegradationReporter interface {
+	SetDegradationHandler(fn func(source string, err error))
+}
@@ -0,0 +1,34 @@
+package loader
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// VariableSource returns the values available for ${VAR} references while a loader runs,
+// keyed by variable name, including nested and scoped names such as "Primary.HOST". The
+// values have passed the interpolation guard of the chain running the loader, which
+// returns an error if one has not.
+type VariableSource func() (map[string]string, error)
+
+// variableSourceKey is the context key of a VariableSource.
+type variableSourceKey struct{}
+
+// WithVariableSource returns a copy of ctx carrying source. InterpolatingChainLoader passes
+// one to each ContextLoader, so loaders expanding ${VAR} references in their own settings
+// use the same checked values as struct tags.
+func WithVariableSource(ctx context.Context, source VariableSource) context.Context {
+	return context.WithValue(ctx, variableSourceKey{}, source)
+}
+
+// Variables returns the values for ${VAR} references from the VariableSource carried by
+// ctx. Without one, as when a loader runs on its own, it falls back to the unchecked values
+// of the populated top-level availableAs fields of the struct v.
+func Variables(ctx context.Context, v reflect.Value) (map[string]string, error) {
+	if source, ok := ctx.Value(variableSourceKey{}).(VariableSource); ok {
+		return source()
+	}
+	return utils.AvailableAsValues(v), nil
+}
@@ -0,0 +1,40 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Field describes a configuration field with its struct tag after variable interpolation.
+type Field struct {
+	Name string            // Name of the struct field
+	Tag  reflect.StructTag // Tag with ${VAR} references resolved
+}
+
+// SourceCheck is the outcome of checking a single source referenced by the configuration.
+type SourceCheck struct {
+	LoaderType string // Type of loader owning the source (e.g., "SecretsManagerLoader")
+	Field      string // Field the source populates, or empty for whole-struct sources such as files
+	Source     string // Source identifier (e.g., secret name, parameter name, file path)
+	Err        error  // Why the source is missing or inaccessible, nil if it is usable
+}
+
+// String returns a one-line summary of the check.
+func (c SourceCheck) String() string {
+	status := "ok"
+	if c.Err != nil {
+		status = c.Err.Error()
+	}
+	if c.Field != "" {
+		return fmt.Sprintf("%s %s (field %s): %s", c.LoaderType, c.Source, c.Field, status)
+	}
+	return fmt.Sprintf("%s %s: %s", c.LoaderType, c.Source, status)
+}
+
+// SourceVerifier is implemented by loaders that can check their sources exist and are
+// accessible without reading any values. Handler.VerifySources calls it for every loader
+// that implements it.
+type SourceVerifier interface {
+	VerifySources(ctx context.Context, fields []Field) []SourceCheck
+}
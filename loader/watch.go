@@ -0,0 +1,19 @@
+package loader
+
+import "context"
+
+// Watcher is implemented by loaders that can report changes to their sources, so the
+// configuration can be reloaded when a source changes rather than on a timer.
+type Watcher interface {
+	// Watch calls changed after each change to a source until ctx is done, then returns
+	// nil. It returns an error if the sources cannot be watched.
+	Watch(ctx context.Context, changed func()) error
+}
+
+// FileSource is implemented by loaders that read local files, so the configuration can be
+// reloaded when one changes. Handler.Watch checks the files for changes.
+type FileSource interface {
+	// SourceFiles returns the paths of the files and directories the loader reads. A
+	// directory changes when a file in it is added, removed or modified.
+	SourceFiles() []string
+}
@@ -0,0 +1,227 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+// Locale is a BCP 47 language tag field, e.g. "en-GB" or "pt-BR". Values are validated and
+// normalised when loaded, so "en_gb" and "EN-gb" both become "en-GB". Specs report the
+// format as "bcp47".
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    DefaultLocale config.Locale `env:"DEFAULT_LOCALE" envDefault:"en-GB"`
+//	}
+//
+//	printer := message.NewPrinter(cfg.DefaultLocale.Tag())
+type Locale struct {
+	tag language.Tag
+	set bool
+}
+
+// ParseLocale parses and normalises a BCP 47 language tag. Underscores are accepted as
+// separators. The empty string is the zero Locale, meaning no locale is configured.
+func ParseLocale(s string) (Locale, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Locale{}, nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(s, "_", "-"))
+	if err != nil {
+		return Locale{}, fmt.Errorf("invalid locale %q: %w", s, err)
+	}
+	return Locale{tag: tag, set: true}, nil
+}
+
+// IsZero reports whether no locale is configured.
+func (l Locale) IsZero() bool {
+	return !l.set
+}
+
+// Tag returns the language tag, or language.Und for the zero Locale.
+func (l Locale) Tag() language.Tag {
+	return l.tag
+}
+
+// Country returns the locale's country: the explicit region, such as GB for "en-GB", or
+// the zero Country when there is none.
+func (l Locale) Country() Country {
+	region, confidence := l.tag.Region()
+	if confidence != language.Exact || !region.IsCountry() {
+		return Country{}
+	}
+	return Country{region: region, set: true}
+}
+
+// String returns the normalised tag, or "" for the zero Locale.
+func (l Locale) String() string {
+	if !l.set {
+		return ""
+	}
+	return l.tag.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Locale) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *Locale) UnmarshalText(text []byte) error {
+	parsed, err := ParseLocale(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Locale) ParameterFormat() string {
+	return "bcp47"
+}
+
+// Country is an ISO 3166-1 country field. Alpha-2 and alpha-3 codes are accepted in any
+// case and normalised to upper-case alpha-2, so "gb", "GBR" and "GB" are all "GB". Codes
+// for regions that are not countries, such as "EU" or "419", are rejected. Specs report the
+// format as "iso3166-1-alpha2".
+type Country struct {
+	region language.Region
+	set    bool
+}
+
+// ParseCountry parses an ISO 3166-1 alpha-2 or alpha-3 country code. The empty string is
+// the zero Country, meaning no country is configured.
+func ParseCountry(s string) (Country, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Country{}, nil
+	}
+	if len(s) != 2 && len(s) != 3 {
+		return Country{}, fmt.Errorf("invalid country %q: want an ISO 3166-1 alpha-2 or alpha-3 code", s)
+	}
+	region, err := language.ParseRegion(s)
+	if err != nil || !region.IsCountry() {
+		return Country{}, fmt.Errorf("invalid country %q: not an ISO 3166-1 country code", s)
+	}
+	return Country{region: region, set: true}, nil
+}
+
+// IsZero reports whether no country is configured.
+func (c Country) IsZero() bool {
+	return !c.set
+}
+
+// Region returns the country as a language.Region.
+func (c Country) Region() language.Region {
+	return c.region
+}
+
+// String returns the alpha-2 code, e.g. "GB", or "" for the zero Country.
+func (c Country) String() string {
+	if !c.set {
+		return ""
+	}
+	return c.region.String()
+}
+
+// Alpha3 returns the alpha-3 code, e.g. "GBR", or "" for the zero Country.
+func (c Country) Alpha3() string {
+	if !c.set {
+		return ""
+	}
+	return c.region.ISO3()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Country) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Country) UnmarshalText(text []byte) error {
+	parsed, err := ParseCountry(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Country) ParameterFormat() string {
+	return "iso3166-1-alpha2"
+}
+
+// Currency is an ISO 4217 currency field. Codes are accepted in any case and normalised
+// to upper case, and unknown codes are rejected. Specs report the format as "iso4217".
+type Currency struct {
+	unit currency.Unit
+	set  bool
+}
+
+// ParseCurrency parses an ISO 4217 currency code. The empty string is the zero Currency,
+// meaning no currency is configured.
+func ParseCurrency(s string) (Currency, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Currency{}, nil
+	}
+	unit, err := currency.ParseISO(s)
+	if err != nil || strings.EqualFold(s, "XXX") {
+		return Currency{}, fmt.Errorf("invalid currency %q: not an ISO 4217 currency code", s)
+	}
+	return Currency{unit: unit, set: true}, nil
+}
+
+// IsZero reports whether no currency is configured.
+func (c Currency) IsZero() bool {
+	return !c.set
+}
+
+// Unit returns the currency as a currency.Unit.
+func (c Currency) Unit() currency.Unit {
+	return c.unit
+}
+
+// Scale returns the number of decimal places amounts in the currency are usually given
+// with, e.g. 2 for GBP and 0 for JPY.
+func (c Currency) Scale() int {
+	scale, _ := currency.Standard.Rounding(c.unit)
+	return scale
+}
+
+// String returns the code, e.g. "GBP", or "" for the zero Currency.
+func (c Currency) String() string {
+	if !c.set {
+		return ""
+	}
+	return c.unit.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Currency) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Currency) UnmarshalText(text []byte) error {
+	parsed, err := ParseCurrency(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Currency) ParameterFormat() string {
+	return "iso4217"
+}
@@ -0,0 +1,110 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := map[string]string{"en-GB": "en-GB", "en_gb": "en-GB", "PT-br": "pt-BR", "de": "de", "zh-Hant-TW": "zh-Hant-TW"}
+	for in, want := range tests {
+		l, err := ParseLocale(in)
+		if err != nil {
+			t.Fatalf("ParseLocale(%q) error = %v", in, err)
+		}
+		if l.String() != want {
+			t.Errorf("ParseLocale(%q) = %q, want %q", in, l, want)
+		}
+	}
+
+	for _, in := range []string{"english", "en-GB-!", "123"} {
+		if _, err := ParseLocale(in); err == nil {
+			t.Errorf("ParseLocale(%q) expected error", in)
+		}
+	}
+
+	l, _ := ParseLocale("en-GB")
+	if got := l.Country().String(); got != "GB" {
+		t.Errorf("Country() = %q, want GB", got)
+	}
+	if l, _ := ParseLocale("fr"); !l.Country().IsZero() {
+		t.Errorf("Country() of fr = %q, want none", l.Country())
+	}
+}
+
+func TestParseCountry(t *testing.T) {
+	tests := map[string][2]string{"gb": {"GB", "GBR"}, "GBR": {"GB", "GBR"}, "us": {"US", "USA"}, "deu": {"DE", "DEU"}}
+	for in, want := range tests {
+		c, err := ParseCountry(in)
+		if err != nil {
+			t.Fatalf("ParseCountry(%q) error = %v", in, err)
+		}
+		if c.String() != want[0] || c.Alpha3() != want[1] {
+			t.Errorf("ParseCountry(%q) = %s/%s, want %s/%s", in, c, c.Alpha3(), want[0], want[1])
+		}
+	}
+
+	for _, in := range []string{"EU", "419", "XX", "United Kingdom", "G"} {
+		if _, err := ParseCountry(in); err == nil {
+			t.Errorf("ParseCountry(%q) expected error", in)
+		}
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := map[string]int{"GBP": 2, "eur": 2, "JPY": 0, "bhd": 3}
+	for in, scale := range tests {
+		c, err := ParseCurrency(in)
+		if err != nil {
+			t.Fatalf("ParseCurrency(%q) error = %v", in, err)
+		}
+		if c.Scale() != scale {
+			t.Errorf("ParseCurrency(%q).Scale() = %d, want %d", in, c.Scale(), scale)
+		}
+	}
+	if c, _ := ParseCurrency("eur"); c.String() != "EUR" {
+		t.Errorf("ParseCurrency(eur) = %q, want EUR", c)
+	}
+
+	for _, in := range []string{"ABC", "XXX", "EURO", "£"} {
+		if _, err := ParseCurrency(in); err == nil {
+			t.Errorf("ParseCurrency(%q) expected error", in)
+		}
+	}
+}
+
+type localeTestConfig struct {
+	Locale   Locale   `env:"LOCALE" json:"locale"`
+	Country  Country  `env:"COUNTRY" json:"country"`
+	Currency Currency `env:"CURRENCY" json:"currency"`
+}
+
+func TestLocaleTypes_Loaders(t *testing.T) {
+	t.Setenv("LOCALE", "en_gb")
+	t.Setenv("COUNTRY", "gbr")
+	t.Setenv("CURRENCY", "gbp")
+
+	var cfg localeTestConfig
+	if err := (&generic.EnvironmentLoader[localeTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil || string(data) != `{"locale":"en-GB","country":"GB","currency":"GBP"}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+
+	if err := (&generic.JSONLoader[localeTestConfig]{Source: []byte(`{"currency": "pounds"}`)}).Load(&cfg); err == nil {
+		t.Error("JSONLoader expected error for an invalid currency")
+	}
+
+	params := DescribeParameters[localeTestConfig]()
+	for i, format := range []string{"bcp47", "iso3166-1-alpha2", "iso4217"} {
+		if params[i].Type != "string" || params[i].Format != format {
+			t.Errorf("parameter %s = %s/%s, want string/%s", params[i].Name, params[i].Type, params[i].Format, format)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+)
+
+// LogLevelHandle is a log level that can be changed at runtime. *slog.LevelVar implements
+// it; wrap other loggers' levels, such as a zap.AtomicLevel, with LogLevelFunc.
+type LogLevelHandle interface {
+	Set(level slog.Level)
+}
+
+// LogLevelFunc adapts a function to a LogLevelHandle.
+//
+// Example:
+//
+//	atom := zap.NewAtomicLevel()
+//	cfg.LogLevel.Bind(config.LogLevelFunc(func(l slog.Level) {
+//	    atom.SetLevel(zapcore.Level(l / 4)) // slog levels are spaced 4 apart: DEBUG=-4, INFO=0, ...
+//	}))
+type LogLevelFunc func(level slog.Level)
+
+// Set calls f(level).
+func (f LogLevelFunc) Set(level slog.Level) {
+	f(level)
+}
+
+// DynamicLogLevel is a log level field that pushes its value to the log level handles bound
+// to it whenever the handler stores a new value: after Load and after a successful
+// ApplyOverride. Changing the level through an admin endpoint, or reloading configuration,
+// then takes effect in the logger without further wiring.
+//
+// Values are parsed with slog.Level.UnmarshalText, e.g. "debug", "INFO", "warn+2". Bind the
+// handles to the field of the configuration passed to the handler, before loading it:
+//
+//	type AppConfig struct {
+//	    LogLevel config.DynamicLogLevel `env:"LOG_LEVEL" envDefault:"info"`
+//	}
+//
+//	var level slog.LevelVar
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: &level}))
+//
+//	var cfg AppConfig
+//	cfg.LogLevel.Bind(&level)
+//	err := handler.LoadAndValidate(&cfg)
+//	// later: handler.ApplyOverride(&cfg, "LogLevel", "debug") also updates level
+//
+// Copies of the field share its handles.
+type DynamicLogLevel struct {
+	level   slog.Level
+	handles *logLevelHandles
+}
+
+// logLevelHandles are the handles bound to a DynamicLogLevel, shared by its copies.
+type logLevelHandles struct {
+	mu   sync.Mutex
+	list []LogLevelHandle
+}
+
+// Bind adds handle to the handles updated with the level, and sets it to the current level.
+func (d *DynamicLogLevel) Bind(handle LogLevelHandle) {
+	if d.handles == nil {
+		d.handles = &logLevelHandles{}
+	}
+	d.handles.mu.Lock()
+	d.handles.list = append(d.handles.list, handle)
+	d.handles.mu.Unlock()
+	handle.Set(d.level)
+}
+
+// Level returns the level.
+func (d DynamicLogLevel) Level() slog.Level {
+	return d.level
+}
+
+// String returns the level's name, e.g. "INFO" or "DEBUG+2".
+func (d DynamicLogLevel) String() string {
+	return d.level.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d DynamicLogLevel) MarshalText() ([]byte, error) {
+	return d.level.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It only stores the level; bound
+// handles are updated once the handler has accepted the new configuration.
+func (d *DynamicLogLevel) UnmarshalText(text []byte) error {
+	return d.level.UnmarshalText(text)
+}
+
+// publish sets the bound handles to the level.
+func (d *DynamicLogLevel) publish() {
+	if d.handles == nil {
+		return
+	}
+	d.handles.mu.Lock()
+	defer d.handles.mu.Unlock()
+	for _, handle := range d.handles.list {
+		handle.Set(d.level)
+	}
+}
+
+// dynamicField is implemented by field types that propagate new values once the handler
+// has stored them, such as DynamicLogLevel.
+type dynamicField interface {
+	publish()
+}
+
+// publishDynamicFields calls publish on every dynamicField in cfg, including those in
+// nested structs and pointers to structs.
+func publishDynamicFields(cfg any) {
+	publishDynamicValue(reflect.ValueOf(cfg))
+}
+
+func publishDynamicValue(v reflect.Value) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !v.CanAddr() {
+		return
+	}
+	if field, ok := v.Addr().Interface().(dynamicField); ok {
+		field.publish()
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			publishDynamicValue(v.Field(i))
+		}
+	}
+}
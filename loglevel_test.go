@@ -0,0 +1,105 @@
+package config
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type logLevelTestConfig struct {
+	LogLevel DynamicLogLevel `env:"LOG_LEVEL"`
+	Service  struct {
+		Level DynamicLogLevel `env:"SERVICE_LOG_LEVEL"`
+	}
+	Port int `env:"PORT" validate:"gte=0"`
+}
+
+func TestDynamicLogLevel_Load(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("SERVICE_LOG_LEVEL", "warn")
+	handler := NewConfigHandler[logLevelTestConfig](WithLoaders[logLevelTestConfig](&generic.EnvironmentLoader[logLevelTestConfig]{}))
+
+	var cfg logLevelTestConfig
+	var level, serviceLevel slog.LevelVar
+	cfg.LogLevel.Bind(&level)
+	cfg.Service.Level.Bind(&serviceLevel)
+
+	if err := handler.LoadAndValidate(&cfg); err != nil {
+		t.Fatalf("LoadAndValidate() error = %v", err)
+	}
+	if cfg.LogLevel.Level() != slog.LevelDebug || level.Level() != slog.LevelDebug {
+		t.Errorf("LogLevel = %v, handle = %v; want DEBUG", cfg.LogLevel, level.Level())
+	}
+	if serviceLevel.Level() != slog.LevelWarn {
+		t.Errorf("nested handle = %v, want WARN", serviceLevel.Level())
+	}
+}
+
+func TestDynamicLogLevel_ApplyOverride(t *testing.T) {
+	handler := NewConfigHandler[logLevelTestConfig](WithLoaders[logLevelTestConfig]())
+
+	var cfg logLevelTestConfig
+	var level slog.LevelVar
+	var funcLevel slog.Level
+	cfg.LogLevel.Bind(&level)
+	cfg.LogLevel.Bind(LogLevelFunc(func(l slog.Level) { funcLevel = l }))
+
+	if err := handler.ApplyOverride(&cfg, "LOG_LEVEL", "error"); err != nil {
+		t.Fatalf("ApplyOverride() error = %v", err)
+	}
+	if level.Level() != slog.LevelError || funcLevel != slog.LevelError {
+		t.Errorf("handles = %v/%v, want ERROR", level.Level(), funcLevel)
+	}
+	if got := handler.Overrides()[0]; got.Previous != "INFO" || got.Value != "error" {
+		t.Errorf("override = %+v, want INFO -> error", got)
+	}
+
+	// A rejected override leaves the handles alone
+	if err := handler.ApplyOverride(&cfg, "LogLevel", "verbose"); err == nil {
+		t.Fatal("ApplyOverride() expected error for an invalid level")
+	}
+	if err := handler.ApplyOverride(&cfg, "Port", "-1"); err == nil {
+		t.Fatal("ApplyOverride() expected validation error")
+	}
+	if level.Level() != slog.LevelError {
+		t.Errorf("handle = %v after failed overrides, want ERROR", level.Level())
+	}
+}
+
+func TestDynamicLogLevel_Bind(t *testing.T) {
+	var d DynamicLogLevel
+	if err := d.UnmarshalText([]byte("warn+2")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Binding sets the handle to the current level, and copies share handles
+	var level slog.LevelVar
+	d.Bind(&level)
+	if level.Level() != slog.LevelWarn+2 {
+		t.Errorf("handle = %v after Bind, want WARN+2", level.Level())
+	}
+	copied := d
+	if err := copied.UnmarshalText([]byte("debug")); err != nil {
+		t.Fatal(err)
+	}
+	if level.Level() != slog.LevelWarn+2 {
+		t.Error("UnmarshalText updated the handle before publish")
+	}
+	copied.publish()
+	if level.Level() != slog.LevelDebug {
+		t.Errorf("handle = %v after publish, want DEBUG", level.Level())
+	}
+
+	text, err := copied.MarshalText()
+	if err != nil || string(text) != "DEBUG" {
+		t.Errorf("MarshalText() = %q, %v; want DEBUG", text, err)
+	}
+}
+
+func TestDescribeParameters_TextUnmarshaler(t *testing.T) {
+	params := DescribeParameters[logLevelTestConfig]()
+	if params[0].Name != "LOG_LEVEL" || params[0].Type != "string" {
+		t.Errorf("LogLevel parameter = %+v, want a string", params[0])
+	}
+}
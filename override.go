@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// OverrideSource is the provenance recorded for values set through Handler.ApplyOverride.
+const OverrideSource = "manual override"
+
+// redactedValue replaces the values of `config:"sensitive"` fields in records and output.
+const redactedValue = "[REDACTED]"
+
+// Override records a value set through Handler.ApplyOverride.
+type Override struct {
+	Path     FieldPath // Field path, e.g. "LogLevel" or "Database.Port"
+	Value    string    // New value, or [REDACTED] for sensitive fields
+	Previous string    // Value before the override, or [REDACTED] for sensitive fields
+	Source   string    // Always OverrideSource
+	Time     time.Time // When the override was applied
+}
+
+// WithOverridePersistence sets a hook called with each override after it has passed
+// validation and before it is applied. Use it to store overrides so they survive restarts;
+// if the hook returns an error the override is not applied. The hook receives the
+// unredacted value so it can be re-applied later.
+func WithOverridePersistence[C any](persist func(Override) error) Option[C] {
+	return func(h *Handler[C]) {
+		h.persistOverride = persist
+	}
+}
+
+// ApplyOverride sets a single field of cfg at runtime, e.g. from an admin endpoint changing
+// the log level, through the same validation as a normal load.
+//
+// path names the field with dot-separated segments, each matching a Go field name
+// (case-insensitively) or its env tag, e.g. "LogLevel", "LOG_LEVEL" or "Database.Port";
+// see FieldPath. Indexes into slices and maps are not supported. value is parsed according
+// to the field type with the handler's decoders: strings, booleans, integers,
+// time.Duration, unsigned integers, floats and types implementing encoding.TextUnmarshaler
+// are supported. Dynamic fields such as DynamicLogLevel update their bound handles once cfg
+// has been updated.
+//
+// The override is applied to a copy of cfg that is validated with the handler's validator
+// and passed to the persistence hook, if any. Only then is cfg updated; on any failure an
+// *OverrideError is returned and cfg is unchanged. Successful overrides are recorded with
+// OverrideSource as their provenance and are listed by Overrides.
+//
+// ApplyOverride does not synchronise with readers of cfg; callers sharing cfg between
+// goroutines must guard it themselves.
+//
+// Example:
+//
+//	if err := handler.ApplyOverride(&cfg, "LogLevel", "debug"); err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	}
+func (c *Handler[C]) ApplyOverride(cfg *C, path FieldPath, value string) error {
+	updated := *cfg
+	field, sensitive, err := overrideField(reflect.ValueOf(&updated).Elem(), path)
+	if err != nil {
+		return &OverrideError{Path: path, Err: err}
+	}
+
+	previous := fmt.Sprint(field.Interface())
+	if err := c.decoders.SetFromString(field, value); err != nil {
+		return &OverrideError{Path: path, Err: err}
+	}
+	if err := c.Validate(&updated); err != nil {
+		return &OverrideError{Path: path, Err: err}
+	}
+
+	record := Override{Path: path, Value: value, Previous: previous, Source: OverrideSource, Time: time.Now()}
+	if c.persistOverride != nil {
+		if err := c.persistOverride(record); err != nil {
+			return &OverrideError{Path: path, Err: fmt.Errorf("persist override: %w", err)}
+		}
+	}
+
+	*cfg = updated
+	publishDynamicFields(cfg)
+	if sensitive {
+		record.Value, record.Previous = redactedValue, redactedValue
+	}
+	c.overrideMu.Lock()
+	c.overrides = append(c.overrides, record)
+	c.overrideMu.Unlock()
+	return nil
+}
+
+// Overrides returns the overrides applied through ApplyOverride, oldest first.
+// Values of sensitive fields are redacted.
+func (c *Handler[C]) Overrides() []Override {
+	c.overrideMu.Lock()
+	defer c.overrideMu.Unlock()
+	return append([]Override(nil), c.overrides...)
+}
+
+// overrideField resolves path to a settable field of v, copying any pointer-to-struct it
+// passes through so the original configuration is not modified. It also reports whether
+// the field is marked `config:"sensitive"`.
+func overrideField(v reflect.Value, path FieldPath) (reflect.Value, bool, error) {
+	return resolveFieldPath(v, path, true)
+}
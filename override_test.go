@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type overrideTestDatabase struct {
+	Host string
+	Port int `validate:"min=1,max=65535"`
+}
+
+type overrideTestConfig struct {
+	LogLevel string        `env:"LOG_LEVEL" validate:"required,oneof=debug info warn error"`
+	Timeout  time.Duration `env:"TIMEOUT"`
+	APIKey   string        `env:"API_KEY" config:"sensitive"`
+	Database *overrideTestDatabase
+}
+
+func TestHandler_ApplyOverride(t *testing.T) {
+	handler := NewConfigHandler[overrideTestConfig](WithLoaders[overrideTestConfig]())
+	db := &overrideTestDatabase{Host: "db", Port: 5432}
+	cfg := &overrideTestConfig{LogLevel: "info", Database: db}
+
+	if err := handler.ApplyOverride(cfg, "LogLevel", "debug"); err != nil {
+		t.Fatalf("ApplyOverride failed: %v", err)
+	}
+	if err := handler.ApplyOverride(cfg, "TIMEOUT", "5s"); err != nil {
+		t.Fatalf("ApplyOverride by env name failed: %v", err)
+	}
+	if err := handler.ApplyOverride(cfg, "database.port", "6543"); err != nil {
+		t.Fatalf("ApplyOverride of nested field failed: %v", err)
+	}
+	if err := handler.ApplyOverride(cfg, "APIKey", "s3cret"); err != nil {
+		t.Fatalf("ApplyOverride of sensitive field failed: %v", err)
+	}
+
+	if cfg.LogLevel != "debug" || cfg.Timeout != 5*time.Second || cfg.Database.Port != 6543 || cfg.APIKey != "s3cret" {
+		t.Errorf("overrides not applied: %+v %+v", cfg, cfg.Database)
+	}
+	if db.Port != 5432 {
+		t.Errorf("expected nested struct to be copied, original changed to %d", db.Port)
+	}
+
+	overrides := handler.Overrides()
+	if len(overrides) != 4 {
+		t.Fatalf("expected 4 recorded overrides, got %d", len(overrides))
+	}
+	if o := overrides[0]; o.Path != "LogLevel" || o.Value != "debug" || o.Previous != "info" || o.Source != OverrideSource || o.Time.IsZero() {
+		t.Errorf("unexpected override record: %+v", o)
+	}
+	if o := overrides[3]; o.Value != redactedValue || o.Previous != redactedValue {
+		t.Errorf("expected sensitive override to be redacted, got %+v", o)
+	}
+}
+
+func TestHandler_ApplyOverride_Rejected(t *testing.T) {
+	handler := NewConfigHandler[overrideTestConfig](WithLoaders[overrideTestConfig]())
+
+	tests := map[string]struct {
+		path  FieldPath
+		value string
+	}{
+		"unknown field":     {"Missing", "x"},
+		"index":             {"Database[0]", "x"},
+		"not a struct":      {"LogLevel.Inner", "x"},
+		"unparsable value":  {"Timeout", "soon"},
+		"fails validation":  {"LogLevel", "verbose"},
+		"nested validation": {"Database.Port", "70000"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &overrideTestConfig{LogLevel: "info", Database: &overrideTestDatabase{Port: 5432}}
+			err := handler.ApplyOverride(cfg, tt.path, tt.value)
+			var overrideErr *OverrideError
+			if !errors.As(err, &overrideErr) || overrideErr.Path != tt.path {
+				t.Fatalf("expected OverrideError, got %v", err)
+			}
+			if cfg.LogLevel != "info" || cfg.Database.Port != 5432 {
+				t.Errorf("expected config to be unchanged, got %+v %+v", cfg, cfg.Database)
+			}
+		})
+	}
+	if len(handler.Overrides()) != 0 {
+		t.Errorf("expected no recorded overrides, got %v", handler.Overrides())
+	}
+}
+
+func TestHandler_ApplyOverride_Persistence(t *testing.T) {
+	var stored []Override
+	persistErr := errors.New("store unavailable")
+	fail := false
+	handler := NewConfigHandler[overrideTestConfig](
+		WithLoaders[overrideTestConfig](),
+		WithOverridePersistence[overrideTestConfig](func(o Override) error {
+			if fail {
+				return persistErr
+			}
+			stored = append(stored, o)
+			return nil
+		}),
+	)
+
+	cfg := &overrideTestConfig{LogLevel: "info"}
+	if err := handler.ApplyOverride(cfg, "APIKey", "s3cret"); err != nil {
+		t.Fatalf("ApplyOverride failed: %v", err)
+	}
+	if len(stored) != 1 || stored[0].Value != "s3cret" {
+		t.Errorf("expected persisted override with raw value, got %+v", stored)
+	}
+
+	fail = true
+	if err := handler.ApplyOverride(cfg, "LogLevel", "warn"); !errors.Is(err, persistErr) {
+		t.Errorf("expected persistence error, got %v", err)
+	}
+	if cfg.LogLevel != "info" {
+		t.Errorf("expected override not to be applied when persistence fails, got %s", cfg.LogLevel)
+	}
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldLoader is implemented by loaders that can load only some top-level fields of a
+// configuration and read only the sources of those fields. Handler.LoadFields and scoped
+// handlers created with For use it so sections they discard are not fetched.
+//
+// Loaders that do not implement it are negotiated according to the handler's
+// PartialStrategy.
+type FieldLoader[T any] interface {
+	Loader[T]
+	// LoadFields loads the top-level fields of c named in fields, by Go field name, and
+	// leaves the others unchanged.
+	LoadFields(c *T, fields []string) error
+}
+
+// PartialStrategy decides how a partial load, by Handler.LoadFields or a scoped handler,
+// runs loaders that do not implement FieldLoader.
+type PartialStrategy int
+
+const (
+	// PartialShadow runs such loaders on a shadow configuration of the full type holding
+	// only the selected fields, then copies the selected fields back. Loaders that need the
+	// complete type, such as CommandLineLoader rejecting unknown flags or file loaders
+	// validating a schema, work unchanged, but still read all their sources.
+	PartialShadow PartialStrategy = iota
+	// PartialStrict fails the load with a *CapabilityError instead.
+	PartialStrict
+)
+
+// String returns the strategy name.
+func (s PartialStrategy) String() string {
+	switch s {
+	case PartialShadow:
+		return "shadow"
+	case PartialStrict:
+		return "strict"
+	}
+	return fmt.Sprintf("PartialStrategy(%d)", int(s))
+}
+
+// CapabilityError is returned by a partial load under PartialStrict when a loader cannot
+// load only the selected fields.
+type CapabilityError struct {
+	Loader string      // Loader type, e.g. "EnvironmentLoader"
+	Fields []FieldPath // Fields the load was restricted to
+}
+
+// Error implements the error interface.
+func (e *CapabilityError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = field.String()
+	}
+	return fmt.Sprintf("%s cannot load only %s: it does not implement FieldLoader", e.Loader, strings.Join(fields, ", "))
+}
+
+// WithPartialStrategy sets how partial loads run loaders that do not implement
+// FieldLoader. The default is PartialShadow.
+//
+// Example:
+//
+//	// Fail rather than fetch every secret when a scoped handler loads one section
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithPartialStrategy[AppConfig](config.PartialStrict),
+//	)
+func WithPartialStrategy[C any](s PartialStrategy) Option[C] {
+	return func(h *Handler[C]) {
+		h.partialStrategy = s
+	}
+}
+
+// LoadFields loads only the fields of cfg at paths, leaving the others unchanged, e.g. to
+// refresh one section without fetching the sources of the rest. Fields with an availableAs
+// declaration are always loaded too, so interpolated tags of the selected fields resolve.
+//
+// Fields are selected by top-level field: a nested path such as "Database.Port" loads the
+// whole Database section. Loaders implementing FieldLoader load only the selected fields;
+// the others are run according to the handler's PartialStrategy. Paths are matched as by
+// ApplyOverride and may not contain indexes.
+//
+// Like Load, LoadFields does not validate cfg.
+//
+// Example:
+//
+//	if err := handler.LoadFields(&cfg, "Database"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Handler[C]) LoadFields(cfg *C, paths ...FieldPath) error {
+	lifeCtx, err := c.life.begin()
+	if err != nil {
+		return err
+	}
+	defer c.life.active.Done()
+
+	t := reflect.TypeOf(cfg).Elem()
+	var fields []int
+	for _, path := range paths {
+		var probe C
+		if _, _, err := resolveFieldPath(reflect.ValueOf(&probe).Elem(), path, false); err != nil {
+			return fmt.Errorf("load fields %q: %w", path, err)
+		}
+		if strings.Contains(string(path), "[") {
+			return fmt.Errorf("load fields %q: %w", path, errPathIndex)
+		}
+		fields = append(fields, fieldIndex(t, path.Elements()[0]))
+	}
+
+	if err := c.chainLoader.loadPartial(cfg, fields, paths, c.partialStrategy); err != nil {
+		return parseFailureError(cfg, err)
+	}
+	if err := c.decryptValues(lifeCtx, cfg); err != nil {
+		return err
+	}
+	if err := ExpandPaths(cfg, c.pathBaseDir); err != nil {
+		return err
+	}
+	publishDynamicFields(cfg)
+	return nil
+}
+
+// loadPartial runs Load with each loader restricted to the top-level fields at the indexes
+// in fields and those with an availableAs declaration. paths are reported in errors.
+func (l *InterpolatingChainLoader[T]) loadPartial(c *T, fields []int, paths []FieldPath, strategy PartialStrategy) error {
+	engine := NewInterpolationEngine[T]()
+	engine.SetGuard(l.Guard)
+	if err := engine.Analyze(c); err != nil {
+		return fmt.Errorf("interpolation analysis failed: %w", err)
+	}
+	selected := make(map[int]bool)
+	for _, i := range fields {
+		selected[i] = true
+	}
+	for _, i := range engine.availableAsMap {
+		selected[i] = true
+	}
+	t := reflect.TypeOf(c).Elem()
+	var indexes []int
+	names := make([]string, 0, len(selected)) // Not nil, which some loaders take as all fields
+	for i := 0; i < t.NumField(); i++ {
+		if selected[i] {
+			indexes = append(indexes, i)
+			names = append(names, t.Field(i).Name)
+		}
+	}
+
+	loaders := make([]Loader[T], len(l.Loaders))
+	for i, ldr := range l.Loaders {
+		switch fl, ok := ldr.(FieldLoader[T]); {
+		case ok:
+			loaders[i] = &selectedLoader[T]{loader: fl, fields: names}
+		case strategy == PartialStrict:
+			return &CapabilityError{Loader: loaderName(ldr), Fields: paths}
+		default:
+			loaders[i] = &shadowLoader[T]{loader: ldr, fields: indexes}
+		}
+	}
+
+	partial := &InterpolatingChainLoader[T]{
+		Loaders:      loaders,
+		ShortCircuit: l.ShortCircuit,
+		Progress:     l.Progress,
+		StageTimeout: l.StageTimeout,
+		Guard:        l.Guard,
+		IsSet:        l.IsSet,
+		Policies:     l.Policies,
+		spawn:        l.spawn,
+	}
+	return partial.Load(c)
+}
+
+// selectedLoader loads only the selected fields through a FieldLoader.
+type selectedLoader[T any] struct {
+	loader FieldLoader[T]
+	fields []string
+}
+
+// Load loads the selected fields of c.
+func (l *selectedLoader[T]) Load(c *T) error {
+	return l.loader.LoadFields(c, l.fields)
+}
+
+// unwrapLoader returns the loader named in sources and errors.
+func (l *selectedLoader[T]) unwrapLoader() any {
+	return l.loader
+}
+
+// shadowLoader runs a loader that cannot select fields on a shadow configuration holding
+// only the selected fields, so it sees the full type but cannot change any other field.
+type shadowLoader[T any] struct {
+	loader Loader[T]
+	fields []int
+}
+
+// Load loads the shadow configuration and copies the selected fields back to c.
+func (l *shadowLoader[T]) Load(c *T) error {
+	shadow := new(T)
+	dst, src := reflect.ValueOf(c).Elem(), reflect.ValueOf(shadow).Elem()
+	for _, i := range l.fields {
+		src.Field(i).Set(dst.Field(i))
+	}
+	if err := l.loader.Load(shadow); err != nil {
+		return err
+	}
+	for _, i := range l.fields {
+		dst.Field(i).Set(src.Field(i))
+	}
+	return nil
+}
+
+// unwrapLoader returns the loader named in sources and errors.
+func (l *shadowLoader[T]) unwrapLoader() any {
+	return l.loader
+}
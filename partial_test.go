@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type partialTestConfig struct {
+	Env      string `config:"availableAs=ENV"`
+	Database scopeTestDatabase
+	Token    string
+	Region   string
+}
+
+// selectingTestLoader records the fields it was asked to load.
+type selectingTestLoader struct {
+	loads [][]string
+}
+
+func (l *selectingTestLoader) Load(c *partialTestConfig) error {
+	return l.LoadFields(c, []string{"Env", "Database", "Token", "Region"})
+}
+
+func (l *selectingTestLoader) LoadFields(c *partialTestConfig, fields []string) error {
+	l.loads = append(l.loads, fields)
+	if slices.Contains(fields, "Token") {
+		c.Token = "from-selecting"
+	}
+	if slices.Contains(fields, "Env") {
+		c.Env = "prod"
+	}
+	return nil
+}
+
+func TestHandler_LoadFields(t *testing.T) {
+	selecting := &selectingTestLoader{}
+	full := &mockLoader[partialTestConfig]{loadFunc: func(c *partialTestConfig) error {
+		c.Database.Host, c.Token, c.Region = "db.internal", "from-full", "eu-west-1"
+		return nil
+	}}
+	handler := NewConfigHandler[partialTestConfig](WithLoaders[partialTestConfig](selecting, full))
+
+	cfg := &partialTestConfig{Region: "unchanged", Database: scopeTestDatabase{Port: 5432}}
+	if err := handler.LoadFields(cfg, "Database.Host"); err != nil {
+		t.Fatalf("LoadFields failed: %v", err)
+	}
+	if want := [][]string{{"Env", "Database"}}; !slices.EqualFunc(selecting.loads, want, slices.Equal) {
+		t.Errorf("expected the FieldLoader to load %v, got %v", want, selecting.loads)
+	}
+	want := partialTestConfig{Env: "prod", Database: scopeTestDatabase{Host: "db.internal", Port: 5432}, Region: "unchanged"}
+	if *cfg != want {
+		t.Errorf("expected only the selected and availableAs fields to change, got %+v", *cfg)
+	}
+}
+
+func TestHandler_LoadFields_Strict(t *testing.T) {
+	handler := NewConfigHandler[partialTestConfig](
+		WithLoaders[partialTestConfig](&selectingTestLoader{}, &generic.EnvironmentLoader[partialTestConfig]{}),
+		WithPartialStrategy[partialTestConfig](PartialStrict),
+	)
+
+	err := handler.LoadFields(&partialTestConfig{}, "Token")
+	var capErr *CapabilityError
+	if !errors.As(err, &capErr) {
+		t.Fatalf("expected *CapabilityError, got %v", err)
+	}
+	if capErr.Loader != "EnvironmentLoader" || !slices.Equal(capErr.Fields, []FieldPath{"Token"}) {
+		t.Errorf("unexpected error: %+v", capErr)
+	}
+}
+
+func TestHandler_LoadFields_InvalidPath(t *testing.T) {
+	handler := NewConfigHandler[partialTestConfig](WithLoaders[partialTestConfig]())
+
+	for _, path := range []FieldPath{"Missing", "Database[0]"} {
+		if err := handler.LoadFields(&partialTestConfig{}, path); err == nil {
+			t.Errorf("expected error for %q", path)
+		}
+	}
+}
+
+func TestFor_SelectsSection(t *testing.T) {
+	selecting := &selectingTestLoader{}
+	parent := NewConfigHandler[partialTestConfig](WithLoaders[partialTestConfig](selecting))
+	dbHandler, err := For[scopeTestDatabase](parent, "Database")
+	if err != nil {
+		t.Fatalf("For failed: %v", err)
+	}
+
+	if err := dbHandler.Load(&scopeTestDatabase{}); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := [][]string{{"Env", "Database"}}; !slices.EqualFunc(selecting.loads, want, slices.Equal) {
+		t.Errorf("expected the scoped load to select %v, got %v", want, selecting.loads)
+	}
+}
@@ -0,0 +1,93 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// PasswordEntropy estimates the entropy of s in bits, as used by the minentropy validation
+// rule. It multiplies the length of s by log2 of the size of the character pool s draws from
+// (lower case, upper case, digits, ASCII symbols and other characters). Runs of the same
+// character count once, so "aaaaaaaa" scores like "a".
+//
+// The estimate assumes characters are chosen at random, so it overrates dictionary words;
+// combine minentropy with no_common_passwords.
+func PasswordEntropy(s string) float64 {
+	var lower, upper, digit, symbol, other bool
+	length := 0
+	var prev rune = -1
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+		if r != prev {
+			length++
+		}
+		prev = r
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(pool))
+}
+
+// IsCommonPassword reports whether s is one of a list of widely used passwords, ignoring case
+// and trailing digits and symbols, so "Password123!" is common.
+func IsCommonPassword(s string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	if _, ok := commonPasswords[normalized]; ok {
+		return true
+	}
+	stem := strings.TrimRightFunc(normalized, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	_, ok := commonPasswords[stem]
+	return ok
+}
+
+// commonPasswords are widely used passwords and default credentials, lower case.
+var commonPasswords = func() map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, p := range strings.Fields(`
+		123456 1234567 12345678 123456789 1234567890 12345 1234 111111 000000 123123 654321
+		666666 121212 112233 123321 987654321 696969 11111111
+		password passw0rd p@ssword p@ssw0rd pass pass123 passwd password1 pa55word
+		qwerty qwertyuiop qwerty123 asdf asdfgh asdfghjkl zxcvbnm 1q2w3e4r 1qaz2wsx qazwsx
+		abc123 abcdef abcd1234 a1b2c3 iloveyou letmein welcome welcome1 hello hello123
+		admin administrator admin123 root toor changeme changeit default secret secret123
+		guest test test123 testing demo sample example user user123 login master
+		dragon monkey football baseball soccer hockey basketball superman batman starwars
+		shadow sunshine princess trustno1 whatever freedom michael jennifer jordan hunter
+		ranger buster thomas robert charlie daniel ashley bailey access flower mustang
+		killer pepper cheese summer winter spring autumn love lovely computer internet
+		postgres mysql oracle redis mongo rabbitmq guestguest minio minioadmin
+		elastic kibana grafana jenkins tomcat vagrant ubuntu raspberry
+		database db dbpass apikey token mypassword mysecret supersecret topsecret
+		none null nopass nopassword empty temp temporary changeme123
+	`) {
+		set[p] = struct{}{}
+	}
+	return set
+}()
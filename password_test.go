@@ -0,0 +1,67 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPasswordEntropy(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"", 0},
+		{"abcdefgh", 8 * math.Log2(26)},
+		{"aaaaaaaa", math.Log2(26)},
+		{"Abcdef12", 8 * math.Log2(62)},
+		{"Ab1!", 4 * math.Log2(95)},
+		{"pässwörd", 7 * math.Log2(126)},
+	}
+	for _, tt := range tests {
+		if got := PasswordEntropy(tt.in); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("PasswordEntropy(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsCommonPassword(t *testing.T) {
+	for _, in := range []string{"password", "Password123!", "QWERTY", "changeme", "123456", " letmein "} {
+		if !IsCommonPassword(in) {
+			t.Errorf("IsCommonPassword(%q) = false, want true", in)
+		}
+	}
+	for _, in := range []string{"", "correct horse battery staple", "k7#Qz!p2vL9w", "passwordmanager"} {
+		if IsCommonPassword(in) {
+			t.Errorf("IsCommonPassword(%q) = true, want false", in)
+		}
+	}
+}
+
+func TestPasswordRules(t *testing.T) {
+	type config struct {
+		DBPassword string `validate:"minentropy=60,no_common_passwords"`
+		APIKey     string `validate:"omitempty,minentropy=80"`
+	}
+
+	v := NewValidator()
+	if err := v.Struct(config{DBPassword: "k7#Qz!p2vL9w"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	handler := &Handler[config]{Validator: &v}
+	err := handler.Validate(&config{DBPassword: "Password123!", APIKey: "short"})
+	got := make([]string, 0)
+	for _, violation := range Violations(err) {
+		got = append(got, violation.String())
+	}
+	want := []string{
+		"DBPassword: is a commonly used password",
+		"APIKey: must have at least 80 bits of entropy",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected violations:\n%s", strings.Join(got, "\n"))
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// WithPathBaseDir sets the directory that relative `config:"path"` fields are resolved against.
+// Typically this is the directory of the main configuration file. When unset, paths are
+// resolved against the current working directory.
+func WithPathBaseDir[C any](dir string) Option[C] {
+	return func(h *Handler[C]) {
+		h.pathBaseDir = dir
+	}
+}
+
+// ExpandPaths expands every exported string or []string field marked with `config:"path"`,
+// including those of nested structs and struct pointers.
+// Each path has a leading ~ replaced with the user's home directory, environment variables
+// ($VAR or ${VAR}, plus %VAR% on Windows) expanded, and is resolved against baseDir if still relative.
+// An empty baseDir means the current working directory. Empty values are left untouched.
+//
+// Example:
+//
+//	type Config struct {
+//	    CertFile string `env:"CERT_FILE" config:"path"`
+//	}
+//	// CERT_FILE=~/certs/app.pem becomes /home/me/certs/app.pem
+func ExpandPaths[T any](cfg *T, baseDir string) error {
+	v := reflect.ValueOf(cfg).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return expandPaths(v, "", baseDir)
+}
+
+// expandPaths expands the path fields of the struct v, the value of the field at path,
+// descending into nested structs and non-nil struct pointers.
+func expandPaths(v reflect.Value, path FieldPath, baseDir string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldValue := v.Field(i)
+		fieldPath := path.Child(field.Name)
+		if !utils.HasTagOption(field.Tag.Get("config"), "path") {
+			if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct && isSection(fieldValue.Type()) {
+				if err := expandPaths(fieldValue, fieldPath, baseDir); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		switch {
+		case fieldValue.Kind() == reflect.String:
+			expanded, err := ExpandPath(fieldValue.String(), baseDir)
+			if err != nil {
+				return &PathExpansionError{FieldName: string(fieldPath), Path: fieldValue.String(), Err: err}
+			}
+			fieldValue.SetString(expanded)
+		case fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() == reflect.String:
+			for j := 0; j < fieldValue.Len(); j++ {
+				elem := fieldValue.Index(j)
+				expanded, err := ExpandPath(elem.String(), baseDir)
+				if err != nil {
+					return &PathExpansionError{FieldName: string(fieldPath), Path: elem.String(), Err: err}
+				}
+				elem.SetString(expanded)
+			}
+		default:
+			return &PathExpansionError{
+				FieldName: string(fieldPath),
+				Err:       fmt.Errorf("path option requires a string or []string field, got %s", field.Type),
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExpandPath expands a leading ~ and environment variables in p and resolves it against
+// baseDir if it is relative. A relative or empty baseDir is itself resolved against
+// the current working directory.
+// An empty p is returned unchanged.
+func ExpandPath(p, baseDir string) (string, error) {
+	if p == "" {
+		return p, nil
+	}
+
+	p, err := utils.ExpandHome(p)
+	if err != nil {
+		return "", err
+	}
+
+	p = utils.ExpandEnv(p)
+
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p), nil
+	}
+
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(base, p), nil
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestExpandPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("home directory unavailable: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	base := t.TempDir()
+	os.Setenv("TEST_PATH_DIR", "certs")
+	defer os.Unsetenv("TEST_PATH_DIR")
+
+	tests := []struct {
+		name    string
+		path    string
+		baseDir string
+		want    string
+	}{
+		{"empty", "", base, ""},
+		{"tilde only", "~", base, home},
+		{"tilde prefix", "~/app.pem", base, filepath.Join(home, "app.pem")},
+		{"env var", "$TEST_PATH_DIR/app.pem", base, filepath.Join(base, "certs", "app.pem")},
+		{"braced env var", "${TEST_PATH_DIR}/app.pem", base, filepath.Join(base, "certs", "app.pem")},
+		{"relative", "data/app.db", base, filepath.Join(base, "data", "app.db")},
+		{"relative to cwd", "data/app.db", "", filepath.Join(wd, "data", "app.db")},
+		{"absolute", filepath.Join(base, "..", "other"), "ignored", filepath.Join(filepath.Dir(base), "other")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandPath(tt.path, tt.baseDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExpandPath(%q, %q) = %q, want %q", tt.path, tt.baseDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPaths_OnlyMarkedFields(t *testing.T) {
+	type Config struct {
+		CertFile  string   `config:"path"`
+		Includes  []string `config:"path"`
+		Name      string
+		Sensitive string `config:"sensitive"`
+	}
+
+	base := t.TempDir()
+	cfg := &Config{
+		CertFile:  "cert.pem",
+		Includes:  []string{"a.yaml", "b.yaml"},
+		Name:      "relative/name",
+		Sensitive: "relative/secret",
+	}
+
+	if err := ExpandPaths(cfg, base); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.CertFile != filepath.Join(base, "cert.pem") {
+		t.Errorf("CertFile not expanded, got %q", cfg.CertFile)
+	}
+	if cfg.Includes[0] != filepath.Join(base, "a.yaml") || cfg.Includes[1] != filepath.Join(base, "b.yaml") {
+		t.Errorf("Includes not expanded, got %v", cfg.Includes)
+	}
+	if cfg.Name != "relative/name" || cfg.Sensitive != "relative/secret" {
+		t.Errorf("unmarked fields should be untouched, got %+v", cfg)
+	}
+}
+
+func TestExpandPaths_NestedFields(t *testing.T) {
+	type TLS struct {
+		CertFile string `config:"path"`
+	}
+	type Config struct {
+		TLS     TLS
+		Client  *TLS
+		Missing *TLS
+	}
+
+	base := t.TempDir()
+	cfg := &Config{TLS: TLS{CertFile: "server.pem"}, Client: &TLS{CertFile: "client.pem"}}
+	if err := ExpandPaths(cfg, base); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TLS.CertFile != filepath.Join(base, "server.pem") || cfg.Client.CertFile != filepath.Join(base, "client.pem") {
+		t.Errorf("nested paths not expanded, got %q and %q", cfg.TLS.CertFile, cfg.Client.CertFile)
+	}
+	if cfg.Missing != nil {
+		t.Error("expected a nil struct pointer to stay nil")
+	}
+
+	type Server struct {
+		Port int `config:"path"`
+	}
+	var pathErr *PathExpansionError
+	if err := ExpandPaths(&struct{ Server Server }{}, ""); !errors.As(err, &pathErr) || pathErr.FieldName != "Server.Port" {
+		t.Errorf("expected PathExpansionError for Server.Port, got %v", err)
+	}
+}
+
+func TestExpandPaths_UnsupportedType(t *testing.T) {
+	type Config struct {
+		Port int `config:"path"`
+	}
+
+	err := ExpandPaths(&Config{Port: 1}, "")
+	var pathErr *PathExpansionError
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("expected PathExpansionError, got %T: %v", err, err)
+	}
+	if pathErr.FieldName != "Port" {
+		t.Errorf("expected FieldName 'Port', got '%s'", pathErr.FieldName)
+	}
+}
+
+func TestHandler_Load_ExpandsPaths(t *testing.T) {
+	type Config struct {
+		DataDir string `env:"TEST_PATH_DATA_DIR" config:"path"`
+	}
+
+	os.Setenv("TEST_PATH_DATA_DIR", "data")
+	defer os.Unsetenv("TEST_PATH_DATA_DIR")
+
+	base := t.TempDir()
+	handler := NewConfigHandler[Config](
+		WithLoaders[Config](&generic.EnvironmentLoader[Config]{}),
+		WithPathBaseDir[Config](base),
+	)
+
+	var cfg Config
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DataDir != filepath.Join(base, "data") {
+		t.Errorf("expected DataDir to be resolved against base dir, got %q", cfg.DataDir)
+	}
+}
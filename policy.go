@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// LoaderPolicy decides what happens when a loader fails, set with WithLoaderPolicy.
+type LoaderPolicy int
+
+const (
+	FailOnError     LoaderPolicy = iota // The load fails with the loader's error (the default)
+	ContinueOnError                     // The error is recorded and the remaining loaders run
+)
+
+// SkippedLoader records the error of a loader with the ContinueOnError policy, which the
+// load carried on without.
+type SkippedLoader struct {
+	Loader string // Type of the loader, e.g. "YAMLLoader"
+	Source string // Files the loader reads, if it reports them
+	Index  int    // Position of the loader in the chain
+	Stage  int    // Interpolation stage in which it first failed, starting at 1
+	Err    error  // Error returned by the loader
+}
+
+// String returns a one-line summary, e.g. "YAMLLoader (config.yaml): open config.yaml: no
+// such file or directory".
+func (s SkippedLoader) String() string {
+	if s.Source == "" {
+		return fmt.Sprintf("%s: %v", s.Loader, s.Err)
+	}
+	return fmt.Sprintf("%s (%s): %v", s.Loader, s.Source, s.Err)
+}
+
+// loaderPolicy is the policy set for one loader by WithLoaderPolicy.
+type loaderPolicy[C any] struct {
+	loader Loader[C]
+	policy LoaderPolicy
+}
+
+// WithLoaderPolicy sets the error policy of l, one of the handler's loaders. With
+// ContinueOnError the loader is optional: if it fails, for example because a config file
+// is missing or a remote source is unreachable, the remaining loaders run and the load
+// succeeds with the values they set. Fields a failed loader set before returning its error
+// are reverted. Skipped loaders are listed in Result.Skipped and
+// LoadReport.Skipped, and published as SourceDegraded events when an EventBus is installed
+// with WithEventBus. A load cancelled through its context still fails.
+//
+// Example:
+//
+//	local := &generic.YAMLLoader[AppConfig]{Source: "config.local.yaml"}
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithLoaders[AppConfig](local, &generic.EnvironmentLoader[AppConfig]{}),
+//	    config.WithLoaderPolicy[AppConfig](local, config.ContinueOnError),
+//	)
+func WithLoaderPolicy[C any](l Loader[C], policy LoaderPolicy) Option[C] {
+	return func(h *Handler[C]) {
+		h.loaderPolicies = append(h.loaderPolicies, loaderPolicy[C]{loader: l, policy: policy})
+	}
+}
+
+// policies returns the policy of each loader of the handler, or nil if none was set.
+func (c *Handler[C]) policies() []LoaderPolicy {
+	if len(c.loaderPolicies) == 0 {
+		return nil
+	}
+	policies := make([]LoaderPolicy, len(c.Loaders))
+	for i, l := range c.Loaders {
+		for _, p := range c.loaderPolicies {
+			if sameLoader(l, p.loader) {
+				policies[i] = p.policy
+			}
+		}
+	}
+	return policies
+}
+
+// sameLoader reports whether a and b are the same loader. Loaders of types that cannot be
+// compared, such as funcs, are never the same.
+func sameLoader(a, b any) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
+
+// loadTrace collects what a load did beyond setting fields.
+type loadTrace struct {
+	sources map[FieldPath]fieldSource // Loader that last set each field, if recorded
+	skipped []SkippedLoader           // Failures of loaders with ContinueOnError
+}
+
+// newLoadTrace returns an empty trace, recording the loader of each field if sources is set.
+func newLoadTrace(sources bool) *loadTrace {
+	trace := &loadTrace{}
+	if sources {
+		trace.sources = make(map[FieldPath]fieldSource)
+	}
+	return trace
+}
+
+// skip records the failure of an optional loader, once per loader, as each stage runs
+// every loader again.
+func (t *loadTrace) skip(s SkippedLoader) {
+	if t == nil || slices.ContainsFunc(t.skipped, func(other SkippedLoader) bool { return other.Index == s.Index }) {
+		return
+	}
+	t.skipped = append(t.skipped, s)
+}
+
+// merge adds what other recorded to t.
+func (t *loadTrace) merge(other *loadTrace) {
+	if t == nil || other == nil {
+		return
+	}
+	for path, source := range other.sources {
+		t.sources[path] = source
+	}
+	for _, s := range other.skipped {
+		t.skip(s)
+	}
+}
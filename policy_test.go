@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type policyTestConfig struct {
+	Name string `json:"name" env:"POLICY_NAME"`
+	Port int    `json:"port"`
+}
+
+// failingLoader fails every load with err.
+type failingLoader struct {
+	err error
+}
+
+func (l *failingLoader) Load(*policyTestConfig) error {
+	return l.err
+}
+
+// partialLoader sets Port and overwrites Name, then fails with err.
+type partialLoader struct {
+	err error
+}
+
+func (l *partialLoader) Load(c *policyTestConfig) error {
+	c.Name = "partial"
+	c.Port = 9090
+	return l.err
+}
+
+func TestWithLoaderPolicy_ContinueOnErrorRevertsPartialLoad(t *testing.T) {
+	t.Setenv("POLICY_NAME", "api")
+	partial := &partialLoader{err: errors.New("connection reset")}
+	handler := NewConfigHandler[policyTestConfig](
+		WithLoaders[policyTestConfig](&generic.EnvironmentLoader[policyTestConfig]{}, partial),
+		WithLoaderPolicy[policyTestConfig](partial, ContinueOnError),
+	)
+
+	var cfg policyTestConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Name != "api" || cfg.Port != 0 {
+		t.Errorf("cfg = %+v, want the fields the failed loader set reverted", cfg)
+	}
+}
+
+type policyNestedConfig struct {
+	Limits   map[string]int
+	Hosts    []string
+	Database *policyTestConfig
+}
+
+// nestedPartialLoader changes values through the map, slice and pointer of the config,
+// then fails.
+type nestedPartialLoader struct{}
+
+func (nestedPartialLoader) Load(c *policyNestedConfig) error {
+	c.Limits["conns"] = 99
+	c.Hosts[0] = "partial"
+	c.Database.Port = 9090
+	return errors.New("connection reset")
+}
+
+func TestWithLoaderPolicy_ContinueOnErrorRevertsNestedValues(t *testing.T) {
+	partial := nestedPartialLoader{}
+	handler := NewConfigHandler[policyNestedConfig](
+		WithLoaders[policyNestedConfig](partial),
+		WithLoaderPolicy[policyNestedConfig](partial, ContinueOnError),
+	)
+
+	cfg := policyNestedConfig{
+		Limits:   map[string]int{"conns": 10},
+		Hosts:    []string{"db.internal"},
+		Database: &policyTestConfig{Name: "api", Port: 5432},
+	}
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Limits["conns"] != 10 || cfg.Hosts[0] != "db.internal" || cfg.Database.Port != 5432 {
+		t.Errorf("cfg = %v, %v, %+v, want the changes of the failed loader reverted", cfg.Limits, cfg.Hosts, cfg.Database)
+	}
+}
+
+func TestWithLoaderPolicy_ContinueOnError(t *testing.T) {
+	t.Setenv("POLICY_NAME", "api")
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	optional := &generic.JSONLoader[policyTestConfig]{Source: missing}
+	remote := &failingLoader{err: errors.New("connection refused")}
+	var bus EventBus
+	sub := bus.Subscribe(4)
+	defer sub.Close()
+	handler := NewConfigHandler[policyTestConfig](
+		WithLoaderPolicy[policyTestConfig](optional, ContinueOnError),
+		WithLoaders[policyTestConfig](
+			optional,
+			&generic.EnvironmentLoader[policyTestConfig]{},
+			remote,
+		),
+		WithLoaderPolicy[policyTestConfig](remote, ContinueOnError),
+		WithEventBus[policyTestConfig](&bus),
+	)
+
+	result, err := handler.LoadResult(&policyTestConfig{})
+	if err != nil {
+		t.Fatalf("LoadResult() error = %v", err)
+	}
+	if result.Config.Name != "api" {
+		t.Errorf("Name = %q, want api", result.Config.Name)
+	}
+	if len(result.Skipped) != 2 {
+		t.Fatalf("Skipped = %v, want two loaders", result.Skipped)
+	}
+	if s := result.Skipped[0]; s.Loader != "JSONLoader" || s.Source != missing || s.Index != 0 || s.Err == nil {
+		t.Errorf("Skipped[0] = %+v", s)
+	}
+	if s := result.Skipped[1]; s.Loader != "failingLoader" || s.Index != 2 || s.String() != "failingLoader: connection refused" {
+		t.Errorf("Skipped[1] = %+v", s)
+	}
+
+	event := <-sub.Events()
+	if event.Type != EventSourceDegraded || event.Loader != "JSONLoader" || event.Source != missing {
+		t.Errorf("event = %+v, want SourceDegraded for JSONLoader", event)
+	}
+
+	report := handler.Explain(&policyTestConfig{})
+	if len(report.Skipped) != 2 || !strings.Contains(report.String(), "Skipped loaders:\n  1. JSONLoader (") {
+		t.Errorf("Explain() = %s", report)
+	}
+}
+
+func TestWithLoaderPolicy_FailOnErrorByDefault(t *testing.T) {
+	loadErr := errors.New("connection refused")
+	optional := &failingLoader{err: loadErr}
+	handler := NewConfigHandler[policyTestConfig](
+		WithLoaders[policyTestConfig](optional, &failingLoader{err: loadErr}),
+		WithLoaderPolicy[policyTestConfig](optional, ContinueOnError),
+	)
+	err := handler.Load(&policyTestConfig{})
+	if !errors.Is(err, loadErr) || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("Load() error = %v, want the error of the loader at index 1", err)
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// WithSourceCache shares cache with the handler's loaders that support prefetching, so
+// handlers for different configuration structs reading the same secrets, parameters or
+// files fetch each source once. It applies to the loaders configured when the handler is
+// created, whatever the order of options.
+//
+// Example:
+//
+//	cache := loader.NewSourceCache()
+//	apiHandler := config.NewConfigHandler[APIConfig](config.WithSourceCache[APIConfig](cache))
+//	workerHandler := config.NewConfigHandler[WorkerConfig](config.WithSourceCache[WorkerConfig](cache))
+func WithSourceCache[C any](cache *loader.SourceCache) Option[C] {
+	return func(h *Handler[C]) {
+		h.sourceCache = cache
+	}
+}
+
+// WithSourcePool shares the cache of pool, such as loader.DefaultSourcePool, with the
+// handler's loaders that support prefetching, so every handler in the process reading the
+// same secret, parameter or file triggers one fetch; concurrent fetches of a source are
+// merged. The handler holds a reference to the pool until Close is called, and the pool's
+// cache is cleared once every handler using it has been closed. WithSourcePool replaces
+// WithSourceCache.
+//
+// Example:
+//
+//	apiHandler := config.NewConfigHandler[APIConfig](config.WithSourcePool[APIConfig](loader.DefaultSourcePool))
+//	defer apiHandler.Close()
+//	workerHandler := config.NewConfigHandler[WorkerConfig](config.WithSourcePool[WorkerConfig](loader.DefaultSourcePool))
+//	defer workerHandler.Close()
+func WithSourcePool[C any](pool *loader.SourcePool) Option[C] {
+	return func(h *Handler[C]) {
+		h.sourcePool = pool
+	}
+}
+
+// Prefetch fetches every source read by the handler's loaders that implement
+// loader.Prefetcher (such as SecretsManagerLoader, SSMParameterStoreLoader and the file
+// loaders) into the handler's source cache, without loading them into a configuration.
+// Later calls to Load, and to other handlers sharing the cache through WithSourceCache, read
+// the cached values. Use it to warm up remote sources concurrently with other startup work.
+// A handler without a source cache gets one of its own.
+//
+// To resolve ${VAR} references in tags, the other loaders (e.g. environment variables and
+// flags) are first run on a scratch configuration that is then discarded. Tags referencing
+// variables that are still unset are skipped and reported in the returned error, together
+// with any prefetch failures; the sources that could be fetched remain cached.
+//
+// Prefetch must not run concurrently with Load on the same handler.
+//
+// Example:
+//
+//	cache := loader.NewSourceCache()
+//	handler := config.NewConfigHandler[AppConfig](config.WithSourceCache[AppConfig](cache))
+//	if err := handler.Prefetch(ctx); err != nil {
+//	    log.Printf("prefetch incomplete: %v", err)
+//	}
+func (c *Handler[C]) Prefetch(ctx context.Context) error {
+	lifeCtx, err := c.life.begin()
+	if err != nil {
+		return err
+	}
+	defer c.life.active.Done()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(lifeCtx, cancel)()
+
+	if c.sourceCache == nil {
+		c.sourceCache = loader.NewSourceCache()
+		c.ownsSourceCache = true
+	}
+
+	var local []Loader[C]
+	var prefetchers []loader.Prefetcher
+	for _, l := range c.Loaders {
+		if prefetcher, ok := l.(loader.Prefetcher); ok {
+			prefetcher.SetSourceCache(c.sourceCache)
+			prefetchers = append(prefetchers, prefetcher)
+		} else if l != nil {
+			local = append(local, l)
+		}
+	}
+	if len(prefetchers) == 0 {
+		return nil
+	}
+
+	var scratch C
+	if len(local) > 0 {
+		if err := (&InterpolatingChainLoader[C]{Loaders: local, Guard: c.interpolationGuard}).Load(&scratch); err != nil {
+			return fmt.Errorf("prefetch: %w", err)
+		}
+	}
+
+	fields, err := resolvedFields(&scratch, c.interpolationGuard)
+	errs := []error{err}
+	for _, prefetcher := range prefetchers {
+		errs = append(errs, prefetcher.Prefetch(ctx, fields))
+	}
+	return errors.Join(errs...)
+}
+
+// applySourceCache sets cache on every loader that supports prefetching.
+func applySourceCache[C any](loaders []Loader[C], cache *loader.SourceCache) {
+	for _, l := range loaders {
+		if prefetcher, ok := l.(loader.Prefetcher); ok {
+			prefetcher.SetSourceCache(cache)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// recordingPrefetcher records the fields it is asked to prefetch and serves the cache.
+type recordingPrefetcher[T any] struct {
+	cache  *loader.SourceCache
+	fields []loader.Field
+	err    error
+}
+
+func (p *recordingPrefetcher[T]) SetSourceCache(cache *loader.SourceCache) { p.cache = cache }
+
+func (p *recordingPrefetcher[T]) Prefetch(_ context.Context, fields []loader.Field) error {
+	p.fields = fields
+	for _, field := range fields {
+		if secret := field.Tag.Get("secret"); secret != "" {
+			p.cache.Set("secret:"+secret, "fetched")
+		}
+	}
+	return p.err
+}
+
+func (p *recordingPrefetcher[T]) Load(*T) error { return nil }
+
+func TestHandler_Prefetch(t *testing.T) {
+	type prefetchConfig struct {
+		Env      string `config:"availableAs=ENV"`
+		Password string `secret:"/${ENV}/db/password"`
+	}
+
+	local := &mockLoader[prefetchConfig]{loadFunc: func(c *prefetchConfig) error {
+		c.Env = "prod"
+		return nil
+	}}
+	prefetcher := &recordingPrefetcher[prefetchConfig]{}
+	cache := loader.NewSourceCache()
+	handler := NewConfigHandler[prefetchConfig](
+		WithLoaders[prefetchConfig](local, prefetcher),
+		WithSourceCache[prefetchConfig](cache),
+	)
+
+	if err := handler.Prefetch(context.Background()); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+	if prefetcher.cache != cache {
+		t.Error("expected the shared cache to be set on the prefetcher")
+	}
+	if _, ok := cache.Get("secret:/prod/db/password"); !ok {
+		t.Errorf("expected the resolved secret path to be prefetched, got fields %+v", prefetcher.fields)
+	}
+}
+
+func TestHandler_Prefetch_ReportsUnresolvedAndFailed(t *testing.T) {
+	type prefetchConfig struct {
+		Env      string `config:"availableAs=ENV"`
+		Password string `secret:"/${ENV}/db/password"`
+	}
+
+	prefetcher := &recordingPrefetcher[prefetchConfig]{err: errors.New("access denied")}
+	handler := NewConfigHandler[prefetchConfig](WithLoaders[prefetchConfig](prefetcher))
+
+	err := handler.Prefetch(context.Background())
+	var undefinedErr *UndefinedVariableError
+	if !errors.As(err, &undefinedErr) || undefinedErr.VariableName != "ENV" {
+		t.Errorf("expected undefined ENV to be reported, got %v", err)
+	}
+	if err == nil || !errors.Is(err, prefetcher.err) {
+		t.Errorf("expected the prefetch failure to be reported, got %v", err)
+	}
+	if handler.sourceCache == nil || prefetcher.cache != handler.sourceCache {
+		t.Error("expected the handler to create its own source cache")
+	}
+}
+
+func TestSourceCache_FetchDeduplicatesConcurrentCalls(t *testing.T) {
+	cache := loader.NewSourceCache()
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	values := make([]string, 5)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = cache.Fetch("secretsmanager:prod/db", func() (string, error) {
+				calls.Add(1)
+				<-release
+				return "secret", nil
+			})
+		}(i)
+	}
+	// Callers arriving after the first fetch starts wait for it or find its cached value
+	for calls.Load() == 0 {
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+
+	for _, value := range values {
+		if value != "secret" {
+			t.Errorf("expected every caller to get the fetched value, got %q", value)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected a single fetch, got %d", n)
+	}
+	if _, err := cache.Fetch("secretsmanager:prod/db", func() (string, error) { return "", errors.New("unexpected fetch") }); err != nil {
+		t.Errorf("expected cached value, got %v", err)
+	}
+}
+
+func TestSourceCache_FetchDoesNotCacheErrors(t *testing.T) {
+	cache := loader.NewSourceCache()
+	if _, err := cache.Fetch("ssm:/app/port", func() (string, error) { return "", errors.New("throttled") }); err == nil {
+		t.Fatal("expected fetch error")
+	}
+	value, err := cache.Fetch("ssm:/app/port", func() (string, error) { return "8080", nil })
+	if err != nil || value != "8080" {
+		t.Errorf("expected retry to fetch again, got %q, %v", value, err)
+	}
+}
+
+func TestWithSourcePool(t *testing.T) {
+	type apiConfig struct{ Host string }
+	type workerConfig struct{ Queue string }
+
+	pool := &loader.SourcePool{}
+	apiPrefetcher := &recordingPrefetcher[apiConfig]{}
+	workerPrefetcher := &recordingPrefetcher[workerConfig]{}
+	api := NewConfigHandler[apiConfig](WithLoaders[apiConfig](apiPrefetcher), WithSourcePool[apiConfig](pool))
+	worker := NewConfigHandler[workerConfig](WithLoaders[workerConfig](workerPrefetcher), WithSourcePool[workerConfig](pool))
+
+	if apiPrefetcher.cache == nil || apiPrefetcher.cache != workerPrefetcher.cache {
+		t.Fatal("expected handlers to share the pool's cache")
+	}
+	if pool.Refs() != 2 {
+		t.Errorf("expected 2 references, got %d", pool.Refs())
+	}
+
+	cache := apiPrefetcher.cache
+	cache.Set("file:config.json", "{}")
+	_ = api.Close()
+	_ = api.Close()
+	if pool.Refs() != 1 || cache.Len() != 1 {
+		t.Errorf("expected the cache to survive while a handler holds it, refs %d, len %d", pool.Refs(), cache.Len())
+	}
+	_ = worker.Close()
+	if pool.Refs() != 0 || cache.Len() != 0 {
+		t.Errorf("expected the cache to be cleared after the last release, refs %d, len %d", pool.Refs(), cache.Len())
+	}
+	if next := pool.Acquire(); next == cache {
+		t.Error("expected a new cache after the pool was emptied")
+	}
+}
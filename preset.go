@@ -0,0 +1,214 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// Preset is a bundle of recommended handler settings for a kind of service, installed with
+// WithPreset so new services start from a hardened baseline.
+type Preset struct {
+	Name                 string        // Preset name, e.g. "WebService"
+	CommandLine          bool          // Load command-line flags after environment variables
+	StageTimeout         time.Duration // Limit on each loading stage, see WithStageTimeout
+	RequireTLS           bool          // In production, URLs must use TLS and Insecure* switches must be off
+	RequireSensitiveTags bool          // Fields named like secrets must be tagged `config:"sensitive"`, so they are redacted
+	Production           func() bool   // Reports whether the process runs in production (defaults to IsProduction)
+}
+
+// Presets are the built-in presets. They can be copied and adjusted:
+//
+//	preset := config.Presets.WebService
+//	preset.StageTimeout = time.Minute
+var Presets = struct {
+	WebService Preset // Long-running HTTP or gRPC service
+	Worker     Preset // Queue consumer or batch job, allowed slower sources
+	Lambda     Preset // AWS Lambda function, configured from the environment within the init phase
+}{
+	WebService: Preset{Name: "WebService", CommandLine: true, StageTimeout: 30 * time.Second, RequireTLS: true, RequireSensitiveTags: true},
+	Worker:     Preset{Name: "Worker", CommandLine: true, StageTimeout: 2 * time.Minute, RequireTLS: true, RequireSensitiveTags: true},
+	Lambda:     Preset{Name: "Lambda", StageTimeout: 5 * time.Second, RequireTLS: true, RequireSensitiveTags: true},
+}
+
+// WithPreset installs the settings of a preset: a loader chain of environment variables,
+// followed by command-line flags if the preset allows them, a stage timeout, and the checks
+// Validate runs in addition to the validator. Options after WithPreset override its loaders
+// and timeout, so a service can add sources while keeping the checks.
+//
+// The checks report *ValidationError values, also listed by Violations:
+//   - tls_required: in production, a URL field (url.URL, *url.URL or a string validated as
+//     url, http_url or uri) uses http or ws with a host other than loopback
+//   - insecure_in_production: in production, a bool field named Insecure... is true
+//   - sensitive_tag: a string field named like a secret (Password, Secret, Token, APIKey,
+//     PrivateKey, Credentials) is not tagged `config:"sensitive"`; names ending in URL,
+//     Path, File or Name are exempt
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](config.WithPreset[AppConfig](config.Presets.WebService))
+func WithPreset[C any](p Preset) Option[C] {
+	return func(h *Handler[C]) {
+		loaders := []Loader[C]{&generic.EnvironmentLoader[C]{}}
+		if p.CommandLine {
+			loaders = append(loaders, &generic.CommandLineLoader[C]{Args: os.Args[1:]})
+		}
+		h.Loaders = loaders
+		h.chainLoader = &InterpolatingChainLoader[C]{Loaders: h.Loaders}
+		h.stageTimeout = p.StageTimeout
+		h.checks = append(h.checks, p.check)
+	}
+}
+
+// IsProduction reports whether the first of APP_ENV, ENVIRONMENT and ENV that is set names
+// production ("prod" or "production", in any case). It is the default Preset.Production.
+func IsProduction() bool {
+	for _, name := range []string{"APP_ENV", "ENVIRONMENT", "ENV"} {
+		if env := os.Getenv(name); env != "" {
+			env = strings.ToLower(env)
+			return env == "prod" || env == "production"
+		}
+	}
+	return false
+}
+
+// check runs the preset's checks on cfg.
+func (p Preset) check(cfg any) error {
+	production := p.Production
+	if production == nil {
+		production = IsProduction
+	}
+	requireTLS := p.RequireTLS && production()
+	if !requireTLS && !p.RequireSensitiveTags {
+		return nil
+	}
+
+	var errs []error
+	walkPresetFields(reflect.ValueOf(cfg), "", func(field reflect.StructField, v reflect.Value, path FieldPath) {
+		if requireTLS {
+			if rawURL, ok := urlFieldValue(field, v); ok && !tlsSatisfied(rawURL) {
+				errs = append(errs, &ValidationError{FieldName: string(path), Rule: "tls_required", Value: redactURL(rawURL), Err: errors.New("URL must use TLS in production")})
+			}
+			if v.Kind() == reflect.Bool && strings.HasPrefix(field.Name, "Insecure") && v.Bool() {
+				errs = append(errs, &ValidationError{FieldName: string(path), Rule: "insecure_in_production", Value: "true", Err: errors.New("insecure option enabled in production")})
+			}
+		}
+		if p.RequireSensitiveTags && v.Kind() == reflect.String && looksSensitive(field.Name) &&
+			!isSensitive(field) {
+			errs = append(errs, &ValidationError{FieldName: string(path), Rule: "sensitive_tag", Err: errors.New(`field looks sensitive but is not tagged config:"sensitive"`)})
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// walkPresetFields calls fn for each exported field of v, descending into sections and
+// list sections.
+func walkPresetFields(v reflect.Value, prefix FieldPath, fn func(field reflect.StructField, v reflect.Value, path FieldPath)) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == urlType:
+		return
+	case v.Kind() == reflect.Slice && containsSection(v.Type().Elem()):
+		for i := 0; i < v.Len(); i++ {
+			walkPresetFields(v.Index(i), prefix.Index(strconv.Itoa(i)), fn)
+		}
+		return
+	case v.Kind() != reflect.Struct || !isSection(v.Type()):
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix.Child(field.Name)
+		fn(field, v.Field(i), path)
+		walkPresetFields(v.Field(i), path, fn)
+	}
+}
+
+// urlFieldValue returns the URL held by a url.URL or *url.URL field, or by a string field
+// validated as a URL, and whether the field is such a field with a value.
+func urlFieldValue(field reflect.StructField, v reflect.Value) (string, bool) {
+	switch u := v.Interface().(type) {
+	case url.URL:
+		return u.String(), u.Host != ""
+	case *url.URL:
+		if u == nil {
+			return "", false
+		}
+		return u.String(), u.Host != ""
+	}
+	if v.Kind() != reflect.String || v.Len() == 0 {
+		return "", false
+	}
+	tag := field.Tag.Get("validate")
+	if utils.HasTagOption(tag, "url") || utils.HasTagOption(tag, "http_url") || utils.HasTagOption(tag, "uri") {
+		return v.String(), true
+	}
+	return "", false
+}
+
+// urlType is the type of url.URL, which the checks treat as a value rather than a section.
+var urlType = reflect.TypeOf(url.URL{})
+
+// redactURL returns rawURL with any password replaced, for error messages.
+func redactURL(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return u.Redacted()
+	}
+	return rawURL
+}
+
+// tlsSatisfied reports whether rawURL meets the TLS requirement: its scheme is not http or
+// ws, or it addresses a loopback host, where TLS adds nothing.
+func tlsSatisfied(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return true // Malformed URLs are reported by the url rules
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "ws" {
+		return true
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// sensitiveNameParts are the name fragments that mark a field as holding a secret.
+var sensitiveNameParts = []string{"password", "passwd", "secret", "token", "apikey", "privatekey", "credential"}
+
+// looksSensitive reports whether a field name suggests it holds a secret.
+func looksSensitive(name string) bool {
+	for _, suffix := range []string{"URL", "Path", "File", "Name"} {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type presetUpstream struct {
+	URL          string `validate:"omitempty,url"`
+	SharedSecret string `config:"sensitive"`
+}
+
+type presetTestConfig struct {
+	PublicURL          string `env:"PRESET_PUBLIC_URL" validate:"required,url"`
+	Callback           *url.URL
+	InsecureSkipVerify bool   `env:"PRESET_INSECURE"`
+	DBPassword         string `env:"PRESET_DB_PASSWORD"`
+	APIKey             string `config:"sensitive"`
+	TokenURL           string
+	Upstreams          []presetUpstream
+}
+
+func TestWithPreset_Settings(t *testing.T) {
+	handler := NewConfigHandler[presetTestConfig](WithPreset[presetTestConfig](Presets.Lambda))
+	if len(handler.Loaders) != 1 {
+		t.Fatalf("Lambda loaders = %d, want environment only", len(handler.Loaders))
+	}
+	if _, ok := handler.Loaders[0].(*generic.EnvironmentLoader[presetTestConfig]); !ok {
+		t.Errorf("Lambda loader = %T", handler.Loaders[0])
+	}
+	if handler.chainLoader.StageTimeout != 5*time.Second {
+		t.Errorf("StageTimeout = %v, want 5s", handler.chainLoader.StageTimeout)
+	}
+
+	handler = NewConfigHandler[presetTestConfig](
+		WithPreset[presetTestConfig](Presets.WebService),
+		WithStageTimeout[presetTestConfig](time.Minute),
+	)
+	if len(handler.Loaders) != 2 || handler.chainLoader.StageTimeout != time.Minute {
+		t.Errorf("WebService loaders = %d, timeout = %v", len(handler.Loaders), handler.chainLoader.StageTimeout)
+	}
+}
+
+func TestWithPreset_Checks(t *testing.T) {
+	callback, _ := url.Parse("http://hooks.example.com/cb")
+	cfg := presetTestConfig{
+		PublicURL:          "http://user:pw@example.com",
+		Callback:           callback,
+		InsecureSkipVerify: true,
+		DBPassword:         "x",
+		TokenURL:           "http://localhost:8080/token",
+		Upstreams:          []presetUpstream{{URL: "https://a.example.com"}, {URL: "http://127.0.0.1:9000"}, {URL: "http://b.example.com"}},
+	}
+
+	tests := []struct {
+		name       string
+		production bool
+		want       []string
+	}{
+		{"production", true, []string{
+			"PublicURL: must use TLS in production",
+			"Callback: must use TLS in production",
+			"InsecureSkipVerify: must not be enabled in production",
+			"DBPassword: looks sensitive but is not tagged config:\"sensitive\"",
+			"Upstreams[2].URL: must use TLS in production",
+		}},
+		{"development", false, []string{
+			"DBPassword: looks sensitive but is not tagged config:\"sensitive\"",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset := Presets.WebService
+			preset.Production = func() bool { return tt.production }
+			handler := NewConfigHandler[presetTestConfig](WithPreset[presetTestConfig](preset))
+
+			err := handler.Validate(&cfg)
+			var got []string
+			for _, violation := range Violations(err) {
+				got = append(got, violation.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("violations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			if strings.Contains(err.Error(), "user:pw") {
+				t.Errorf("error leaks URL password: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithPreset_Passes(t *testing.T) {
+	t.Setenv("APP_ENV", "Production")
+	t.Setenv("PRESET_PUBLIC_URL", "https://example.com")
+	type safeConfig struct {
+		PublicURL  string `env:"PRESET_PUBLIC_URL" validate:"required,url"`
+		DBPassword string `env:"PRESET_DB_PASSWORD" config:"sensitive"`
+	}
+	handler := NewConfigHandler[safeConfig](WithPreset[safeConfig](Presets.Worker))
+	var cfg safeConfig
+	if err := handler.LoadAndValidate(&cfg); err != nil {
+		t.Fatalf("LoadAndValidate() error = %v", err)
+	}
+}
+
+func TestIsProduction(t *testing.T) {
+	tests := []struct {
+		appEnv, environment, env string
+		want                     bool
+	}{
+		{"", "", "", false},
+		{"prod", "", "", true},
+		{"", "PRODUCTION", "", true},
+		{"", "", "staging", false},
+		{"dev", "production", "", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("APP_ENV", tt.appEnv)
+		t.Setenv("ENVIRONMENT", tt.environment)
+		t.Setenv("ENV", tt.env)
+		if got := IsProduction(); got != tt.want {
+			t.Errorf("IsProduction() with %q/%q/%q = %v, want %v", tt.appEnv, tt.environment, tt.env, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// Provenance describes where the value of a field came from, as reported by
+// Handler.Provenance.
+type Provenance struct {
+	Loader string // Type of the loader that last set the field, e.g. "SecretsManagerLoader"
+	Source string // Source the loader read, e.g. a secret name or file path, or "" if unknown
+	Stage  int    // Interpolation stage that set the field, starting at 1
+}
+
+// String returns a one-line summary, e.g. "SecretsManagerLoader prod/db (stage 2)".
+func (p Provenance) String() string {
+	if p.Source == "" {
+		return fmt.Sprintf("%s (stage %d)", p.Loader, p.Stage)
+	}
+	return fmt.Sprintf("%s %s (stage %d)", p.Loader, p.Source, p.Stage)
+}
+
+// fieldSource records the loader that last set a field during a load, its index in the
+// chain and the stage it ran in.
+type fieldSource struct {
+	loader any
+	index  int
+	stage  int
+}
+
+// WithProvenance records where each field came from on every load, for Handler.Provenance.
+// Recording compares the configuration before and after each loader, so it is off by
+// default.
+func WithProvenance[C any]() Option[C] {
+	return func(h *Handler[C]) {
+		h.trackProvenance = true
+	}
+}
+
+// Provenance returns, for every field a loader set in the last successful Load,
+// LoadContext or LoadResult, the loader that last set it, the source it read and the
+// interpolation stage, e.g. "Database.Password": SecretsManagerLoader prod/db (stage 2).
+// Loads only record provenance with WithProvenance, or through LoadResult; Provenance
+// returns nil before the first such load. Fields are recorded as in Result.Sources.
+//
+// Sources are named by loaders implementing loader.FetchPlanner, such as the AWS loaders
+// and HTTPLoader, with their tags resolved against the loaded configuration, and by file
+// loaders implementing loader.FileSource. Other loaders, such as EnvironmentLoader, leave
+// Source empty.
+//
+// Example:
+//
+//	handler := config.NewConfigHandler[AppConfig](config.WithProvenance[AppConfig]())
+//	...
+//	for path, p := range handler.Provenance() {
+//	    slog.Debug("config field", "field", path, "from", p)
+//	}
+func (c *Handler[C]) Provenance() map[FieldPath]Provenance {
+	c.provenanceMu.Lock()
+	defer c.provenanceMu.Unlock()
+	return maps.Clone(c.provenance)
+}
+
+// recordProvenance stores the provenance of the fields of cfg set by the load recorded in
+// sources.
+func (c *Handler[C]) recordProvenance(cfg *C, sources map[FieldPath]fieldSource) {
+	provenance := c.provenanceOf(cfg, sources)
+	c.provenanceMu.Lock()
+	defer c.provenanceMu.Unlock()
+	c.provenance = provenance
+}
+
+// provenanceOf returns the provenance of the fields of cfg set by the load recorded in
+// sources.
+func (c *Handler[C]) provenanceOf(cfg *C, sources map[FieldPath]fieldSource) map[FieldPath]Provenance {
+	fields, _ := resolvedFields(cfg, c.interpolationGuard)
+	fetches := make(map[int][]loader.Fetch)
+	provenance := make(map[FieldPath]Provenance, len(sources))
+	for path, source := range sources {
+		planned, ok := fetches[source.index]
+		if !ok {
+			if planner, isPlanner := source.loader.(loader.FetchPlanner); isPlanner {
+				planned = planner.PlanFetches(fields)
+			}
+			fetches[source.index] = planned
+		}
+		provenance[path] = Provenance{
+			Loader: loaderName(source.loader),
+			Source: sourceOf(source.loader, planned, path),
+			Stage:  source.stage,
+		}
+	}
+	return provenance
+}
+
+// sourceOf returns the source l read the field at path from: the planned fetch for the
+// field or its closest enclosing section, a fetch for the whole configuration, or the
+// files l reads.
+func sourceOf(l any, fetches []loader.Fetch, path FieldPath) string {
+	best := -1
+	for i, fetch := range fetches {
+		if path.Within(FieldPath(fetch.Field)) && (best < 0 || len(fetch.Field) > len(fetches[best].Field)) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return fetches[best].Source
+	}
+	if files, ok := l.(loader.FileSource); ok {
+		return strings.Join(files.SourceFiles(), ", ")
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type provenanceDB struct {
+	Host     string `json:"host"`
+	Password string
+}
+
+type provenanceTestConfig struct {
+	Env  string       `env:"PROVENANCE_ENV" config:"availableAs=ENV"`
+	Name string       `json:"name"`
+	DB   provenanceDB `json:"db" store:"${ENV}/db"`
+	Port int
+}
+
+// storeLoader stands in for a remote loader implementing loader.FetchPlanner, reading the
+// store named by the `store` tag of DB once Env is known.
+type storeLoader struct{}
+
+func (l *storeLoader) Load(c *provenanceTestConfig) error {
+	if c.Env != "" {
+		c.DB.Password = "secret"
+	}
+	return nil
+}
+
+func (l *storeLoader) PlanFetches(fields []loader.Field) []loader.Fetch {
+	var fetches []loader.Fetch
+	for _, field := range fields {
+		if store := field.Tag.Get("store"); store != "" {
+			fetches = append(fetches, loader.Fetch{LoaderType: "storeLoader", Field: field.Name, Source: store, Action: "store:Get"})
+		}
+	}
+	return fetches
+}
+
+func TestHandler_Provenance(t *testing.T) {
+	t.Setenv("PROVENANCE_ENV", "prod")
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"name": "api", "db": {"host": "db.internal"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewConfigHandler[provenanceTestConfig](
+		WithLoaders[provenanceTestConfig](
+			&storeLoader{},
+			&generic.JSONLoader[provenanceTestConfig]{Source: path},
+			&generic.EnvironmentLoader[provenanceTestConfig]{},
+		),
+		WithProvenance[provenanceTestConfig](),
+	)
+
+	if got := handler.Provenance(); got != nil {
+		t.Errorf("Provenance() before loading = %v, want nil", got)
+	}
+	if err := handler.Load(&provenanceTestConfig{}); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	got := handler.Provenance()
+	want := map[FieldPath]Provenance{
+		"Env":         {Loader: "EnvironmentLoader", Stage: 1},
+		"Name":        {Loader: "JSONLoader", Source: path, Stage: 1},
+		"DB.Host":     {Loader: "JSONLoader", Source: path, Stage: 1},
+		"DB.Password": {Loader: "storeLoader", Source: "prod/db", Stage: 2},
+	}
+	if len(got) != len(want) {
+		t.Errorf("Provenance() = %v, want %v", got, want)
+	}
+	for field, p := range want {
+		if got[field] != p {
+			t.Errorf("Provenance()[%q] = %v, want %v", field, got[field], p)
+		}
+	}
+	if s := got["DB.Password"].String(); s != "storeLoader prod/db (stage 2)" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestHandler_Provenance_Disabled(t *testing.T) {
+	handler := NewConfigHandler[provenanceTestConfig](
+		WithLoaders[provenanceTestConfig](&generic.JSONLoader[provenanceTestConfig]{Source: []byte(`{"name": "api"}`)}),
+	)
+	if err := handler.Load(&provenanceTestConfig{}); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := handler.Provenance(); got != nil {
+		t.Errorf("Provenance() without WithProvenance = %v, want nil", got)
+	}
+
+	// LoadResult records provenance regardless
+	if _, err := handler.LoadResult(&provenanceTestConfig{}); err != nil {
+		t.Fatalf("LoadResult() error = %v", err)
+	}
+	if got := handler.Provenance()["Name"]; got != (Provenance{Loader: "JSONLoader", Stage: 1}) {
+		t.Errorf("Provenance()[Name] = %v", got)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is a rate limit or quota field written as a compact expression:
+//
+//	100/s              100 events per second, burst 100
+//	100/s burst 200    100 events per second, bursts of up to 200
+//	5000/h             5000 events per hour
+//	10/30s             10 events per 30 seconds
+//
+// The count is followed by "/" and a unit (s, sec, second, m, min, minute, h, hour, d,
+// day) or a duration such as 30s or 1m30s. Burst defaults to the count rounded up, at most math.MaxInt. The
+// empty string is the zero RateLimit, meaning no limit is configured.
+//
+// RateLimit implements encoding.TextUnmarshaler, so it can be set from environment
+// variables, flags, JSON, YAML, MapLoader values and ApplyOverride, and invalid
+// expressions fail the load. Parameter specs report it as a string with format
+// "rate-limit".
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    APILimit config.RateLimit `env:"API_LIMIT" envDefault:"100/s burst 200"`
+//	}
+//
+//	limiter := rate.NewLimiter(rate.Limit(cfg.APILimit.PerSecond()), cfg.APILimit.Burst)
+type RateLimit struct {
+	Count float64       // Events allowed per Per
+	Per   time.Duration // Period Count applies to
+	Burst int           // Events allowed at once
+}
+
+// ParseRateLimit parses a rate limit expression. See RateLimit for the syntax.
+func ParseRateLimit(s string) (RateLimit, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return RateLimit{}, nil
+	}
+	if len(fields) != 1 && (len(fields) != 3 || !strings.EqualFold(fields[1], "burst")) {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: want \"<count>/<unit>\" optionally followed by \"burst <n>\"", s)
+	}
+
+	countText, unit, ok := strings.Cut(fields[0], "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: missing \"/\"", s)
+	}
+	count, err := strconv.ParseFloat(countText, 64)
+	if err != nil || !(count > 0) || math.IsInf(count, 0) {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: count must be a positive number", s)
+	}
+	per, err := parseRatePeriod(unit)
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: %w", s, err)
+	}
+
+	burst := math.MaxInt
+	if count < float64(math.MaxInt) {
+		burst = int(math.Ceil(count))
+	}
+	if len(fields) == 3 {
+		if burst, err = strconv.Atoi(fields[2]); err != nil || burst < 1 {
+			return RateLimit{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", s)
+		}
+	}
+	return RateLimit{Count: count, Per: per, Burst: burst}, nil
+}
+
+// ratePeriods are the named units of a rate limit expression.
+var ratePeriods = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second, "sec": time.Second, "second": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hour": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour,
+}
+
+// parseRatePeriod parses a named unit or a duration.
+func parseRatePeriod(unit string) (time.Duration, error) {
+	if per, ok := ratePeriods[strings.ToLower(unit)]; ok {
+		return per, nil
+	}
+	per, err := time.ParseDuration(unit)
+	if err != nil || per <= 0 {
+		return 0, fmt.Errorf("unknown unit %q", unit)
+	}
+	return per, nil
+}
+
+// IsZero reports whether no limit is configured.
+func (r RateLimit) IsZero() bool {
+	return r == RateLimit{}
+}
+
+// PerSecond returns the rate in events per second, or 0 when no limit is configured.
+// It can be passed to golang.org/x/time/rate as rate.Limit(r.PerSecond()).
+func (r RateLimit) PerSecond() float64 {
+	if r.Per <= 0 {
+		return 0
+	}
+	return r.Count / r.Per.Seconds()
+}
+
+// Interval returns the average time between events, or 0 when no limit is configured.
+func (r RateLimit) Interval() time.Duration {
+	if r.Count <= 0 {
+		return 0
+	}
+	return time.Duration(float64(r.Per) / r.Count)
+}
+
+// String returns the limit as an expression, e.g. "100/s burst 200", or "" for the zero
+// RateLimit.
+func (r RateLimit) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	unit := r.Per.String()
+	for _, named := range []struct {
+		name string
+		per  time.Duration
+	}{{"ms", time.Millisecond}, {"s", time.Second}, {"m", time.Minute}, {"h", time.Hour}, {"d", 24 * time.Hour}} {
+		if r.Per == named.per {
+			unit = named.name
+		}
+	}
+	return strconv.FormatFloat(r.Count, 'f', -1, 64) + "/" + unit + " burst " + strconv.Itoa(r.Burst)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r RateLimit) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *RateLimit) UnmarshalText(text []byte) error {
+	parsed, err := ParseRateLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (RateLimit) ParameterFormat() string {
+	return "rate-limit"
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want RateLimit
+		str  string
+	}{
+		{"100/s", RateLimit{Count: 100, Per: time.Second, Burst: 100}, "100/s burst 100"},
+		{"100/s burst 200", RateLimit{Count: 100, Per: time.Second, Burst: 200}, "100/s burst 200"},
+		{" 5000/hour  BURST 50 ", RateLimit{Count: 5000, Per: time.Hour, Burst: 50}, "5000/h burst 50"},
+		{"10/30s", RateLimit{Count: 10, Per: 30 * time.Second, Burst: 10}, "10/30s burst 10"},
+		{"0.5/min", RateLimit{Count: 0.5, Per: time.Minute, Burst: 1}, "0.5/m burst 1"},
+		{"1000/d", RateLimit{Count: 1000, Per: 24 * time.Hour, Burst: 1000}, "1000/d burst 1000"},
+		{"", RateLimit{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.in)
+			if err != nil {
+				t.Fatalf("ParseRateLimit() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRateLimit() = %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.str {
+				t.Errorf("String() = %q, want %q", got.String(), tt.str)
+			}
+			if roundTrip, err := ParseRateLimit(got.String()); err != nil || roundTrip != got {
+				t.Errorf("round trip = %+v, %v; want %+v", roundTrip, err, got)
+			}
+		})
+	}
+}
+
+func TestParseRateLimit_Errors(t *testing.T) {
+	for _, in := range []string{"100", "100/fortnight", "-1/s", "0/s", "abc/s", "100/s burst", "100/s burst 0", "100/s burst x", "100/s limit 5", "100/-1s", "NaN/s", "nan/s", "+Inf/s"} {
+		if _, err := ParseRateLimit(in); err == nil {
+			t.Errorf("ParseRateLimit(%q) expected error", in)
+		}
+	}
+}
+
+func TestParseRateLimit_HugeCount(t *testing.T) {
+	got, err := ParseRateLimit("1e300/s")
+	if err != nil || got.Burst != math.MaxInt {
+		t.Errorf("ParseRateLimit() = %+v, %v, want the burst capped at math.MaxInt", got, err)
+	}
+}
+
+func TestRateLimit_Conversions(t *testing.T) {
+	r := RateLimit{Count: 300, Per: time.Minute, Burst: 10}
+	if got := r.PerSecond(); got != 5 {
+		t.Errorf("PerSecond() = %v, want 5", got)
+	}
+	if got := r.Interval(); got != 200*time.Millisecond {
+		t.Errorf("Interval() = %v, want 200ms", got)
+	}
+	if (RateLimit{}).PerSecond() != 0 || (RateLimit{}).Interval() != 0 || !(RateLimit{}).IsZero() {
+		t.Error("zero RateLimit should have no rate")
+	}
+}
+
+type rateLimitTestConfig struct {
+	APILimit   RateLimit `env:"API_LIMIT" json:"apiLimit" yaml:"apiLimit"`
+	LoginLimit RateLimit `env:"LOGIN_LIMIT" envDefault:"5/min burst 2" json:"loginLimit" yaml:"loginLimit"`
+}
+
+func TestRateLimit_Loaders(t *testing.T) {
+	t.Setenv("API_LIMIT", "100/s burst 200")
+	var cfg rateLimitTestConfig
+	if err := (&generic.EnvironmentLoader[rateLimitTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	if cfg.APILimit != (RateLimit{Count: 100, Per: time.Second, Burst: 200}) || cfg.LoginLimit != (RateLimit{Count: 5, Per: time.Minute, Burst: 2}) {
+		t.Errorf("environment = %+v", cfg)
+	}
+
+	cfg = rateLimitTestConfig{}
+	if err := (&generic.YAMLLoader[rateLimitTestConfig]{Source: []byte("apiLimit: 10/s burst 20\n")}).Load(&cfg); err != nil {
+		t.Fatalf("YAMLLoader error = %v", err)
+	}
+	if cfg.APILimit.Burst != 20 {
+		t.Errorf("yaml = %+v", cfg.APILimit)
+	}
+
+	cfg = rateLimitTestConfig{}
+	if err := (&generic.JSONLoader[rateLimitTestConfig]{Source: []byte(`{"apiLimit": "10/x"}`)}).Load(&cfg); err == nil {
+		t.Error("JSONLoader expected error for an invalid rate limit")
+	}
+
+	data, err := json.Marshal(rateLimitTestConfig{APILimit: RateLimit{Count: 1, Per: time.Second, Burst: 1}})
+	if err != nil || string(data) != `{"apiLimit":"1/s burst 1","loginLimit":""}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
+
+func TestRateLimit_OverrideAndSpec(t *testing.T) {
+	handler := NewConfigHandler[rateLimitTestConfig](WithLoaders[rateLimitTestConfig]())
+	var cfg rateLimitTestConfig
+	if err := handler.ApplyOverride(&cfg, "API_LIMIT", "50/s"); err != nil {
+		t.Fatalf("ApplyOverride() error = %v", err)
+	}
+	if cfg.APILimit.Count != 50 {
+		t.Errorf("APILimit = %+v after override", cfg.APILimit)
+	}
+
+	params := DescribeParameters[rateLimitTestConfig]()
+	if params[0].Type != "string" || params[0].Format != "rate-limit" {
+		t.Errorf("parameter = %+v, want string with format rate-limit", params[0])
+	}
+}
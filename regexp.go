@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// Regexp is a regular expression field compiled as it loads, so an invalid pattern fails
+// startup instead of its first use. It embeds *regexp.Regexp, so its methods can be called
+// directly. The empty string is the zero Regexp, whose embedded *regexp.Regexp is nil.
+//
+// A pattern that does not compile fails the load with a *ValidationError whose Rule is
+// "regexp" and whose Value is the quoted pattern. Handler.Load sets its FieldName to the path
+// of the field, e.g. "Routes[2].Match". Parameter specs report Regexp as a string with
+// format "regex".
+//
+// Plain *regexp.Regexp fields are also compiled during load, but their errors are not
+// converted into ValidationErrors.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    AllowedOrigins config.Regexp `env:"ALLOWED_ORIGINS" envDefault:"^https://([a-z0-9-]+\\.)?example\\.com$"`
+//	}
+//
+//	if !cfg.AllowedOrigins.MatchString(origin) {
+//	    return errForbidden
+//	}
+type Regexp struct {
+	*regexp.Regexp
+	failed string // Pattern that failed to compile, used to name the field in errors
+}
+
+// MustCompileRegexp returns a Regexp for pattern, for defaults set in code. It panics if
+// the pattern does not compile.
+func MustCompileRegexp(pattern string) Regexp {
+	return Regexp{Regexp: regexp.MustCompile(pattern)}
+}
+
+// IsZero reports whether no pattern is configured.
+func (r Regexp) IsZero() bool {
+	return r.Regexp == nil
+}
+
+// String returns the source pattern, or "" for the zero Regexp.
+func (r Regexp) String() string {
+	if r.Regexp == nil {
+		return ""
+	}
+	return r.Regexp.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Regexp) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, compiling the pattern.
+func (r *Regexp) UnmarshalText(text []byte) error {
+	pattern := string(text)
+	if pattern == "" {
+		*r = Regexp{}
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		*r = Regexp{failed: pattern}
+		return &ValidationError{FieldName: "<unknown>", Rule: "regexp", Value: strconv.Quote(pattern), Err: err}
+	}
+	*r = Regexp{Regexp: re}
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Regexp) ParameterFormat() string {
+	return "regex"
+}
+
+// failedParse implements parseFailure.
+func (r Regexp) failedParse() (string, string) {
+	return "regexp", r.failed
+}
+
+// parseFailure is implemented by field types that compile or parse their value as it loads,
+// such as Regexp. failedParse returns the validation rule and the value that failed to parse,
+// or an empty value if it parsed.
+type parseFailure interface {
+	failedParse() (rule, value string)
+}
+
+// parseFailureError returns err with the FieldName of its ValidationError set to the path of
+// the field in cfg that failed to parse. Loaders whose errors do not unwrap, such as
+// EnvironmentLoader, have their error wrapped in a new ValidationError instead.
+func parseFailureError(cfg any, err error) error {
+	path, rule, value, ok := failedParsePath(reflect.ValueOf(cfg), "")
+	if !ok {
+		return err
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && validationErr.Rule == rule {
+		validationErr.FieldName = path
+		return err
+	}
+	return &ValidationError{FieldName: path, Rule: rule, Value: strconv.Quote(value), Err: err}
+}
+
+// failedParsePath returns the path, rule and value of the first parseFailure under v that
+// failed to parse, searching struct fields and slice elements.
+func failedParsePath(v reflect.Value, path string) (string, string, string, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", "", "", false
+		}
+		v = v.Elem()
+	}
+	if v.CanInterface() {
+		if field, ok := v.Interface().(parseFailure); ok && v.Kind() == reflect.Struct {
+			rule, value := field.failedParse()
+			return path, rule, value, value != ""
+		}
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			name := t.Field(i).Name
+			if path != "" {
+				name = path + "." + name
+			}
+			if found, rule, value, ok := failedParsePath(v.Field(i), name); ok {
+				return found, rule, value, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if found, rule, value, ok := failedParsePath(v.Index(i), path+"["+strconv.Itoa(i)+"]"); ok {
+				return found, rule, value, true
+			}
+		}
+	}
+	return "", "", "", false
+}
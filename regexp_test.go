@@ -0,0 +1,85 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type regexpRoute struct {
+	Match Regexp `json:"match" yaml:"match"`
+}
+
+type regexpTestConfig struct {
+	Origins Regexp         `env:"ORIGINS" json:"origins" yaml:"origins"`
+	Plain   *regexp.Regexp `env:"PLAIN" json:"plain" yaml:"plain"`
+	Routes  []regexpRoute  `json:"routes" yaml:"routes"`
+}
+
+func TestRegexp_Loaders(t *testing.T) {
+	t.Setenv("ORIGINS", `^https://([a-z]+\.)?example\.com$`)
+	t.Setenv("PLAIN", "^v[0-9]+$")
+	var cfg regexpTestConfig
+	if err := (&generic.EnvironmentLoader[regexpTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	if !cfg.Origins.MatchString("https://api.example.com") || cfg.Origins.MatchString("https://evil.com") {
+		t.Errorf("Origins = %v", cfg.Origins)
+	}
+	if cfg.Plain == nil || !cfg.Plain.MatchString("v2") {
+		t.Errorf("Plain = %v", cfg.Plain)
+	}
+
+	sources := map[string]interface {
+		Load(*regexpTestConfig) error
+	}{
+		"yaml": &generic.YAMLLoader[regexpTestConfig]{Source: []byte("routes:\n  - match: ^/api/\n")},
+		"json": &generic.JSONLoader[regexpTestConfig]{Source: []byte(`{"routes": [{"match": "^/api/"}]}`)},
+	}
+	for name, ldr := range sources {
+		t.Run(name, func(t *testing.T) {
+			var cfg regexpTestConfig
+			if err := ldr.Load(&cfg); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(cfg.Routes) != 1 || !cfg.Routes[0].Match.MatchString("/api/users") || !cfg.Origins.IsZero() {
+				t.Errorf("loaded = %+v", cfg)
+			}
+		})
+	}
+
+	data, err := json.Marshal(regexpTestConfig{Origins: MustCompileRegexp("^a+$")})
+	if err != nil || string(data) != `{"origins":"^a+$","plain":null,"routes":null}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
+
+func TestRegexp_InvalidPattern(t *testing.T) {
+	tests := map[string]struct {
+		loader Loader[regexpTestConfig]
+		field  string
+	}{
+		"env":  {&generic.EnvironmentLoader[regexpTestConfig]{}, "Origins"},
+		"json": {&generic.JSONLoader[regexpTestConfig]{Source: []byte(`{"routes": [{"match": "^/ok"}, {"match": "^/api/(v1"}]}`)}, "Routes[1].Match"},
+		"yaml": {&generic.YAMLLoader[regexpTestConfig]{Source: []byte("origins: \"[a-\"\n")}, "Origins"},
+	}
+	t.Setenv("ORIGINS", "(unclosed")
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := NewConfigHandler[regexpTestConfig](WithLoaders(tt.loader))
+			var cfg regexpTestConfig
+			err := handler.Load(&cfg)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.FieldName != tt.field || validationErr.Rule != "regexp" || validationErr.Value == "" {
+				t.Errorf("ValidationError = %+v", validationErr)
+			}
+		})
+	}
+}
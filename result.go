@@ -0,0 +1,228 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Result is the outcome of Handler.LoadResult: the loaded configuration together with
+// metadata about how it was loaded. It is experimental and may gain fields.
+type Result[C any] struct {
+	Config      *C                   // The configuration passed to LoadResult
+	Sources     map[FieldPath]string // Field path to the loader that last set it, e.g. "Database.Port": "EnvironmentLoader"
+	Warnings    []Warning            // Problems that did not fail the load
+	Skipped     []SkippedLoader      // Failures of loaders with the ContinueOnError policy
+	Fingerprint string               // Fingerprint of Config, see Fingerprint
+}
+
+// Source returns the loader that last set the field at path, or "" if no loader set it.
+func (r *Result[C]) Source(path FieldPath) string {
+	return r.Sources[path]
+}
+
+// Warning is a problem found while loading that does not fail the load.
+type Warning struct {
+	Field   FieldPath // Field path, e.g. "Database.Password"
+	Message string    // Description of the problem
+}
+
+// String returns the field and message, e.g. "APIKey: sensitive value set from the command line".
+func (w Warning) String() string {
+	return string(w.Field) + ": " + w.Message
+}
+
+// LoadResult loads cfg like Load and returns a Result describing the load. Load shares its
+// implementation but skips recording the Result, so it allocates less.
+//
+// Sources records, for every field whose value a loader replaced, the type name of the last
+// loader to do so. Nested sections are recorded per field; slices, maps and TextUnmarshaler
+// types such as URLSet are recorded as a whole. Values changed in place through a shared
+// pointer or map are not detected.
+//
+// A sensitive field set by CommandLineLoader is reported as a Warning, since command lines
+// are visible to other processes.
+//
+// Example:
+//
+//	result, err := handler.LoadResult(&cfg)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, w := range result.Warnings {
+//	    slog.Warn("config", "warning", w)
+//	}
+//	slog.Info("config loaded", "fingerprint", result.Fingerprint, "port_from", result.Source("Port"))
+func (c *Handler[C]) LoadResult(cfg *C) (*Result[C], error) {
+	trace := newLoadTrace(true)
+	if err := c.load(context.Background(), cfg, trace); err != nil {
+		return nil, err
+	}
+	sources := make(map[FieldPath]string, len(trace.sources))
+	for path, source := range trace.sources {
+		sources[path] = loaderName(source.loader)
+	}
+	return &Result[C]{
+		Config:      cfg,
+		Sources:     sources,
+		Warnings:    loadWarnings(cfg, sources),
+		Skipped:     trace.skipped,
+		Fingerprint: Fingerprint(cfg),
+	}, nil
+}
+
+// Fingerprint returns a hex SHA-256 digest of cfg's JSON encoding, for detecting whether
+// two instances run the same configuration. Values of sensitive fields are included in the
+// digest, so compare fingerprints rather than publishing them alongside weak secrets. Types
+// JSON cannot encode are digested from their Go syntax representation instead. Secret
+// values, which encode as "***", are digested separately, so a rotated Secret changes the
+// fingerprint.
+func Fingerprint(cfg any) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%#v", cfg))
+	}
+	h := sha256.New()
+	h.Write(data)
+	writeSecrets(h, reflect.ValueOf(cfg), make(map[uintptr]bool))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeSecrets writes the value of every Secret in v to w, each followed by a NUL byte,
+// descending into structs, pointers, interfaces, slices, arrays and maps with string keys
+// in key order. Pointers already in visited are skipped.
+func writeSecrets(w io.Writer, v reflect.Value, visited map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.Type() == secretType {
+			io.WriteString(w, v.String()+"\x00")
+		}
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		writeSecrets(w, v.Elem(), visited)
+	case reflect.Interface:
+		if !v.IsNil() {
+			writeSecrets(w, v.Elem(), visited)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				writeSecrets(w, v.Field(i), visited)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !mayHoldSecret(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			writeSecrets(w, v.Index(i), visited)
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || !mayHoldSecret(v.Type().Elem()) {
+			return
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		for _, key := range keys {
+			writeSecrets(w, v.MapIndex(key), visited)
+		}
+	}
+}
+
+// mayHoldSecret reports whether values of t can be or contain a Secret, so byte slices and
+// other plain values are not walked element by element.
+func mayHoldSecret(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String:
+		return t == secretType
+	case reflect.Ptr, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// loadWarnings returns warnings about how the fields of cfg were loaded.
+func loadWarnings(cfg any, sources map[FieldPath]string) []Warning {
+	var warnings []Warning
+	walkSensitiveFields(reflect.TypeOf(cfg).Elem(), "", func(path FieldPath) {
+		if sources[path] == "CommandLineLoader" {
+			warnings = append(warnings, Warning{Field: path, Message: "sensitive value set from the command line, which other processes can read"})
+		}
+	})
+	return warnings
+}
+
+// walkSensitiveFields calls fn with the path of every `config:"sensitive"` field of t and
+// its nested sections.
+func walkSensitiveFields(t reflect.Type, prefix FieldPath, fn func(path FieldPath)) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix.Child(field.Name)
+		if isSensitive(field) {
+			fn(path)
+		} else if isSection(field.Type) {
+			walkSensitiveFields(field.Type, path, fn)
+		}
+	}
+}
+
+// recordSources sets sources[path] to source for each field under after that differs from
+// before.
+func recordSources(sources map[FieldPath]fieldSource, source fieldSource, before, after reflect.Value, prefix FieldPath) {
+	t := after.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		path := prefix.Child(t.Field(i).Name)
+		if isSection(t.Field(i).Type) {
+			recordSources(sources, source, before.Field(i), after.Field(i), path)
+		} else if !reflect.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+			sources[path] = source
+		}
+	}
+}
+
+// isSection reports whether t is a nested struct section rather than a value type such as
+// time.Time or URLSet.
+func isSection(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !t.Implements(textUnmarshalerType) &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType) && !t.Implements(jsonMarshalerType)
+}
+
+// jsonMarshalerType is the type of json.Marshaler.
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// loaderName returns the type name of a loader without its package and type arguments,
+// e.g. "EnvironmentLoader".
+func loaderName(l any) string {
+	t := reflect.TypeOf(unwrapLoader(l))
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return name
+}
+
+// unwrapLoader returns the loader wrapped by a partial load, or l itself.
+func unwrapLoader(l any) any {
+	if w, ok := l.(interface{ unwrapLoader() any }); ok {
+		return w.unwrapLoader()
+	}
+	return l
+}
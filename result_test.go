@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type resultDatabase struct {
+	Host     string `env:"RESULT_DB_HOST" json:"host"`
+	Password string `env:"RESULT_DB_PASSWORD" json:"password" config:"sensitive"`
+}
+
+type resultTestConfig struct {
+	Port     int            `env:"RESULT_PORT" json:"port"`
+	Timeout  time.Duration  `env:"RESULT_TIMEOUT" json:"timeout"`
+	Name     string         `json:"name"`
+	APIKey   string         `clap:"--api-key" json:"apiKey" config:"sensitive"`
+	Database resultDatabase `json:"database"`
+}
+
+func TestHandler_LoadResult(t *testing.T) {
+	t.Setenv("RESULT_PORT", "9090")
+	t.Setenv("RESULT_DB_HOST", "db.internal")
+	t.Setenv("RESULT_DB_PASSWORD", "secret")
+	handler := NewConfigHandler[resultTestConfig](WithLoaders[resultTestConfig](
+		&generic.JSONLoader[resultTestConfig]{Source: []byte(`{"port": 8080, "name": "app", "database": {"host": "localhost"}}`)},
+		&generic.EnvironmentLoader[resultTestConfig]{},
+		&generic.CommandLineLoader[resultTestConfig]{Args: []string{"--api-key", "hunter2"}},
+	))
+
+	var cfg resultTestConfig
+	result, err := handler.LoadResult(&cfg)
+	if err != nil {
+		t.Fatalf("LoadResult() error = %v", err)
+	}
+	if result.Config != &cfg || cfg.Port != 9090 {
+		t.Errorf("Config = %+v", result.Config)
+	}
+
+	want := map[FieldPath]string{
+		"Port":              "EnvironmentLoader",
+		"Name":              "JSONLoader",
+		"Database.Host":     "EnvironmentLoader",
+		"Database.Password": "EnvironmentLoader",
+		"APIKey":            "CommandLineLoader",
+	}
+	for path, source := range want {
+		if got := result.Source(path); got != source {
+			t.Errorf("Source(%q) = %q, want %q", path, got, source)
+		}
+	}
+	if got := result.Source("Timeout"); got != "" {
+		t.Errorf("Source(Timeout) = %q, want none", got)
+	}
+
+	if len(result.Warnings) != 1 || result.Warnings[0].Field != "APIKey" {
+		t.Errorf("Warnings = %v", result.Warnings)
+	}
+
+	var again resultTestConfig
+	if err := handler.Load(&again); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if Fingerprint(&again) != result.Fingerprint || len(result.Fingerprint) != 64 {
+		t.Errorf("Fingerprint = %q, want %q", Fingerprint(&again), result.Fingerprint)
+	}
+	again.Port++
+	if Fingerprint(&again) == result.Fingerprint {
+		t.Error("expected fingerprint to change with the configuration")
+	}
+}
+
+func TestHandler_LoadResult_StageTimeout(t *testing.T) {
+	t.Setenv("RESULT_PORT", "7070")
+	handler := NewConfigHandler[resultTestConfig](
+		WithLoaders[resultTestConfig](&generic.EnvironmentLoader[resultTestConfig]{}),
+		WithStageTimeout[resultTestConfig](time.Minute),
+	)
+
+	var cfg resultTestConfig
+	result, err := handler.LoadResult(&cfg)
+	if err != nil {
+		t.Fatalf("LoadResult() error = %v", err)
+	}
+	if result.Source("Port") != "EnvironmentLoader" {
+		t.Errorf("Sources = %v", result.Sources)
+	}
+}
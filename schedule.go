@@ -0,0 +1,267 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a cron schedule field, parsed and validated when configuration is loaded so
+// a bad expression fails at startup rather than at the first tick.
+//
+// Expressions have the five standard fields, minute, hour, day of month, month and day of
+// week, each a "*", a value, a range "a-b" or a comma-separated list of them, optionally
+// with a "/step". Months and weekdays may be given by name (JAN-DEC, SUN-SAT), and 7 is
+// also Sunday. When both day fields are restricted, a time matches either, as in cron.
+// The macros @yearly (or @annually), @monthly, @weekly, @daily (or @midnight) and @hourly
+// are supported.
+//
+// A "CRON_TZ=<zone> " or "TZ=<zone> " prefix, e.g. "CRON_TZ=Europe/London 0 9 * * MON-FRI",
+// evaluates the schedule in that IANA time zone; without one, Next uses the location of
+// the time it is given. Zones are loaded with time.LoadLocation, so binaries for systems
+// without a zone database should import time/tzdata.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    ReportSchedule config.Schedule `env:"REPORT_SCHEDULE" envDefault:"CRON_TZ=UTC 0 6 * * *"`
+//	}
+//
+//	timer := time.NewTimer(time.Until(cfg.ReportSchedule.Next(time.Now())))
+type Schedule struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64 // Bit i is set when value i matches
+	domRestricted, dowRestricted  bool
+	loc                           *time.Location
+}
+
+// scheduleMacros are the supported @ shorthands.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// scheduleField describes the range and names of one field of a cron expression.
+type scheduleField struct {
+	name     string
+	min, max int
+	names    []string // Names for min, min+1, ...
+}
+
+var (
+	minuteField = scheduleField{name: "minute", min: 0, max: 59}
+	hourField   = scheduleField{name: "hour", min: 0, max: 23}
+	domField    = scheduleField{name: "day of month", min: 1, max: 31}
+	monthField  = scheduleField{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	dowField    = scheduleField{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// ParseSchedule parses a cron expression. See Schedule for the syntax. The empty string is
+// the zero Schedule, which never fires.
+func ParseSchedule(s string) (Schedule, error) {
+	expr := strings.TrimSpace(s)
+	if expr == "" {
+		return Schedule{}, nil
+	}
+	sched := Schedule{expr: expr}
+
+	spec := expr
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		zone, rest, _ := strings.Cut(spec, " ")
+		_, name, _ := strings.Cut(zone, "=")
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: unknown time zone %q", s, name)
+		}
+		sched.loc = loc
+		spec = strings.TrimSpace(rest)
+	}
+	if macro, ok := scheduleMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("invalid schedule %q: want 5 fields (minute hour day-of-month month day-of-week), got %d", s, len(fields))
+	}
+	targets := []*uint64{&sched.minute, &sched.hour, &sched.dom, &sched.month, &sched.dow}
+	for i, field := range []scheduleField{minuteField, hourField, domField, monthField, dowField} {
+		set, err := field.parse(fields[i])
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: %w", s, err)
+		}
+		*targets[i] = set
+	}
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1 // 7 is also Sunday
+	}
+	sched.domRestricted = !strings.HasPrefix(fields[2], "*")
+	sched.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return sched, nil
+}
+
+// parse returns the values matched by a field of a cron expression as a bit set.
+func (f scheduleField) parse(text string) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%s: invalid step %q", f.name, stepText)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangeText == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangeText, "-"):
+			loText, hiText, _ := strings.Cut(rangeText, "-")
+			var err error
+			if lo, err = f.value(loText); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(hiText); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s: range %q is backwards", f.name, rangeText)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangeText); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name of the field.
+func (f scheduleField) value(text string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(text, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s: %q is not between %d and %d", f.name, text, f.min, f.max)
+	}
+	return n, nil
+}
+
+// IsZero reports whether no schedule is configured.
+func (s Schedule) IsZero() bool {
+	return s.expr == ""
+}
+
+// Location returns the time zone set with CRON_TZ or TZ, or nil when there is none.
+func (s Schedule) Location() *time.Location {
+	return s.loc
+}
+
+// Next returns the first time after t that matches the schedule, in the schedule's time
+// zone if it has one and in t's location otherwise. It returns the zero time for the zero
+// Schedule and for schedules that do not fire within five years, such as 30 February.
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.IsZero() {
+		return time.Time{}
+	}
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+	t = t.In(loc).Truncate(time.Minute).Add(time.Minute)
+
+	yearLimit := t.Year() + 5
+	for t.Year() <= yearLimit {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc), time.Hour)
+		case !s.dayMatches(t):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc), time.Hour)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc), time.Hour-time.Duration(t.Minute())*time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// forward returns next, or t plus step when next is not after t. time.Date resolves local
+// times skipped by a daylight saving change to an earlier instant, which would otherwise
+// stop Next from advancing.
+func forward(t, next time.Time, step time.Duration) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(step)
+}
+
+// NextN returns the next n times after t that match the schedule.
+func (s Schedule) NextN(t time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// dayMatches applies cron's day rule: when both day fields are restricted either may
+// match, otherwise both must.
+func (s Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// String returns the expression the schedule was parsed from.
+func (s Schedule) String() string {
+	return s.expr
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Schedule) MarshalText() ([]byte, error) {
+	return []byte(s.expr), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Schedule) UnmarshalText(text []byte) error {
+	parsed, err := ParseSchedule(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Schedule) ParameterFormat() string {
+	return "cron"
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func mustParseSchedule(t *testing.T, expr string) Schedule {
+	t.Helper()
+	s, err := ParseSchedule(expr)
+	if err != nil {
+		t.Fatalf("ParseSchedule(%q) error = %v", expr, err)
+	}
+	return s
+}
+
+func TestSchedule_Next(t *testing.T) {
+	from := time.Date(2025, time.March, 14, 10, 17, 30, 0, time.UTC) // a Friday
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+		{"30 6 1 * *", time.Date(2025, 4, 1, 6, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 13 * 5", time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)}, // day of month or Friday
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"5,10 10-11 * jan,mar *", time.Date(2025, 3, 14, 11, 5, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"17 10 * * *", time.Date(2025, 3, 15, 10, 17, 0, 0, time.UTC)}, // strictly after
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if got := mustParseSchedule(t, tt.expr).Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_TimeZone(t *testing.T) {
+	s := mustParseSchedule(t, "CRON_TZ=America/New_York 0 9 * * *")
+	if s.Location() == nil || s.Location().String() != "America/New_York" {
+		t.Fatalf("Location() = %v", s.Location())
+	}
+
+	// 2025-03-09 is the start of daylight saving time in New York
+	from := time.Date(2025, time.March, 8, 20, 0, 0, 0, time.UTC)
+	got := s.NextN(from, 2)
+	want := []time.Time{
+		time.Date(2025, 3, 9, 13, 0, 0, 0, time.UTC), // 09:00 EDT
+		time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC),
+	}
+	if len(got) != 2 || !got[0].Equal(want[0]) || !got[1].Equal(want[1]) {
+		t.Errorf("NextN() = %v, want %v", got, want)
+	}
+	if got[0].Location().String() != "America/New_York" {
+		t.Errorf("Next() location = %v, want the schedule's zone", got[0].Location())
+	}
+
+	// 02:30 does not exist on 9 March, so the next run is on 10 March
+	s = mustParseSchedule(t, "TZ=America/New_York 30 2 * * *")
+	if got := s.Next(from); !got.Equal(time.Date(2025, 3, 10, 6, 30, 0, 0, time.UTC)) {
+		t.Errorf("Next() across the DST gap = %v, want 2025-03-10 02:30 EDT", got)
+	}
+	s = mustParseSchedule(t, "TZ=America/New_York 0 3 9 3 *")
+	if got := s.Next(from); !got.Equal(time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() after the DST gap = %v, want 2025-03-09 03:00 EDT", got)
+	}
+}
+
+func TestParseSchedule_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * FOO *",
+		"CRON_TZ=Mars/Olympus 0 0 * * *",
+		"@fortnightly",
+	} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("ParseSchedule(%q) expected error", expr)
+		}
+	}
+}
+
+func TestSchedule_Zero(t *testing.T) {
+	var s Schedule
+	if !s.IsZero() || !s.Next(time.Now()).IsZero() || len(s.NextN(time.Now(), 3)) != 0 {
+		t.Error("zero Schedule should never fire")
+	}
+	if !mustParseSchedule(t, "0 0 30 2 *").Next(time.Now()).IsZero() {
+		t.Error("30 February should never fire")
+	}
+}
+
+type scheduleTestConfig struct {
+	Report Schedule `env:"REPORT_SCHEDULE" yaml:"report"`
+}
+
+func TestSchedule_Loaders(t *testing.T) {
+	t.Setenv("REPORT_SCHEDULE", "CRON_TZ=UTC 0 6 * * *")
+	var cfg scheduleTestConfig
+	if err := (&generic.EnvironmentLoader[scheduleTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	if cfg.Report.String() != "CRON_TZ=UTC 0 6 * * *" {
+		t.Errorf("Report = %q", cfg.Report)
+	}
+
+	t.Setenv("REPORT_SCHEDULE", "0 25 * * *")
+	if err := (&generic.EnvironmentLoader[scheduleTestConfig]{}).Load(&cfg); err == nil {
+		t.Error("EnvironmentLoader expected error for an invalid schedule")
+	}
+
+	if err := (&generic.YAMLLoader[scheduleTestConfig]{Source: []byte("report: '@daily'\n")}).Load(&cfg); err != nil {
+		t.Fatalf("YAMLLoader error = %v", err)
+	}
+	if params := DescribeParameters[scheduleTestConfig](); params[0].Format != "cron" {
+		t.Errorf("parameter = %+v, want format cron", params[0])
+	}
+}
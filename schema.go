@@ -0,0 +1,46 @@
+package config
+
+import "github.com/gymshark/go-easy-config/schema"
+
+// schemaSetter is implemented by loaders that validate documents against a JSON Schema
+// before decoding them (JSONLoader, YAMLLoader and DiscoveryLoader).
+type schemaSetter interface {
+	SetSchema(s *schema.Schema)
+}
+
+// WithSchema validates every JSON and YAML document read by the handler's file loaders
+// against s before it is decoded, so invalid files fail with path-based errors such as
+// "/database/port: must be <= 65535, got 70000" instead of Go decode errors.
+// It applies to the loaders configured when the handler is created, whatever the order of
+// options, including loaders wrapped by generic.CachingLoader, generic.FixtureLoader or
+// generic.FaultInjector and the layers of WithLayers.
+//
+// The schema is set on the loaders themselves through SetSchema, replacing their Schema
+// field, so a loader shared with another handler validates against s there too. Give each
+// handler its own loaders when they need different schemas.
+//
+// Example:
+//
+//	s, err := schema.Parse(schemaJSON)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	handler := config.NewConfigHandler[AppConfig](
+//	    config.WithLoaders[AppConfig](&generic.YAMLLoader[AppConfig]{Source: "config.yaml"}),
+//	    config.WithSchema[AppConfig](s),
+//	)
+func WithSchema[C any](s *schema.Schema) Option[C] {
+	return func(h *Handler[C]) {
+		h.docSchema = s
+	}
+}
+
+// applySchema sets s on every loader that supports schema validation. Wrapper loaders pass
+// it on to the loader they wrap.
+func applySchema[C any](loaders []Loader[C], s *schema.Schema) {
+	for _, l := range loaders {
+		if setter, ok := unwrapLoader(l).(schemaSetter); ok {
+			setter.SetSchema(s)
+		}
+	}
+}
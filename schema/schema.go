@@ -0,0 +1,360 @@
+// Package schema validates decoded configuration documents against a JSON Schema, so
+// file-sourced configuration can be rejected with precise path-based errors before it is
+// decoded into Go types.
+//
+// The supported keywords are the ones configuration schemas rely on:
+//
+//   - type (a single type or a list), enum, const
+//   - properties, required, additionalProperties (boolean or schema)
+//   - items, minItems, maxItems
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum (numeric form)
+//   - minLength, maxLength, pattern
+//   - $ref to local definitions ("#/$defs/name" or "#/definitions/name")
+//
+// Other keywords, such as format and description, are accepted and ignored.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a parsed JSON Schema.
+type Schema struct {
+	root  *node
+	defs  map[string]*node
+	regex map[string]*regexp.Regexp
+}
+
+// node is a single (sub)schema. Fields mirror the JSON Schema keywords they are named after.
+type node struct {
+	Ref                  string           `json:"$ref"`
+	Type                 typeList         `json:"type"`
+	Enum                 []any            `json:"enum"`
+	Const                *any             `json:"const"`
+	Properties           map[string]*node `json:"properties"`
+	Required             []string         `json:"required"`
+	AdditionalProperties *additional      `json:"additionalProperties"`
+	Items                *node            `json:"items"`
+	MinItems             *int             `json:"minItems"`
+	MaxItems             *int             `json:"maxItems"`
+	Minimum              *float64         `json:"minimum"`
+	Maximum              *float64         `json:"maximum"`
+	ExclusiveMinimum     *float64         `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64         `json:"exclusiveMaximum"`
+	MinLength            *int             `json:"minLength"`
+	MaxLength            *int             `json:"maxLength"`
+	Pattern              string           `json:"pattern"`
+	Defs                 map[string]*node `json:"$defs"`
+	Definitions          map[string]*node `json:"definitions"`
+}
+
+// typeList accepts both "type": "string" and "type": ["string", "null"].
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = many
+	return nil
+}
+
+// additional accepts both a boolean and a schema for additionalProperties.
+type additional struct {
+	Allowed bool
+	Schema  *node
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// Parse parses a JSON Schema document.
+func Parse(data []byte) (*Schema, error) {
+	var root node
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+
+	s := &Schema{root: &root, defs: make(map[string]*node), regex: make(map[string]*regexp.Regexp)}
+	for name, def := range root.Definitions {
+		s.defs["#/definitions/"+name] = def
+	}
+	for name, def := range root.Defs {
+		s.defs["#/$defs/"+name] = def
+	}
+	if err := s.compile(&root); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile checks references and pre-compiles patterns throughout n.
+func (s *Schema) compile(n *node) error {
+	if n == nil {
+		return nil
+	}
+	if n.Ref != "" {
+		if _, ok := s.defs[n.Ref]; !ok {
+			return fmt.Errorf("parse schema: unresolved $ref %q", n.Ref)
+		}
+	}
+	if n.Pattern != "" {
+		if _, ok := s.regex[n.Pattern]; !ok {
+			re, err := regexp.Compile(n.Pattern)
+			if err != nil {
+				return fmt.Errorf("parse schema: invalid pattern %q: %w", n.Pattern, err)
+			}
+			s.regex[n.Pattern] = re
+		}
+	}
+
+	children := []*node{n.Items}
+	if n.AdditionalProperties != nil {
+		children = append(children, n.AdditionalProperties.Schema)
+	}
+	for _, group := range []map[string]*node{n.Properties, n.Defs, n.Definitions} {
+		for _, child := range group {
+			children = append(children, child)
+		}
+	}
+	for _, child := range children {
+		if err := s.compile(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidationError describes a single schema violation.
+type ValidationError struct {
+	Path    string // JSON Pointer to the offending value (e.g., "/database/port"); empty for the document root
+	Message string // What is wrong with the value
+}
+
+// Error returns the path and message.
+func (e *ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// ValidationErrors lists every violation found in a document, ordered by path.
+type ValidationErrors []*ValidationError
+
+// Error returns one violation per line.
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return "schema validation failed:\n  " + strings.Join(lines, "\n  ")
+}
+
+// Validate checks a decoded document (as produced by encoding/json or yaml.v3 decoding into
+// an interface{}) against the schema. It returns ValidationErrors, or nil if the document is valid.
+func (s *Schema) Validate(doc any) error {
+	var errs ValidationErrors
+	s.validate(s.root, normalise(doc), "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Path < errs[j].Path })
+	return errs
+}
+
+// ValidateJSON decodes data and validates it against the schema.
+func (s *Schema) ValidateJSON(data []byte) error {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return s.Validate(doc)
+}
+
+func (s *Schema) validate(n *node, value any, path string, errs *ValidationErrors) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.Ref != "" {
+		s.validate(s.defs[n.Ref], value, path, errs)
+		return
+	}
+
+	if len(n.Type) > 0 && !matchesType(n.Type, value) {
+		fail("expected %s, got %s", strings.Join(n.Type, " or "), typeOf(value))
+		return
+	}
+	if n.Const != nil && !reflect.DeepEqual(normalise(*n.Const), value) {
+		fail("must be %v", *n.Const)
+	}
+	if len(n.Enum) > 0 && !inEnum(n.Enum, value) {
+		fail("must be one of %v", n.Enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range n.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			childPath := path + "/" + escapePointer(name)
+			if prop, ok := n.Properties[name]; ok {
+				s.validate(prop, v[name], childPath, errs)
+				continue
+			}
+			if n.AdditionalProperties == nil {
+				continue
+			}
+			if n.AdditionalProperties.Schema != nil {
+				s.validate(n.AdditionalProperties.Schema, v[name], childPath, errs)
+			} else if !n.AdditionalProperties.Allowed {
+				*errs = append(*errs, &ValidationError{Path: childPath, Message: "unknown property"})
+			}
+		}
+	case []any:
+		if n.MinItems != nil && len(v) < *n.MinItems {
+			fail("must have at least %d items, got %d", *n.MinItems, len(v))
+		}
+		if n.MaxItems != nil && len(v) > *n.MaxItems {
+			fail("must have at most %d items, got %d", *n.MaxItems, len(v))
+		}
+		if n.Items != nil {
+			for i, item := range v {
+				s.validate(n.Items, item, fmt.Sprintf("%s/%d", path, i), errs)
+			}
+		}
+	case float64:
+		if n.Minimum != nil && v < *n.Minimum {
+			fail("must be >= %v, got %v", *n.Minimum, v)
+		}
+		if n.Maximum != nil && v > *n.Maximum {
+			fail("must be <= %v, got %v", *n.Maximum, v)
+		}
+		if n.ExclusiveMinimum != nil && v <= *n.ExclusiveMinimum {
+			fail("must be > %v, got %v", *n.ExclusiveMinimum, v)
+		}
+		if n.ExclusiveMaximum != nil && v >= *n.ExclusiveMaximum {
+			fail("must be < %v, got %v", *n.ExclusiveMaximum, v)
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if n.MinLength != nil && length < *n.MinLength {
+			fail("must be at least %d characters, got %d", *n.MinLength, length)
+		}
+		if n.MaxLength != nil && length > *n.MaxLength {
+			fail("must be at most %d characters, got %d", *n.MaxLength, length)
+		}
+		if n.Pattern != "" && !s.regex[n.Pattern].MatchString(v) {
+			fail("must match pattern %q", n.Pattern)
+		}
+	}
+}
+
+// normalise converts YAML-decoded values to the shapes encoding/json produces:
+// map[string]any, []any and float64 for every number.
+func normalise(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = normalise(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[fmt.Sprint(k)] = normalise(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalise(item)
+		}
+		return out
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	default:
+		return value
+	}
+}
+
+func matchesType(types []string, value any) bool {
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, candidate := range enum {
+		if reflect.DeepEqual(normalise(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// escapePointer escapes a property name for use in a JSON Pointer.
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
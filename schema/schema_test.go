@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["database", "logLevel"],
+	"additionalProperties": false,
+	"properties": {
+		"logLevel": {"type": "string", "enum": ["debug", "info", "warn", "error"]},
+		"database": {"$ref": "#/$defs/database"},
+		"hosts": {"type": "array", "minItems": 1, "items": {"type": "string", "pattern": "^[a-z0-9.-]+$"}},
+		"ratio": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+		"labels": {"type": "object", "additionalProperties": {"type": "string", "maxLength": 5}},
+		"optional": {"type": ["string", "null"], "description": "ignored keyword"}
+	},
+	"$defs": {
+		"database": {
+			"type": "object",
+			"required": ["host", "port"],
+			"properties": {
+				"host": {"type": "string", "minLength": 1},
+				"port": {"type": "integer", "minimum": 1, "maximum": 65535}
+			}
+		}
+	}
+}`
+
+func mustParse(t *testing.T, data string) *Schema {
+	t.Helper()
+	s, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return s
+}
+
+func TestSchema_ValidateJSON_Valid(t *testing.T) {
+	s := mustParse(t, testSchema)
+	doc := `{"logLevel":"info","database":{"host":"db","port":5432},"hosts":["a.example"],"ratio":0.5,"labels":{"team":"core"},"optional":null}`
+	if err := s.ValidateJSON([]byte(doc)); err != nil {
+		t.Errorf("expected valid document, got %v", err)
+	}
+}
+
+func TestSchema_ValidateJSON_ReportsEveryViolationByPath(t *testing.T) {
+	s := mustParse(t, testSchema)
+	doc := `{"logLevel":"verbose","database":{"host":"","port":70000},"hosts":["Bad Host"],"ratio":0,"labels":{"team":"platform"},"extra":true}`
+
+	err := s.ValidateJSON([]byte(doc))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	want := map[string]string{
+		"/database/host": "at least 1 characters",
+		"/database/port": "must be <= 65535",
+		"/extra":         "unknown property",
+		"/hosts/0":       "must match pattern",
+		"/labels/team":   "at most 5 characters",
+		"/logLevel":      "must be one of",
+		"/ratio":         "must be > 0",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d violations, got %d: %v", len(want), len(errs), err)
+	}
+	for _, e := range errs {
+		if !strings.Contains(e.Message, want[e.Path]) {
+			t.Errorf("unexpected violation at %s: %s", e.Path, e.Message)
+		}
+	}
+}
+
+func TestSchema_Validate_TypesAndRequired(t *testing.T) {
+	s := mustParse(t, testSchema)
+
+	err := s.Validate(map[string]any{"database": map[string]any{"host": "db", "port": 1.5}})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected two violations, got %v", err)
+	}
+	if errs[0].Path != "" || !strings.Contains(errs[0].Message, `missing required property "logLevel"`) {
+		t.Errorf("unexpected root violation: %v", errs[0])
+	}
+	if errs[1].Path != "/database/port" || !strings.Contains(errs[1].Message, "expected integer, got number") {
+		t.Errorf("unexpected port violation: %v", errs[1])
+	}
+}
+
+func TestSchema_Validate_YAMLShapes(t *testing.T) {
+	s := mustParse(t, `{"type":"object","properties":{"port":{"type":"integer","maximum":10},"tags":{"type":"array","items":{"type":"string"}}}}`)
+
+	doc := map[string]any{"port": 8, "tags": []any{"a", "b"}}
+	if err := s.Validate(doc); err != nil {
+		t.Errorf("expected yaml-decoded ints and slices to validate, got %v", err)
+	}
+	if err := s.Validate(map[any]any{"port": 11}); err == nil {
+		t.Error("expected map[any]any document to be validated")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":   `{`,
+		"unresolved ref": `{"properties":{"a":{"$ref":"#/$defs/missing"}}}`,
+		"bad pattern":    `{"pattern":"("}`,
+		"bad type":       `{"type":5}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Error("expected parse error")
+			}
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	if got := (&ValidationError{Message: "bad"}).Error(); got != "/: bad" {
+		t.Errorf("unexpected root error message: %s", got)
+	}
+	if got := (&ValidationError{Path: "/a~1b", Message: "bad"}).Error(); got != "/a~1b: bad" {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+	"github.com/gymshark/go-easy-config/schema"
+)
+
+func TestWithSchema_AppliesToFileLoaders(t *testing.T) {
+	type Config struct {
+		Port int `json:"port"`
+	}
+
+	s, err := schema.Parse([]byte(`{"type":"object","properties":{"port":{"type":"integer","maximum":65535}}}`))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	jsonLoader := &generic.JSONLoader[Config]{Source: []byte(`{"port":70000}`)}
+	handler := NewConfigHandler[Config](
+		WithSchema[Config](s),
+		WithLoaders[Config](&generic.MapLoader[Config]{}, jsonLoader),
+	)
+
+	if jsonLoader.Schema != s {
+		t.Fatal("expected schema to be set on JSONLoader regardless of option order")
+	}
+
+	var schemaErrs schema.ValidationErrors
+	if err := handler.Load(&Config{}); !errors.As(err, &schemaErrs) || schemaErrs[0].Path != "/port" {
+		t.Errorf("expected schema violation at /port, got %v", err)
+	}
+}
+
+func TestWithSchema_AppliesToWrappedLoaders(t *testing.T) {
+	type Config struct {
+		Port int `json:"port"`
+	}
+
+	s, err := schema.Parse([]byte(`{"type":"object","properties":{"port":{"type":"integer","maximum":65535}}}`))
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	jsonLoader := &generic.JSONLoader[Config]{Source: []byte(`{"port":70000}`)}
+	handler := NewConfigHandler[Config](
+		WithLoaders[Config](&generic.FaultInjector[Config]{Loader: jsonLoader}),
+		WithSchema[Config](s),
+	)
+
+	if jsonLoader.Schema != s {
+		t.Fatal("expected schema to be passed on to the wrapped JSONLoader")
+	}
+
+	var schemaErrs schema.ValidationErrors
+	if err := handler.Load(&Config{}); !errors.As(err, &schemaErrs) || schemaErrs[0].Path != "/port" {
+		t.Errorf("expected schema violation at /port, got %v", err)
+	}
+}
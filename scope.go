@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// For derives a handler for the nested section of C at path, so a library that accepts
+// only its own configuration type can reuse the parent's loaders and validator.
+//
+// path names the section field with dot-separated segments, matched as in
+// Handler.ApplyOverride (e.g. "Database" or "Services.Cache"); the field must be of type S
+// or *S. Go methods cannot take type parameters, so For is a function rather than a
+// Handler method.
+//
+// The derived handler loads C through the parent handler with Handler.LoadFields, so
+// section fields see the same sources, interpolation context and env prefixes
+// (`envPrefix`) as in the parent, then keeps only the section. Loaders implementing
+// FieldLoader skip the other sections; the rest follow the parent's PartialStrategy.
+// Values already set on the section are passed in first. It validates S with the parent's
+// validator.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    Env      string         `env:"ENV" config:"availableAs=ENV"`
+//	    Database DatabaseConfig `envPrefix:"DB_"`
+//	}
+//	dbHandler, err := config.For[DatabaseConfig](handler, "Database")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	db, err := database.New(dbHandler) // accepts *config.Handler[DatabaseConfig]
+func For[S, C any](parent *Handler[C], path FieldPath) (*Handler[S], error) {
+	var probe C
+	field, _, err := overrideField(reflect.ValueOf(&probe).Elem(), path)
+	if err != nil {
+		return nil, fmt.Errorf("scope %q: %w", path, err)
+	}
+	want := reflect.TypeOf((*S)(nil)).Elem()
+	if field.Type() != want && field.Type() != reflect.PointerTo(want) {
+		return nil, fmt.Errorf("scope %q: field is %s, not %s", path, field.Type(), want)
+	}
+
+	loaders := []Loader[S]{&scopedLoader[S, C]{parent: parent, path: path}}
+	return &Handler[S]{
+		Validator:   parent.Validator,
+		Loaders:     loaders,
+		chainLoader: &InterpolatingChainLoader[S]{Loaders: loaders},
+		pathBaseDir: parent.pathBaseDir,
+		features:    parent.features,
+		decoders:    parent.decoders,
+	}, nil
+}
+
+// scopedLoader loads a section of C through the parent handler.
+type scopedLoader[S, C any] struct {
+	parent *Handler[C]
+	path   FieldPath
+}
+
+// Load seeds a zero C with s, loads the section through the parent handler and copies it back.
+func (l *scopedLoader[S, C]) Load(s *S) error {
+	var c C
+	field, _, err := overrideField(reflect.ValueOf(&c).Elem(), l.path)
+	if err != nil {
+		return fmt.Errorf("scope %q: %w", l.path, err)
+	}
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.ValueOf(s))
+	} else {
+		field.Set(reflect.ValueOf(s).Elem())
+	}
+
+	if err := l.parent.LoadFields(&c, l.path); err != nil {
+		return err
+	}
+
+	// Loaders may have replaced the pointer or reloaded the section field
+	field, _, _ = overrideField(reflect.ValueOf(&c).Elem(), l.path)
+	if field.Kind() == reflect.Ptr {
+		if !field.IsNil() {
+			*s = *field.Interface().(*S)
+		}
+		return nil
+	}
+	*s = field.Interface().(S)
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type scopeTestDatabase struct {
+	Host string `env:"HOST" validate:"required"`
+	Port int    `env:"PORT" validate:"min=1"`
+}
+
+type scopeTestCache struct {
+	URL string `env:"URL"`
+}
+
+type scopeTestConfig struct {
+	Env      string            `env:"SCOPE_TEST_ENV"`
+	Database scopeTestDatabase `envPrefix:"SCOPE_TEST_DB_"`
+	Cache    *scopeTestCache   `envPrefix:"SCOPE_TEST_CACHE_"`
+}
+
+func TestFor_LoadsSectionThroughParent(t *testing.T) {
+	t.Setenv("SCOPE_TEST_ENV", "prod")
+	t.Setenv("SCOPE_TEST_DB_HOST", "db.internal")
+	t.Setenv("SCOPE_TEST_CACHE_URL", "redis://cache")
+
+	parent := NewConfigHandler[scopeTestConfig](WithLoaders[scopeTestConfig](&generic.EnvironmentLoader[scopeTestConfig]{}))
+
+	dbHandler, err := For[scopeTestDatabase](parent, "database")
+	if err != nil {
+		t.Fatalf("For failed: %v", err)
+	}
+	db := &scopeTestDatabase{Port: 5432}
+	if err := dbHandler.LoadAndValidate(db); err != nil {
+		t.Fatalf("LoadAndValidate failed: %v", err)
+	}
+	if db.Host != "db.internal" || db.Port != 5432 {
+		t.Errorf("unexpected database section: %+v", db)
+	}
+
+	cacheHandler, err := For[scopeTestCache](parent, "Cache")
+	if err != nil {
+		t.Fatalf("For pointer section failed: %v", err)
+	}
+	var cache scopeTestCache
+	if err := cacheHandler.Load(&cache); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cache.URL != "redis://cache" {
+		t.Errorf("unexpected cache section: %+v", cache)
+	}
+}
+
+func TestFor_ValidatesSection(t *testing.T) {
+	parent := NewConfigHandler[scopeTestConfig](WithLoaders[scopeTestConfig](&plainTestLoader[scopeTestConfig]{}))
+	dbHandler, err := For[scopeTestDatabase](parent, "Database")
+	if err != nil {
+		t.Fatalf("For failed: %v", err)
+	}
+
+	var validationErr *ValidationError
+	if err := dbHandler.LoadAndValidate(&scopeTestDatabase{Port: 1}); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for missing host, got %v", err)
+	}
+}
+
+func TestFor_InvalidPath(t *testing.T) {
+	parent := NewConfigHandler[scopeTestConfig](WithLoaders[scopeTestConfig]())
+
+	if _, err := For[scopeTestDatabase](parent, "Missing"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	if _, err := For[scopeTestCache](parent, "Database"); err == nil {
+		t.Error("expected error for mismatched section type")
+	}
+}
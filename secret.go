@@ -0,0 +1,74 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// secretMask is what a Secret prints and encodes as.
+const secretMask = "***"
+
+// Secret is a string that does not leak into logs: it prints, logs and encodes to JSON and
+// YAML as "***", so a configuration holding one can be passed to fmt, slog or an error
+// message safely. Loaders read it as an ordinary string; call Value for the plaintext.
+// Secret fields are sensitive without a tag, as if marked `sensitive:"true"`.
+//
+// Because it encodes as "***", a Secret cannot be round-tripped through JSON or YAML.
+// Fingerprint still covers its value, and generic.CachingLoader and generic.FixtureLoader
+// store it gob-encoded, keeping the plaintext.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    DBPassword config.Secret `env:"DB_PASSWORD" validate:"required"`
+//	}
+//	...
+//	slog.Info("config loaded", "config", cfg) // DBPassword:***
+//	db.Connect(cfg.DBPassword.Value())
+type Secret string
+
+// Value returns the secret in plaintext.
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String returns "***".
+func (s Secret) String() string {
+	return secretMask
+}
+
+// GoString returns "***", so %#v does not print the secret either.
+func (s Secret) GoString() string {
+	return secretMask
+}
+
+// LogValue returns "***" for log/slog.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(secretMask)
+}
+
+// MarshalJSON encodes the secret as "***".
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + secretMask + `"`), nil
+}
+
+// MarshalYAML encodes the secret as "***".
+func (s Secret) MarshalYAML() (any, error) {
+	return secretMask, nil
+}
+
+// secretType is the type of Secret.
+var secretType = reflect.TypeOf(Secret(""))
+
+// isSensitive reports whether the value of field must not be shown: it is marked
+// `config:"sensitive"` or `sensitive:"true"`, or holds a Secret.
+func isSensitive(field reflect.StructField) bool {
+	t := field.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == secretType || field.Tag.Get("sensitive") == "true" ||
+		utils.HasTagOption(field.Tag.Get("config"), "sensitive")
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+	"gopkg.in/yaml.v3"
+)
+
+type secretTestConfig struct {
+	Name     string `env:"SECRET_TEST_NAME" json:"name" yaml:"name"`
+	Password Secret `env:"SECRET_TEST_PASSWORD" json:"password" yaml:"password"`
+	Token    string `json:"token" sensitive:"true"`
+}
+
+func TestSecret_DoesNotLeak(t *testing.T) {
+	t.Setenv("SECRET_TEST_NAME", "api")
+	t.Setenv("SECRET_TEST_PASSWORD", "hunter2")
+	handler := NewConfigHandler[secretTestConfig](
+		WithLoaders[secretTestConfig](&generic.EnvironmentLoader[secretTestConfig]{}),
+	)
+	var cfg secretTestConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Password.Value() != "hunter2" {
+		t.Fatalf("Value() = %q, want hunter2", cfg.Password.Value())
+	}
+
+	outputs := map[string]string{
+		"%v":  fmt.Sprintf("%v", cfg),
+		"%+v": fmt.Sprintf("%+v", cfg),
+		"%#v": fmt.Sprintf("%#v", cfg),
+		"%q":  fmt.Sprintf("%q", cfg.Password),
+	}
+	data, _ := json.Marshal(cfg)
+	outputs["json"] = string(data)
+	data, _ = yaml.Marshal(cfg)
+	outputs["yaml"] = string(data)
+	var logs bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logs, nil)).Info("loaded", "password", cfg.Password)
+	outputs["slog"] = logs.String()
+	for name, out := range outputs {
+		if strings.Contains(out, "hunter2") || !strings.Contains(out, "***") {
+			t.Errorf("%s = %s, want the secret masked", name, out)
+		}
+	}
+}
+
+func TestSecret_Sensitive(t *testing.T) {
+	cfg := &secretTestConfig{Name: "api", Password: "hunter2", Token: "token"}
+
+	rotated := *cfg
+	rotated.Password = "hunter3"
+	if Fingerprint(cfg) == Fingerprint(&rotated) {
+		t.Error("Fingerprint did not change with the Secret value")
+	}
+
+	snapshot, err := TakeSnapshot(cfg)
+	if err != nil {
+		t.Fatalf("TakeSnapshot() error = %v", err)
+	}
+	if len(snapshot.Redacted) != 2 || snapshot.Redacted[0] != "Password" || snapshot.Redacted[1] != "Token" {
+		t.Errorf("Redacted = %v, want [Password Token]", snapshot.Redacted)
+	}
+
+	dump, err := NewConfigHandler[secretTestConfig]().Dump(cfg, DumpOptions{Redact: true})
+	if err != nil || strings.Contains(string(dump), `"token": "token"`) || strings.Contains(string(dump), "hunter2") {
+		t.Errorf("Dump() = %s, %v", dump, err)
+	}
+
+	params := DescribeParameters[secretTestConfig]()
+	for _, p := range params {
+		if p.Field != "Name" && !p.Sensitive {
+			t.Errorf("parameter %s is not sensitive", p.Field)
+		}
+	}
+}
+
+func TestSecret_RoundTripsThroughCachingLoader(t *testing.T) {
+	t.Setenv("SECRET_TEST_NAME", "api")
+	t.Setenv("SECRET_TEST_PASSWORD", "hunter2")
+	ldr := &generic.CachingLoader[secretTestConfig]{
+		Loader: &generic.EnvironmentLoader[secretTestConfig]{},
+		Key:    "env",
+		Dir:    t.TempDir(),
+		TTL:    time.Hour,
+	}
+	var cfg secretTestConfig
+	if err := ldr.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Served from the cache, now that the source no longer has the values
+	t.Setenv("SECRET_TEST_NAME", "")
+	t.Setenv("SECRET_TEST_PASSWORD", "")
+	var cached secretTestConfig
+	if err := ldr.Load(&cached); err != nil {
+		t.Fatalf("Load() from cache error = %v", err)
+	}
+	if cached.Name != "api" || cached.Password.Value() != "hunter2" {
+		t.Errorf("cached config = {Name: %q, Password: %q}, want api and hunter2", cached.Name, cached.Password.Value())
+	}
+}
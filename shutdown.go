@@ -0,0 +1,107 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Load, LoadResult and Prefetch once the handler has been shut
+// down with Shutdown or Close.
+var ErrClosed = errors.New("config: handler is shut down")
+
+// lifecycle tracks the work of a handler so Shutdown can stop and wait for it: loads and
+// prefetches in progress, and background goroutines such as stage loads abandoned when a
+// stage timed out.
+type lifecycle struct {
+	mu     sync.Mutex
+	closed bool
+	ctx    context.Context    // Cancelled by shutdown; created on first use
+	cancel context.CancelFunc // Cancels ctx
+	active sync.WaitGroup     // Work in progress
+}
+
+// begin registers work and returns a context cancelled by shutdown, or ErrClosed.
+// The caller must call active.Done when the work ends.
+func (l *lifecycle) begin() (context.Context, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, ErrClosed
+	}
+	if l.ctx == nil {
+		l.ctx, l.cancel = context.WithCancel(context.Background())
+	}
+	l.active.Add(1)
+	return l.ctx, nil
+}
+
+// spawn runs fn in a goroutine that shutdown waits for. It is used for work outliving the
+// call that started it.
+func (l *lifecycle) spawn(fn func()) {
+	l.active.Add(1)
+	go func() {
+		defer l.active.Done()
+		fn()
+	}()
+}
+
+// shutdown rejects new work, cancels the context of work in progress and waits for it
+// until ctx is done. It reports whether the work finished.
+func (l *lifecycle) shutdown(ctx context.Context) bool {
+	l.mu.Lock()
+	l.closed = true
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		l.active.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Shutdown stops the handler and waits for its work to finish. New calls to Load,
+// LoadResult and Prefetch return ErrClosed, loads and prefetches in progress have their
+// context cancelled, and Shutdown waits for loads in progress and for stage loads left running in
+// the background after a stage timeout (see WithStageTimeout). Once they are done, the
+// handler releases its reference to its source pool and clears a source cache it created
+// itself, so fetched secrets do not stay in memory.
+//
+// If ctx is done first, Shutdown returns its error without releasing the caches, which the
+// remaining work may still use. Shutdown is safe to call more than once and concurrently
+// with Load.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := handler.Shutdown(ctx); err != nil {
+//	    log.Printf("configuration shutdown: %v", err)
+//	}
+func (c *Handler[C]) Shutdown(ctx context.Context) error {
+	if !c.life.shutdown(ctx) {
+		return ctx.Err()
+	}
+	c.closeOnce.Do(func() {
+		if c.sourcePool != nil {
+			c.sourcePool.Release()
+		} else if c.ownsSourceCache {
+			c.sourceCache.Clear()
+		}
+	})
+	return nil
+}
+
+// Close shuts the handler down without a deadline. See Shutdown.
+func (c *Handler[C]) Close() error {
+	return c.Shutdown(context.Background())
+}
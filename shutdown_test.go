@@ -0,0 +1,102 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+type shutdownConfig struct {
+	Name string
+}
+
+func TestHandler_Shutdown_RejectsLoads(t *testing.T) {
+	handler := NewConfigHandler[shutdownConfig](WithLoaders[shutdownConfig](&mockLoader[shutdownConfig]{}))
+
+	if err := handler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := handler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second Shutdown() error = %v", err)
+	}
+	if err := handler.Load(&shutdownConfig{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Load() after Shutdown error = %v, want ErrClosed", err)
+	}
+	if err := handler.Prefetch(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Prefetch() after Shutdown error = %v, want ErrClosed", err)
+	}
+}
+
+func TestHandler_Shutdown_WaitsForTimedOutStage(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	slow := &mockLoader[shutdownConfig]{loadFunc: func(c *shutdownConfig) error {
+		defer close(finished)
+		<-release
+		return nil
+	}}
+	handler := NewConfigHandler[shutdownConfig](
+		WithLoaders[shutdownConfig](slow),
+		WithStageTimeout[shutdownConfig](10*time.Millisecond),
+	)
+
+	var timeoutErr *StageTimeoutError
+	if err := handler.Load(&shutdownConfig{}); !errors.As(err, &timeoutErr) {
+		t.Fatalf("Load() error = %v, want StageTimeoutError", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := handler.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() with a stage still running error = %v, want DeadlineExceeded", err)
+	}
+
+	close(release)
+	if err := handler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("Shutdown returned before the abandoned stage finished")
+	}
+}
+
+// blockingPrefetcher blocks in Prefetch until its context is cancelled.
+type blockingPrefetcher struct {
+	started chan struct{}
+}
+
+func (p *blockingPrefetcher) SetSourceCache(*loader.SourceCache) {}
+
+func (p *blockingPrefetcher) Prefetch(ctx context.Context, _ []loader.Field) error {
+	close(p.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (p *blockingPrefetcher) Load(*shutdownConfig) error { return nil }
+
+func TestHandler_Shutdown_CancelsPrefetch(t *testing.T) {
+	prefetcher := &blockingPrefetcher{started: make(chan struct{})}
+	handler := NewConfigHandler[shutdownConfig](WithLoaders[shutdownConfig](prefetcher))
+
+	errs := make(chan error, 1)
+	go func() { errs <- handler.Prefetch(context.Background()) }()
+	<-prefetcher.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := handler.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Prefetch() error = %v, want context.Canceled", err)
+	}
+	if handler.sourceCache.Len() != 0 {
+		t.Errorf("expected the handler's own cache to be cleared, got %d values", handler.sourceCache.Len())
+	}
+}
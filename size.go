@@ -0,0 +1,210 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Size is a byte count written with an optional decimal (KB, MB, GB, TB, PB) or binary
+// (KiB, MiB, GiB, TiB, PiB) unit:
+//
+//	512MiB    536870912 bytes
+//	1.5GB     1500000000 bytes
+//	64 kib    65536 bytes, units are case-insensitive and may follow a space
+//	4096      4096 bytes, B and no unit both mean bytes
+//
+// K, M, G, T and P are accepted as short forms of the decimal units. The empty string is
+// the zero Size.
+//
+// Size implements encoding.TextUnmarshaler, so it can be set from environment variables,
+// flags, MapLoader values and ApplyOverride. JSON and YAML accept a string or a number of
+// bytes. Size is an int64, so it compares with < and >, and the size_min and size_max
+// validation rules take bounds in the same syntax. Parameter specs report it as a string
+// with format "byte-size".
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    MaxUpload config.Size `env:"MAX_UPLOAD" envDefault:"32MiB" validate:"size_min=1MiB,size_max=1GiB"`
+//	}
+//
+//	r.Body = http.MaxBytesReader(w, r.Body, cfg.MaxUpload.Bytes())
+type Size int64
+
+// Decimal and binary Size units.
+const (
+	Byte Size = 1
+
+	KB Size = 1000 * Byte
+	MB Size = 1000 * KB
+	GB Size = 1000 * MB
+	TB Size = 1000 * GB
+	PB Size = 1000 * TB
+
+	KiB Size = 1024 * Byte
+	MiB Size = 1024 * KiB
+	GiB Size = 1024 * MiB
+	TiB Size = 1024 * GiB
+	PiB Size = 1024 * TiB
+)
+
+// sizeUnits are the units of a Size, keyed by lower-case name.
+var sizeUnits = map[string]Size{
+	"": Byte, "b": Byte,
+	"k": KB, "kb": KB, "kib": KiB,
+	"m": MB, "mb": MB, "mib": MiB,
+	"g": GB, "gb": GB, "gib": GiB,
+	"t": TB, "tb": TB, "tib": TiB,
+	"p": PB, "pb": PB, "pib": PiB,
+}
+
+// sizeFormatUnits are the units String chooses from, largest first.
+var sizeFormatUnits = []struct {
+	name string
+	size Size
+}{
+	{"PiB", PiB}, {"PB", PB}, {"TiB", TiB}, {"TB", TB}, {"GiB", GiB}, {"GB", GB},
+	{"MiB", MiB}, {"MB", MB}, {"KiB", KiB}, {"KB", KB},
+}
+
+// ParseSize parses a byte count. See Size for the syntax. Fractional byte counts are
+// rounded to the nearest byte.
+func ParseSize(s string) (Size, error) {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return 0, nil
+	}
+
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if end < 0 {
+		end = len(text)
+	}
+	number, unit := text[:end], strings.TrimSpace(text[end:])
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: want a number of bytes with an optional unit such as MiB or GB", s)
+	}
+	multiplier, ok := sizeUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, unit)
+	}
+	bytes := math.Round(n * float64(multiplier))
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q: too large", s)
+	}
+	return Size(bytes), nil
+}
+
+// Bytes returns the size in bytes.
+func (s Size) Bytes() int64 {
+	return int64(s)
+}
+
+// Int returns the size in bytes as an int, for APIs such as bufio.NewReaderSize. Sizes
+// larger than the largest int are clamped to it.
+func (s Size) Int() int {
+	if int64(s) > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(s)
+}
+
+// Compare returns -1, 0 or +1 as s is less than, equal to or greater than other.
+func (s Size) Compare(other Size) int {
+	switch {
+	case s < other:
+		return -1
+	case s > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Clamp returns s limited to the range [lo, hi].
+func (s Size) Clamp(lo, hi Size) Size {
+	return min(max(s, lo), hi)
+}
+
+// IsZero reports whether the size is zero.
+func (s Size) IsZero() bool {
+	return s == 0
+}
+
+// String returns the size in the largest unit that represents it exactly with at most
+// three decimal places, e.g. "1.5GiB" or "1500KB", or in bytes, e.g. "1001".
+func (s Size) String() string {
+	if s < 0 {
+		return "-" + (-s).String()
+	}
+	for _, unit := range sizeFormatUnits {
+		if s < unit.size {
+			continue
+		}
+		value := float64(s) / float64(unit.size)
+		text := strconv.FormatFloat(value, 'f', -1, 64)
+		if _, frac, ok := strings.Cut(text, "."); ok && len(frac) > 3 {
+			continue
+		}
+		if parsed, err := ParseSize(text + unit.name); err == nil && parsed == s {
+			return text + unit.name
+		}
+	}
+	return strconv.FormatInt(int64(s), 10)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Size) UnmarshalText(text []byte) error {
+	parsed, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts a size string or a number of bytes.
+func (s *Size) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return s.UnmarshalText([]byte(text))
+	}
+	var bytes int64
+	if err := json.Unmarshal(data, &bytes); err != nil {
+		return fmt.Errorf("size must be a string or a whole number of bytes")
+	}
+	*s = Size(bytes)
+	return nil
+}
+
+// UnmarshalYAML accepts a size string or a number of bytes. It uses the yaml.v2-style
+// signature, which gopkg.in/yaml.v3 also supports.
+func (s *Size) UnmarshalYAML(unmarshal func(any) error) error {
+	var bytes int64
+	if err := unmarshal(&bytes); err == nil {
+		*s = Size(bytes)
+		return nil
+	}
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return fmt.Errorf("size must be a string or a whole number of bytes")
+	}
+	return s.UnmarshalText([]byte(text))
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Size) ParameterFormat() string {
+	return "byte-size"
+}
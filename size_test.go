@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Size
+		str  string
+	}{
+		{"512MiB", 512 * MiB, "512MiB"},
+		{"1.5GB", 1500 * MB, "1.5GB"},
+		{"1.5GiB", 1536 * MiB, "1.5GiB"},
+		{" 64 kib ", 64 * KiB, "64KiB"},
+		{"4096", 4096, "4KiB"},
+		{"1001B", 1001, "1.001KB"},
+		{"1023", 1023, "1.023KB"},
+		{"999", 999, "999"},
+		{"2M", 2 * MB, "2MB"},
+		{"0.5KiB", 512, "512"},
+		{"1PiB", PiB, "1PiB"},
+		{"", 0, "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSize(tt.in)
+			if err != nil {
+				t.Fatalf("ParseSize() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSize() = %d, want %d", got, tt.want)
+			}
+			if got.String() != tt.str {
+				t.Errorf("String() = %q, want %q", got.String(), tt.str)
+			}
+			if roundTrip, err := ParseSize(got.String()); err != nil || roundTrip != got {
+				t.Errorf("round trip = %d, %v; want %d", roundTrip, err, got)
+			}
+		})
+	}
+}
+
+func TestParseSize_Errors(t *testing.T) {
+	for _, in := range []string{"-1MB", "MB", "1.2.3GB", "10 bytes", "5XB", "1e3", "100000PB"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q) expected error", in)
+		}
+	}
+}
+
+func TestSize_Helpers(t *testing.T) {
+	if (512*MiB).Compare(GiB) != -1 || GiB.Compare(GiB) != 0 || GB.Compare(MB) != 1 {
+		t.Error("unexpected Compare result")
+	}
+	if got := (4 * GiB).Clamp(MiB, GiB); got != GiB {
+		t.Errorf("Clamp() = %v, want 1GiB", got)
+	}
+	if got := Size(10).Clamp(KiB, MiB); got != KiB {
+		t.Errorf("Clamp() = %v, want 1KiB", got)
+	}
+	if MiB.Bytes() != 1<<20 || MiB.Int() != 1<<20 || !Size(0).IsZero() {
+		t.Error("unexpected conversions")
+	}
+}
+
+type sizeTestConfig struct {
+	MaxUpload Size `env:"MAX_UPLOAD" json:"maxUpload" yaml:"maxUpload"`
+	Buffer    Size `env:"BUFFER" envDefault:"64KiB" json:"buffer" yaml:"buffer"`
+}
+
+func TestSize_Loaders(t *testing.T) {
+	t.Setenv("MAX_UPLOAD", "32MiB")
+	var cfg sizeTestConfig
+	if err := (&generic.EnvironmentLoader[sizeTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	if cfg.MaxUpload != 32*MiB || cfg.Buffer != 64*KiB {
+		t.Errorf("environment = %+v", cfg)
+	}
+
+	sources := map[string]interface {
+		Load(*sizeTestConfig) error
+	}{
+		"yaml string": &generic.YAMLLoader[sizeTestConfig]{Source: []byte("maxUpload: 1.5GB\nbuffer: 4096\n")},
+		"json string": &generic.JSONLoader[sizeTestConfig]{Source: []byte(`{"maxUpload": "1.5GB", "buffer": 4096}`)},
+	}
+	for name, ldr := range sources {
+		t.Run(name, func(t *testing.T) {
+			var cfg sizeTestConfig
+			if err := ldr.Load(&cfg); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.MaxUpload != 1500*MB || cfg.Buffer != 4096 {
+				t.Errorf("loaded = %+v", cfg)
+			}
+		})
+	}
+
+	if err := (&generic.JSONLoader[sizeTestConfig]{Source: []byte(`{"maxUpload": 1.5}`)}).Load(&cfg); err == nil {
+		t.Error("JSONLoader expected error for a fractional byte count")
+	}
+
+	data, err := json.Marshal(sizeTestConfig{MaxUpload: 32 * MiB, Buffer: 1000})
+	if err != nil || string(data) != `{"maxUpload":"32MiB","buffer":"1KB"}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
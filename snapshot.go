@@ -0,0 +1,266 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// snapshotVersion is the format version written by TakeSnapshot.
+const snapshotVersion = 1
+
+// Snapshot is a redacted copy of a configuration, compact enough to attach to crash and
+// panic reports, that SnapshotLoader can load again to reproduce the configuration in a
+// debugging session.
+//
+// Values are stored by Go field name, so snapshots do not depend on json tags. Sensitive
+// fields, including those inside list and keyed sections, are left out and listed in
+// Redacted, and are not part of Fingerprint either: unlike the Fingerprint function, which
+// digests them, an unsalted digest of a weak secret could be reversed by guessing. Fields that cannot be encoded as JSON, such as functions and channels, are left
+// out and listed in Skipped.
+type Snapshot struct {
+	Version     int             `json:"v"`
+	Type        string          `json:"type"`               // Go type of the configuration, e.g. "main.AppConfig"
+	Taken       time.Time       `json:"taken"`              // When the snapshot was taken
+	Fingerprint string          `json:"fingerprint"`        // Hex SHA-256 digest of Config, so it reveals nothing Config does not
+	Config      json.RawMessage `json:"config"`             // Field values keyed by Go field name
+	Redacted    []FieldPath     `json:"redacted,omitempty"` // Paths of sensitive fields left out
+	Skipped     []FieldPath     `json:"skipped,omitempty"`  // Paths of fields that could not be encoded
+}
+
+// TakeSnapshot returns a redacted snapshot of cfg.
+//
+// Example:
+//
+//	defer func() {
+//	    if r := recover(); r != nil {
+//	        snapshot, _ := config.TakeSnapshot(&cfg)
+//	        blob, _ := snapshot.Encode()
+//	        sentry.CurrentHub().Scope().SetContext("config", map[string]any{"snapshot": string(blob)})
+//	        panic(r)
+//	    }
+//	}()
+func TakeSnapshot[C any](cfg *C) (*Snapshot, error) {
+	s := &Snapshot{
+		Version: snapshotVersion,
+		Type:    reflect.TypeOf(cfg).Elem().String(),
+		Taken:   time.Now().UTC(),
+	}
+	tree, _ := s.encodeValue(reflect.ValueOf(cfg).Elem(), "")
+	config, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	s.Config = config
+	digest := sha256.Sum256(config)
+	s.Fingerprint = hex.EncodeToString(digest[:])
+	slices.Sort(s.Redacted)
+	slices.Sort(s.Skipped)
+	return s, nil
+}
+
+// Encode returns the snapshot as compact JSON.
+func (s *Snapshot) Encode() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// DecodeSnapshot parses a snapshot produced by Snapshot.Encode.
+func DecodeSnapshot(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	return &s, nil
+}
+
+// encodeValue returns v as a JSON-encodable tree, recording redacted and skipped paths, and
+// false if v could not be encoded. Sections are encoded field by field so sensitive fields
+// inside them can be left out; other values are encoded with encoding/json.
+func (s *Snapshot) encodeValue(v reflect.Value, path FieldPath) (any, bool) {
+	switch {
+	case v.Kind() == reflect.Ptr && isSection(v.Type().Elem()):
+		if v.IsNil() {
+			return nil, true
+		}
+		return s.encodeValue(v.Elem(), path)
+	case v.Kind() == reflect.Struct && isSection(v.Type()):
+		fields := make(map[string]any)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := path.Child(field.Name)
+			if isSensitive(field) {
+				if !v.Field(i).IsZero() {
+					s.Redacted = append(s.Redacted, fieldPath)
+				}
+				continue
+			}
+			if value, ok := s.encodeValue(v.Field(i), fieldPath); ok {
+				fields[field.Name] = value
+			}
+		}
+		return fields, true
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && containsSection(v.Type().Elem()):
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, true
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i], _ = s.encodeValue(v.Index(i), path.Index(strconv.Itoa(i)))
+		}
+		return items, true
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && containsSection(v.Type().Elem()):
+		if v.IsNil() {
+			return nil, true
+		}
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			entries[key], _ = s.encodeValue(iter.Value(), path.Index(key))
+		}
+		return entries, true
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		s.Skipped = append(s.Skipped, path)
+		return nil, false
+	}
+	return json.RawMessage(data), true
+}
+
+// containsSection reports whether t, or the pointer it points to, is a section.
+func containsSection(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isSection(t)
+}
+
+// decodeValue sets v from a tree written by encodeValue.
+func decodeValue(v reflect.Value, data json.RawMessage, path FieldPath) error {
+	switch {
+	case v.Kind() == reflect.Ptr && isSection(v.Type().Elem()):
+		if string(data) == "null" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(v.Elem(), data, path)
+	case v.Kind() == reflect.Struct && isSection(v.Type()):
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		for name, value := range fields {
+			field, ok := v.Type().FieldByName(name)
+			if !ok || !field.IsExported() {
+				return fmt.Errorf("%s: no field %s", pathOrRoot(path), name)
+			}
+			if err := decodeValue(v.FieldByIndex(field.Index), value, path.Child(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case v.Kind() == reflect.Slice && containsSection(v.Type().Elem()):
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		if items == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeValue(slice.Index(i), item, path.Index(strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && containsSection(v.Type().Elem()):
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		if entries == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(entries))
+		for key, entry := range entries {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeValue(elem, entry, path.Index(key)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+		return nil
+	}
+	if err := json.Unmarshal(data, v.Addr().Interface()); err != nil {
+		return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+	}
+	return nil
+}
+
+// pathOrRoot returns path, or "<root>" for the configuration itself.
+func pathOrRoot(path FieldPath) string {
+	if path == "" {
+		return "<root>"
+	}
+	return string(path)
+}
+
+// SnapshotLoader loads a snapshot written by Snapshot.Encode, reproducing the configuration
+// it was taken from. Every field in the snapshot is set, overwriting earlier loaders;
+// redacted and skipped fields are left as they are, so later loaders, such as
+// EnvironmentLoader with development credentials, can fill them.
+//
+// The snapshot must have been taken from the same configuration type.
+//
+// Example:
+//
+//	blob, _ := os.ReadFile("crash-1234-config.json")
+//	handler := config.NewConfigHandler[AppConfig](config.WithLoaders[AppConfig](
+//	    &config.SnapshotLoader[AppConfig]{Source: blob},
+//	    &generic.EnvironmentLoader[AppConfig]{},
+//	))
+type SnapshotLoader[C any] struct {
+	Source []byte // Encoded snapshot
+}
+
+// Load sets the fields of c from the snapshot.
+func (l *SnapshotLoader[C]) Load(c *C) error {
+	snapshot, err := DecodeSnapshot(l.Source)
+	if err != nil {
+		return &loader.LoaderError{LoaderType: "SnapshotLoader", Operation: "decode snapshot", Err: err}
+	}
+	if want := reflect.TypeOf(c).Elem().String(); snapshot.Type != want {
+		return &loader.LoaderError{
+			LoaderType: "SnapshotLoader",
+			Operation:  "check snapshot type",
+			Err:        fmt.Errorf("snapshot is of %s, not %s", snapshot.Type, want),
+		}
+	}
+	if err := decodeValue(reflect.ValueOf(c).Elem(), snapshot.Config, ""); err != nil {
+		return &loader.LoaderError{LoaderType: "SnapshotLoader", Operation: "load snapshot", Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type snapshotSink struct {
+	URL   string `json:"url"`
+	Token string `json:"token" config:"sensitive"`
+}
+
+type snapshotTestConfig struct {
+	Port     int                      `json:"port"`
+	Timeout  time.Duration            `json:"timeout"`
+	Allowed  URLSet                   `json:"allowed"`
+	APIKey   string                   `json:"apiKey" config:"sensitive"`
+	Database resultDatabase           `json:"database"`
+	Sinks    []snapshotSink           `json:"sinks"`
+	Regions  map[string]*snapshotSink `json:"regions"`
+	Hook     func()                   `json:"-"`
+}
+
+func snapshotTestValue(t *testing.T) snapshotTestConfig {
+	allowed, err := ParseURLSet("https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snapshotTestConfig{
+		Port:     8080,
+		Timeout:  5 * time.Second,
+		Allowed:  allowed,
+		APIKey:   "hunter2",
+		Database: resultDatabase{Host: "db.internal", Password: "secret"},
+		Sinks:    []snapshotSink{{URL: "https://a.example.com", Token: "t1"}, {URL: "https://b.example.com"}},
+		Regions:  map[string]*snapshotSink{"eu": {URL: "https://eu.example.com", Token: "t2"}},
+		Hook:     func() {},
+	}
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	cfg := snapshotTestValue(t)
+	snapshot, err := TakeSnapshot(&cfg)
+	if err != nil {
+		t.Fatalf("TakeSnapshot() error = %v", err)
+	}
+
+	if snapshot.Type != "config.snapshotTestConfig" {
+		t.Errorf("Type = %q", snapshot.Type)
+	}
+	rotated := cfg
+	rotated.APIKey = "correct horse"
+	if other, _ := TakeSnapshot(&rotated); other.Fingerprint != snapshot.Fingerprint {
+		t.Error("expected Fingerprint to leave out sensitive values")
+	}
+	rotated.Port = 9090
+	if other, _ := TakeSnapshot(&rotated); other.Fingerprint == snapshot.Fingerprint {
+		t.Error("expected Fingerprint to change with the configuration")
+	}
+	wantRedacted := []FieldPath{"APIKey", "Database.Password", "Regions[eu].Token", "Sinks[0].Token"}
+	if !slices.Equal(snapshot.Redacted, wantRedacted) {
+		t.Errorf("Redacted = %v, want %v", snapshot.Redacted, wantRedacted)
+	}
+	if !slices.Equal(snapshot.Skipped, []FieldPath{"Hook"}) {
+		t.Errorf("Skipped = %v, want [Hook]", snapshot.Skipped)
+	}
+
+	blob, err := snapshot.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, secret := range []string{"hunter2", "secret", "t1", "t2"} {
+		if strings.Contains(string(blob), `"`+secret+`"`) {
+			t.Errorf("snapshot contains sensitive value %q: %s", secret, blob)
+		}
+	}
+}
+
+func TestTakeSnapshot_SecretFingerprint(t *testing.T) {
+	type config struct {
+		Token Secret
+	}
+	a, _ := TakeSnapshot(&config{Token: "s3cret"})
+	b, _ := TakeSnapshot(&config{Token: "rotated"})
+	if a.Fingerprint != b.Fingerprint {
+		t.Error("expected Fingerprint to leave out Secret values")
+	}
+}
+
+func TestSnapshotLoader(t *testing.T) {
+	cfg := snapshotTestValue(t)
+	snapshot, err := TakeSnapshot(&cfg)
+	if err != nil {
+		t.Fatalf("TakeSnapshot() error = %v", err)
+	}
+	blob, err := snapshot.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	handler := NewConfigHandler[snapshotTestConfig](WithLoaders[snapshotTestConfig](
+		&SnapshotLoader[snapshotTestConfig]{Source: blob},
+	))
+	var restored snapshotTestConfig
+	if err := handler.Load(&restored); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if restored.Port != 8080 || restored.Timeout != 5*time.Second || restored.Database.Host != "db.internal" {
+		t.Errorf("restored = %+v", restored)
+	}
+	if !restored.Allowed.Match("https://example.com/path") {
+		t.Errorf("Allowed = %v", restored.Allowed)
+	}
+	if restored.APIKey != "" || restored.Database.Password != "" || restored.Sinks[0].Token != "" {
+		t.Errorf("expected sensitive fields to stay empty, got %+v", restored)
+	}
+	if len(restored.Sinks) != 2 || restored.Sinks[1].URL != "https://b.example.com" {
+		t.Errorf("Sinks = %+v", restored.Sinks)
+	}
+	if restored.Regions["eu"] == nil || restored.Regions["eu"].URL != "https://eu.example.com" {
+		t.Errorf("Regions = %+v", restored.Regions)
+	}
+
+	restored.APIKey = cfg.APIKey
+	restored.Database.Password = cfg.Database.Password
+	restored.Sinks[0].Token = cfg.Sinks[0].Token
+	restored.Regions["eu"].Token = cfg.Regions["eu"].Token
+	restored.Hook = nil
+	cfg.Hook = nil
+	if Fingerprint(&restored) != Fingerprint(&cfg) {
+		t.Error("expected the restored configuration to match once sensitive values are restored")
+	}
+}
+
+func TestSnapshotLoader_Errors(t *testing.T) {
+	cfg := resultTestConfig{Port: 1}
+	snapshot, _ := TakeSnapshot(&cfg)
+	otherType, _ := snapshot.Encode()
+
+	tests := map[string][]byte{
+		"invalid JSON":  []byte(`{`),
+		"bad version":   []byte(`{"v":99,"type":"config.snapshotTestConfig","config":{}}`),
+		"other type":    otherType,
+		"bad value":     []byte(`{"v":1,"type":"config.snapshotTestConfig","config":{"Port":"eighty"}}`),
+		"unknown field": []byte(`{"v":1,"type":"config.snapshotTestConfig","config":{"Missing":1}}`),
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c snapshotTestConfig
+			err := (&SnapshotLoader[snapshotTestConfig]{Source: source}).Load(&c)
+			var loaderErr *loader.LoaderError
+			if !errors.As(err, &loaderErr) || loaderErr.LoaderType != "SnapshotLoader" {
+				t.Errorf("Load() error = %v, want a SnapshotLoader error", err)
+			}
+		})
+	}
+}
+
+func TestSnapshotLoader_LaterLoadersFillRedacted(t *testing.T) {
+	t.Setenv("RESULT_DB_PASSWORD", "dev-password")
+	cfg := resultTestConfig{Port: 9090, Database: resultDatabase{Host: "db.internal", Password: "prod-password"}}
+	snapshot, _ := TakeSnapshot(&cfg)
+	blob, _ := snapshot.Encode()
+
+	handler := NewConfigHandler[resultTestConfig](WithLoaders[resultTestConfig](
+		&SnapshotLoader[resultTestConfig]{Source: blob},
+		&generic.EnvironmentLoader[resultTestConfig]{},
+	))
+	var restored resultTestConfig
+	if err := handler.Load(&restored); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if restored.Port != 9090 || restored.Database.Password != "dev-password" {
+		t.Errorf("restored = %+v", restored)
+	}
+}
@@ -0,0 +1,145 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+// ErrUnknownSourceScheme is returned by SourceRegistry.Resolve for a URI whose scheme has no
+// registered factory.
+var ErrUnknownSourceScheme = errors.New("unknown source scheme")
+
+// SourceFactory creates the loader for a source URI whose scheme it was registered for.
+type SourceFactory[C any] func(u *url.URL) (Loader[C], error)
+
+// SourceRegistry resolves source URIs such as "env://", "file:///etc/app.yaml" or
+// "ssm:///myapp/prod/" to loaders, so the sources of a deployment can be listed in an
+// environment variable or flag and changed without code changes.
+//
+// NewSourceRegistry registers these schemes:
+//   - env:// loads environment variables with EnvironmentLoader.
+//   - file:///path loads a file with FileLoader, whose format is detected or given with
+//     ?format=yaml. A path ending in "/" loads a conf.d style directory with
+//     DirectoryLoader. Relative paths are written file:config.yaml.
+//   - http:// and https:// load a document with HTTPLoader.
+//
+// Other schemes are registered with Register, e.g. for the AWS loaders, which live in a
+// separate package.
+//
+// Example:
+//
+//	sources := config.NewSourceRegistry[AppConfig]()
+//	sources.Register("ssm", func(u *url.URL) (config.Loader[AppConfig], error) {
+//	    return &aws.SSMParameterStoreLoader[AppConfig]{Path: u.Path}, nil
+//	})
+//	// CONFIG_SOURCES="file:///etc/myapp/config.yaml,ssm:///myapp/prod/,env://"
+//	loaders, err := sources.ResolveEnv("CONFIG_SOURCES")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	handler := config.NewConfigHandler[AppConfig](config.WithLoaders(loaders...))
+type SourceRegistry[C any] struct {
+	mu        sync.RWMutex
+	factories map[string]SourceFactory[C]
+}
+
+// NewSourceRegistry returns a registry with the env, file, http and https schemes.
+func NewSourceRegistry[C any]() *SourceRegistry[C] {
+	r := &SourceRegistry[C]{factories: make(map[string]SourceFactory[C])}
+	r.Register("env", func(*url.URL) (Loader[C], error) {
+		return &generic.EnvironmentLoader[C]{}, nil
+	})
+	r.Register("file", fileSource[C])
+	r.Register("http", httpSource[C])
+	r.Register("https", httpSource[C])
+	return r
+}
+
+// Register sets the factory for scheme, replacing any factory already registered for it.
+// Schemes are case-insensitive.
+func (r *SourceRegistry[C]) Register(scheme string, factory SourceFactory[C]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[strings.ToLower(scheme)] = factory
+}
+
+// Resolve returns a loader for each URI, in order, so later sources override earlier ones
+// when the loaders are passed to WithLoaders. It fails for a URI that cannot be parsed,
+// has no scheme or has a scheme without a factory (wrapping ErrUnknownSourceScheme).
+func (r *SourceRegistry[C]) Resolve(uris ...string) ([]Loader[C], error) {
+	loaders := make([]Loader[C], 0, len(uris))
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", uri, err)
+		}
+		if u.Scheme == "" {
+			return nil, fmt.Errorf("source %q: missing scheme, e.g. file:// or env://", uri)
+		}
+		r.mu.RLock()
+		factory, ok := r.factories[u.Scheme]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("source %q: %w %q", uri, ErrUnknownSourceScheme, u.Scheme)
+		}
+		ldr, err := factory(u)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", uri, err)
+		}
+		loaders = append(loaders, ldr)
+	}
+	return loaders, nil
+}
+
+// ResolveEnv resolves the comma- or whitespace-separated URIs in the environment variable
+// name. It returns no loaders when the variable is unset or empty.
+func (r *SourceRegistry[C]) ResolveEnv(name string) ([]Loader[C], error) {
+	uris := strings.FieldsFunc(os.Getenv(name), func(c rune) bool {
+		return c == ',' || c == ' ' || c == '\t' || c == '\n'
+	})
+	if len(uris) == 0 {
+		return nil, nil
+	}
+	loaders, err := r.Resolve(uris...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return loaders, nil
+}
+
+// fileSource creates a FileLoader, or a DirectoryLoader for a path ending in "/".
+func fileSource[C any](u *url.URL) (Loader[C], error) {
+	path := u.Path
+	if u.Opaque != "" {
+		path = u.Opaque
+	} else if u.Host != "" && u.Host != "localhost" {
+		// file://config.yaml is read as a relative path rather than a host
+		path = u.Host + u.Path
+	} else if runtime.GOOS == "windows" && len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:] // file:///C:/app/config.yaml
+	}
+	if path == "" {
+		return nil, errors.New("missing file path")
+	}
+
+	format := u.Query().Get("format")
+	if strings.HasSuffix(path, "/") {
+		return &generic.DirectoryLoader[C]{Dir: filepath.FromSlash(path), Format: format, Required: true}, nil
+	}
+	return &generic.FileLoader[C]{Path: filepath.FromSlash(path), Format: format}, nil
+}
+
+// httpSource creates an HTTPLoader for the URL.
+func httpSource[C any](u *url.URL) (Loader[C], error) {
+	return &generic.HTTPLoader[C]{URL: u.String()}, nil
+}
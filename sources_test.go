@@ -0,0 +1,113 @@
+//go:build !tinygo
+
+package config
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type sourcesTestConfig struct {
+	Name string `env:"SOURCES_TEST_NAME" yaml:"name"`
+	Port int    `yaml:"port"`
+}
+
+func TestSourceRegistry_Resolve_Builtins(t *testing.T) {
+	r := NewSourceRegistry[sourcesTestConfig]()
+	loaders, err := r.Resolve(
+		"env://",
+		"file:///etc/myapp/config.yaml",
+		"file:config.toml?format=toml",
+		"file:///etc/myapp/conf.d/",
+		"https://config.example.com/app.json",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaders) != 5 {
+		t.Fatalf("expected 5 loaders, got %d", len(loaders))
+	}
+	if _, ok := loaders[0].(*generic.EnvironmentLoader[sourcesTestConfig]); !ok {
+		t.Errorf("expected EnvironmentLoader, got %T", loaders[0])
+	}
+	if file, ok := loaders[1].(*generic.FileLoader[sourcesTestConfig]); !ok || file.Path != filepath.FromSlash("/etc/myapp/config.yaml") {
+		t.Errorf("expected FileLoader for /etc/myapp/config.yaml, got %#v", loaders[1])
+	}
+	if file, ok := loaders[2].(*generic.FileLoader[sourcesTestConfig]); !ok || file.Path != "config.toml" || file.Format != "toml" {
+		t.Errorf("expected TOML FileLoader for config.toml, got %#v", loaders[2])
+	}
+	if dir, ok := loaders[3].(*generic.DirectoryLoader[sourcesTestConfig]); !ok || !dir.Required {
+		t.Errorf("expected required DirectoryLoader, got %#v", loaders[3])
+	}
+	if http, ok := loaders[4].(*generic.HTTPLoader[sourcesTestConfig]); !ok || http.URL != "https://config.example.com/app.json" {
+		t.Errorf("expected HTTPLoader, got %#v", loaders[4])
+	}
+}
+
+func TestSourceRegistry_Register(t *testing.T) {
+	r := NewSourceRegistry[sourcesTestConfig]()
+	var got *url.URL
+	r.Register("SSM", func(u *url.URL) (Loader[sourcesTestConfig], error) {
+		got = u
+		return &generic.MapLoader[sourcesTestConfig]{}, nil
+	})
+	if _, err := r.Resolve("ssm:///myapp/prod/"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || got.Path != "/myapp/prod/" {
+		t.Errorf("expected factory to receive the parsed URI, got %v", got)
+	}
+
+	r.Register("vault", func(*url.URL) (Loader[sourcesTestConfig], error) {
+		return nil, errors.New("no token")
+	})
+	if _, err := r.Resolve("vault://secret/myapp"); err == nil {
+		t.Error("expected factory error")
+	}
+}
+
+func TestSourceRegistry_Resolve_Errors(t *testing.T) {
+	r := NewSourceRegistry[sourcesTestConfig]()
+	if _, err := r.Resolve("s3://bucket/key"); !errors.Is(err, ErrUnknownSourceScheme) {
+		t.Errorf("expected ErrUnknownSourceScheme, got %v", err)
+	}
+	if _, err := r.Resolve("config.yaml"); err == nil {
+		t.Error("expected error for a URI without a scheme")
+	}
+	if _, err := r.Resolve("file://"); err == nil {
+		t.Error("expected error for a file URI without a path")
+	}
+}
+
+func TestSourceRegistry_ResolveEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("name: from-file\nport: 8080\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	t.Setenv("SOURCES_TEST_NAME", "from-env")
+	t.Setenv("CONFIG_SOURCES", (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()+", env://")
+
+	r := NewSourceRegistry[sourcesTestConfig]()
+	loaders, err := r.ResolveEnv("CONFIG_SOURCES")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler := NewConfigHandler[sourcesTestConfig](WithLoaders(loaders...))
+	var cfg sourcesTestConfig
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "from-env" || cfg.Port != 8080 {
+		t.Errorf("expected the environment to override the file, got %+v", cfg)
+	}
+
+	t.Setenv("CONFIG_SOURCES", "")
+	if loaders, err := r.ResolveEnv("CONFIG_SOURCES"); err != nil || loaders != nil {
+		t.Errorf("expected no loaders for an empty variable, got %v, %v", loaders, err)
+	}
+}
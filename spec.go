@@ -0,0 +1,205 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gymshark/go-easy-config/utils"
+)
+
+// Parameter describes a single configuration field for service catalogs and documentation.
+type Parameter struct {
+	Name        string `json:"name" yaml:"name"`                                   // Environment variable name, or the clap flag or field name when there is none
+	Field       string `json:"field" yaml:"field"`                                 // Go struct field name
+	Type        string `json:"type" yaml:"type"`                                   // JSON Schema type: string, integer, number, boolean, array or object
+	Format      string `json:"format,omitempty" yaml:"format,omitempty"`           // Refinement of Type, e.g. "duration" for time.Duration
+	Required    bool   `json:"required" yaml:"required"`                           // Set by `env:",required"` or a `validate:"required"` rule
+	Default     string `json:"default,omitempty" yaml:"default,omitempty"`         // From the envDefault or default tag
+	Validate    string `json:"validate,omitempty" yaml:"validate,omitempty"`       // Validation rules from the validate tag
+	Description string `json:"description,omitempty" yaml:"description,omitempty"` // From the description tag
+	Sensitive   bool   `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`     // Set by `config:"sensitive"`
+}
+
+// DescribeParameters returns a Parameter for every exported field of C, in declaration order.
+//
+// Example:
+//
+//	type Config struct {
+//	    Port   int    `env:"PORT" envDefault:"8080" description:"HTTP listen port"`
+//	    APIKey string `env:"API_KEY" validate:"required" config:"sensitive"`
+//	}
+//	params := config.DescribeParameters[Config]()
+//	// [{Name:PORT Field:Port Type:integer Default:8080 Description:HTTP listen port}
+//	//  {Name:API_KEY Field:APIKey Type:string Required:true Sensitive:true}]
+func DescribeParameters[C any]() []Parameter {
+	t := reflect.TypeOf((*C)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		envName, envOpts, _ := strings.Cut(field.Tag.Get("env"), ",")
+		name := envName
+		if name == "" {
+			name, _, _ = strings.Cut(field.Tag.Get("clap"), ",")
+			name = strings.TrimLeft(name, "-")
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		def, ok := field.Tag.Lookup("envDefault")
+		if !ok {
+			def = field.Tag.Get("default")
+		}
+
+		typ, format := parameterType(field.Type)
+		params = append(params, Parameter{
+			Name:        name,
+			Field:       field.Name,
+			Type:        typ,
+			Format:      format,
+			Required:    utils.HasTagOption(envOpts, "required") || utils.HasTagOption(field.Tag.Get("validate"), "required"),
+			Default:     def,
+			Validate:    field.Tag.Get("validate"),
+			Description: field.Tag.Get("description"),
+			Sensitive:   isSensitive(field),
+		})
+	}
+	return params
+}
+
+// ParameterFormatter can be implemented by field types to set the format reported for
+// their parameters, e.g. "rate-limit" for RateLimit.
+type ParameterFormatter interface {
+	ParameterFormat() string
+}
+
+// textUnmarshalerType is the type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// parameterFormatterType is the type of ParameterFormatter.
+var parameterFormatterType = reflect.TypeOf((*ParameterFormatter)(nil)).Elem()
+
+// parameterType maps a Go type to a JSON Schema type and format. Types implementing
+// encoding.TextUnmarshaler, such as DynamicLogLevel, are strings, formatted by their
+// ParameterFormat method if they have one.
+func parameterType(t reflect.Type) (string, string) {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "string", "duration"
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string", "date-time"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		if t.Implements(parameterFormatterType) {
+			return "string", reflect.Zero(t).Interface().(ParameterFormatter).ParameterFormat()
+		}
+		return "string", ""
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", ""
+	case reflect.Float32, reflect.Float64:
+		return "number", ""
+	case reflect.Slice, reflect.Array:
+		return "array", ""
+	case reflect.Struct, reflect.Map:
+		return "object", ""
+	default:
+		return "string", ""
+	}
+}
+
+// WriteEnvSpec writes a 12-factor environment spec for C to w as indented JSON:
+// an object with a "variables" array of Parameters.
+//
+// Example output:
+//
+//	{
+//	  "variables": [
+//	    {"name": "PORT", "field": "Port", "type": "integer", "required": false, "default": "8080"}
+//	  ]
+//	}
+func WriteEnvSpec[C any](w io.Writer) error {
+	params := DescribeParameters[C]()
+	if params == nil {
+		params = []Parameter{}
+	}
+	return writeIndentedJSON(w, map[string]any{"variables": params})
+}
+
+// openAPIParameter is an OpenAPI 3 Parameter Object.
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required"`
+	Schema      map[string]any `json:"schema"`
+	Sensitive   bool           `json:"x-sensitive,omitempty"`
+	Field       string         `json:"x-go-field"`
+}
+
+// WriteOpenAPIParameters writes the parameters of C to w as an OpenAPI 3 document fragment,
+// with one entry per field under components.parameters keyed by parameter name.
+// OpenAPI has no location for environment variables, so each parameter uses "in": "query";
+// the Go field name and sensitivity are carried in the x-go-field and x-sensitive extensions.
+func WriteOpenAPIParameters[C any](w io.Writer) error {
+	parameters := make(map[string]openAPIParameter)
+	for _, p := range DescribeParameters[C]() {
+		s := map[string]any{"type": p.Type}
+		if p.Format != "" {
+			s["format"] = p.Format
+		}
+		if p.Default != "" {
+			s["default"] = typedDefault(p)
+		}
+		parameters[p.Name] = openAPIParameter{
+			Name:        p.Name,
+			In:          "query",
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      s,
+			Sensitive:   p.Sensitive,
+			Field:       p.Field,
+		}
+	}
+	return writeIndentedJSON(w, map[string]any{
+		"openapi":    "3.0.3",
+		"components": map[string]any{"parameters": parameters},
+	})
+}
+
+// typedDefault returns the default of a boolean or numeric parameter as a JSON value of that
+// type, so it matches the parameter schema. Other defaults are returned as strings.
+func typedDefault(p Parameter) any {
+	if p.Type == "boolean" || p.Type == "integer" || p.Type == "number" {
+		var v any
+		if err := json.Unmarshal([]byte(p.Default), &v); err == nil {
+			return v
+		}
+	}
+	return p.Default
+}
+
+func writeIndentedJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
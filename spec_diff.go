@@ -0,0 +1,162 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies a SpecChange.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"    // Parameter only in the new spec
+	ChangeRemoved  ChangeKind = "removed"  // Parameter only in the old spec
+	ChangeRenamed  ChangeKind = "renamed"  // Same Go field under a new parameter name
+	ChangeModified ChangeKind = "modified" // Same parameter with a different type, default, validation or sensitivity
+)
+
+// SpecChange describes a difference between two versions of a configuration spec.
+type SpecChange struct {
+	Kind    ChangeKind
+	Name    string   // Parameter name in the new spec (the old spec for removals)
+	OldName string   // Previous name, for renames
+	Details []string // Human-readable attribute changes, e.g. `default: "8080" -> "9090"`
+}
+
+// String returns a one-line summary of the change.
+func (c SpecChange) String() string {
+	var s string
+	switch c.Kind {
+	case ChangeRenamed:
+		s = fmt.Sprintf("renamed %s to %s", c.OldName, c.Name)
+	default:
+		s = fmt.Sprintf("%s %s", c.Kind, c.Name)
+	}
+	if len(c.Details) > 0 {
+		s += " (" + strings.Join(c.Details, "; ") + ")"
+	}
+	return s
+}
+
+// ReadEnvSpec reads an environment spec written by WriteEnvSpec.
+func ReadEnvSpec(r io.Reader) ([]Parameter, error) {
+	var spec struct {
+		Variables []Parameter `json:"variables"`
+	}
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("read env spec: %w", err)
+	}
+	return spec.Variables, nil
+}
+
+// DiffParameters compares two versions of a configuration spec, such as the output of
+// DescribeParameters for the previous release (read back with ReadEnvSpec) and the current code.
+//
+// Parameters are matched by name. An unmatched parameter whose Go field is still present
+// under another name is reported as renamed; the rest are added or removed. Matched and
+// renamed parameters also report changes to type, format, required, default, validation
+// rules and sensitivity. Description changes are ignored. Changes are ordered by kind
+// (added, removed, renamed, modified) and then by name.
+func DiffParameters(old, current []Parameter) []SpecChange {
+	oldByName := make(map[string]Parameter, len(old))
+	for _, p := range old {
+		oldByName[p.Name] = p
+	}
+	currentByName := make(map[string]Parameter, len(current))
+	for _, p := range current {
+		currentByName[p.Name] = p
+	}
+
+	// Index unmatched old parameters by field to detect renames
+	removedByField := make(map[string]Parameter)
+	for _, p := range old {
+		if _, ok := currentByName[p.Name]; !ok {
+			removedByField[p.Field] = p
+		}
+	}
+
+	var changes []SpecChange
+	for _, p := range current {
+		if prev, ok := oldByName[p.Name]; ok {
+			if details := parameterDetails(prev, p); len(details) > 0 {
+				changes = append(changes, SpecChange{Kind: ChangeModified, Name: p.Name, Details: details})
+			}
+			continue
+		}
+		if prev, ok := removedByField[p.Field]; ok && p.Field != "" {
+			delete(removedByField, p.Field)
+			changes = append(changes, SpecChange{Kind: ChangeRenamed, Name: p.Name, OldName: prev.Name, Details: parameterDetails(prev, p)})
+			continue
+		}
+		changes = append(changes, SpecChange{Kind: ChangeAdded, Name: p.Name})
+	}
+	for _, p := range removedByField {
+		changes = append(changes, SpecChange{Kind: ChangeRemoved, Name: p.Name})
+	}
+
+	order := map[ChangeKind]int{ChangeAdded: 0, ChangeRemoved: 1, ChangeRenamed: 2, ChangeModified: 3}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return order[changes[i].Kind] < order[changes[j].Kind]
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// parameterDetails lists the attribute differences between two versions of a parameter.
+func parameterDetails(old, current Parameter) []string {
+	var details []string
+	diff := func(attr string, before, after any) {
+		if before != after {
+			details = append(details, fmt.Sprintf("%s: %q -> %q", attr, fmt.Sprint(before), fmt.Sprint(after)))
+		}
+	}
+	diff("type", old.Type, current.Type)
+	diff("format", old.Format, current.Format)
+	diff("required", old.Required, current.Required)
+	diff("default", old.Default, current.Default)
+	diff("validate", old.Validate, current.Validate)
+	diff("sensitive", old.Sensitive, current.Sensitive)
+	return details
+}
+
+// WriteReleaseNotes writes changes to w as a Markdown list grouped by kind, suitable for
+// pasting into release notes. Nothing is written if there are no changes.
+//
+// Example output:
+//
+//	### Configuration changes
+//
+//	- Added `CACHE_TTL`
+//	- Renamed `DB_HOST` to `DATABASE_HOST`
+//	- Changed `PORT`: default: "8080" -> "9090"
+func WriteReleaseNotes(w io.Writer, changes []SpecChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("### Configuration changes\n\n")
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeAdded:
+			fmt.Fprintf(&b, "- Added `%s`", c.Name)
+		case ChangeRemoved:
+			fmt.Fprintf(&b, "- Removed `%s`", c.Name)
+		case ChangeRenamed:
+			fmt.Fprintf(&b, "- Renamed `%s` to `%s`", c.OldName, c.Name)
+		default:
+			fmt.Fprintf(&b, "- Changed `%s`", c.Name)
+		}
+		if len(c.Details) > 0 {
+			b.WriteString(": " + strings.Join(c.Details, ", "))
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
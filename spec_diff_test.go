@@ -0,0 +1,85 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffParameters(t *testing.T) {
+	old := []Parameter{
+		{Name: "PORT", Field: "Port", Type: "integer", Default: "8080"},
+		{Name: "DB_HOST", Field: "DBHost", Type: "string", Required: true},
+		{Name: "LEGACY", Field: "Legacy", Type: "string"},
+		{Name: "API_KEY", Field: "APIKey", Type: "string", Description: "old text"},
+	}
+	current := []Parameter{
+		{Name: "PORT", Field: "Port", Type: "integer", Default: "9090", Validate: "min=1"},
+		{Name: "DATABASE_HOST", Field: "DBHost", Type: "string", Required: true},
+		{Name: "CACHE_TTL", Field: "CacheTTL", Type: "string", Format: "duration"},
+		{Name: "API_KEY", Field: "APIKey", Type: "string", Description: "new text", Sensitive: true},
+	}
+
+	want := []SpecChange{
+		{Kind: ChangeAdded, Name: "CACHE_TTL"},
+		{Kind: ChangeRemoved, Name: "LEGACY"},
+		{Kind: ChangeRenamed, Name: "DATABASE_HOST", OldName: "DB_HOST"},
+		{Kind: ChangeModified, Name: "API_KEY", Details: []string{`sensitive: "false" -> "true"`}},
+		{Kind: ChangeModified, Name: "PORT", Details: []string{`default: "8080" -> "9090"`, `validate: "" -> "min=1"`}},
+	}
+	if got := DiffParameters(old, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected changes:\n got %+v\nwant %+v", got, want)
+	}
+	if got := DiffParameters(current, current); len(got) != 0 {
+		t.Errorf("expected no changes between identical specs, got %+v", got)
+	}
+}
+
+func TestReadEnvSpec_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEnvSpec[specTestConfig](&buf); err != nil {
+		t.Fatalf("WriteEnvSpec failed: %v", err)
+	}
+	params, err := ReadEnvSpec(&buf)
+	if err != nil {
+		t.Fatalf("ReadEnvSpec failed: %v", err)
+	}
+	if !reflect.DeepEqual(params, DescribeParameters[specTestConfig]()) {
+		t.Errorf("round trip changed parameters: %+v", params)
+	}
+	if _, err := ReadEnvSpec(strings.NewReader("{")); err == nil {
+		t.Error("expected error for invalid spec")
+	}
+}
+
+func TestWriteReleaseNotes(t *testing.T) {
+	changes := []SpecChange{
+		{Kind: ChangeAdded, Name: "CACHE_TTL"},
+		{Kind: ChangeRenamed, Name: "DATABASE_HOST", OldName: "DB_HOST"},
+		{Kind: ChangeModified, Name: "PORT", Details: []string{`default: "8080" -> "9090"`}},
+	}
+	var buf bytes.Buffer
+	if err := WriteReleaseNotes(&buf, changes); err != nil {
+		t.Fatalf("WriteReleaseNotes failed: %v", err)
+	}
+	want := "### Configuration changes\n\n" +
+		"- Added `CACHE_TTL`\n" +
+		"- Renamed `DB_HOST` to `DATABASE_HOST`\n" +
+		"- Changed `PORT`: default: \"8080\" -> \"9090\"\n"
+	if buf.String() != want {
+		t.Errorf("unexpected notes:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteReleaseNotes(&buf, nil); err != nil || buf.Len() != 0 {
+		t.Errorf("expected no output without changes, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestSpecChange_String(t *testing.T) {
+	c := SpecChange{Kind: ChangeRenamed, Name: "B", OldName: "A", Details: []string{`default: "1" -> "2"`}}
+	if got := c.String(); got != `renamed A to B (default: "1" -> "2")` {
+		t.Errorf("unexpected string: %s", got)
+	}
+}
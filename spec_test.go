@@ -0,0 +1,85 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type specTestConfig struct {
+	Port    int           `env:"PORT" envDefault:"8080" description:"HTTP listen port"`
+	APIKey  string        `env:"API_KEY,required" config:"sensitive"`
+	Region  string        `ssm:"region" default:"eu-west-1" validate:"required,oneof=eu-west-1 us-east-1"`
+	Verbose bool          `clap:"--verbose"`
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+	Hosts   []string      `env:"HOSTS" validate:"required_if=Verbose true"`
+	ignored string
+}
+
+func TestDescribeParameters(t *testing.T) {
+	want := []Parameter{
+		{Name: "PORT", Field: "Port", Type: "integer", Default: "8080", Description: "HTTP listen port"},
+		{Name: "API_KEY", Field: "APIKey", Type: "string", Required: true, Sensitive: true},
+		{Name: "Region", Field: "Region", Type: "string", Required: true, Default: "eu-west-1", Validate: "required,oneof=eu-west-1 us-east-1"},
+		{Name: "verbose", Field: "Verbose", Type: "boolean"},
+		{Name: "TIMEOUT", Field: "Timeout", Type: "string", Format: "duration", Default: "5s"},
+		{Name: "HOSTS", Field: "Hosts", Type: "array", Validate: "required_if=Verbose true"},
+	}
+	if got := DescribeParameters[specTestConfig](); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected parameters:\n got %+v\nwant %+v", got, want)
+	}
+	if got := DescribeParameters[string](); got != nil {
+		t.Errorf("expected nil for non-struct type, got %+v", got)
+	}
+}
+
+func TestWriteEnvSpec(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEnvSpec[specTestConfig](&buf); err != nil {
+		t.Fatalf("WriteEnvSpec failed: %v", err)
+	}
+
+	var spec struct {
+		Variables []Parameter `json:"variables"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(spec.Variables) != 6 || spec.Variables[1].Name != "API_KEY" || !spec.Variables[1].Required {
+		t.Errorf("unexpected variables: %+v", spec.Variables)
+	}
+}
+
+func TestWriteOpenAPIParameters(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteOpenAPIParameters[specTestConfig](&buf); err != nil {
+		t.Fatalf("WriteOpenAPIParameters failed: %v", err)
+	}
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Parameters map[string]map[string]any `json:"parameters"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.OpenAPI == "" || len(doc.Components.Parameters) != 6 {
+		t.Fatalf("unexpected document: %s", buf.String())
+	}
+
+	port := doc.Components.Parameters["PORT"]
+	schema, _ := port["schema"].(map[string]any)
+	if port["in"] != "query" || port["description"] != "HTTP listen port" || schema["default"] != float64(8080) {
+		t.Errorf("unexpected PORT parameter: %v", port)
+	}
+	if apiKey := doc.Components.Parameters["API_KEY"]; apiKey["required"] != true || apiKey["x-sensitive"] != true {
+		t.Errorf("unexpected API_KEY parameter: %v", apiKey)
+	}
+	if timeout := doc.Components.Parameters["TIMEOUT"]["schema"].(map[string]any); timeout["format"] != "duration" || timeout["default"] != "5s" {
+		t.Errorf("unexpected TIMEOUT schema: %v", timeout)
+	}
+}
@@ -4,10 +4,35 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/gymshark/go-easy-config/utils"
 )
 
-// Variable reference pattern: ${VAR_NAME} where VAR_NAME contains alphanumeric, underscore, or hyphen
-var variableReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z0-9_-]+)\}`)
+// configTagOptions lists the config tag options understood alongside availableAs.
+// A config tag made up solely of these options does not declare a variable.
+var configTagOptions = map[string]bool{
+	"sensitive": true,
+	"path":      true,
+	"key":       true,
+	"if":        true,
+}
+
+// hasOnlyConfigTagOptions reports whether a config tag consists solely of known options,
+// such as `config:"sensitive"`, and therefore carries no availableAs declaration.
+func hasOnlyConfigTagOptions(tag string) bool {
+	found := false
+	for _, part := range strings.Split(tag, ",") {
+		key, _, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if key == "" {
+			continue
+		}
+		if !configTagOptions[key] {
+			return false
+		}
+		found = true
+	}
+	return found
+}
 
 // ParseConfigTag extracts the availableAs value from a config struct tag.
 // Returns the variable name and nil error if found, or empty string and TagParseError if not found or malformed.
@@ -84,7 +109,7 @@ func ParseConfigTag(tag string) (string, error) {
 //	FindVariableReferences("${VAR1}/${VAR2}") returns []string{"VAR1", "VAR2"}
 //	FindVariableReferences("${VAR}${VAR}") returns []string{"VAR", "VAR"}
 func FindVariableReferences(s string) []string {
-	matches := variableReferenceRegex.FindAllStringSubmatch(s, -1)
+	matches := utils.VariableReference.FindAllStringSubmatch(s, -1)
 	if len(matches) == 0 {
 		return nil
 	}
@@ -110,7 +135,7 @@ func FindVariableReferences(s string) []string {
 func InterpolateString(s string, context map[string]string) (string, error) {
 	var missingVars []string
 
-	result := variableReferenceRegex.ReplaceAllStringFunc(s, func(match string) string {
+	result := utils.VariableReference.ReplaceAllStringFunc(s, func(match string) string {
 		// Extract variable name from ${VAR}
 		varName := match[2 : len(match)-1]
 
@@ -0,0 +1,161 @@
+package config
+
+import (
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// templateFilePrefix marks a Template or HTMLTemplate value as a file path.
+const templateFilePrefix = "file:"
+
+// Template is a text/template field parsed as it loads, for services whose messages are
+// configuration. The value is either the template itself or "file:" followed by the path of
+// a file holding it, e.g. "file:templates/welcome.tmpl". File paths have a leading ~ and
+// environment variables expanded and are resolved against the working directory. The file
+// is read on every load, so a reload picks up edits to it.
+//
+// A template that does not parse, or a file that cannot be read, fails the load with a
+// *ValidationError whose Rule is "template" and whose Value is the quoted source. Handler.Load
+// sets its FieldName to the path of the field. Templates are parsed without custom functions.
+// Parameter specs report Template as a string with format "template".
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    WelcomeEmail config.Template `env:"WELCOME_EMAIL" envDefault:"file:templates/welcome.tmpl"`
+//	}
+//
+//	err := cfg.WelcomeEmail.Execute(w, user)
+type Template struct {
+	*template.Template
+	source string // Value the template was loaded from
+	failed bool   // Whether source failed to load
+}
+
+// HTMLTemplate is an html/template field, loaded and reported like Template.
+type HTMLTemplate struct {
+	*htmltemplate.Template
+	source string // Value the template was loaded from
+	failed bool   // Whether source failed to load
+}
+
+// readTemplateSource returns the name and text of a template value, reading it from a file
+// for "file:" values.
+func readTemplateSource(source string) (string, string, error) {
+	path, ok := strings.CutPrefix(source, templateFilePrefix)
+	if !ok {
+		return "inline", source, nil
+	}
+	expanded, err := ExpandPath(path, "")
+	if err != nil {
+		return "", "", err
+	}
+	data, err := os.ReadFile(expanded)
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Base(expanded), string(data), nil
+}
+
+// templateError returns the ValidationError for a template that failed to load.
+func templateError(source string, err error) error {
+	return &ValidationError{FieldName: "<unknown>", Rule: "template", Value: strconv.Quote(source), Err: err}
+}
+
+// IsZero reports whether no template is configured.
+func (t Template) IsZero() bool {
+	return t.Template == nil
+}
+
+// String returns the value the template was loaded from, e.g. "file:templates/welcome.tmpl".
+func (t Template) String() string {
+	return t.source
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Template) MarshalText() ([]byte, error) {
+	return []byte(t.source), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the template.
+func (t *Template) UnmarshalText(text []byte) error {
+	source := string(text)
+	if source == "" {
+		*t = Template{}
+		return nil
+	}
+	name, body, err := readTemplateSource(source)
+	if err == nil {
+		var tmpl *template.Template
+		if tmpl, err = template.New(name).Parse(body); err == nil {
+			*t = Template{Template: tmpl, source: source}
+			return nil
+		}
+	}
+	*t = Template{source: source, failed: true}
+	return templateError(source, err)
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (Template) ParameterFormat() string {
+	return "template"
+}
+
+// failedParse implements parseFailure.
+func (t Template) failedParse() (string, string) {
+	if !t.failed {
+		return "template", ""
+	}
+	return "template", t.source
+}
+
+// IsZero reports whether no template is configured.
+func (t HTMLTemplate) IsZero() bool {
+	return t.Template == nil
+}
+
+// String returns the value the template was loaded from, e.g. "file:templates/page.html".
+func (t HTMLTemplate) String() string {
+	return t.source
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t HTMLTemplate) MarshalText() ([]byte, error) {
+	return []byte(t.source), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the template.
+func (t *HTMLTemplate) UnmarshalText(text []byte) error {
+	source := string(text)
+	if source == "" {
+		*t = HTMLTemplate{}
+		return nil
+	}
+	name, body, err := readTemplateSource(source)
+	if err == nil {
+		var tmpl *htmltemplate.Template
+		if tmpl, err = htmltemplate.New(name).Parse(body); err == nil {
+			*t = HTMLTemplate{Template: tmpl, source: source}
+			return nil
+		}
+	}
+	*t = HTMLTemplate{source: source, failed: true}
+	return templateError(source, err)
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (HTMLTemplate) ParameterFormat() string {
+	return "html-template"
+}
+
+// failedParse implements parseFailure.
+func (t HTMLTemplate) failedParse() (string, string) {
+	if !t.failed {
+		return "template", ""
+	}
+	return "template", t.source
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type templateTestConfig struct {
+	Welcome Template     `env:"WELCOME" json:"welcome" yaml:"welcome"`
+	Page    HTMLTemplate `env:"PAGE" json:"page" yaml:"page"`
+}
+
+func TestTemplate_Loaders(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.html")
+	if err := os.WriteFile(path, []byte("<p>{{.}}</p>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("WELCOME", "Hello {{.}}!")
+	t.Setenv("PAGE", "file:"+path)
+	var cfg templateTestConfig
+	if err := (&generic.EnvironmentLoader[templateTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+
+	var out strings.Builder
+	if err := cfg.Welcome.Execute(&out, "Ada"); err != nil || out.String() != "Hello Ada!" {
+		t.Errorf("Welcome = %q, %v", out.String(), err)
+	}
+	out.Reset()
+	if err := cfg.Page.Execute(&out, "<b>"); err != nil || out.String() != "<p>&lt;b&gt;</p>" {
+		t.Errorf("Page = %q, %v", out.String(), err)
+	}
+	if cfg.Page.String() != "file:"+path || cfg.Page.Name() != "page.html" {
+		t.Errorf("Page source = %q, name = %q", cfg.Page.String(), cfg.Page.Name())
+	}
+
+	// Reloading reads the file again
+	if err := os.WriteFile(path, []byte("<h1>{{.}}</h1>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&generic.EnvironmentLoader[templateTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader reload error = %v", err)
+	}
+	out.Reset()
+	if err := cfg.Page.Execute(&out, "x"); err != nil || out.String() != "<h1>x</h1>" {
+		t.Errorf("reloaded Page = %q, %v", out.String(), err)
+	}
+
+	var fromJSON templateTestConfig
+	if err := (&generic.JSONLoader[templateTestConfig]{Source: []byte(`{"welcome": "Hi {{.}}"}`)}).Load(&fromJSON); err != nil {
+		t.Fatalf("JSONLoader error = %v", err)
+	}
+	if fromJSON.Welcome.IsZero() || !fromJSON.Page.IsZero() {
+		t.Errorf("loaded = %+v", fromJSON)
+	}
+	data, err := json.Marshal(fromJSON)
+	if err != nil || string(data) != `{"welcome":"Hi {{.}}","page":""}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
+
+func TestTemplate_InvalidTemplate(t *testing.T) {
+	tests := map[string]struct {
+		loader Loader[templateTestConfig]
+		field  string
+	}{
+		"env parse":    {&generic.EnvironmentLoader[templateTestConfig]{}, "Welcome"},
+		"json missing": {&generic.JSONLoader[templateTestConfig]{Source: []byte(`{"page": "file:/nonexistent/page.html"}`)}, "Page"},
+		"yaml parse":   {&generic.YAMLLoader[templateTestConfig]{Source: []byte("page: \"{{if}}\"\n")}, "Page"},
+	}
+	t.Setenv("WELCOME", "Hello {{.Name")
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := NewConfigHandler[templateTestConfig](WithLoaders(tt.loader))
+			var cfg templateTestConfig
+			err := handler.Load(&cfg)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.FieldName != tt.field || validationErr.Rule != "template" || validationErr.Value == "" {
+				t.Errorf("ValidationError = %+v", validationErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type togglesTestConfig struct {
+	Features DynamicToggles `env:"FEATURES" json:"features" yaml:"features"`
+}
+
+func TestToggles_EnabledFor(t *testing.T) {
+	toggles := Toggles{
+		"quarter": {Percentage: 25},
+		"beta":    {Percentage: 0, IDs: []string{"tenant-42"}},
+		"full":    {Percentage: 100},
+	}
+
+	var enabled int
+	for i := 0; i < 10000; i++ {
+		id := fmt.Sprintf("user-%d", i)
+		if toggles.EnabledFor("quarter", id) {
+			enabled++
+		}
+		if toggles.EnabledFor("quarter", id) != toggles.EnabledFor("quarter", id) {
+			t.Fatalf("EnabledFor(quarter, %s) is not deterministic", id)
+		}
+	}
+	if enabled < 2300 || enabled > 2700 {
+		t.Errorf("quarter enabled for %d of 10000 IDs, want about 2500", enabled)
+	}
+
+	if !toggles.EnabledFor("beta", "tenant-42") || toggles.EnabledFor("beta", "tenant-7") {
+		t.Error("beta should be enabled only for its listed ID")
+	}
+	if !toggles.EnabledFor("full", "anyone") || !toggles.Enabled("full") || toggles.Enabled("quarter") {
+		t.Error("only full should be fully enabled")
+	}
+	if toggles.EnabledFor("missing", "anyone") || toggles.Enabled("missing") {
+		t.Error("unknown toggles should be disabled")
+	}
+}
+
+func TestToggles_RaisingPercentageOnlyAddsIDs(t *testing.T) {
+	low := Toggles{"rollout": {Percentage: 10}}
+	high := Toggles{"rollout": {Percentage: 50}}
+	for i := 0; i < 2000; i++ {
+		id := fmt.Sprintf("user-%d", i)
+		if low.EnabledFor("rollout", id) && !high.EnabledFor("rollout", id) {
+			t.Fatalf("%s enabled at 10%% but not at 50%%", id)
+		}
+	}
+}
+
+func TestDynamicToggles_UnmarshalText(t *testing.T) {
+	var d DynamicToggles
+	if err := d.UnmarshalText([]byte("checkout=25%, dark-mode ,search=off,api=on,ratio=12.5")); err != nil {
+		t.Fatalf("UnmarshalText() error = %v", err)
+	}
+	want := map[string]float64{"checkout": 25, "dark-mode": 100, "search": 0, "api": 100, "ratio": 12.5}
+	for name, percentage := range want {
+		if got := d.Toggles()[name].Percentage; got != percentage {
+			t.Errorf("%s = %v%%, want %v%%", name, got, percentage)
+		}
+	}
+	if got := d.String(); got != "api=100%,checkout=25%,dark-mode=100%,ratio=12.5%,search=0%" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, text := range []string{"checkout=150%", "checkout=-1", "checkout=half"} {
+		if err := d.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) expected error", text)
+		}
+	}
+}
+
+func TestDynamicToggles_Sources(t *testing.T) {
+	tests := []struct {
+		name   string
+		loader interface {
+			Load(*togglesTestConfig) error
+		}
+	}{
+		{"yaml", &generic.YAMLLoader[togglesTestConfig]{Source: []byte("features:\n  checkout: {percentage: 25}\n  beta: {percentage: 0, ids: [tenant-42]}\n")}},
+		{"json", &generic.JSONLoader[togglesTestConfig]{Source: []byte(`{"features": {"checkout": {"percentage": 25}, "beta": {"percentage": 0, "ids": ["tenant-42"]}}}`)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg togglesTestConfig
+			if err := tt.loader.Load(&cfg); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Features.Toggles()["checkout"].Percentage != 25 || !cfg.Features.EnabledFor("beta", "tenant-42") {
+				t.Errorf("toggles = %+v", cfg.Features.Toggles())
+			}
+		})
+	}
+
+	var cfg togglesTestConfig
+	err := (&generic.JSONLoader[togglesTestConfig]{Source: []byte(`{"features": {"checkout": {"percentage": 101}}}`)}).Load(&cfg)
+	if err == nil {
+		t.Error("Load() expected error for a percentage above 100")
+	}
+}
+
+func TestDynamicToggles_ReloadUpdatesToggleSet(t *testing.T) {
+	t.Setenv("FEATURES", "checkout=10%")
+	handler := NewConfigHandler[togglesTestConfig](WithLoaders[togglesTestConfig](&generic.EnvironmentLoader[togglesTestConfig]{}))
+
+	var cfg togglesTestConfig
+	var features ToggleSet
+	cfg.Features.Bind(&features)
+
+	if err := handler.LoadAndValidate(&cfg); err != nil {
+		t.Fatalf("LoadAndValidate() error = %v", err)
+	}
+	if got := features.Toggles()["checkout"].Percentage; got != 10 {
+		t.Errorf("checkout = %v%% after load, want 10%%", got)
+	}
+
+	if err := handler.ApplyOverride(&cfg, "FEATURES", "checkout=on"); err != nil {
+		t.Fatalf("ApplyOverride() error = %v", err)
+	}
+	if !features.Enabled("checkout") || !features.EnabledFor("checkout", "user-1") {
+		t.Error("override did not reach the ToggleSet")
+	}
+
+	t.Setenv("FEATURES", "checkout=off")
+	if err := handler.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if features.EnabledFor("checkout", "user-1") {
+		t.Error("reload did not reach the ToggleSet")
+	}
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// URLSet is a list of URL patterns for allowlists and denylists, such as permitted webhook
+// targets or redirect destinations. It loads from a comma-separated list (environment
+// variables, flags, overrides) or an array (JSON, YAML), and every entry is validated as it
+// loads.
+//
+// Each entry is a host or a URL, and the host may start with "*." to match subdomains:
+//
+//	example.com                     http or https on the default port, any path
+//	*.example.com                   any subdomain of example.com, but not example.com itself
+//	https://hooks.example.com/v1    https only, paths /v1 and /v1/...
+//	http://localhost:8080           http on port 8080 only
+//
+// Schemes and hosts are compared case-insensitively, default ports are implied, and paths
+// are cleaned before matching, so "/v1/../admin" does not match "/v1".
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    WebhookAllow config.URLSet `env:"WEBHOOK_ALLOW" yaml:"webhookAllow"`
+//	    WebhookDeny  config.URLSet `env:"WEBHOOK_DENY" yaml:"webhookDeny"`
+//	}
+//
+//	if !cfg.WebhookAllow.Match(target) || cfg.WebhookDeny.Match(target) {
+//	    return errors.New("webhook target not allowed")
+//	}
+type URLSet struct {
+	patterns []urlPattern
+}
+
+// urlPattern is a normalised URLSet entry.
+type urlPattern struct {
+	scheme   string // Empty for http or https
+	host     string // Lower case, without a "*." prefix or trailing dot
+	wildcard bool   // Match subdomains of host
+	port     string // Empty for the scheme's default port
+	path     string // Cleaned path prefix, empty for any path
+}
+
+// NewURLSet returns a URLSet of the given entries, or an error naming the first invalid one.
+func NewURLSet(entries ...string) (URLSet, error) {
+	var set URLSet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		p, err := parseURLPattern(entry)
+		if err != nil {
+			return URLSet{}, err
+		}
+		set.patterns = append(set.patterns, p)
+	}
+	return set, nil
+}
+
+// ParseURLSet parses a comma-separated list of entries.
+func ParseURLSet(s string) (URLSet, error) {
+	return NewURLSet(strings.Split(s, ",")...)
+}
+
+// parseURLPattern parses and normalises one entry.
+func parseURLPattern(entry string) (urlPattern, error) {
+	raw := entry
+	if !strings.Contains(raw, "://") {
+		raw = "//" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return urlPattern{}, fmt.Errorf("invalid URL set entry %q: %w", entry, err)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return urlPattern{}, fmt.Errorf("invalid URL set entry %q: user info, query and fragment are not allowed", entry)
+	}
+
+	p := urlPattern{scheme: strings.ToLower(u.Scheme), port: u.Port()}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if rest, ok := strings.CutPrefix(host, "*."); ok {
+		p.wildcard = true
+		host = rest
+	}
+	if host == "" || strings.Contains(host, "*") {
+		return urlPattern{}, fmt.Errorf("invalid URL set entry %q: want a host, optionally starting with \"*.\"", entry)
+	}
+	p.host = host
+	if p.port == defaultPort(p.scheme) {
+		p.port = ""
+	}
+	if u.Path != "" && u.Path != "/" {
+		p.path = path.Clean(u.Path)
+	}
+	return p, nil
+}
+
+// defaultPort returns the default port of a scheme, or "" when it has none.
+func defaultPort(scheme string) string {
+	switch scheme {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	}
+	return ""
+}
+
+// Match reports whether rawURL matches any entry. URLs that do not parse, or have no host,
+// never match.
+func (s URLSet) Match(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return s.MatchURL(u)
+}
+
+// MatchURL reports whether u matches any entry.
+func (s URLSet) MatchURL(u *url.URL) bool {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return false
+	}
+	port := u.Port()
+	if port == defaultPort(scheme) {
+		port = ""
+	}
+	cleaned := path.Clean("/" + u.Path)
+
+	for _, p := range s.patterns {
+		if p.matches(scheme, host, port, cleaned) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the normalised parts of a URL match the pattern.
+func (p urlPattern) matches(scheme, host, port, cleanPath string) bool {
+	if p.scheme == "" {
+		if scheme != "http" && scheme != "https" {
+			return false
+		}
+	} else if scheme != p.scheme {
+		return false
+	}
+	if p.wildcard {
+		if !strings.HasSuffix(host, "."+p.host) {
+			return false
+		}
+	} else if host != p.host {
+		return false
+	}
+	if port != p.port {
+		return false
+	}
+	return p.path == "" || cleanPath == p.path || strings.HasPrefix(cleanPath, p.path+"/")
+}
+
+// Len returns the number of entries.
+func (s URLSet) Len() int {
+	return len(s.patterns)
+}
+
+// IsZero reports whether the set has no entries.
+func (s URLSet) IsZero() bool {
+	return len(s.patterns) == 0
+}
+
+// Entries returns the normalised entries.
+func (s URLSet) Entries() []string {
+	entries := make([]string, len(s.patterns))
+	for i, p := range s.patterns {
+		entries[i] = p.String()
+	}
+	return entries
+}
+
+// String returns the entry in its normalised form.
+func (p urlPattern) String() string {
+	host := p.host
+	if p.wildcard {
+		host = "*." + host
+	}
+	if p.port != "" {
+		host += ":" + p.port
+	}
+	if p.scheme == "" {
+		return host + p.path
+	}
+	return p.scheme + "://" + host + p.path
+}
+
+// String returns the normalised entries, comma-separated.
+func (s URLSet) String() string {
+	return strings.Join(s.Entries(), ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s URLSet) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for comma-separated entries.
+func (s *URLSet) UnmarshalText(text []byte) error {
+	parsed, err := ParseURLSet(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts an array of entries or a comma-separated string.
+func (s *URLSet) UnmarshalJSON(data []byte) error {
+	var entries []string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("URL set must be an array or a comma-separated string")
+		}
+		return s.UnmarshalText([]byte(text))
+	}
+	parsed, err := NewURLSet(entries...)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// MarshalJSON encodes the normalised entries as an array.
+func (s URLSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Entries())
+}
+
+// UnmarshalYAML accepts a sequence of entries or a comma-separated string. It uses the
+// yaml.v2-style signature, which gopkg.in/yaml.v3 also supports.
+func (s *URLSet) UnmarshalYAML(unmarshal func(any) error) error {
+	var entries []string
+	if err := unmarshal(&entries); err != nil {
+		var text string
+		if err := unmarshal(&text); err != nil {
+			return fmt.Errorf("URL set must be a sequence or a comma-separated string")
+		}
+		return s.UnmarshalText([]byte(text))
+	}
+	parsed, err := NewURLSet(entries...)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// ParameterFormat implements ParameterFormatter.
+func (URLSet) ParameterFormat() string {
+	return "url-set"
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+func TestURLSet_Match(t *testing.T) {
+	set, err := NewURLSet("example.com", "*.partner.io", "https://hooks.example.org/v1", "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("NewURLSet() error = %v", err)
+	}
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com/anything", true},
+		{"http://EXAMPLE.com./", true},
+		{"https://example.com:443/x", true},
+		{"https://example.com:8443/x", false},
+		{"ftp://example.com/", false},
+		{"https://sub.example.com/", false},
+		{"https://a.partner.io/hook", true},
+		{"https://a.b.partner.io/hook", true},
+		{"https://partner.io/hook", false},
+		{"https://evilpartner.io/hook", false},
+		{"https://hooks.example.org/v1", true},
+		{"https://hooks.example.org/v1/orders", true},
+		{"https://hooks.example.org/v10", false},
+		{"https://hooks.example.org/v1/../admin", false},
+		{"http://hooks.example.org/v1", false},
+		{"http://localhost:8080/callback", true},
+		{"http://localhost/callback", false},
+		{"https://example.com@evil.com/", false},
+		{"/relative/path", false},
+		{"://bad", false},
+	}
+	for _, tt := range tests {
+		if got := set.Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestURLSet_Parse(t *testing.T) {
+	set, err := ParseURLSet(" Example.COM , HTTPS://*.Partner.io:443/Hooks/ ,, localhost:3000/cb")
+	if err != nil {
+		t.Fatalf("ParseURLSet() error = %v", err)
+	}
+	if got := set.String(); got != "example.com,https://*.partner.io/Hooks,localhost:3000/cb" {
+		t.Errorf("String() = %q", got)
+	}
+	if roundTrip, err := ParseURLSet(set.String()); err != nil || roundTrip.String() != set.String() {
+		t.Errorf("round trip = %q, %v", roundTrip, err)
+	}
+
+	for _, entry := range []string{"https://", "*", "foo.*.com", "https://user@example.com", "https://example.com/?q=1", "https://example.com/#top", "http://[::1"} {
+		if _, err := ParseURLSet(entry); err == nil {
+			t.Errorf("ParseURLSet(%q) expected error", entry)
+		}
+	}
+}
+
+type urlSetTestConfig struct {
+	Allow URLSet `env:"ALLOW" json:"allow" yaml:"allow"`
+}
+
+func TestURLSet_Loaders(t *testing.T) {
+	t.Setenv("ALLOW", "example.com,*.partner.io")
+	var cfg urlSetTestConfig
+	if err := (&generic.EnvironmentLoader[urlSetTestConfig]{}).Load(&cfg); err != nil {
+		t.Fatalf("EnvironmentLoader error = %v", err)
+	}
+	if cfg.Allow.Len() != 2 {
+		t.Errorf("environment entries = %v", cfg.Allow.Entries())
+	}
+
+	sources := map[string]interface{ Load(*urlSetTestConfig) error }{
+		"yaml sequence": &generic.YAMLLoader[urlSetTestConfig]{Source: []byte("allow:\n  - example.com\n  - https://hooks.example.org/v1\n")},
+		"yaml string":   &generic.YAMLLoader[urlSetTestConfig]{Source: []byte("allow: example.com, https://hooks.example.org/v1\n")},
+		"json array":    &generic.JSONLoader[urlSetTestConfig]{Source: []byte(`{"allow": ["example.com", "https://hooks.example.org/v1"]}`)},
+		"json string":   &generic.JSONLoader[urlSetTestConfig]{Source: []byte(`{"allow": "example.com,https://hooks.example.org/v1"}`)},
+	}
+	for name, ldr := range sources {
+		t.Run(name, func(t *testing.T) {
+			var cfg urlSetTestConfig
+			if err := ldr.Load(&cfg); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !cfg.Allow.Match("https://hooks.example.org/v1/x") || cfg.Allow.Len() != 2 {
+				t.Errorf("entries = %v", cfg.Allow.Entries())
+			}
+		})
+	}
+
+	if err := (&generic.JSONLoader[urlSetTestConfig]{Source: []byte(`{"allow": ["https://"]}`)}).Load(&cfg); err == nil {
+		t.Error("JSONLoader expected error for an invalid entry")
+	}
+
+	data, err := json.Marshal(urlSetTestConfig{Allow: cfg.Allow})
+	if err != nil || string(data) != `{"allow":["example.com","*.partner.io"]}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DecodeFunc parses a string into a value of the type it is registered for.
+type DecodeFunc func(s string) (any, error)
+
+// Decoders maps Go types to functions decoding them from strings, so custom domain types
+// such as account IDs or SKUs are parsed the same way by every loader. Lookups that find
+// no decoder fall back to the parent registry, if any. A Decoders is safe for concurrent
+// use and the zero value is an empty registry without a parent.
+type Decoders struct {
+	mu     sync.RWMutex
+	funcs  map[reflect.Type]DecodeFunc
+	parent *Decoders
+}
+
+// DefaultDecoders is the process-wide registry, consulted by SetFromString and by every
+// registry created with NewDecoders(DefaultDecoders).
+var DefaultDecoders = &Decoders{}
+
+// NewDecoders returns an empty registry falling back to parent, which may be nil.
+func NewDecoders(parent *Decoders) *Decoders {
+	return &Decoders{parent: parent}
+}
+
+// RegisterDecoder registers fn as the decoder for T in d, replacing any decoder
+// registered for T before.
+func RegisterDecoder[T any](d *Decoders, fn func(s string) (T, error)) {
+	d.Register(reflect.TypeOf((*T)(nil)).Elem(), func(s string) (any, error) {
+		return fn(s)
+	})
+}
+
+// Register registers fn as the decoder for t, replacing any decoder registered for t
+// before. fn must return values assignable to t.
+func (d *Decoders) Register(t reflect.Type, fn DecodeFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.funcs == nil {
+		d.funcs = make(map[reflect.Type]DecodeFunc)
+	}
+	d.funcs[t] = fn
+}
+
+// Lookup returns the decoder for t from d or its parents.
+func (d *Decoders) Lookup(t reflect.Type) (DecodeFunc, bool) {
+	for ; d != nil; d = d.parent {
+		d.mu.RLock()
+		fn, ok := d.funcs[t]
+		d.mu.RUnlock()
+		if ok {
+			return fn, true
+		}
+	}
+	return nil, false
+}
+
+// Funcs returns every decoder of d and its parents, with those of d taking precedence,
+// or nil if there are none.
+func (d *Decoders) Funcs() map[reflect.Type]DecodeFunc {
+	if d == nil {
+		return nil
+	}
+	funcs := d.parent.Funcs()
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if funcs == nil && len(d.funcs) > 0 {
+		funcs = make(map[reflect.Type]DecodeFunc, len(d.funcs))
+	}
+	for t, fn := range d.funcs {
+		funcs[t] = fn
+	}
+	return funcs
+}
+
+// Decode sets v to s decoded with the decoder registered for the type of v, or for its
+// element type when v is a slice, from a comma-separated list. It reports whether a
+// decoder was found.
+func (d *Decoders) Decode(v reflect.Value, s string) (bool, error) {
+	if fn, ok := d.Lookup(v.Type()); ok {
+		return true, decodeInto(v, fn, s)
+	}
+	if v.Kind() != reflect.Slice {
+		return false, nil
+	}
+	fn, ok := d.Lookup(v.Type().Elem())
+	if !ok {
+		return false, nil
+	}
+	slice := reflect.MakeSlice(v.Type(), 0, 0)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := decodeInto(elem, fn, part); err != nil {
+			return true, err
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	v.Set(slice)
+	return true, nil
+}
+
+// SetFromString sets v from s with the decoder registered in d or its parents for the
+// type of v, falling back to the package-level SetFromString. A nil d uses
+// DefaultDecoders.
+func (d *Decoders) SetFromString(v reflect.Value, s string) error {
+	if d == nil {
+		d = DefaultDecoders
+	}
+	if ok, err := d.Decode(v, s); ok {
+		return err
+	}
+	return setFromString(v, s)
+}
+
+// decodeInto sets v to the result of fn for s.
+func decodeInto(v reflect.Value, fn DecodeFunc, s string) error {
+	value, err := fn(s)
+	if err != nil {
+		return err
+	}
+	decoded := reflect.ValueOf(value)
+	if !decoded.IsValid() || !decoded.Type().AssignableTo(v.Type()) {
+		return fmt.Errorf("decoder for %s returned %T", v.Type(), value)
+	}
+	v.Set(decoded)
+	return nil
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type decoderSKU string
+
+func parseSKU(s string) (decoderSKU, error) {
+	if !strings.HasPrefix(strings.ToUpper(s), "SKU-") {
+		return "", fmt.Errorf("invalid SKU %q", s)
+	}
+	return decoderSKU(strings.ToUpper(s)), nil
+}
+
+func TestDecoders_SetFromString(t *testing.T) {
+	parent := NewDecoders(nil)
+	RegisterDecoder(parent, parseSKU)
+	d := NewDecoders(parent)
+
+	var sku decoderSKU
+	if err := d.SetFromString(reflect.ValueOf(&sku).Elem(), "sku-12"); err != nil || sku != "SKU-12" {
+		t.Errorf("SetFromString() = %q, %v, want SKU-12 from the parent's decoder", sku, err)
+	}
+	var skus []decoderSKU
+	if err := d.SetFromString(reflect.ValueOf(&skus).Elem(), "sku-1, sku-2,"); err != nil || !reflect.DeepEqual(skus, []decoderSKU{"SKU-1", "SKU-2"}) {
+		t.Errorf("SetFromString() = %q, %v, want decoded slice elements", skus, err)
+	}
+	if err := d.SetFromString(reflect.ValueOf(&sku).Elem(), "12"); err == nil || !strings.Contains(err.Error(), "invalid SKU") {
+		t.Errorf("expected the decoder's error, got %v", err)
+	}
+
+	// The child's decoder takes precedence over the parent's
+	RegisterDecoder(d, func(s string) (decoderSKU, error) { return decoderSKU("child:" + s), nil })
+	if err := d.SetFromString(reflect.ValueOf(&sku).Elem(), "x"); err != nil || sku != "child:x" {
+		t.Errorf("SetFromString() = %q, %v, want the child's decoder", sku, err)
+	}
+	if funcs := d.Funcs(); len(funcs) != 1 {
+		t.Errorf("Funcs() has %d entries, want 1", len(funcs))
+	}
+
+	// Types without a decoder are parsed by kind
+	var port int
+	if err := d.SetFromString(reflect.ValueOf(&port).Elem(), "8080"); err != nil || port != 8080 {
+		t.Errorf("SetFromString() = %d, %v, want 8080", port, err)
+	}
+}
+
+func TestDecoders_WrongType(t *testing.T) {
+	d := NewDecoders(nil)
+	d.Register(reflect.TypeOf(decoderSKU("")), func(string) (any, error) { return 42, nil })
+	var sku decoderSKU
+	if err := d.SetFromString(reflect.ValueOf(&sku).Elem(), "x"); err == nil {
+		t.Error("expected an error for a decoder returning the wrong type")
+	}
+}
+
+func TestDecoders_Empty(t *testing.T) {
+	var d *Decoders
+	if funcs := NewDecoders(d).Funcs(); funcs != nil {
+		t.Errorf("Funcs() = %v, want nil", funcs)
+	}
+	if _, ok := d.Lookup(reflect.TypeOf("")); ok {
+		t.Error("expected a nil registry to have no decoders")
+	}
+}
+
+func TestSetFromString_DefaultDecoders(t *testing.T) {
+	type accountID int
+	errInvalid := errors.New("invalid account")
+	RegisterDecoder(DefaultDecoders, func(s string) (accountID, error) {
+		if !strings.HasPrefix(s, "acct_") {
+			return 0, errInvalid
+		}
+		var n int
+		_, err := fmt.Sscanf(s, "acct_%d", &n)
+		return accountID(n), err
+	})
+
+	var id accountID
+	if err := SetFromString(reflect.ValueOf(&id).Elem(), "acct_42"); err != nil || id != 42 {
+		t.Errorf("SetFromString() = %d, %v, want 42", id, err)
+	}
+	if err := SetFromString(reflect.ValueOf(&id).Elem(), "42"); !errors.Is(err, errInvalid) {
+		t.Errorf("SetFromString() error = %v, want the decoder's error", err)
+	}
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"reflect"
+	"sync"
+)
+
+// IsSetFunc reports whether v, a field or value of the type it is registered for, counts
+// as set.
+type IsSetFunc func(v reflect.Value) bool
+
+// IsSetFuncs maps Go types and struct fields to functions deciding whether a value counts
+// as set, replacing the default rule that any non-zero value is set. Use it where zero is
+// a valid value, such as a Port of 0 asking the OS for a free port, or where a non-zero
+// value should still count as unset, such as an empty slice.
+//
+// Loaders consult it when deciding whether a field still needs a value: short-circuiting,
+// layer merging and IsConfigFullyPopulated. Lookups that find no function fall back to the
+// parent registry, if any. An IsSetFuncs is safe for concurrent use and the zero value is
+// an empty registry without a parent.
+type IsSetFuncs struct {
+	mu     sync.RWMutex
+	types  map[reflect.Type]IsSetFunc
+	fields map[structField]IsSetFunc
+	parent *IsSetFuncs
+}
+
+// structField identifies a field by the struct type declaring it and its name.
+type structField struct {
+	parent reflect.Type
+	name   string
+}
+
+// DefaultIsSetFuncs is the process-wide registry, consulted by IsConfigFullyPopulated and
+// by every registry created with NewIsSetFuncs(DefaultIsSetFuncs).
+var DefaultIsSetFuncs = &IsSetFuncs{}
+
+// NewIsSetFuncs returns an empty registry falling back to parent, which may be nil.
+func NewIsSetFuncs(parent *IsSetFuncs) *IsSetFuncs {
+	return &IsSetFuncs{parent: parent}
+}
+
+// RegisterIsSet registers fn as the function deciding whether values of type T are set,
+// replacing any function registered for T before.
+//
+// Example:
+//
+//	// Treat empty slices as unset, as well as nil ones
+//	utils.RegisterIsSet(utils.DefaultIsSetFuncs, func(s []string) bool { return len(s) > 0 })
+func RegisterIsSet[T any](r *IsSetFuncs, fn func(v T) bool) {
+	r.Register(reflect.TypeOf((*T)(nil)).Elem(), func(v reflect.Value) bool {
+		return fn(v.Interface().(T))
+	})
+}
+
+// RegisterFieldIsSet registers fn as the function deciding whether the field named field
+// of struct type S is set. It takes precedence over functions registered for the field's
+// type.
+//
+// Example:
+//
+//	// Port 0 is a valid setting, so never treat Port as missing
+//	utils.RegisterFieldIsSet[AppConfig](utils.DefaultIsSetFuncs, "Port", utils.AlwaysSet)
+func RegisterFieldIsSet[S any](r *IsSetFuncs, field string, fn IsSetFunc) {
+	r.RegisterField(reflect.TypeOf((*S)(nil)).Elem(), field, fn)
+}
+
+// AlwaysSet is an IsSetFunc treating every value, including zero, as set.
+func AlwaysSet(reflect.Value) bool {
+	return true
+}
+
+// Register registers fn for values of type t, replacing any function registered for t
+// before.
+func (r *IsSetFuncs) Register(t reflect.Type, fn IsSetFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.types == nil {
+		r.types = make(map[reflect.Type]IsSetFunc)
+	}
+	r.types[t] = fn
+}
+
+// RegisterField registers fn for the field named field of struct type parent, replacing
+// any function registered for that field before.
+func (r *IsSetFuncs) RegisterField(parent reflect.Type, field string, fn IsSetFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fields == nil {
+		r.fields = make(map[structField]IsSetFunc)
+	}
+	r.fields[structField{parent, field}] = fn
+}
+
+// IsSet reports whether v is set according to the function registered for its type in r
+// or its parents, or is non-zero if there is none. A nil r uses DefaultIsSetFuncs.
+func (r *IsSetFuncs) IsSet(v reflect.Value) bool {
+	if r == nil {
+		r = DefaultIsSetFuncs
+	}
+	for d := r; d != nil; d = d.parent {
+		d.mu.RLock()
+		fn, ok := d.types[v.Type()]
+		d.mu.RUnlock()
+		if ok {
+			return fn(v)
+		}
+	}
+	return !IsZero(v)
+}
+
+// IsFieldSet reports whether field i of the struct v is set according to the function
+// registered for that field in r or its parents, falling back to IsSet. A nil r uses
+// DefaultIsSetFuncs.
+func (r *IsSetFuncs) IsFieldSet(v reflect.Value, i int) bool {
+	if r == nil {
+		r = DefaultIsSetFuncs
+	}
+	key := structField{v.Type(), v.Type().Field(i).Name}
+	for d := r; d != nil; d = d.parent {
+		d.mu.RLock()
+		fn, ok := d.fields[key]
+		d.mu.RUnlock()
+		if ok {
+			return fn(v.Field(i))
+		}
+	}
+	return r.IsSet(v.Field(i))
+}
+
+// FullyPopulated reports whether every exported field of the struct v is set.
+func (r *IsSetFuncs) FullyPopulated(v reflect.Value) bool {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() && !r.IsFieldSet(v, i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+type isSetTestConfig struct {
+	Port  int
+	Hosts []string
+	Name  string
+	debug bool
+}
+
+func TestIsSetFuncs_IsFieldSet(t *testing.T) {
+	parent := NewIsSetFuncs(nil)
+	RegisterIsSet(parent, func(s []string) bool { return len(s) > 0 })
+	r := NewIsSetFuncs(parent)
+	RegisterFieldIsSet[isSetTestConfig](r, "Port", AlwaysSet)
+
+	v := reflect.ValueOf(isSetTestConfig{Hosts: []string{}})
+	for i, want := range []bool{true, false, false, false} {
+		if got := r.IsFieldSet(v, i); got != want {
+			t.Errorf("IsFieldSet(%s) = %v, want %v", v.Type().Field(i).Name, got, want)
+		}
+	}
+	if !r.IsSet(reflect.ValueOf([]string{"a"})) {
+		t.Error("IsSet() = false for a non-empty slice, want true from the parent's function")
+	}
+	if !NewIsSetFuncs(nil).IsSet(reflect.ValueOf([]string{})) {
+		t.Error("IsSet() = false for an empty non-nil slice without a registered function, want true")
+	}
+}
+
+func TestIsSetFuncs_FullyPopulated(t *testing.T) {
+	r := NewIsSetFuncs(nil)
+	cfg := isSetTestConfig{Hosts: []string{"a"}, Name: "app"}
+	if r.FullyPopulated(reflect.ValueOf(cfg)) {
+		t.Error("FullyPopulated() = true with Port 0, want false")
+	}
+	RegisterFieldIsSet[isSetTestConfig](r, "Port", AlwaysSet)
+	if !r.FullyPopulated(reflect.ValueOf(cfg)) {
+		t.Error("FullyPopulated() = false with Port registered as always set, want true (unexported fields are ignored)")
+	}
+}
+
+func TestIsConfigFullyPopulated_DefaultIsSetFuncs(t *testing.T) {
+	type config struct {
+		Port int
+	}
+	if IsConfigFullyPopulated(&config{}) {
+		t.Fatal("IsConfigFullyPopulated() = true for Port 0, want false")
+	}
+	RegisterFieldIsSet[config](DefaultIsSetFuncs, "Port", AlwaysSet)
+	t.Cleanup(func() {
+		DefaultIsSetFuncs.mu.Lock()
+		delete(DefaultIsSetFuncs.fields, structField{reflect.TypeOf(config{}), "Port"})
+		DefaultIsSetFuncs.mu.Unlock()
+	})
+	if !IsConfigFullyPopulated(&config{}) {
+		t.Error("IsConfigFullyPopulated() = false, want true with Port registered in DefaultIsSetFuncs")
+	}
+}
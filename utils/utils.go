@@ -1,9 +1,20 @@
 // Package utils provides utility functions for configuration handling.
 package utils
 
-import "reflect"
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// IsConfigFullyPopulated checks if all exported fields in a configuration struct are non-zero.
+// IsConfigFullyPopulated checks if all exported fields in a configuration struct are set:
+// non-zero, or set according to a function registered in DefaultIsSetFuncs.
 // This is used by InterpolatingChainLoader with ShortCircuit enabled to determine when to stop loading.
 func IsConfigFullyPopulated[T any](c *T) bool {
 	if c == nil {
@@ -16,18 +27,7 @@ func IsConfigFullyPopulated[T any](c *T) bool {
 	if v.Kind() != reflect.Struct {
 		return false
 	}
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		structField := t.Field(i)
-		if structField.PkgPath != "" { // skip unexported fields
-			continue
-		}
-		if IsZero(field) {
-			return false
-		}
-	}
-	return true
+	return DefaultIsSetFuncs.FullyPopulated(v)
 }
 
 // IsZero determines if a reflect.Value represents a zero value for its type.
@@ -71,3 +71,206 @@ func IsZero(v reflect.Value) bool {
 	}
 	return false
 }
+
+// HasTagOption reports whether a comma-separated struct tag value contains the given
+// option, either as a bare flag (e.g. "sensitive") or as a key=value pair (e.g. "path=config").
+func HasTagOption(tag, option string) bool {
+	_, ok := TagOptionValue(tag, option)
+	return ok
+}
+
+// TagOptionValue returns the value of a key=value option in a comma-separated struct tag value.
+// Bare flags are reported as present with an empty value.
+func TagOptionValue(tag, option string) (string, bool) {
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		key, value, _ := strings.Cut(part, "=")
+		if key == option {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// ExpandHome replaces a leading ~ in p with the current user's home directory.
+// Paths that do not start with ~ (or use the unsupported ~user form) are returned unchanged.
+func ExpandHome(p string) (string, error) {
+	if p != "~" && !strings.HasPrefix(p, "~/") && !strings.HasPrefix(p, `~\`) {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return home + p[1:], nil
+}
+
+// ExpandEnv expands $VAR and ${VAR} references in s using the process environment.
+// On Windows, %VAR% references are expanded as well.
+func ExpandEnv(s string) string {
+	if runtime.GOOS == "windows" {
+		s = ExpandPercentEnv(s)
+	}
+	return os.ExpandEnv(s)
+}
+
+// ExpandPercentEnv expands Windows-style %VAR% references in s using the process environment.
+// As with cmd.exe, references to unset variables and a lone % are left unchanged.
+func ExpandPercentEnv(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '%')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '%')
+		if end == -1 {
+			break
+		}
+		end += start + 1
+
+		name := s[start+1 : end]
+		value, ok := os.LookupEnv(name)
+		if name == "" || !ok {
+			// Keep the opening % and continue scanning from the closing one
+			b.WriteString(s[:end])
+			s = s[end:]
+			continue
+		}
+
+		b.WriteString(s[:start])
+		b.WriteString(value)
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// VariableReference matches a ${VAR} reference and captures VAR, which may be scoped, e.g.
+// ${Database.HOST}. It is the pattern of the interpolation engine, shared by loaders that
+// expand references in their own settings so both accept the same names.
+var VariableReference = regexp.MustCompile(`\$\{([A-Za-z0-9_-]+(?:\.[A-Za-z0-9_-]+)*)\}`)
+
+// AvailableAsValues returns the values of the populated top-level fields of the struct v
+// that declare `config:"availableAs=VAR"`, keyed by variable name. Loaders use it to resolve
+// ${VAR} references in their own settings from values loaded in earlier stages.
+func AvailableAsValues(v reflect.Value) map[string]string {
+	values := make(map[string]string)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := TagOptionValue(field.Tag.Get("config"), "availableAs")
+		if !field.IsExported() || !ok || name == "" || v.Field(i).IsZero() {
+			continue
+		}
+		values[name] = fmt.Sprint(v.Field(i).Interface())
+	}
+	return values
+}
+
+// ExpandVariables replaces ${VAR} references in s with their values, and returns the names
+// of referenced variables missing from values, which are replaced with "".
+func ExpandVariables(s string, values map[string]string) (string, []string) {
+	var missing []string
+	expanded := VariableReference.ReplaceAllStringFunc(s, func(match string) string {
+		name := match[2 : len(match)-1]
+		value, ok := values[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	return expanded, missing
+}
+
+// SetFromString parses s into v with the decoder registered in DefaultDecoders for the
+// type of v, or otherwise according to its kind.
+// Supports strings, booleans, integers (including time.Duration), unsigned integers, floats,
+// addressable values whose pointer implements encoding.TextUnmarshaler, pointers to such
+// values, e.g. *regexp.Regexp, and slices of such values, e.g. []netip.Prefix, from a
+// comma-separated list.
+func SetFromString(v reflect.Value, s string) error {
+	return DefaultDecoders.SetFromString(v, s)
+}
+
+// setFromString is SetFromString without the registered decoders.
+func setFromString(v reflect.Value, s string) error {
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+	if v.Kind() == reflect.Ptr && v.Type().Implements(textUnmarshalerType) {
+		elem := reflect.New(v.Type().Elem())
+		if err := elem.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if v.Kind() == reflect.Slice && reflect.PointerTo(v.Type().Elem()).Implements(textUnmarshalerType) {
+		return setTextSlice(v, s)
+	}
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type: %s", v.Type())
+	}
+	return nil
+}
+
+// textUnmarshalerType is the type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setTextSlice sets the slice v to the comma-separated elements of s, each parsed with
+// its UnmarshalText method. Empty elements are skipped.
+func setTextSlice(v reflect.Value, s string) error {
+	slice := reflect.MakeSlice(v.Type(), 0, 0)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := elem.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(part)); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, elem.Elem())
+	}
+	v.Set(slice)
+	return nil
+}
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestExpandPercentEnv(t *testing.T) {
+	t.Setenv("TEST_PERCENT_DIR", `C:\ProgramData`)
+	os.Unsetenv("TEST_PERCENT_UNSET")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`%TEST_PERCENT_DIR%\myapp`, `C:\ProgramData\myapp`},
+		{`%TEST_PERCENT_DIR%%TEST_PERCENT_DIR%`, `C:\ProgramDataC:\ProgramData`},
+		{`%TEST_PERCENT_UNSET%\myapp`, `%TEST_PERCENT_UNSET%\myapp`},
+		{`100% %TEST_PERCENT_DIR%`, `100% C:\ProgramData`},
+		{`%%`, `%%`},
+		{`no variables`, `no variables`},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandPercentEnv(tt.input); got != tt.want {
+			t.Errorf("ExpandPercentEnv(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("TEST_EXPAND_DIR", "configs")
+
+	if got := ExpandEnv("$TEST_EXPAND_DIR/${TEST_EXPAND_DIR}"); got != "configs/configs" {
+		t.Errorf("unexpected $VAR expansion: %q", got)
+	}
+
+	want := "%TEST_EXPAND_DIR%"
+	if runtime.GOOS == "windows" {
+		want = "configs"
+	}
+	if got := ExpandEnv("%TEST_EXPAND_DIR%"); got != want {
+		t.Errorf("ExpandEnv(%%TEST_EXPAND_DIR%%) = %q, want %q", got, want)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("home directory unavailable: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"~", home},
+		{"~/app", home + "/app"},
+		{`~\app`, home + `\app`},
+		{"~other/app", "~other/app"},
+		{filepath.Join("a", "b"), filepath.Join("a", "b")},
+	}
+
+	for _, tt := range tests {
+		got, err := ExpandHome(tt.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("ExpandHome(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTagOptionValue(t *testing.T) {
+	tag := "availableAs=ENV, sensitive,path=config"
+
+	if !HasTagOption(tag, "sensitive") || !HasTagOption(tag, "availableAs") {
+		t.Errorf("expected options to be found in %q", tag)
+	}
+	if HasTagOption(tag, "sens") {
+		t.Errorf("expected partial option name not to match")
+	}
+	if value, ok := TagOptionValue(tag, "path"); !ok || value != "config" {
+		t.Errorf("TagOptionValue(path) = %q, %v", value, ok)
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	type config struct {
+		Env    string `config:"availableAs=ENV"`
+		Port   int    `config:"availableAs=PORT"`
+		Region string `config:"availableAs=REGION"`
+		Other  string
+	}
+	values := AvailableAsValues(reflect.ValueOf(config{Env: "prod", Port: 8080, Other: "x"}))
+	if len(values) != 2 || values["ENV"] != "prod" || values["PORT"] != "8080" {
+		t.Fatalf("AvailableAsValues() = %v", values)
+	}
+
+	values["Primary.HOST"] = "db1"
+	got, missing := ExpandVariables("app#${ENV}#${Primary.HOST}:${PORT}/${REGION}", values)
+	if got != "app#prod#db1:8080/" || len(missing) != 1 || missing[0] != "REGION" {
+		t.Errorf("ExpandVariables() = %q, %v", got, missing)
+	}
+}
+
+func TestSetFromString(t *testing.T) {
+	var cfg struct {
+		S string
+		B bool
+		I int8
+		U uint
+		F float64
+		D time.Duration
+		M map[string]string
+	}
+	v := reflect.ValueOf(&cfg).Elem()
+
+	for i, s := range []string{"text", "true", "-5", "7", "1.5", "2m"} {
+		if err := SetFromString(v.Field(i), s); err != nil {
+			t.Fatalf("SetFromString(%s, %q) failed: %v", v.Type().Field(i).Name, s, err)
+		}
+	}
+	if cfg.S != "text" || !cfg.B || cfg.I != -5 || cfg.U != 7 || cfg.F != 1.5 || cfg.D != 2*time.Minute {
+		t.Errorf("unexpected values: %+v", cfg)
+	}
+
+	if err := SetFromString(v.Field(2), "300"); err == nil {
+		t.Error("expected overflow error for int8")
+	}
+	if err := SetFromString(v.Field(6), "a=b"); err == nil {
+		t.Error("expected error for unsupported map field")
+	}
+}
+
+func TestSetFromString_TextSlice(t *testing.T) {
+	var prefixes []netip.Prefix
+	v := reflect.ValueOf(&prefixes).Elem()
+
+	if err := SetFromString(v, "10.0.0.0/8, fd00::/8,"); err != nil {
+		t.Fatalf("SetFromString failed: %v", err)
+	}
+	if len(prefixes) != 2 || prefixes[0].String() != "10.0.0.0/8" || prefixes[1].String() != "fd00::/8" {
+		t.Errorf("unexpected prefixes: %v", prefixes)
+	}
+	if err := SetFromString(v, "10.0.0.0/8,not-a-prefix"); err == nil {
+		t.Error("expected error for an invalid element")
+	}
+}
+
+func TestSetFromString_TextPointer(t *testing.T) {
+	var re *regexp.Regexp
+	v := reflect.ValueOf(&re).Elem()
+
+	if err := SetFromString(v, "^a+$"); err != nil {
+		t.Fatalf("SetFromString failed: %v", err)
+	}
+	if re == nil || !re.MatchString("aaa") {
+		t.Errorf("unexpected regexp: %v", re)
+	}
+	if err := SetFromString(v, "(a"); err == nil {
+		t.Error("expected error for an invalid pattern")
+	}
+}
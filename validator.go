@@ -1,6 +1,12 @@
+//go:build !tinygo
+
 package config
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -41,9 +47,58 @@ func NewValidator() validator.Validate {
 		return !fl.Field().IsZero() || !atMostOneFieldNotSet(fl.Param(), fl)
 	})
 
+	// Size field must be at least, or at most, the size given as the parameter, e.g. size_max=1GiB
+	_ = validate.RegisterValidation("size_min", func(fl validator.FieldLevel) bool {
+		return fieldSize(fl) >= sizeParam(fl)
+	})
+	_ = validate.RegisterValidation("size_max", func(fl validator.FieldLevel) bool {
+		return fieldSize(fl) <= sizeParam(fl)
+	})
+
+	// String field must have at least the given bits of estimated entropy, e.g. minentropy=60
+	_ = validate.RegisterValidation("minentropy", func(fl validator.FieldLevel) bool {
+		bits, err := strconv.ParseFloat(fl.Param(), 64)
+		if err != nil {
+			panic(fmt.Sprintf("%s: invalid minentropy parameter %q", fl.FieldName(), fl.Param()))
+		}
+		value := fl.Field().String()
+		return value == "" || PasswordEntropy(value) >= bits
+	})
+
+	// String field must not be a commonly used password
+	_ = validate.RegisterValidation("no_common_passwords", func(fl validator.FieldLevel) bool {
+		return !IsCommonPassword(fl.Field().String())
+	})
+
+	// Numeric or duration field must compare with another field, e.g. ltfield_dur=ReadTimeout,
+	// and fields tagged sum_max=Budget must add up to at most Budget
+	registerCrossFieldRules(validate)
+
 	return *validate
 }
 
+// fieldSize returns an integer field as a Size.
+func fieldSize(fl validator.FieldLevel) Size {
+	f := fl.Field()
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Size(f.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Size(f.Uint())
+	}
+	panic(fmt.Sprintf("%s: size rules need an integer field, got %s", fl.FieldName(), f.Type()))
+}
+
+// sizeParam parses the parameter of a size rule. Like the built-in min and max rules,
+// it panics on an invalid parameter, since that is a mistake in the struct tag.
+func sizeParam(fl validator.FieldLevel) Size {
+	bound, err := ParseSize(fl.Param())
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", fl.FieldName(), err))
+	}
+	return bound
+}
+
 func allFieldsSet(param string, fl validator.FieldLevel) bool {
 	fields := strings.Fields(param)
 	for _, name := range fields {
@@ -101,3 +156,49 @@ func atMostOneFieldNotSet(param string, fl validator.FieldLevel) bool {
 	}
 	return count <= 1
 }
+
+// validatorViolations converts go-playground/validator errors in err into FieldViolations,
+// dropping the root struct name from each namespace.
+func validatorViolations(err error) []FieldViolation {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	violations := make([]FieldViolation, len(errs))
+	for i, fieldErr := range errs {
+		path := fieldErr.Namespace()
+		if _, rest, ok := strings.Cut(path, "."); ok {
+			path = rest
+		}
+		violations[i] = FieldViolation{
+			Path:    FieldPath(path),
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: ruleMessage(fieldErr.Tag(), fieldErr.Param(), fieldErr.Kind()),
+		}
+	}
+	return violations
+}
+
+// dropInactiveValidatorErrors removes the validator errors about inactive fields from err,
+// returning nil if none remain.
+func dropInactiveValidatorErrors(err error, inactive []FieldPath) error {
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+	var kept validator.ValidationErrors
+	for _, fieldErr := range errs {
+		path := fieldErr.Namespace()
+		if _, rest, ok := strings.Cut(path, "."); ok {
+			path = rest
+		}
+		if !isInactive(FieldPath(path), inactive) {
+			kept = append(kept, fieldErr)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
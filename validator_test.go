@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
@@ -147,3 +148,80 @@ func TestRequiredIfAtMostOneNotSet(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 }
+
+func TestValidator_MapSectionErrorsReferenceKey(t *testing.T) {
+	type target struct {
+		URL string `validate:"required,url"`
+	}
+	type config struct {
+		Targets map[string]target `validate:"dive"`
+	}
+
+	v := NewValidator()
+	err := v.Struct(config{Targets: map[string]target{"eu": {URL: "https://eu"}, "us": {}}})
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("expected one validation error, got %v", err)
+	}
+	if ns := errs[0].Namespace(); ns != "config.Targets[us].URL" {
+		t.Errorf("expected error namespace to reference the map key, got %s", ns)
+	}
+}
+
+func TestViolations_SliceSections(t *testing.T) {
+	type endpoint struct {
+		URL  string `validate:"required,url"`
+		Name string `validate:"max=3"`
+	}
+	type config struct {
+		Endpoints []endpoint `validate:"min=1,max=2,dive"`
+		Backups   []endpoint `validate:"min=1"`
+	}
+
+	v := NewValidator()
+	handler := &Handler[config]{Validator: &v}
+	err := handler.Validate(&config{Endpoints: []endpoint{{URL: "https://a"}, {URL: "not a url", Name: "toolong"}}})
+
+	got := make([]string, 0)
+	for _, violation := range Violations(err) {
+		got = append(got, violation.String())
+	}
+	want := []string{
+		"Endpoints[1].URL: invalid url",
+		"Endpoints[1].Name: must be at most 3 characters",
+		"Backups: must have at least 1 elements",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected violations:\n%s", strings.Join(got, "\n"))
+	}
+
+	if Violations(nil) != nil {
+		t.Error("expected no violations for nil error")
+	}
+}
+
+func TestSizeRules(t *testing.T) {
+	type config struct {
+		MaxUpload Size   `validate:"size_min=1MiB,size_max=1GiB"`
+		Buffer    uint32 `validate:"size_max=64KiB"`
+	}
+
+	v := NewValidator()
+	if err := v.Struct(config{MaxUpload: 32 * MiB, Buffer: 4096}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := v.Struct(config{MaxUpload: GiB, Buffer: uint32(64 * KiB)}); err != nil {
+		t.Errorf("Unexpected error at the bounds: %v", err)
+	}
+
+	handler := &Handler[config]{Validator: &v}
+	err := handler.Validate(&config{MaxUpload: 512 * KiB, Buffer: 1 << 20})
+	got := make([]string, 0)
+	for _, violation := range Violations(err) {
+		got = append(got, violation.String())
+	}
+	want := []string{"MaxUpload: must be at least 1MiB", "Buffer: must be at most 64KiB"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected violations:\n%s", strings.Join(got, "\n"))
+	}
+}
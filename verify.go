@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// SourceReport aggregates the source checks made by Handler.VerifySources.
+type SourceReport struct {
+	Checks []loader.SourceCheck
+}
+
+// Failed returns the checks whose source is missing or inaccessible.
+func (r *SourceReport) Failed() []loader.SourceCheck {
+	var failed []loader.SourceCheck
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// Err returns a SourceVerificationError listing every failed check, or nil if all passed.
+func (r *SourceReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return &SourceVerificationError{Failed: failed}
+}
+
+// String returns one line per check.
+func (r *SourceReport) String() string {
+	lines := make([]string, len(r.Checks))
+	for i, check := range r.Checks {
+		lines[i] = check.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// VerifySources checks that every secret, parameter and file referenced by the configured
+// loaders exists and is accessible, without reading any values. Loaders take part by
+// implementing loader.SourceVerifier; others are skipped.
+//
+// Tags are resolved with the availableAs fields already set on cfg, so set those (e.g. the
+// environment name) before calling. Unresolvable references are returned as an error
+// alongside the report covering the remaining fields. Failed checks are recorded in the
+// report rather than returned; use SourceReport.Err to fail a pipeline.
+//
+// Example:
+//
+//	cfg := &AppConfig{Env: "prod"}
+//	report, err := handler.VerifySources(ctx, cfg)
+//	if err == nil {
+//	    err = report.Err()
+//	}
+func (c *Handler[C]) VerifySources(ctx context.Context, cfg *C) (*SourceReport, error) {
+	fields, resolveErr := resolvedFields(cfg, c.interpolationGuard)
+
+	report := &SourceReport{}
+	for _, l := range c.Loaders {
+		if verifier, ok := l.(loader.SourceVerifier); ok {
+			report.Checks = append(report.Checks, verifier.VerifySources(ctx, fields)...)
+		}
+	}
+	return report, resolveErr
+}
+
+// resolvedFields returns every field of cfg with ${VAR} references in its tag replaced by
+// the values of the availableAs fields already set on cfg, checked by guard.
+func resolvedFields[C any](cfg *C, guard *InterpolationGuard) ([]loader.Field, error) {
+	engine := NewInterpolationEngine[C]()
+	engine.SetGuard(guard)
+	if err := engine.Analyze(cfg); err != nil {
+		return nil, fmt.Errorf("interpolation analysis failed: %w", err)
+	}
+
+	context, err := engine.availableAsContext(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := engine.ResolveAll(context)
+	fields := make([]loader.Field, len(resolved))
+	for i, field := range resolved {
+		fields[i] = loader.Field{Name: field.FieldName, Tag: field.Tag}
+	}
+	return fields, err
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// stubVerifier reports a check per `secret` tag, failing the names in missing.
+type stubVerifier[T any] struct {
+	missing map[string]bool
+}
+
+func (s *stubVerifier[T]) Load(c *T) error { return nil }
+
+func (s *stubVerifier[T]) VerifySources(_ context.Context, fields []loader.Field) []loader.SourceCheck {
+	var checks []loader.SourceCheck
+	for _, field := range fields {
+		name := field.Tag.Get("secret")
+		if name == "" {
+			continue
+		}
+		check := loader.SourceCheck{LoaderType: "stub", Field: field.Name, Source: name}
+		if s.missing[name] {
+			check.Err = errors.New("not found")
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+type verifyTestConfig struct {
+	Env        string `env:"ENV" config:"availableAs=ENV"`
+	DBPassword string `secret:"/${ENV}/db/password"`
+	APIKey     string `secret:"/${ENV}/api-key"`
+	Plain      string `env:"PLAIN"`
+}
+
+func TestHandler_VerifySources(t *testing.T) {
+	verifier := &stubVerifier[verifyTestConfig]{missing: map[string]bool{"/prod/api-key": true}}
+	handler := NewConfigHandler[verifyTestConfig](WithLoaders[verifyTestConfig](verifier, &plainTestLoader[verifyTestConfig]{}))
+
+	report, err := handler.VerifySources(context.Background(), &verifyTestConfig{Env: "prod"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Checks) != 2 || report.Checks[0].Source != "/prod/db/password" {
+		t.Fatalf("unexpected checks: %v", report.Checks)
+	}
+
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Field != "APIKey" {
+		t.Errorf("expected APIKey to fail, got %v", failed)
+	}
+
+	var verifyErr *SourceVerificationError
+	if err := report.Err(); !errors.As(err, &verifyErr) || !strings.Contains(err.Error(), "/prod/api-key") {
+		t.Errorf("expected SourceVerificationError naming the missing secret, got %v", err)
+	}
+}
+
+func TestHandler_VerifySources_UnresolvedVariable(t *testing.T) {
+	verifier := &stubVerifier[verifyTestConfig]{}
+	handler := NewConfigHandler[verifyTestConfig](WithLoaders[verifyTestConfig](verifier))
+
+	report, err := handler.VerifySources(context.Background(), &verifyTestConfig{})
+	var undefinedErr *UndefinedVariableError
+	if !errors.As(err, &undefinedErr) || undefinedErr.VariableName != "ENV" {
+		t.Fatalf("expected UndefinedVariableError for ENV, got %v", err)
+	}
+	if len(report.Checks) != 0 || report.Err() != nil {
+		t.Errorf("expected no checks for unresolved fields, got %v", report.Checks)
+	}
+}
+
+// plainTestLoader is a loader that does not implement loader.SourceVerifier.
+type plainTestLoader[T any] struct{}
+
+func (plainTestLoader[T]) Load(c *T) error { return nil }
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader"
+)
+
+// DefaultWatchInterval is how often a Watcher checks file sources for changes when Interval
+// is unset.
+const DefaultWatchInterval = 2 * time.Second
+
+// Watcher keeps a configuration current while an application runs. It reloads and
+// validates the configuration when a source changes, passing the context of Run to
+// ContextLoaders, and swaps the new value in atomically, so readers calling Current always
+// see a complete, validated configuration. Subscribers are called with the old and new
+// values after each change.
+//
+// Sources are watched in three ways:
+//   - files read by loaders implementing loader.FileSource, such as generic.FileLoader and
+//     generic.DirectoryLoader, are polled every Interval for changes to their size and
+//     modification time rather than watched with file system notifications, which also
+//     catches Kubernetes ConfigMap updates made by swapping symbolic links;
+//   - loaders implementing loader.Watcher, such as aws.SecretsManagerLoader, report their
+//     own changes;
+//   - with a ReloadInterval, every source is reloaded periodically, for remote sources that
+//     cannot be watched, such as SSM parameters or HTTP documents.
+//
+// Loaders wrapping another, such as generic.CachingLoader, report the files and changes of
+// the loader they wrap, and a LayeredLoader the files of the layers it last loaded. The
+// files are listed again after each successful reload, so files a loader starts reading
+// are watched from then on.
+//
+// A reload that fails to load or validate, or loads a configuration equal to the current
+// one by Fingerprint, leaves the current configuration in place. With an EventBus installed
+// by WithEventBus, a rejected reload is published as ReloadFailed and an accepted one as
+// Reloaded. Each value passed to subscribers is a new struct, so they may keep it.
+//
+// Example:
+//
+//	watcher := config.NewWatcher(handler)
+//	watcher.ReloadInterval = 5 * time.Minute
+//	watcher.OnError = func(err error) { slog.Error("config reload failed", "error", err) }
+//	watcher.Subscribe(func(old, new *AppConfig) {
+//	    if old != nil && old.MaxConns != new.MaxConns {
+//	        pool.Resize(new.MaxConns)
+//	    }
+//	})
+//	go func() {
+//	    if err := watcher.Run(ctx); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	}()
+//	...
+//	cfg := watcher.Current()
+type Watcher[C any] struct {
+	Interval       time.Duration // How often file sources are checked (defaults to DefaultWatchInterval)
+	ReloadInterval time.Duration // How often every source is reloaded regardless of changes (0 disables)
+	New            func() *C     // Returns the value each load starts from (defaults to a zero C)
+	OnError        func(error)   // Optional hook called when a reload fails
+
+	handler *Handler[C]
+	current atomic.Pointer[C]
+	mu      sync.Mutex
+	subs    []func(old, new *C)
+}
+
+// NewWatcher returns a watcher reloading the configuration of handler.
+func NewWatcher[C any](handler *Handler[C]) *Watcher[C] {
+	return &Watcher[C]{handler: handler}
+}
+
+// Current returns the configuration last loaded, or nil before the first load.
+func (w *Watcher[C]) Current() *C {
+	return w.current.Load()
+}
+
+// Subscribe adds fn to the functions called after the configuration changes. old is nil
+// for the first load. Subscribers run in turn on the goroutine calling Run.
+func (w *Watcher[C]) Subscribe(fn func(old, new *C)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs = append(w.subs, fn)
+}
+
+// Run loads the configuration, then reloads it whenever a source changes, until ctx is
+// done or the handler is shut down, and returns nil. It returns the error of the first
+// load, or of a loader.Watcher that cannot watch its sources.
+func (w *Watcher[C]) Run(ctx context.Context) error {
+	lifeCtx, err := w.handler.life.begin()
+	if err != nil {
+		return err
+	}
+	defer w.handler.life.active.Done()
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(lifeCtx, cancel)
+	defer stop()
+
+	if err := w.reload(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	// Changes reported while a reload runs are coalesced into one more reload
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	watchErr := make(chan error, len(w.handler.Loaders))
+	for _, l := range w.handler.Loaders {
+		if watcher, ok := unwrapLoader(l).(loader.Watcher); ok {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := watcher.Watch(ctx, notify); err != nil {
+					watchErr <- err
+				}
+			}()
+		}
+	}
+
+	var fileCheck, periodicReload <-chan time.Time
+	var fileTicker *time.Ticker
+	defer func() {
+		if fileTicker != nil {
+			fileTicker.Stop()
+		}
+	}()
+	var files []string
+	var stamps map[string]string
+	// watchFiles updates the watched files after a load, as loaders such as LayeredLoader
+	// may read different files each time, and starts checking them once there are any
+	watchFiles := func() {
+		next := w.files()
+		if slices.Equal(files, next) {
+			return
+		}
+		files, stamps = next, fileStamps(next)
+		if len(files) > 0 && fileTicker == nil {
+			interval := w.Interval
+			if interval <= 0 {
+				interval = DefaultWatchInterval
+			}
+			fileTicker = time.NewTicker(interval)
+			fileCheck = fileTicker.C
+		}
+	}
+	watchFiles()
+	if w.ReloadInterval > 0 {
+		ticker := time.NewTicker(w.ReloadInterval)
+		defer ticker.Stop()
+		periodicReload = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watchErr:
+			return err
+		case <-fileCheck:
+			next := fileStamps(files)
+			if maps.Equal(stamps, next) {
+				continue
+			}
+			stamps = next
+		case <-periodicReload:
+		case <-changed:
+		}
+		if err := w.reload(ctx); err != nil {
+			if w.OnError != nil && ctx.Err() == nil {
+				w.OnError(err)
+			}
+		} else {
+			watchFiles()
+		}
+	}
+}
+
+// reload loads and validates a new configuration with ctx, and swaps it in and calls the
+// subscribers if it differs from the current one. The load is published as an event only
+// once validation has accepted or rejected it.
+func (w *Watcher[C]) reload(ctx context.Context) error {
+	next := new(C)
+	if w.New != nil {
+		next = w.New()
+	}
+	err := w.handler.loadUnpublished(ctx, next, nil)
+	if err == nil {
+		err = w.handler.Validate(next)
+	}
+	w.handler.publishLoad(next, err)
+	if err != nil {
+		return err
+	}
+	old := w.current.Load()
+	if old != nil && Fingerprint(old) == Fingerprint(next) {
+		return nil
+	}
+	w.current.Store(next)
+
+	w.mu.Lock()
+	subs := slices.Clone(w.subs)
+	w.mu.Unlock()
+	for _, fn := range subs {
+		fn(old, next)
+	}
+	return nil
+}
+
+// files returns the files read by the handler's loaders, without duplicates.
+func (w *Watcher[C]) files() []string {
+	var files []string
+	for _, l := range w.handler.Loaders {
+		if source, ok := unwrapLoader(l).(loader.FileSource); ok {
+			files = append(files, source.SourceFiles()...)
+		}
+	}
+	slices.Sort(files)
+	return slices.Compact(files)
+}
+
+// fileStamps returns the size and modification time of each file, or of the entries of
+// each directory, following symbolic links. Missing files have an empty stamp, so their
+// creation counts as a change.
+func fileStamps(paths []string) map[string]string {
+	stamps := make(map[string]string, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			stamps[path] = ""
+			continue
+		}
+		stamps[path] = fileStamp(info)
+		if !info.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			entryPath := filepath.Join(path, entry.Name())
+			if info, err := os.Stat(entryPath); err == nil {
+				stamps[entryPath] = fileStamp(info)
+			}
+		}
+	}
+	return stamps
+}
+
+// fileStamp identifies a version of a file by its size and modification time.
+func fileStamp(info os.FileInfo) string {
+	return fmt.Sprintf("%d/%d", info.Size(), info.ModTime().UnixNano())
+}
+
+// Watch keeps the configuration current with a Watcher using the default settings,
+// calling onChange with the old and new values after each change, starting with the first
+// load, for which old is nil. It returns as Watcher.Run does. Failed reloads are published
+// as ReloadFailed events when an EventBus is installed with WithEventBus.
+//
+// Example:
+//
+//	var current atomic.Pointer[AppConfig]
+//	go handler.Watch(ctx, func(old, new *AppConfig) {
+//	    current.Store(new)
+//	})
+func (c *Handler[C]) Watch(ctx context.Context, onChange func(old, new *C)) error {
+	w := NewWatcher(c)
+	w.Subscribe(onChange)
+	return w.Run(ctx)
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gymshark/go-easy-config/loader/generic"
+)
+
+type watchTestConfig struct {
+	Name     string `json:"name"`
+	MaxConns int    `json:"maxConns" validate:"max=100"`
+}
+
+// triggerLoader stands in for a remote loader implementing loader.Watcher.
+type triggerLoader struct {
+	value   atomic.Int64
+	changed chan func()
+	err     error
+}
+
+func (l *triggerLoader) Load(c *watchTestConfig) error {
+	c.MaxConns = int(l.value.Load())
+	return nil
+}
+
+func (l *triggerLoader) Watch(ctx context.Context, changed func()) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.changed <- changed
+	<-ctx.Done()
+	return nil
+}
+
+// nextChange waits for a change delivered on changes.
+func nextChange(t *testing.T, changes <-chan [2]*watchTestConfig) (old, new *watchTestConfig) {
+	t.Helper()
+	select {
+	case change := <-changes:
+		return change[0], change[1]
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a configuration change")
+		return nil, nil
+	}
+}
+
+func TestWatcher_ReloadsChangedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"name": "api"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewConfigHandler[watchTestConfig](
+		WithLoaders[watchTestConfig](&generic.JSONLoader[watchTestConfig]{Source: path}),
+	)
+
+	watcher := NewWatcher(handler)
+	watcher.Interval = 10 * time.Millisecond
+	changes := make(chan [2]*watchTestConfig, 4)
+	watcher.Subscribe(func(old, new *watchTestConfig) { changes <- [2]*watchTestConfig{old, new} })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+
+	old, cfg := nextChange(t, changes)
+	if old != nil || cfg.Name != "api" || watcher.Current() != cfg {
+		t.Fatalf("first load = %+v, %+v, want nil, api", old, cfg)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name": "api-v2"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old, cfg = nextChange(t, changes)
+	if old.Name != "api" || cfg.Name != "api-v2" || watcher.Current().Name != "api-v2" {
+		t.Errorf("reload = %q -> %q, want api -> api-v2", old.Name, cfg.Name)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
+
+func TestWatcher_ReloadsWrappedFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func(path string) []Option[watchTestConfig]
+	}{
+		{"fault injector", func(path string) []Option[watchTestConfig] {
+			return []Option[watchTestConfig]{WithLoaders[watchTestConfig](&generic.FaultInjector[watchTestConfig]{
+				Loader: &generic.JSONLoader[watchTestConfig]{Source: path},
+			})}
+		}},
+		{"layers", func(path string) []Option[watchTestConfig] {
+			return []Option[watchTestConfig]{WithLayers[watchTestConfig](
+				func(source string) Loader[watchTestConfig] {
+					return &generic.JSONLoader[watchTestConfig]{Source: source}
+				},
+				Layer[watchTestConfig]{Name: "global", Source: path},
+			)}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(`{"name": "api"}`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			watcher := NewWatcher(NewConfigHandler[watchTestConfig](tt.options(path)...))
+			watcher.Interval = 10 * time.Millisecond
+			changes := make(chan [2]*watchTestConfig, 4)
+			watcher.Subscribe(func(old, new *watchTestConfig) { changes <- [2]*watchTestConfig{old, new} })
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go watcher.Run(ctx)
+
+			if _, cfg := nextChange(t, changes); cfg.Name != "api" {
+				t.Fatalf("first load Name = %q, want api", cfg.Name)
+			}
+			if err := os.WriteFile(path, []byte(`{"name": "api-v2"}`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, cfg := nextChange(t, changes); cfg.Name != "api-v2" {
+				t.Errorf("reload Name = %q, want api-v2", cfg.Name)
+			}
+		})
+	}
+}
+
+// switchingFileLoader reads the file at next, reporting the file it last read as its source.
+type switchingFileLoader struct {
+	next atomic.Pointer[string]
+	last atomic.Pointer[string]
+}
+
+func (l *switchingFileLoader) Load(c *watchTestConfig) error {
+	path := l.next.Load()
+	l.last.Store(path)
+	return (&generic.JSONLoader[watchTestConfig]{Source: *path}).Load(c)
+}
+
+func (l *switchingFileLoader) SourceFiles() []string {
+	return []string{*l.last.Load()}
+}
+
+func TestWatcher_WatchesFilesReadAfterReload(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.json"), filepath.Join(dir, "second.json")
+	if err := os.WriteFile(first, []byte(`{"name": "first"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte(`{"name": "second"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ldr := &switchingFileLoader{}
+	ldr.next.Store(&first)
+	watcher := NewWatcher(NewConfigHandler[watchTestConfig](WithLoaders[watchTestConfig](ldr)))
+	watcher.Interval = 10 * time.Millisecond
+	changes := make(chan [2]*watchTestConfig, 4)
+	watcher.Subscribe(func(old, new *watchTestConfig) { changes <- [2]*watchTestConfig{old, new} })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	if _, cfg := nextChange(t, changes); cfg.Name != "first" {
+		t.Fatalf("first load Name = %q, want first", cfg.Name)
+	}
+	ldr.next.Store(&second)
+	if err := os.WriteFile(first, []byte(`{"name": "switch"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, cfg := nextChange(t, changes); cfg.Name != "second" {
+		t.Fatalf("reload Name = %q, want second", cfg.Name)
+	}
+	if err := os.WriteFile(second, []byte(`{"name": "second-v2"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, cfg := nextChange(t, changes); cfg.Name != "second-v2" {
+		t.Errorf("reload of the new file Name = %q, want second-v2", cfg.Name)
+	}
+}
+
+func TestWatcher_InvalidReloadPublishesReloadFailed(t *testing.T) {
+	var bus EventBus
+	sub := bus.Subscribe(8)
+	remote := &triggerLoader{changed: make(chan func(), 1)}
+	remote.value.Store(10)
+	handler := NewConfigHandler[watchTestConfig](
+		WithLoaders[watchTestConfig](remote),
+		WithEventBus[watchTestConfig](&bus),
+	)
+
+	watcher := NewWatcher(handler)
+	failed := make(chan error, 1)
+	watcher.OnError = func(err error) { failed <- err }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	changed := <-remote.changed
+	remote.value.Store(200)
+	changed()
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the invalid reload to fail")
+	}
+	if cfg := watcher.Current(); cfg.MaxConns != 10 {
+		t.Errorf("Current().MaxConns = %d, want 10", cfg.MaxConns)
+	}
+
+	var types []EventType
+	for len(types) < 2 {
+		select {
+		case event := <-sub.Events():
+			types = append(types, event.Type)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("events = %v, want Loaded, ReloadFailed", types)
+		}
+	}
+	if types[0] != EventLoaded || types[1] != EventReloadFailed {
+		t.Errorf("events = %v, want Loaded, ReloadFailed", types)
+	}
+}
+
+func TestHandler_Watch_LoaderWatcher(t *testing.T) {
+	remote := &triggerLoader{changed: make(chan func(), 1)}
+	remote.value.Store(10)
+	handler := NewConfigHandler[watchTestConfig](WithLoaders[watchTestConfig](remote))
+
+	changes := make(chan [2]*watchTestConfig, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.Watch(context.Background(), func(old, new *watchTestConfig) {
+			changes <- [2]*watchTestConfig{old, new}
+		})
+	}()
+
+	if _, cfg := nextChange(t, changes); cfg.MaxConns != 10 {
+		t.Fatalf("MaxConns = %d, want 10", cfg.MaxConns)
+	}
+	changed := <-remote.changed
+	changed() // Unchanged values do not reach subscribers
+	remote.value.Store(20)
+	changed()
+	if old, cfg := nextChange(t, changes); old.MaxConns != 10 || cfg.MaxConns != 20 {
+		t.Errorf("reload = %d -> %d, want 10 -> 20", old.MaxConns, cfg.MaxConns)
+	}
+
+	// Shutting the handler down stops the watch
+	if err := handler.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Watch() error = %v", err)
+	}
+}
+
+func TestWatcher_Run_WatchError(t *testing.T) {
+	watchErr := errors.New("cannot watch")
+	handler := NewConfigHandler[watchTestConfig](
+		WithLoaders[watchTestConfig](&triggerLoader{err: watchErr}),
+	)
+	if err := NewWatcher(handler).Run(context.Background()); !errors.Is(err, watchErr) {
+		t.Errorf("Run() error = %v, want %v", err, watchErr)
+	}
+}